@@ -132,53 +132,90 @@ type InsecureParameters struct {
 	Interval int64 `json:"interval,omitempty"`
 }
 
-// SanityCheck does basic sanity checking on the genesis state.
-func (g *Genesis) SanityCheck() error {
-	switch g.Parameters.Backend {
+// SanityCheck performs a sanity check on the consensus parameters.
+func (p *ConsensusParameters) SanityCheck() error {
+	switch p.Backend {
 	case BackendInsecure:
-		params := g.Parameters.InsecureParameters
+		params := p.InsecureParameters
 		if params == nil {
-			return fmt.Errorf("beacon: sanity check failed: insecure backend not configured")
+			return fmt.Errorf("insecure backend not configured")
 		}
 
-		if params.Interval <= 0 && !g.Parameters.DebugMockBackend {
-			return fmt.Errorf("beacon: sanity check failed: epoch interval must be > 0")
+		if params.Interval <= 0 && !p.DebugMockBackend {
+			return fmt.Errorf("epoch interval must be > 0")
 		}
 	case BackendPVSS:
-		params := g.Parameters.PVSSParameters
+		params := p.PVSSParameters
 		if params == nil {
-			return fmt.Errorf("beacon: sanity check failed: PVSS backend not configured")
+			return fmt.Errorf("PVSS backend not configured")
 		}
 
 		if params.Participants <= 1 {
-			return fmt.Errorf("beacon: sanity check failed: PVSS participants must be > 1")
+			return fmt.Errorf("PVSS participants must be > 1")
 		}
 		if params.Participants > math.MaxInt32 {
-			return fmt.Errorf("beacon: sanity check failed: PVSS participants must be < %d", math.MaxInt32)
+			return fmt.Errorf("PVSS participants must be < %d", math.MaxInt32)
 		}
 		if n := params.Threshold; n <= 1 || n > params.Participants {
-			return fmt.Errorf("beacon: sanity check failed: PVSS threshold must be > 1 and <= participants")
+			return fmt.Errorf("PVSS threshold must be > 1 and <= participants")
 		}
 
 		if params.CommitInterval <= 0 {
-			return fmt.Errorf("beacon: sanity check failed: PVSS commit interval must be > 0")
+			return fmt.Errorf("PVSS commit interval must be > 0")
 		}
 		if params.RevealInterval <= 0 {
-			return fmt.Errorf("beacon: sanity check failed: PVSS reveal interval must be > 0")
+			return fmt.Errorf("PVSS reveal interval must be > 0")
 		}
 		if params.TransitionDelay <= 0 {
-			return fmt.Errorf("beacon: sanity check failed: PVSS transition delay must be > 0")
+			return fmt.Errorf("PVSS transition delay must be > 0")
 		}
 		if len(params.DebugForcedParticipants) > 0 && !flags.DebugDontBlameOasis() {
-			return fmt.Errorf("beacon: sanity check failed: PVSS forced participants set")
+			return fmt.Errorf("PVSS forced participants set")
 		}
 	default:
-		return fmt.Errorf("beacon: sanity check failed: unknown backend: '%s'", g.Parameters.Backend)
+		return fmt.Errorf("unknown backend: '%s'", p.Backend)
 	}
 
-	unsafeFlags := g.Parameters.DebugMockBackend || g.Parameters.DebugDeterministic
+	unsafeFlags := p.DebugMockBackend || p.DebugDeterministic
 	if unsafeFlags && !flags.DebugDontBlameOasis() {
-		return fmt.Errorf("beacon: sanity check failed: one or more unsafe debug flags set")
+		return fmt.Errorf("one or more unsafe debug flags set")
+	}
+
+	return nil
+}
+
+// ConsensusParameterChanges are allowed beacon consensus parameter changes.
+type ConsensusParameterChanges struct {
+	// InsecureParameters are the new beacon parameters for the insecure backend.
+	InsecureParameters *InsecureParameters `json:"insecure_parameters,omitempty"`
+
+	// PVSSParameters are the new beacon parameters for the PVSS backend.
+	PVSSParameters *PVSSParameters `json:"pvss_parameters,omitempty"`
+}
+
+// SanityCheck performs a sanity check on the consensus parameter changes.
+func (c *ConsensusParameterChanges) SanityCheck() error {
+	if c.InsecureParameters == nil && c.PVSSParameters == nil {
+		return fmt.Errorf("consensus parameter changes should not be empty")
+	}
+	return nil
+}
+
+// Apply applies changes to the given consensus parameters.
+func (c *ConsensusParameterChanges) Apply(params *ConsensusParameters) error {
+	if c.InsecureParameters != nil {
+		params.InsecureParameters = c.InsecureParameters
+	}
+	if c.PVSSParameters != nil {
+		params.PVSSParameters = c.PVSSParameters
+	}
+	return nil
+}
+
+// SanityCheck does basic sanity checking on the genesis state.
+func (g *Genesis) SanityCheck() error {
+	if err := g.Parameters.SanityCheck(); err != nil {
+		return fmt.Errorf("beacon: sanity check failed: %w", err)
 	}
 
 	if g.Base == EpochInvalid {
@@ -136,5 +136,21 @@ func clientStreamErrorMapper(
 	opts ...grpc.CallOption,
 ) (grpc.ClientStream, error) {
 	cs, err := streamer(ctx, desc, cc, method, opts...)
-	return cs, errorFromGrpc(err)
+	if err != nil {
+		return nil, errorFromGrpc(err)
+	}
+	return &errorMappingClientStream{cs}, nil
+}
+
+// errorMappingClientStream is a client stream which maps errors returned by the server.
+type errorMappingClientStream struct {
+	grpc.ClientStream
+}
+
+func (s *errorMappingClientStream) SendMsg(m interface{}) error {
+	return errorFromGrpc(s.ClientStream.SendMsg(m))
+}
+
+func (s *errorMappingClientStream) RecvMsg(m interface{}) error {
+	return errorFromGrpc(s.ClientStream.RecvMsg(m))
 }
@@ -13,6 +13,9 @@ var (
 	// ContextKeyCommissionScheduleIndex is the key to retrieve the rate (bound)
 	// index in a commission schedule (amendment).
 	ContextKeyCommissionScheduleIndex = contextKey("staking/commission-schedule-index")
+	// ContextKeyCurrentEpoch is the key to retrieve the current epoch from a
+	// context.
+	ContextKeyCurrentEpoch = contextKey("beacon/current-epoch")
 )
 
 type contextKey string
@@ -4,11 +4,13 @@ package api
 
 import (
 	"context"
+	"math"
 	"strings"
 	"time"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
@@ -56,6 +58,9 @@ var (
 
 	// ErrInvalidArgument is the error returned when the request contains an invalid argument.
 	ErrInvalidArgument = errors.New(moduleName, 6, "consensus: invalid argument")
+
+	// ErrNoSuchTransaction is the error returned when the given transaction cannot be found.
+	ErrNoSuchTransaction = errors.New(moduleName, 7, "consensus: transaction not found")
 )
 
 // FeatureMask is the consensus backend feature bitmask.
@@ -98,6 +103,17 @@ type ClientBackend interface {
 	// in a block. Use SubmitTxNoWait if you only need to broadcast the transaction.
 	SubmitTx(ctx context.Context, tx *transaction.SignedTransaction) error
 
+	// SubmitTxWithProof submits a signed consensus transaction, waits for the transaction to be
+	// included in a block and returns the execution result together with a proof of inclusion.
+	//
+	// In contrast to SubmitTx, failed execution of an included transaction is not treated as an
+	// error and is instead reported in the returned result.
+	SubmitTxWithProof(ctx context.Context, tx *transaction.SignedTransaction) (*TransactionWithProof, error)
+
+	// SubmitMultiSignedTx submits a consensus transaction signed by the signers of a multisig
+	// account and waits for the transaction to be included in a block.
+	SubmitMultiSignedTx(ctx context.Context, tx *transaction.MultiSignedTransaction) error
+
 	// StateToGenesis returns the genesis state at the specified block height.
 	StateToGenesis(ctx context.Context, height int64) (*genesis.Document, error)
 
@@ -118,6 +134,12 @@ type ClientBackend interface {
 	// height.
 	GetTransactionsWithResults(ctx context.Context, height int64) (*TransactionsWithResults, error)
 
+	// GetTransactionByHash returns the transaction with the given hash, the height of the block
+	// that includes it and its execution results.
+	//
+	// This requires the transaction index to be enabled on the node.
+	GetTransactionByHash(ctx context.Context, txHash hash.Hash) (*TransactionWithResult, error)
+
 	// GetUnconfirmedTransactions returns a list of transactions currently in the local node's
 	// mempool. These have not yet been included in a block.
 	GetUnconfirmedTransactions(ctx context.Context) ([][]byte, error)
@@ -126,6 +148,14 @@ type ClientBackend interface {
 	// blocks as they are being finalized.
 	WatchBlocks(ctx context.Context) (<-chan *Block, pubsub.ClosableSubscription, error)
 
+	// WatchEventsFrom returns a channel that produces a stream of events emitted by all consensus
+	// services, starting at the position given by the request.
+	//
+	// Events of already finalized blocks are replayed first, followed by events of blocks as they
+	// are being finalized. In case of errors the channel is closed, the error is reported by the
+	// subscription and the stream can be resumed after the cursor of the last received event.
+	WatchEventsFrom(ctx context.Context, req *WatchEventsRequest) (<-chan *Event, *EventSubscription, error)
+
 	// GetGenesisDocument returns the original genesis document.
 	GetGenesisDocument(ctx context.Context) (*genesis.Document, error)
 
@@ -267,3 +297,105 @@ type TransactionsWithResults struct {
 	Transactions [][]byte          `json:"transactions"`
 	Results      []*results.Result `json:"results"`
 }
+
+// TransactionWithProof is SubmitTxWithProof response.
+type TransactionWithProof struct {
+	// Height is the height of the block that includes the transaction.
+	Height int64 `json:"height"`
+	// Index is the index of the transaction within the block.
+	Index uint32 `json:"index"`
+	// Result is the result of executing the transaction.
+	Result *results.Result `json:"result"`
+	// Proof contains the consensus backend specific proof of inclusion of the transaction in
+	// the block.
+	Proof []byte `json:"proof"`
+}
+
+// TransactionWithResult is GetTransactionByHash response.
+type TransactionWithResult struct {
+	// Height is the height of the block that includes the transaction.
+	Height int64 `json:"height"`
+	// Index is the index of the transaction within the block.
+	Index uint32 `json:"index"`
+	// Transaction is the raw transaction.
+	Transaction []byte `json:"transaction"`
+	// Result is the result of executing the transaction.
+	Result *results.Result `json:"result"`
+}
+
+const (
+	// TxIndexBeginBlock is the transaction index of events emitted at the beginning of a block.
+	TxIndexBeginBlock int32 = -1
+	// TxIndexEndBlock is the transaction index of events emitted at the end of a block.
+	TxIndexEndBlock int32 = math.MaxInt32
+)
+
+// EventCursor is the position of an event in the stream of consensus events.
+//
+// Cursors of streamed events are strictly increasing.
+type EventCursor struct {
+	// Height is the height of the block that emitted the event.
+	Height int64 `json:"height"`
+	// TxIndex is the index of the transaction that emitted the event within the block or one of
+	// TxIndexBeginBlock and TxIndexEndBlock for events not emitted by transactions.
+	TxIndex int32 `json:"tx_index"`
+	// EventIndex is the index of the event among the events emitted by the same transaction (or
+	// the same block phase).
+	EventIndex uint32 `json:"event_index"`
+}
+
+// Less returns true iff the cursor is positioned before the other cursor.
+func (c *EventCursor) Less(other *EventCursor) bool {
+	if c.Height != other.Height {
+		return c.Height < other.Height
+	}
+	if c.TxIndex != other.TxIndex {
+		return c.TxIndex < other.TxIndex
+	}
+	return c.EventIndex < other.EventIndex
+}
+
+// WatchEventsRequest is a WatchEventsFrom request.
+type WatchEventsRequest struct {
+	// Height is the height of the block to start streaming events from. Passing HeightLatest only
+	// streams events of blocks finalized after the call.
+	Height int64 `json:"height"`
+	// After is an optional cursor of the last received event. If set, the stream resumes with the
+	// event following the cursor and Height is ignored.
+	After *EventCursor `json:"after,omitempty"`
+}
+
+// EventSubscription is a subscription to the stream of consensus events.
+type EventSubscription struct {
+	pubsub.ClosableSubscription
+
+	err error
+}
+
+// Err returns the error that caused the event stream to end or nil in case the stream ended
+// because the subscription has been closed.
+//
+// It must only be called after the event channel has been closed.
+func (s *EventSubscription) Err() error {
+	return s.err
+}
+
+// SetErr sets the error that caused the event stream to end.
+//
+// It must only be called by the event producer before closing the event channel.
+func (s *EventSubscription) SetErr(err error) {
+	s.err = err
+}
+
+// NewEventSubscription creates a new event subscription wrapping the given subscription.
+func NewEventSubscription(sub pubsub.ClosableSubscription) *EventSubscription {
+	return &EventSubscription{ClosableSubscription: sub}
+}
+
+// Event is a consensus service event together with its position in the event stream.
+type Event struct {
+	results.Event
+
+	// Cursor is the position of the event in the event stream.
+	Cursor EventCursor `json:"cursor"`
+}
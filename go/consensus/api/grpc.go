@@ -2,10 +2,12 @@ package api
 
 import (
 	"context"
+	"io"
 
 	"google.golang.org/grpc"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
@@ -25,6 +27,10 @@ var (
 
 	// methodSubmitTx is the SubmitTx method.
 	methodSubmitTx = serviceName.NewMethod("SubmitTx", transaction.SignedTransaction{})
+	// methodSubmitMultiSignedTx is the SubmitMultiSignedTx method.
+	methodSubmitMultiSignedTx = serviceName.NewMethod("SubmitMultiSignedTx", transaction.MultiSignedTransaction{})
+	// methodSubmitTxWithProof is the SubmitTxWithProof method.
+	methodSubmitTxWithProof = serviceName.NewMethod("SubmitTxWithProof", transaction.SignedTransaction{})
 	// methodStateToGenesis is the StateToGenesis method.
 	methodStateToGenesis = serviceName.NewMethod("StateToGenesis", int64(0))
 	// methodEstimateGas is the EstimateGas method.
@@ -37,6 +43,8 @@ var (
 	methodGetTransactions = serviceName.NewMethod("GetTransactions", int64(0))
 	// methodGetTransactionsWithResults is the GetTransactionsWithResults method.
 	methodGetTransactionsWithResults = serviceName.NewMethod("GetTransactionsWithResults", int64(0))
+	// methodGetTransactionByHash is the GetTransactionByHash method.
+	methodGetTransactionByHash = serviceName.NewMethod("GetTransactionByHash", hash.Hash{})
 	// methodGetUnconfirmedTransactions is the GetUnconfirmedTransactions method.
 	methodGetUnconfirmedTransactions = serviceName.NewMethod("GetUnconfirmedTransactions", nil)
 	// methodGetGenesisDocument is the GetGenesisDocument method.
@@ -46,6 +54,8 @@ var (
 
 	// methodWatchBlocks is the WatchBlocks method.
 	methodWatchBlocks = serviceName.NewMethod("WatchBlocks", nil)
+	// methodWatchEventsFrom is the WatchEventsFrom method.
+	methodWatchEventsFrom = serviceName.NewMethod("WatchEventsFrom", WatchEventsRequest{})
 
 	// methodGetLightBlock is the GetLightBlock method.
 	methodGetLightBlock = lightServiceName.NewMethod("GetLightBlock", int64(0))
@@ -57,6 +67,8 @@ var (
 	methodStateSyncGetPrefixes = lightServiceName.NewMethod("StateSyncGetPrefixes", syncer.GetPrefixesRequest{})
 	// methodStateSyncIterate is the StateSyncIterate method.
 	methodStateSyncIterate = lightServiceName.NewMethod("StateSyncIterate", syncer.IterateRequest{})
+	// methodStateSyncGetRange is the StateSyncGetRange method.
+	methodStateSyncGetRange = lightServiceName.NewMethod("StateSyncGetRange", syncer.GetRangeRequest{})
 	// methodSubmitTxNoWait is the SubmitTxNoWait method.
 	methodSubmitTxNoWait = lightServiceName.NewMethod("SubmitTxNoWait", transaction.SignedTransaction{})
 	// methodSubmitEvidence is the SubmitEvidence method.
@@ -71,6 +83,14 @@ var (
 				MethodName: methodSubmitTx.ShortName(),
 				Handler:    handlerSubmitTx,
 			},
+			{
+				MethodName: methodSubmitMultiSignedTx.ShortName(),
+				Handler:    handlerSubmitMultiSignedTx,
+			},
+			{
+				MethodName: methodSubmitTxWithProof.ShortName(),
+				Handler:    handlerSubmitTxWithProof,
+			},
 			{
 				MethodName: methodStateToGenesis.ShortName(),
 				Handler:    handlerStateToGenesis,
@@ -95,6 +115,10 @@ var (
 				MethodName: methodGetTransactionsWithResults.ShortName(),
 				Handler:    handlerGetTransactionsWithResults,
 			},
+			{
+				MethodName: methodGetTransactionByHash.ShortName(),
+				Handler:    handlerGetTransactionByHash,
+			},
 			{
 				MethodName: methodGetUnconfirmedTransactions.ShortName(),
 				Handler:    handlerGetUnconfirmedTransactions,
@@ -114,6 +138,11 @@ var (
 				Handler:       handlerWatchBlocks,
 				ServerStreams: true,
 			},
+			{
+				StreamName:    methodWatchEventsFrom.ShortName(),
+				Handler:       handlerWatchEventsFrom,
+				ServerStreams: true,
+			},
 		},
 	}
 
@@ -142,6 +171,10 @@ var (
 				MethodName: methodStateSyncIterate.ShortName(),
 				Handler:    handlerStateSyncIterate,
 			},
+			{
+				MethodName: methodStateSyncGetRange.ShortName(),
+				Handler:    handlerStateSyncGetRange,
+			},
 			{
 				MethodName: methodSubmitTxNoWait.ShortName(),
 				Handler:    handlerSubmitTxNoWait,
@@ -177,6 +210,52 @@ func handlerSubmitTx( // nolint: golint
 	return interceptor(ctx, rq, info, handler)
 }
 
+func handlerSubmitMultiSignedTx( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	rq := new(transaction.MultiSignedTransaction)
+	if err := dec(rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return nil, srv.(ClientBackend).SubmitMultiSignedTx(ctx, rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodSubmitMultiSignedTx.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, srv.(ClientBackend).SubmitMultiSignedTx(ctx, req.(*transaction.MultiSignedTransaction))
+	}
+	return interceptor(ctx, rq, info, handler)
+}
+
+func handlerSubmitTxWithProof( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	rq := new(transaction.SignedTransaction)
+	if err := dec(rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientBackend).SubmitTxWithProof(ctx, rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodSubmitTxWithProof.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientBackend).SubmitTxWithProof(ctx, req.(*transaction.SignedTransaction))
+	}
+	return interceptor(ctx, rq, info, handler)
+}
+
 func handlerStateToGenesis( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -315,6 +394,29 @@ func handlerGetTransactionsWithResults( // nolint: golint
 	return interceptor(ctx, height, info, handler)
 }
 
+func handlerGetTransactionByHash( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var txHash hash.Hash
+	if err := dec(&txHash); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientBackend).GetTransactionByHash(ctx, txHash)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetTransactionByHash.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientBackend).GetTransactionByHash(ctx, req.(hash.Hash))
+	}
+	return interceptor(ctx, txHash, info, handler)
+}
+
 func handlerGetUnconfirmedTransactions( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -400,6 +502,36 @@ func handlerWatchBlocks(srv interface{}, stream grpc.ServerStream) error {
 	}
 }
 
+func handlerWatchEventsFrom(srv interface{}, stream grpc.ServerStream) error {
+	var req WatchEventsRequest
+	if err := stream.RecvMsg(&req); err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	ch, sub, err := srv.(ClientBackend).WatchEventsFrom(ctx, &req)
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+
+	for {
+		select {
+		case ev, ok := <-ch:
+			if !ok {
+				// Propagate the reason for the end of the stream to the client.
+				return sub.Err()
+			}
+
+			if err := stream.SendMsg(ev); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
 func handlerGetLightBlock( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -515,6 +647,29 @@ func handlerStateSyncIterate( // nolint: golint
 	return interceptor(ctx, rq, info, handler)
 }
 
+func handlerStateSyncGetRange( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	rq := new(syncer.GetRangeRequest)
+	if err := dec(rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightClientBackend).State().SyncGetRange(ctx, rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodStateSyncGetRange.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightClientBackend).State().SyncGetRange(ctx, req.(*syncer.GetRangeRequest))
+	}
+	return interceptor(ctx, rq, info, handler)
+}
+
 func handlerSubmitTxNoWait( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -625,6 +780,15 @@ func (rs *stateReadSync) SyncIterate(ctx context.Context, request *syncer.Iterat
 	return &rsp, nil
 }
 
+// Implements syncer.ReadSyncer.
+func (rs *stateReadSync) SyncGetRange(ctx context.Context, request *syncer.GetRangeRequest) (*syncer.ProofResponse, error) {
+	var rsp syncer.ProofResponse
+	if err := rs.c.conn.Invoke(ctx, methodStateSyncGetRange.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 // Implements LightClientBackend.
 func (c *consensusLightClient) State() syncer.ReadSyncer {
 	return &stateReadSync{c}
@@ -650,6 +814,18 @@ func (c *consensusClient) SubmitTx(ctx context.Context, tx *transaction.SignedTr
 	return c.conn.Invoke(ctx, methodSubmitTx.FullName(), tx, nil)
 }
 
+func (c *consensusClient) SubmitMultiSignedTx(ctx context.Context, tx *transaction.MultiSignedTransaction) error {
+	return c.conn.Invoke(ctx, methodSubmitMultiSignedTx.FullName(), tx, nil)
+}
+
+func (c *consensusClient) SubmitTxWithProof(ctx context.Context, tx *transaction.SignedTransaction) (*TransactionWithProof, error) {
+	var rsp TransactionWithProof
+	if err := c.conn.Invoke(ctx, methodSubmitTxWithProof.FullName(), tx, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *consensusClient) StateToGenesis(ctx context.Context, height int64) (*genesis.Document, error) {
 	var rsp genesis.Document
 	if err := c.conn.Invoke(ctx, methodStateToGenesis.FullName(), height, &rsp); err != nil {
@@ -698,6 +874,14 @@ func (c *consensusClient) GetTransactionsWithResults(ctx context.Context, height
 	return &rsp, nil
 }
 
+func (c *consensusClient) GetTransactionByHash(ctx context.Context, txHash hash.Hash) (*TransactionWithResult, error) {
+	var rsp TransactionWithResult
+	if err := c.conn.Invoke(ctx, methodGetTransactionByHash.FullName(), txHash, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *consensusClient) GetUnconfirmedTransactions(ctx context.Context) ([][]byte, error) {
 	var rsp [][]byte
 	if err := c.conn.Invoke(ctx, methodGetUnconfirmedTransactions.FullName(), nil, &rsp); err != nil {
@@ -757,6 +941,45 @@ func (c *consensusClient) WatchBlocks(ctx context.Context) (<-chan *Block, pubsu
 	return ch, sub, nil
 }
 
+func (c *consensusClient) WatchEventsFrom(ctx context.Context, req *WatchEventsRequest) (<-chan *Event, *EventSubscription, error) {
+	ctx, csub := pubsub.NewContextSubscription(ctx)
+	sub := NewEventSubscription(csub)
+
+	stream, err := c.conn.NewStream(ctx, &serviceDesc.Streams[1], methodWatchEventsFrom.FullName())
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = stream.SendMsg(req); err != nil {
+		return nil, nil, err
+	}
+	if err = stream.CloseSend(); err != nil {
+		return nil, nil, err
+	}
+
+	ch := make(chan *Event)
+	go func() {
+		defer close(ch)
+
+		for {
+			var ev Event
+			if serr := stream.RecvMsg(&ev); serr != nil {
+				if serr != io.EOF && ctx.Err() == nil {
+					sub.SetErr(serr)
+				}
+				return
+			}
+
+			select {
+			case ch <- &ev:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, sub, nil
+}
+
 func (c *consensusClient) Beacon() beacon.Backend {
 	return beacon.NewBeaconClient(c.conn)
 }
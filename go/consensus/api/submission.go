@@ -57,6 +57,20 @@ type SubmissionManager interface {
 	//
 	// It also automatically handles retries in case the nonce was incorrectly estimated.
 	SignAndSubmitTx(ctx context.Context, signer signature.Signer, tx *transaction.Transaction) error
+
+	// SignAndSubmitTxWithProof populates the nonce and fee fields in the transaction, signs the
+	// transaction with the passed signer, submits it to consensus backend and returns the
+	// execution result together with a proof of inclusion.
+	//
+	// It also automatically handles retries in case the nonce was incorrectly estimated.
+	SignAndSubmitTxWithProof(ctx context.Context, signer signature.Signer, tx *transaction.Transaction) (*TransactionWithProof, error)
+
+	// SignAndSubmitTxNoWait populates the nonce and fee fields in the transaction, signs the
+	// transaction with the passed signer and submits it to consensus backend without waiting for
+	// the transaction to be included in a block.
+	//
+	// It also automatically handles retries in case the nonce was incorrectly estimated.
+	SignAndSubmitTxNoWait(ctx context.Context, signer signature.Signer, tx *transaction.Transaction) error
 }
 
 type submissionManager struct {
@@ -117,7 +131,12 @@ func (m *submissionManager) EstimateGasAndSetFee(ctx context.Context, signer sig
 	return nil
 }
 
-func (m *submissionManager) signAndSubmitTx(ctx context.Context, signer signature.Signer, tx *transaction.Transaction) error {
+func (m *submissionManager) signAndSubmitTx(
+	ctx context.Context,
+	signer signature.Signer,
+	tx *transaction.Transaction,
+	submit func(context.Context, *transaction.SignedTransaction) error,
+) error {
 	// Update transaction nonce.
 	var err error
 	signerAddr := staking.NewAddress(signer.Public())
@@ -146,7 +165,7 @@ func (m *submissionManager) signAndSubmitTx(ctx context.Context, signer signatur
 		return backoff.Permanent(err)
 	}
 
-	if err = m.backend.SubmitTx(ctx, sigTx); err != nil {
+	if err = submit(ctx, sigTx); err != nil {
 		if errors.Is(err, transaction.ErrInvalidNonce) {
 			// Invalid nonce, retry submission.
 			m.logger.Debug("retrying transaction submission due to invalid nonce",
@@ -168,7 +187,52 @@ func (m *submissionManager) SignAndSubmitTx(ctx context.Context, signer signatur
 	sched.MaxElapsedTime = maxSubmissionRetryElapsedTime
 
 	return backoff.Retry(func() error {
-		return m.signAndSubmitTx(ctx, signer, tx)
+		return m.signAndSubmitTx(ctx, signer, tx, m.backend.SubmitTx)
+	}, backoff.WithContext(sched, ctx))
+}
+
+// Implements SubmissionManager.
+func (m *submissionManager) SignAndSubmitTxWithProof(
+	ctx context.Context,
+	signer signature.Signer,
+	tx *transaction.Transaction,
+) (*TransactionWithProof, error) {
+	sched := backoff.NewExponentialBackOff()
+	sched.MaxInterval = maxSubmissionRetryInterval
+	sched.MaxElapsedTime = maxSubmissionRetryElapsedTime
+
+	var rsp *TransactionWithProof
+	submit := func(ctx context.Context, sigTx *transaction.SignedTransaction) error {
+		var err error
+		if rsp, err = m.backend.SubmitTxWithProof(ctx, sigTx); err != nil {
+			return err
+		}
+		if !rsp.Result.IsSuccess() {
+			// Treat an invalid nonce as an error so that the submission is retried, but report
+			// any other execution failures as part of the result.
+			if err = errors.FromCode(rsp.Result.Error.Module, rsp.Result.Error.Code); errors.Is(err, transaction.ErrInvalidNonce) {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := backoff.Retry(func() error {
+		return m.signAndSubmitTx(ctx, signer, tx, submit)
+	}, backoff.WithContext(sched, ctx)); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+// Implements SubmissionManager.
+func (m *submissionManager) SignAndSubmitTxNoWait(ctx context.Context, signer signature.Signer, tx *transaction.Transaction) error {
+	sched := backoff.NewExponentialBackOff()
+	sched.MaxInterval = maxSubmissionRetryInterval
+	sched.MaxElapsedTime = maxSubmissionRetryElapsedTime
+
+	return backoff.Retry(func() error {
+		return m.signAndSubmitTx(ctx, signer, tx, m.backend.SubmitTxNoWait)
 	}, backoff.WithContext(sched, ctx))
 }
 
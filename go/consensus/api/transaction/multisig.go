@@ -0,0 +1,237 @@
+package transaction
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/errors"
+	"github.com/oasisprotocol/oasis-core/go/common/prettyprint"
+)
+
+// MaxMultisigSigners is the maximum number of signers in a multisig descriptor.
+const MaxMultisigSigners = 16
+
+var (
+	// ErrInvalidMultisig is the error returned when a multisig transaction is not correctly signed.
+	ErrInvalidMultisig = errors.New(moduleName, 4, "transaction: invalid multisig signatures")
+
+	// MultisigSignatureContext is the context used for signing multisig transactions.
+	//
+	// It differs from the context used for regular transactions so that a signature made for a
+	// multisig account cannot be used to authorize a transaction of the signer's own account.
+	MultisigSignatureContext = signature.NewContext(
+		"oasis-core/consensus: multisig tx",
+		signature.WithChainSeparation(),
+	)
+
+	_ prettyprint.PrettyPrinter = (*MultiSignedTransaction)(nil)
+)
+
+// MultisigDescriptor describes an M-of-N multisig account.
+type MultisigDescriptor struct {
+	// Threshold is the number of distinct signers that must sign a transaction.
+	Threshold uint8 `json:"threshold"`
+	// Signers are the public keys of the signers.
+	Signers []signature.PublicKey `json:"signers"`
+}
+
+// ValidateBasic performs basic multisig descriptor validity checks.
+func (d *MultisigDescriptor) ValidateBasic() error {
+	switch {
+	case len(d.Signers) == 0:
+		return fmt.Errorf("transaction: multisig descriptor has no signers")
+	case len(d.Signers) > MaxMultisigSigners:
+		return fmt.Errorf("transaction: multisig descriptor has too many signers (max: %d got: %d)",
+			MaxMultisigSigners,
+			len(d.Signers),
+		)
+	case d.Threshold == 0:
+		return fmt.Errorf("transaction: multisig descriptor threshold must be positive")
+	case int(d.Threshold) > len(d.Signers):
+		return fmt.Errorf("transaction: multisig descriptor threshold exceeds number of signers")
+	}
+
+	seen := make(map[signature.PublicKey]bool)
+	for _, pk := range d.Signers {
+		if !pk.IsValid() {
+			return fmt.Errorf("transaction: multisig descriptor has invalid signer %s", pk)
+		}
+		if seen[pk] {
+			return fmt.Errorf("transaction: multisig descriptor has duplicate signer %s", pk)
+		}
+		seen[pk] = true
+	}
+	return nil
+}
+
+// IsSigner returns true iff the given public key is one of the descriptor's signers.
+func (d *MultisigDescriptor) IsSigner(pk signature.PublicKey) bool {
+	for _, v := range d.Signers {
+		if v.Equal(pk) {
+			return true
+		}
+	}
+	return false
+}
+
+// VerifySigners verifies that the given (already authenticated) signers are distinct signers of
+// the descriptor and that there are enough of them to satisfy the threshold.
+func (d *MultisigDescriptor) VerifySigners(signers []signature.PublicKey) error {
+	if err := d.ValidateBasic(); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidMultisig, err)
+	}
+
+	seen := make(map[signature.PublicKey]bool)
+	for _, pk := range signers {
+		if !d.IsSigner(pk) {
+			return fmt.Errorf("%w: %s is not a signer", ErrInvalidMultisig, pk)
+		}
+		if seen[pk] {
+			return fmt.Errorf("%w: duplicate signer %s", ErrInvalidMultisig, pk)
+		}
+		seen[pk] = true
+	}
+	if len(seen) < int(d.Threshold) {
+		return fmt.Errorf("%w: threshold not met (threshold: %d signers: %d)",
+			ErrInvalidMultisig,
+			d.Threshold,
+			len(seen),
+		)
+	}
+	return nil
+}
+
+// MultiSignedTransaction is a transaction signed by the signers of a multisig account.
+type MultiSignedTransaction struct {
+	signature.MultiSigned
+
+	// Descriptor is the descriptor of the multisig account.
+	Descriptor MultisigDescriptor `json:"descriptor"`
+}
+
+// Hash returns the cryptographic hash of the encoded transaction.
+func (s *MultiSignedTransaction) Hash() hash.Hash {
+	return hash.NewFrom(s)
+}
+
+// Signers returns the public keys of all signers that signed the transaction.
+//
+// Note: This does not verify the signatures.
+func (s *MultiSignedTransaction) Signers() []signature.PublicKey {
+	signers := make([]signature.PublicKey, 0, len(s.Signatures))
+	for _, sig := range s.Signatures {
+		signers = append(signers, sig.PublicKey)
+	}
+	return signers
+}
+
+// Open first verifies the blob signatures and that all of them were made by the descriptor's
+// signers and then unmarshals the blob.
+//
+// Note: This does not verify that the descriptor's threshold is met.
+func (s *MultiSignedTransaction) Open(tx *Transaction) error { // nolint: interfacer
+	if err := s.Descriptor.ValidateBasic(); err != nil {
+		return err
+	}
+	for _, sig := range s.Signatures {
+		if !s.Descriptor.IsSigner(sig.PublicKey) {
+			return fmt.Errorf("%w: %s is not a signer", ErrInvalidMultisig, sig.PublicKey)
+		}
+	}
+	return s.MultiSigned.Open(MultisigSignatureContext, tx)
+}
+
+// Combine adds the signatures of another partially signed multisig transaction over the same
+// transaction and descriptor.
+func (s *MultiSignedTransaction) Combine(other *MultiSignedTransaction) error {
+	if !bytes.Equal(s.Blob, other.Blob) {
+		return fmt.Errorf("transaction: cannot combine signatures over different transactions")
+	}
+	if !bytes.Equal(cbor.Marshal(s.Descriptor), cbor.Marshal(other.Descriptor)) {
+		return fmt.Errorf("transaction: cannot combine signatures for different multisig descriptors")
+	}
+
+	for _, sig := range other.Signatures {
+		if s.IsSignedBy(sig.PublicKey) {
+			continue
+		}
+		if !s.Descriptor.IsSigner(sig.PublicKey) {
+			return fmt.Errorf("%w: %s is not a signer", ErrInvalidMultisig, sig.PublicKey)
+		}
+		if !sig.Verify(MultisigSignatureContext, s.Blob) {
+			return fmt.Errorf("%w: invalid signature by %s", ErrInvalidMultisig, sig.PublicKey)
+		}
+		s.Signatures = append(s.Signatures, sig)
+	}
+	return nil
+}
+
+// PrettyPrint writes a pretty-printed representation of the type
+// to the given writer.
+func (s MultiSignedTransaction) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
+	fmt.Fprintf(w, "%sHash: %s\n", prefix, s.Hash())
+
+	fmt.Fprintf(w, "%sMultisig threshold: %d\n", prefix, s.Descriptor.Threshold)
+	fmt.Fprintf(w, "%sMultisig signers:\n", prefix)
+	for _, pk := range s.Descriptor.Signers {
+		fmt.Fprintf(w, "%s  %s\n", prefix, pk)
+	}
+
+	fmt.Fprintf(w, "%sSignatures:\n", prefix)
+	for _, sig := range s.Signatures {
+		fmt.Fprintf(w, "%s  %s\n", prefix, sig.PublicKey)
+		fmt.Fprintf(w, "%s    (signature: %s)\n", prefix, sig.Signature)
+		if !sig.Verify(MultisigSignatureContext, s.Blob) {
+			fmt.Fprintf(w, "%s    [INVALID SIGNATURE]\n", prefix)
+		}
+	}
+
+	// Display the blob even if signature verification failed as it may
+	// be useful to look into it regardless.
+	var tx Transaction
+	fmt.Fprintf(w, "%sContent:\n", prefix)
+	if err := cbor.Unmarshal(s.Blob, &tx); err != nil {
+		fmt.Fprintf(w, "%s  <error: %s>\n", prefix, err)
+		fmt.Fprintf(w, "%s  <malformed: %s>\n", prefix, base64.StdEncoding.EncodeToString(s.Blob))
+		return
+	}
+
+	tx.PrettyPrint(ctx, prefix+"  ", w)
+}
+
+// PrettyType returns a representation of the type that can be used for pretty printing.
+func (s MultiSignedTransaction) PrettyType() (interface{}, error) {
+	var tx Transaction
+	if err := cbor.Unmarshal(s.Blob, &tx); err != nil {
+		return nil, fmt.Errorf("malformed signed blob: %w", err)
+	}
+	return signature.NewPrettyMultiSigned(s.MultiSigned, tx)
+}
+
+// SignMultisig partially signs a transaction for the given multisig account.
+//
+// The resulting partially signed transactions of different signers can be combined using Combine.
+func SignMultisig(signer signature.Signer, descriptor *MultisigDescriptor, tx *Transaction) (*MultiSignedTransaction, error) {
+	if err := descriptor.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	if !descriptor.IsSigner(signer.Public()) {
+		return nil, fmt.Errorf("transaction: %s is not a multisig signer", signer.Public())
+	}
+
+	signed, err := signature.SignMultiSigned([]signature.Signer{signer}, MultisigSignatureContext, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MultiSignedTransaction{
+		MultiSigned: *signed,
+		Descriptor:  *descriptor,
+	}, nil
+}
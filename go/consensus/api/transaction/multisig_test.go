@@ -0,0 +1,119 @@
+package transaction
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+)
+
+func TestMultisigDescriptorValidateBasic(t *testing.T) {
+	pk1 := memorySigner.NewTestSigner("multisig test signer 1").Public()
+	pk2 := memorySigner.NewTestSigner("multisig test signer 2").Public()
+
+	tooManySigners := make([]signature.PublicKey, 0, MaxMultisigSigners+1)
+	for i := 0; i <= MaxMultisigSigners; i++ {
+		tooManySigners = append(tooManySigners, memorySigner.NewTestSigner(string(rune('a'+i))).Public())
+	}
+
+	for _, tc := range []struct {
+		msg        string
+		descriptor MultisigDescriptor
+		valid      bool
+	}{
+		{"valid 1-of-1", MultisigDescriptor{Threshold: 1, Signers: []signature.PublicKey{pk1}}, true},
+		{"valid 2-of-2", MultisigDescriptor{Threshold: 2, Signers: []signature.PublicKey{pk1, pk2}}, true},
+		{"no signers", MultisigDescriptor{Threshold: 1}, false},
+		{"zero threshold", MultisigDescriptor{Threshold: 0, Signers: []signature.PublicKey{pk1}}, false},
+		{"threshold too high", MultisigDescriptor{Threshold: 2, Signers: []signature.PublicKey{pk1}}, false},
+		{"duplicate signer", MultisigDescriptor{Threshold: 1, Signers: []signature.PublicKey{pk1, pk1}}, false},
+		{"too many signers", MultisigDescriptor{Threshold: 1, Signers: tooManySigners}, false},
+	} {
+		err := tc.descriptor.ValidateBasic()
+		if tc.valid {
+			require.NoError(t, err, tc.msg)
+		} else {
+			require.Error(t, err, tc.msg)
+		}
+	}
+}
+
+func TestMultiSignedTransaction(t *testing.T) {
+	require := require.New(t)
+
+	signature.SetChainContext("test: oasis-core tests")
+
+	signer1 := memorySigner.NewTestSigner("multisig test signer 1")
+	signer2 := memorySigner.NewTestSigner("multisig test signer 2")
+	signer3 := memorySigner.NewTestSigner("multisig test signer 3")
+	outsider := memorySigner.NewTestSigner("multisig test outsider")
+
+	descriptor := &MultisigDescriptor{
+		Threshold: 2,
+		Signers:   []signature.PublicKey{signer1.Public(), signer2.Public(), signer3.Public()},
+	}
+	tx := NewTransaction(0, nil, MethodName("test.Multisig"), nil)
+
+	_, err := SignMultisig(outsider, descriptor, tx)
+	require.Error(err, "SignMultisig should fail for non-signers")
+
+	partial1, err := SignMultisig(signer1, descriptor, tx)
+	require.NoError(err, "SignMultisig")
+	partial2, err := SignMultisig(signer2, descriptor, tx)
+	require.NoError(err, "SignMultisig")
+
+	var opened Transaction
+	err = partial1.Open(&opened)
+	require.NoError(err, "Open should succeed for partially signed transactions")
+	err = descriptor.VerifySigners(partial1.Signers())
+	require.ErrorIs(err, ErrInvalidMultisig, "VerifySigners should fail when threshold is not met")
+
+	err = partial1.Combine(partial2)
+	require.NoError(err, "Combine")
+	err = partial1.Combine(partial2)
+	require.NoError(err, "Combine should ignore already present signatures")
+	require.Len(partial1.Signatures, 2, "combined transaction should have two signatures")
+
+	err = partial1.Open(&opened)
+	require.NoError(err, "Open")
+	require.EqualValues(tx, &opened, "opened transaction should match")
+	err = descriptor.VerifySigners(partial1.Signers())
+	require.NoError(err, "VerifySigners should succeed when threshold is met")
+
+	// Signatures over a different transaction can't be combined.
+	otherTx := NewTransaction(1, nil, MethodName("test.Multisig"), nil)
+	otherPartial, err := SignMultisig(signer3, descriptor, otherTx)
+	require.NoError(err, "SignMultisig")
+	err = partial1.Combine(otherPartial)
+	require.Error(err, "Combine should fail for different transactions")
+
+	// Signatures by non-signers are rejected.
+	forged, err := signature.SignMultiSigned([]signature.Signer{signer1, outsider}, MultisigSignatureContext, tx)
+	require.NoError(err, "SignMultiSigned")
+	forgedTx := &MultiSignedTransaction{MultiSigned: *forged, Descriptor: *descriptor}
+	err = forgedTx.Open(&opened)
+	require.ErrorIs(err, ErrInvalidMultisig, "Open should fail for signatures by non-signers")
+
+	// Partial signatures can't be used as regular transaction signatures.
+	lifted := &SignedTransaction{Signed: signature.Signed{Blob: partial2.Blob, Signature: partial2.Signatures[0]}}
+	err = lifted.Open(&opened)
+	require.Error(err, "Open should fail for partial signatures used as regular signatures")
+
+	// Regular transaction signatures can't be used as partial signatures.
+	plain, err := Sign(signer3, tx)
+	require.NoError(err, "Sign")
+	plainPartial := &MultiSignedTransaction{
+		MultiSigned: signature.MultiSigned{Blob: plain.Blob, Signatures: []signature.Signature{plain.Signature}},
+		Descriptor:  *descriptor,
+	}
+	err = plainPartial.Open(&opened)
+	require.Error(err, "Open should fail for regular signatures used as partial signatures")
+	err = partial1.Combine(plainPartial)
+	require.Error(err, "Combine should fail for regular signatures")
+
+	// Duplicate signatures do not count towards the threshold.
+	err = descriptor.VerifySigners([]signature.PublicKey{signer1.Public(), signer1.Public()})
+	require.ErrorIs(err, ErrInvalidMultisig, "VerifySigners should fail for duplicate signers")
+}
@@ -0,0 +1,642 @@
+package abci
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/dgraph-io/badger/v2/options"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	cmnBadger "github.com/oasisprotocol/oasis-core/go/common/badger"
+	"github.com/oasisprotocol/oasis-core/go/common/cache/lru"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/keyformat"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
+	nodedb "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
+	badgerNodedb "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/badger"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/writelog"
+)
+
+const (
+	archiveDBVersion = 2
+
+	// archiveFileName is the name of the state archive database file.
+	archiveFileName = "archive.badger.db"
+	// archiveCheckpointsDirName is the name of the directory holding the archived checkpoints.
+	archiveCheckpointsDirName = "archive.checkpoints"
+	// archiveReconstructDirName is the name of the directory holding the temporary node databases
+	// of reconstructed state versions.
+	archiveReconstructDirName = "archive.reconstruct"
+
+	// archiveCheckpointChunkSize is the chunk size of archived checkpoints.
+	archiveCheckpointChunkSize = 8 * 1024 * 1024
+
+	// archiveCacheSize is the number of reconstructed state versions kept around.
+	archiveCacheSize = 4
+	// archiveReconstructCacheSize is the size of the node cache of each temporary node database.
+	archiveReconstructCacheSize = 16 * 1024 * 1024
+)
+
+var (
+	// archiveMetadataKeyFmt is the archive metadata key format.
+	//
+	// Value is CBOR-serialized archiveMetadata.
+	archiveMetadataKeyFmt = keyformat.New(0x01)
+	// archiveWriteLogKeyFmt is the archived write log key format (version).
+	//
+	// Value is CBOR-serialized archivedWriteLog.
+	archiveWriteLogKeyFmt = keyformat.New(0x02, uint64(0))
+	// archiveCheckpointKeyFmt is the archived checkpoint key format (version).
+	//
+	// Value is CBOR-serialized archivedCheckpoint.
+	archiveCheckpointKeyFmt = keyformat.New(0x03, uint64(0))
+)
+
+type archiveMetadata struct {
+	// Version is the database schema version.
+	Version uint64 `json:"version"`
+
+	// LastVersion is the last state version that can be reconstructed from the archive.
+	LastVersion uint64 `json:"last_version"`
+}
+
+// archivedWriteLog is the write log of a single block.
+type archivedWriteLog struct {
+	// Root is the state root hash after applying the write log.
+	Root hash.Hash `json:"root"`
+	// WriteLog is the write log applied to the state of the previous version.
+	WriteLog writelog.WriteLog `json:"write_log"`
+}
+
+// archivedCheckpoint is a marker for a fully archived state version.
+type archivedCheckpoint struct {
+	// Root is the state root hash of the checkpoint.
+	Root hash.Hash `json:"root"`
+}
+
+// root returns the state root of the checkpoint at the given version.
+func (cp *archivedCheckpoint) root(version uint64) node.Root {
+	return node.Root{
+		Version: version,
+		Type:    node.RootTypeState,
+		Hash:    cp.Root,
+	}
+}
+
+// archivedState is a reconstructed state version backed by a temporary node database.
+//
+// The state is reference counted as it may be shared between multiple queries and the archive
+// cache. Its resources are released once the last reference is gone.
+type archivedState struct {
+	logger *logging.Logger
+
+	tree mkvs.Tree
+	ndb  nodedb.NodeDB
+	dir  string
+
+	refs int32
+}
+
+func (s *archivedState) acquire() *archivedTree {
+	atomic.AddInt32(&s.refs, 1)
+	return &archivedTree{ImmutableKeyValueTree: s.tree, state: s}
+}
+
+func (s *archivedState) release() {
+	if atomic.AddInt32(&s.refs, -1) > 0 {
+		return
+	}
+
+	if s.tree != nil {
+		s.tree.Close()
+	}
+	s.ndb.Close()
+	if s.dir != "" {
+		if err := os.RemoveAll(s.dir); err != nil {
+			s.logger.Error("failed to remove reconstructed state",
+				"err", err,
+				"dir", s.dir,
+			)
+		}
+	}
+}
+
+// archivedTree is a read-only view of a reconstructed state version. Closing it releases the
+// reference to the underlying state, which must not be used afterwards.
+type archivedTree struct {
+	mkvs.ImmutableKeyValueTree
+
+	state     *archivedState
+	closeOnce sync.Once
+}
+
+// Close releases the reference to the reconstructed state.
+func (t *archivedTree) Close() {
+	t.closeOnce.Do(t.state.release)
+}
+
+// stateArchive is an archive of ABCI state versions that have been pruned from the node database.
+//
+// The archive stores MKVS checkpoints of the full state at checkpoint versions and the write logs
+// of all blocks so that any version following a checkpoint can be reconstructed by replaying the
+// blocks' write logs on top of the nearest checkpoint.
+type stateArchive struct {
+	logger *logging.Logger
+
+	db *badger.DB
+	gc *cmnBadger.GCWorker
+
+	checkpoints    checkpoint.Creator
+	checkpointsDir string
+
+	// reconstructDir is the directory holding the temporary node databases of reconstructed
+	// state versions. It is empty in case the archive is memory-only.
+	reconstructDir string
+
+	cacheLock sync.Mutex
+	cache     *lru.Cache
+
+	// reconstructLock serializes state reconstructions so that only a single temporary node
+	// database is being populated at any given time.
+	reconstructLock sync.Mutex
+}
+
+func newStateArchive(fn string, memoryOnly bool) (*stateArchive, error) {
+	logger := logging.GetLogger("abci-mux/archive").With("path", fn)
+
+	opts := badger.DefaultOptions(fn)
+	opts = opts.WithLogger(cmnBadger.NewLogAdapter(logger))
+	opts = opts.WithSyncWrites(true)
+	// Allow value log truncation if required (this is needed to recover the
+	// value log file which can get corrupted in crashes).
+	opts = opts.WithTruncate(true)
+	opts = opts.WithCompression(options.Snappy)
+	if memoryOnly {
+		opts = opts.WithInMemory(true).WithDir("").WithValueDir("")
+	}
+
+	// Checkpoints are always stored on disk, in a temporary directory in case of a memory-only
+	// archive. Reconstructed state is kept in memory in the latter case.
+	var checkpointsDir, reconstructDir string
+	if memoryOnly {
+		var err error
+		if checkpointsDir, err = ioutil.TempDir("", archiveCheckpointsDirName); err != nil {
+			return nil, fmt.Errorf("abci/archive: failed to create checkpoint directory: %w", err)
+		}
+	} else {
+		checkpointsDir = filepath.Join(filepath.Dir(fn), archiveCheckpointsDirName)
+		reconstructDir = filepath.Join(filepath.Dir(fn), archiveReconstructDirName)
+
+		// Remove any reconstructed state left over from a previous run.
+		if err := os.RemoveAll(reconstructDir); err != nil {
+			return nil, fmt.Errorf("abci/archive: failed to remove reconstructed state: %w", err)
+		}
+		if err := common.Mkdir(reconstructDir); err != nil {
+			return nil, fmt.Errorf("abci/archive: failed to create reconstructed state directory: %w", err)
+		}
+	}
+	cleanupCheckpoints := func() {
+		if memoryOnly {
+			_ = os.RemoveAll(checkpointsDir)
+		}
+	}
+	// This checkpoint creator is only used to retrieve archived checkpoints as the node database
+	// is only provided when archiving a version.
+	checkpoints, err := checkpoint.NewFileCreator(checkpointsDir, nil)
+	if err != nil {
+		cleanupCheckpoints()
+		return nil, fmt.Errorf("abci/archive: failed to create checkpoint creator: %w", err)
+	}
+
+	db, err := badger.Open(opts)
+	if err != nil {
+		cleanupCheckpoints()
+		return nil, fmt.Errorf("abci/archive: failed to open database: %w", err)
+	}
+
+	cache, err := lru.New(
+		lru.Capacity(archiveCacheSize, false),
+		lru.OnEvict(func(key, value interface{}) {
+			value.(*archivedState).release()
+		}),
+	)
+	if err != nil {
+		_ = db.Close()
+		cleanupCheckpoints()
+		return nil, fmt.Errorf("abci/archive: failed to create cache: %w", err)
+	}
+
+	a := &stateArchive{
+		logger:         logger,
+		db:             db,
+		gc:             cmnBadger.NewGCWorker(logger, db),
+		checkpoints:    checkpoints,
+		checkpointsDir: checkpointsDir,
+		reconstructDir: reconstructDir,
+		cache:          cache,
+	}
+
+	// Ensure metadata is valid.
+	if err = a.ensureMetadata(); err != nil {
+		a.close()
+		return nil, err
+	}
+
+	return a, nil
+}
+
+func (a *stateArchive) queryGetMetadata(tx *badger.Txn) (*archiveMetadata, error) {
+	item, err := tx.Get(archiveMetadataKeyFmt.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	var meta archiveMetadata
+	err = item.Value(func(val []byte) error {
+		return cbor.Unmarshal(val, &meta)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &meta, nil
+}
+
+func (a *stateArchive) ensureMetadata() error {
+	return a.db.Update(func(tx *badger.Txn) error {
+		meta, err := a.queryGetMetadata(tx)
+		switch err {
+		case nil:
+		case badger.ErrKeyNotFound:
+			// Create new metadata section.
+			meta := archiveMetadata{
+				Version: archiveDBVersion,
+			}
+			return tx.Set(archiveMetadataKeyFmt.Encode(), cbor.Marshal(meta))
+		default:
+			return err
+		}
+
+		// Verify metadata section.
+		if meta.Version != archiveDBVersion {
+			return fmt.Errorf("abci/archive: unsupported database version (expected: %d got: %d)",
+				archiveDBVersion,
+				meta.Version,
+			)
+		}
+		return nil
+	})
+}
+
+func (a *stateArchive) metadata() (*archiveMetadata, error) {
+	var meta *archiveMetadata
+	err := a.db.View(func(tx *badger.Txn) error {
+		var err error
+		meta, err = a.queryGetMetadata(tx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// PutWriteLog archives the write log of the block that resulted in the given state root.
+func (a *stateArchive) PutWriteLog(version uint64, root hash.Hash, writeLog writelog.WriteLog) error {
+	return a.db.Update(func(tx *badger.Txn) error {
+		return tx.Set(archiveWriteLogKeyFmt.Encode(version), cbor.Marshal(&archivedWriteLog{
+			Root:     root,
+			WriteLog: writeLog,
+		}))
+	})
+}
+
+// Archive makes sure that the given version can be reconstructed from the archive before it is
+// pruned from the node database.
+//
+// The full state is archived in case the version is a multiple of the archive interval or in case
+// the version cannot be reconstructed from the previous version and the archived write logs.
+func (a *stateArchive) Archive(ctx context.Context, ndb nodedb.NodeDB, version, interval uint64) error {
+	meta, err := a.metadata()
+	if err != nil {
+		return fmt.Errorf("abci/archive: failed to get metadata: %w", err)
+	}
+	if meta.LastVersion != 0 && version <= meta.LastVersion {
+		// Already archived.
+		return nil
+	}
+
+	checkpoint := version%interval == 0
+	if !checkpoint {
+		var hasWriteLog bool
+		err = a.db.View(func(tx *badger.Txn) error {
+			_, txErr := tx.Get(archiveWriteLogKeyFmt.Encode(version))
+			switch txErr {
+			case nil:
+				hasWriteLog = true
+			case badger.ErrKeyNotFound:
+			default:
+				return txErr
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("abci/archive: failed to query write log: %w", err)
+		}
+
+		checkpoint = meta.LastVersion == 0 || meta.LastVersion != version-1 || !hasWriteLog
+	}
+
+	if checkpoint {
+		if err = a.putCheckpoint(ctx, ndb, version); err != nil {
+			return err
+		}
+	}
+
+	meta.LastVersion = version
+	return a.db.Update(func(tx *badger.Txn) error {
+		return tx.Set(archiveMetadataKeyFmt.Encode(), cbor.Marshal(meta))
+	})
+}
+
+func (a *stateArchive) putCheckpoint(ctx context.Context, ndb nodedb.NodeDB, version uint64) error {
+	roots, err := ndb.GetRootsForVersion(ctx, version)
+	if err != nil {
+		return fmt.Errorf("abci/archive: failed to get roots for version %d: %w", version, err)
+	}
+	if len(roots) != 1 {
+		return fmt.Errorf("abci/archive: incorrect number of roots for version %d: %d", version, len(roots))
+	}
+
+	a.logger.Debug("archiving state checkpoint",
+		"version", version,
+		"root", roots[0].Hash,
+	)
+
+	// The state is archived as an MKVS checkpoint as reconstructing the state from its entries
+	// would not preserve the versions of the tree nodes and would result in a different root.
+	creator, err := checkpoint.NewFileCreator(a.checkpointsDir, ndb)
+	if err != nil {
+		return fmt.Errorf("abci/archive: failed to create checkpoint creator: %w", err)
+	}
+	if _, err = creator.CreateCheckpoint(ctx, roots[0], archiveCheckpointChunkSize); err != nil {
+		return fmt.Errorf("abci/archive: failed to create checkpoint: %w", err)
+	}
+
+	// The checkpoint marker is written last so that incomplete checkpoints are never used.
+	err = a.db.Update(func(tx *badger.Txn) error {
+		return tx.Set(archiveCheckpointKeyFmt.Encode(version), cbor.Marshal(&archivedCheckpoint{
+			Root: roots[0].Hash,
+		}))
+	})
+	if err != nil {
+		return fmt.Errorf("abci/archive: failed to archive checkpoint: %w", err)
+	}
+	return nil
+}
+
+// State reconstructs the state at the given version by replaying the archived write logs on top
+// of the nearest preceding checkpoint.
+//
+// The state is reconstructed into a temporary node database so that memory usage is bounded by
+// the tree cache capacity regardless of the size of the state. The returned tree should be closed
+// after use.
+func (a *stateArchive) State(ctx context.Context, version uint64) (mkvs.ImmutableKeyValueTree, error) {
+	if tree, ok := a.cachedState(version); ok {
+		return tree, nil
+	}
+
+	a.reconstructLock.Lock()
+	defer a.reconstructLock.Unlock()
+
+	// Another query may have reconstructed the state while we were waiting.
+	if tree, ok := a.cachedState(version); ok {
+		return tree, nil
+	}
+
+	state, err := a.reconstruct(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+	tree := state.acquire()
+
+	a.cacheLock.Lock()
+	defer a.cacheLock.Unlock()
+	if err = a.cache.Put(version, state); err != nil {
+		// Not being able to cache the state is not fatal, drop the cache reference.
+		state.release()
+	}
+	return tree, nil
+}
+
+func (a *stateArchive) cachedState(version uint64) (*archivedTree, bool) {
+	a.cacheLock.Lock()
+	defer a.cacheLock.Unlock()
+
+	cached, ok := a.cache.Get(version)
+	if !ok {
+		return nil, false
+	}
+	return cached.(*archivedState).acquire(), true
+}
+
+func (a *stateArchive) reconstruct(ctx context.Context, version uint64) (*archivedState, error) {
+	checkpointVersion, cp, err := a.nearestCheckpoint(version)
+	if err != nil {
+		return nil, err
+	}
+
+	a.logger.Debug("reconstructing state",
+		"version", version,
+		"checkpoint", checkpointVersion,
+	)
+
+	var dir string
+	if a.reconstructDir != "" {
+		if dir, err = ioutil.TempDir(a.reconstructDir, fmt.Sprintf("%d-", version)); err != nil {
+			return nil, fmt.Errorf("abci/archive: failed to create reconstructed state directory: %w", err)
+		}
+	}
+	ndb, err := badgerNodedb.New(&nodedb.Config{
+		DB:               dir,
+		NoFsync:          true,
+		MemoryOnly:       dir == "",
+		MaxCacheSize:     archiveReconstructCacheSize,
+		DiscardWriteLogs: true,
+	})
+	if err != nil {
+		if dir != "" {
+			_ = os.RemoveAll(dir)
+		}
+		return nil, fmt.Errorf("abci/archive: failed to create reconstructed state database: %w", err)
+	}
+
+	state := &archivedState{
+		logger: a.logger,
+		ndb:    ndb,
+		dir:    dir,
+		refs:   1,
+	}
+	if err = a.restoreCheckpoint(ctx, ndb, cp.root(checkpointVersion)); err != nil {
+		state.release()
+		return nil, err
+	}
+	if state.tree, err = a.replay(ctx, ndb, cp.root(checkpointVersion), version); err != nil {
+		state.release()
+		return nil, err
+	}
+	return state, nil
+}
+
+func (a *stateArchive) nearestCheckpoint(version uint64) (uint64, *archivedCheckpoint, error) {
+	var (
+		checkpointVersion uint64
+		cp                archivedCheckpoint
+	)
+	err := a.db.View(func(tx *badger.Txn) error {
+		it := tx.NewIterator(badger.IteratorOptions{
+			Reverse: true,
+			Prefix:  archiveCheckpointKeyFmt.Encode(),
+		})
+		defer it.Close()
+
+		it.Seek(archiveCheckpointKeyFmt.Encode(version))
+		if !it.ValidForPrefix(archiveCheckpointKeyFmt.Encode()) {
+			return consensus.ErrVersionNotFound
+		}
+		if !archiveCheckpointKeyFmt.Decode(it.Item().Key(), &checkpointVersion) {
+			return fmt.Errorf("abci/archive: malformed checkpoint key")
+		}
+		return it.Item().Value(func(val []byte) error {
+			return cbor.Unmarshal(val, &cp)
+		})
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	return checkpointVersion, &cp, nil
+}
+
+func (a *stateArchive) restoreCheckpoint(ctx context.Context, ndb nodedb.NodeDB, root node.Root) error {
+	cp, err := a.checkpoints.GetCheckpoint(ctx, 1, root)
+	if err != nil {
+		return fmt.Errorf("abci/archive: failed to get checkpoint for version %d: %w", root.Version, err)
+	}
+
+	restorer, err := checkpoint.NewRestorer(ndb)
+	if err != nil {
+		return fmt.Errorf("abci/archive: failed to create checkpoint restorer: %w", err)
+	}
+	if err = restorer.StartRestore(ctx, cp); err != nil {
+		return fmt.Errorf("abci/archive: failed to start checkpoint restore: %w", err)
+	}
+	for idx := range cp.Chunks {
+		chunk, err := cp.GetChunkMetadata(uint64(idx))
+		if err != nil {
+			return fmt.Errorf("abci/archive: failed to get chunk metadata: %w", err)
+		}
+
+		var buf bytes.Buffer
+		if err = a.checkpoints.GetCheckpointChunk(ctx, chunk, &buf); err != nil {
+			return fmt.Errorf("abci/archive: failed to get checkpoint chunk %d: %w", idx, err)
+		}
+		if _, err = restorer.RestoreChunk(ctx, uint64(idx), &buf); err != nil {
+			return fmt.Errorf("abci/archive: failed to restore checkpoint chunk %d: %w", idx, err)
+		}
+	}
+	if err = ndb.Finalize(ctx, []node.Root{root}); err != nil {
+		return fmt.Errorf("abci/archive: failed to finalize checkpoint: %w", err)
+	}
+	return nil
+}
+
+// replay applies the archived write logs of all blocks following the given checkpoint root up to
+// and including the given version.
+func (a *stateArchive) replay(ctx context.Context, ndb nodedb.NodeDB, root node.Root, version uint64) (mkvs.Tree, error) {
+	tree := mkvs.NewWithRoot(nil, ndb, root, mkvs.WithoutWriteLog())
+	err := a.db.View(func(tx *badger.Txn) error {
+		for v := root.Version + 1; v <= version; v++ {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			item, err := tx.Get(archiveWriteLogKeyFmt.Encode(v))
+			switch err {
+			case nil:
+			case badger.ErrKeyNotFound:
+				return consensus.ErrVersionNotFound
+			default:
+				return err
+			}
+
+			var wl archivedWriteLog
+			if err = item.Value(func(val []byte) error {
+				return cbor.Unmarshal(val, &wl)
+			}); err != nil {
+				return fmt.Errorf("abci/archive: malformed write log: %w", err)
+			}
+			if err = tree.ApplyWriteLog(ctx, writelog.NewStaticIterator(wl.WriteLog)); err != nil {
+				return fmt.Errorf("abci/archive: failed to apply write log for version %d: %w", v, err)
+			}
+
+			// Persist the state of each version so that memory usage remains bounded.
+			var rootHash hash.Hash
+			if _, rootHash, err = tree.Commit(ctx, common.Namespace{}, v); err != nil {
+				return fmt.Errorf("abci/archive: failed to persist reconstructed state: %w", err)
+			}
+			if !rootHash.Equal(&wl.Root) {
+				return fmt.Errorf("abci/archive: reconstructed state root mismatch for version %d (expected: %s got: %s)",
+					v,
+					wl.Root,
+					rootHash,
+				)
+			}
+			if err = ndb.Finalize(ctx, []node.Root{{Version: v, Type: node.RootTypeState, Hash: rootHash}}); err != nil {
+				return fmt.Errorf("abci/archive: failed to finalize reconstructed state: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		tree.Close()
+		return nil, err
+	}
+	return tree, nil
+}
+
+func (a *stateArchive) close() {
+	// Release all cached reconstructed state. Any state still referenced by queries is released
+	// once those are done with it.
+	a.cacheLock.Lock()
+	for _, key := range a.cache.Keys() {
+		if cached, ok := a.cache.Peek(key); ok {
+			a.cache.Remove(key)
+			cached.(*archivedState).release()
+		}
+	}
+	a.cacheLock.Unlock()
+
+	if a.reconstructDir == "" {
+		// Memory-only archives store their checkpoints in a temporary directory.
+		_ = os.RemoveAll(a.checkpointsDir)
+	}
+
+	a.gc.Close()
+
+	if err := a.db.Close(); err != nil {
+		a.logger.Error("failed to close state archive",
+			"err", err,
+		)
+	}
+}
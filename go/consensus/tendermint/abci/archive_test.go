@@ -0,0 +1,100 @@
+package abci
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	mkvsDB "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
+	mkvsBadgerDB "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/badger"
+	mkvsNode "github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+)
+
+func TestStateArchiveReconstruct(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "abci-archive.test.badger")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dir)
+
+	ndb, err := mkvsBadgerDB.New(&mkvsDB.Config{
+		DB:           filepath.Join(dir, "state"),
+		NoFsync:      true,
+		MaxCacheSize: 16 * 1024 * 1024,
+	})
+	require.NoError(err, "New")
+	defer ndb.Close()
+
+	archive, err := newStateArchive(filepath.Join(dir, archiveFileName), false)
+	require.NoError(err, "newStateArchive")
+	var closed bool
+	defer func() {
+		if !closed {
+			archive.close()
+		}
+	}()
+
+	// Generate state with nodes created at different versions.
+	ctx := context.Background()
+	tree := mkvs.New(nil, ndb, mkvsNode.RootTypeState)
+	defer tree.Close()
+	for i := 0; i < 1000; i++ {
+		err = tree.Insert(ctx, []byte(fmt.Sprintf("key:%d", i)), []byte(fmt.Sprintf("value:%d", i)))
+		require.NoError(err, "Insert")
+	}
+
+	numVersions := uint64(archiveCacheSize + 2)
+	for i := uint64(1); i <= numVersions; i++ {
+		err = tree.Insert(ctx, []byte("version"), []byte(fmt.Sprintf("%d", i)))
+		require.NoError(err, "Insert")
+		writeLog, rootHash, cerr := tree.Commit(ctx, common.Namespace{}, i)
+		require.NoError(cerr, "Commit")
+		err = ndb.Finalize(ctx, []mkvsNode.Root{{Namespace: common.Namespace{}, Version: i, Type: mkvsNode.RootTypeState, Hash: rootHash}})
+		require.NoError(err, "Finalize")
+		err = archive.PutWriteLog(i, rootHash, writeLog)
+		require.NoError(err, "PutWriteLog")
+		err = archive.Archive(ctx, ndb, i, numVersions)
+		require.NoError(err, "Archive")
+	}
+
+	reconstructed := func() []os.FileInfo {
+		entries, rerr := ioutil.ReadDir(archive.reconstructDir)
+		require.NoError(rerr, "ReadDir")
+		return entries
+	}
+
+	// Keep the first reconstructed version referenced while it gets evicted from the cache.
+	first, err := archive.State(ctx, 1)
+	require.NoError(err, "State")
+	for i := uint64(1); i <= numVersions; i++ {
+		state, serr := archive.State(ctx, i)
+		require.NoError(serr, "State(%d)", i)
+
+		value, gerr := state.Get(ctx, []byte("version"))
+		require.NoError(gerr, "Get")
+		require.EqualValues(fmt.Sprintf("%d", i), value, "archived state should be correct")
+		value, gerr = state.Get(ctx, []byte("key:0"))
+		require.NoError(gerr, "Get")
+		require.EqualValues("value:0", value, "archived state should be correct")
+
+		state.(mkvs.ClosableTree).Close()
+	}
+	require.Len(reconstructed(), archiveCacheSize+1, "evicted state should be kept while referenced")
+
+	value, err := first.Get(ctx, []byte("version"))
+	require.NoError(err, "Get")
+	require.EqualValues("1", value, "evicted state should remain usable while referenced")
+	first.(mkvs.ClosableTree).Close()
+	require.Len(reconstructed(), archiveCacheSize, "evicted state should be removed once released")
+
+	archive.close()
+	closed = true
+	require.Len(reconstructed(), 0, "all reconstructed state should be removed on close")
+}
@@ -1,6 +1,8 @@
 package abci
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/go-multierror"
 
 	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
@@ -21,16 +23,28 @@ func (md *messageDispatcher) Subscribe(kind interface{}, ms api.MessageSubscribe
 }
 
 // Implements api.MessageDispatcher.
-func (md *messageDispatcher) Publish(ctx *api.Context, kind, msg interface{}) error {
+func (md *messageDispatcher) Publish(ctx *api.Context, kind, msg interface{}) (interface{}, error) {
 	if len(md.subscriptions[kind]) == 0 {
-		return api.ErrNoSubscribers
+		return nil, api.ErrNoSubscribers
 	}
 
-	var errs error
+	var (
+		result interface{}
+		errs   error
+	)
 	for _, ms := range md.subscriptions[kind] {
-		if err := ms.ExecuteMessage(ctx, kind, msg); err != nil {
+		resp, err := ms.ExecuteMessage(ctx, kind, msg)
+		if err != nil {
 			errs = multierror.Append(errs, err)
+			continue
+		}
+		if resp == nil {
+			continue
+		}
+		if result != nil {
+			panic(fmt.Sprintf("tendermint: multiple subscribers returned a result for message kind %v", kind))
 		}
+		result = resp
 	}
-	return errs
+	return result, errs
 }
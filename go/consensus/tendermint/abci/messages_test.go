@@ -24,6 +24,8 @@ type testMessage struct {
 
 type errorMessage struct{}
 
+type resultMessage struct{}
+
 var errTest = fmt.Errorf("error")
 
 type testSubscriber struct {
@@ -32,16 +34,18 @@ type testSubscriber struct {
 }
 
 // Implements api.MessageSubscriber.
-func (s *testSubscriber) ExecuteMessage(ctx *api.Context, kind, msg interface{}) error {
+func (s *testSubscriber) ExecuteMessage(ctx *api.Context, kind, msg interface{}) (interface{}, error) {
 	switch m := msg.(type) {
 	case *testMessage:
 		s.msgs = append(s.msgs, m.foo)
 		if s.fail {
-			return errTest
+			return nil, errTest
 		}
-		return nil
+		return nil, nil
 	case *errorMessage:
-		return errTest
+		return nil, errTest
+	case *resultMessage:
+		return 42, nil
 	default:
 		panic("unexpected message was delivered")
 	}
@@ -58,35 +62,35 @@ func TestMessageDispatcher(t *testing.T) {
 	var md messageDispatcher
 
 	// Publish without subscribers should work.
-	err := md.Publish(ctx, testMessageA, &testMessage{foo: 42})
+	_, err := md.Publish(ctx, testMessageA, &testMessage{foo: 42})
 	require.Error(err, "Publish")
 	require.Equal(api.ErrNoSubscribers, err)
 
 	// With a subscriber.
 	var ms testSubscriber
 	md.Subscribe(testMessageA, &ms)
-	err = md.Publish(ctx, testMessageA, &testMessage{foo: 42})
+	_, err = md.Publish(ctx, testMessageA, &testMessage{foo: 42})
 	require.NoError(err, "Publish")
 	require.EqualValues([]int32{42}, ms.msgs, "correct messages should be delivered")
 
-	err = md.Publish(ctx, testMessageA, &testMessage{foo: 43})
+	_, err = md.Publish(ctx, testMessageA, &testMessage{foo: 43})
 	require.NoError(err, "Publish")
 	require.EqualValues([]int32{42, 43}, ms.msgs, "correct messages should be delivered")
 
-	err = md.Publish(ctx, testMessageB, &testMessage{foo: 44})
+	_, err = md.Publish(ctx, testMessageB, &testMessage{foo: 44})
 	require.Error(err, "Publish")
 	require.Equal(api.ErrNoSubscribers, err)
 	require.EqualValues([]int32{42, 43}, ms.msgs, "correct messages should be delivered")
 
 	// Returning an error.
-	err = md.Publish(ctx, testMessageA, &errorMessage{})
+	_, err = md.Publish(ctx, testMessageA, &errorMessage{})
 	require.Error(err, "Publish")
 	require.True(errors.Is(err, errTest), "returned error should be the correct one")
 
 	// Multiple subscribers.
 	var ms2 testSubscriber
 	md.Subscribe(testMessageA, &ms2)
-	err = md.Publish(ctx, testMessageA, &testMessage{foo: 44})
+	_, err = md.Publish(ctx, testMessageA, &testMessage{foo: 44})
 	require.NoError(err, "Publish")
 	require.EqualValues([]int32{42, 43, 44}, ms.msgs, "correct messages should be delivered")
 	require.EqualValues([]int32{44}, ms2.msgs, "correct messages should be delivered")
@@ -94,9 +98,20 @@ func TestMessageDispatcher(t *testing.T) {
 	// Multiple subscribers, some succeed some fail.
 	ms2.fail = true
 
-	err = md.Publish(ctx, testMessageA, &testMessage{foo: 45})
+	_, err = md.Publish(ctx, testMessageA, &testMessage{foo: 45})
 	require.Error(err, "Publish")
 	require.True(errors.Is(err, errTest), "returned error should be the correct one")
 	require.EqualValues([]int32{42, 43, 44, 45}, ms.msgs, "correct messages should be delivered")
 	require.EqualValues([]int32{44, 45}, ms2.msgs, "correct messages should be delivered")
+
+	// Returning a result.
+	var ms3 testSubscriber
+	md.Subscribe(testMessageB, &ms3)
+	res, err := md.Publish(ctx, testMessageB, &resultMessage{})
+	require.NoError(err, "Publish")
+	require.EqualValues(42, res, "correct result should be returned")
+
+	// Multiple subscribers returning a result.
+	md.Subscribe(testMessageA, &ms3)
+	require.Panics(func() { _, _ = md.Publish(ctx, testMessageA, &resultMessage{}) }, "Publish should panic")
 }
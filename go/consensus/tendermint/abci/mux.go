@@ -8,6 +8,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math"
+	"path/filepath"
 	"sort"
 	"sync"
 	"sync/atomic"
@@ -84,6 +85,9 @@ type ApplicationConfig struct { // nolint: maligned
 
 	// InitialHeight is the height of the initial block.
 	InitialHeight uint64
+
+	// TxIndex enables indexing of transactions by their hash.
+	TxIndex bool
 }
 
 // ApplicationServer implements a tendermint ABCI application + socket server,
@@ -176,6 +180,36 @@ func (a *ApplicationServer) WatchInvalidatedTx(txHash hash.Hash) (<-chan error,
 	return a.mux.watchInvalidatedTx(txHash)
 }
 
+// GetTransactionIndexEntry returns the location of the transaction with the given hash.
+func (a *ApplicationServer) GetTransactionIndexEntry(txHash hash.Hash) (*TxIndexEntry, error) {
+	if a.mux.txIndex == nil {
+		return nil, consensus.ErrUnsupported
+	}
+	return a.mux.txIndex.lookup(txHash)
+}
+
+// BackfillTransactionIndex indexes the transactions of all retained blocks that are missing from
+// the transaction index, e.g. because the node stopped before they were indexed or because the
+// index has only been enabled recently.
+//
+// The getTxs function should return the raw transactions of the block at the given height or
+// consensus.ErrVersionNotFound in case the block is not available.
+func (a *ApplicationServer) BackfillTransactionIndex(getTxs func(height int64) ([][]byte, error)) error {
+	if a.mux.txIndex == nil {
+		return nil
+	}
+
+	fromHeight := a.mux.state.InitialHeight()
+	lastRetainedHeight := int64(a.mux.state.statePruner.GetLastRetainedVersion())
+	if lastRetainedHeight > fromHeight {
+		fromHeight = lastRetainedHeight
+	}
+	if err := a.mux.txIndex.backfill(fromHeight, int64(a.mux.state.BlockHeight()), getTxs); err != nil {
+		return err
+	}
+	return a.mux.txIndex.prune(lastRetainedHeight)
+}
+
 // EstimateGas calculates the amount of gas required to execute the given transaction.
 func (a *ApplicationServer) EstimateGas(caller signature.PublicKey, tx *transaction.Transaction) (transaction.Gas, error) {
 	return a.mux.EstimateGas(caller, tx)
@@ -227,6 +261,9 @@ type abciMux struct {
 	invalidatedTxs sync.Map
 
 	md messageDispatcher
+
+	// txIndex is the optional transaction index.
+	txIndex *txIndex
 }
 
 type invalidatedTxSubscription struct {
@@ -395,6 +432,9 @@ func (mux *abciMux) BeginBlock(req types.RequestBeginBlock) types.ResponseBeginB
 		mux.state.blockCtx.Set(api.GasAccountantKey{}, api.NewNopGasAccountant())
 	}
 	mux.state.blockCtx.Set(api.BlockProposerKey{}, req.Header.ProposerAddress)
+	if mux.txIndex != nil {
+		mux.txIndex.discardBlock()
+	}
 	// Create BeginBlock context.
 	ctx := mux.state.NewContext(api.ContextBeginBlock, mux.currentTime)
 	defer ctx.Close()
@@ -481,10 +521,12 @@ func (mux *abciMux) BeginBlock(req types.RequestBeginBlock) types.ResponseBeginB
 	return response
 }
 
-func (mux *abciMux) decodeTx(ctx *api.Context, rawTx []byte) (*transaction.Transaction, *transaction.SignedTransaction, error) {
+// decodeTx decodes and verifies the given transaction and sets the authenticated transaction
+// signer in the context.
+func (mux *abciMux) decodeTx(ctx *api.Context, rawTx []byte) (*transaction.Transaction, error) {
 	if mux.state.haltMode {
 		ctx.Logger().Debug("executeTx: in halt, rejecting all transactions")
-		return nil, nil, fmt.Errorf("halt mode, rejecting all transactions")
+		return nil, fmt.Errorf("halt mode, rejecting all transactions")
 	}
 
 	params := mux.state.ConsensusParameters()
@@ -494,32 +536,59 @@ func (mux *abciMux) decodeTx(ctx *api.Context, rawTx []byte) (*transaction.Trans
 		ctx.Logger().Error("received oversized transaction",
 			"tx_size", len(rawTx),
 		)
-		return nil, nil, consensus.ErrOversizedTx
+		return nil, consensus.ErrOversizedTx
 	}
 
 	// Unmarshal envelope and verify transaction.
-	var sigTx transaction.SignedTransaction
-	if err := cbor.Unmarshal(rawTx, &sigTx); err != nil {
-		ctx.Logger().Error("failed to unmarshal signed transaction",
-			"tx", base64.StdEncoding.EncodeToString(rawTx),
-		)
-		return nil, nil, err
-	}
-	var tx transaction.Transaction
-	if err := sigTx.Open(&tx); err != nil {
-		ctx.Logger().Error("failed to verify transaction signature",
-			"tx", base64.StdEncoding.EncodeToString(rawTx),
-		)
-		return nil, nil, err
+	var (
+		tx    transaction.Transaction
+		sigTx transaction.SignedTransaction
+	)
+	if err := cbor.Unmarshal(rawTx, &sigTx); err == nil {
+		if err = sigTx.Open(&tx); err != nil {
+			ctx.Logger().Error("failed to verify transaction signature",
+				"tx", base64.StdEncoding.EncodeToString(rawTx),
+			)
+			return nil, err
+		}
+
+		// Set authenticated transaction signer.
+		ctx.SetTxSigner(sigTx.Signature.PublicKey)
+	} else {
+		var multiSigTx transaction.MultiSignedTransaction
+		if cbor.Unmarshal(rawTx, &multiSigTx) != nil {
+			ctx.Logger().Error("failed to unmarshal signed transaction",
+				"tx", base64.StdEncoding.EncodeToString(rawTx),
+			)
+			return nil, err
+		}
+		if err = multiSigTx.Open(&tx); err != nil {
+			ctx.Logger().Error("failed to verify multisig transaction signatures",
+				"tx", base64.StdEncoding.EncodeToString(rawTx),
+			)
+			return nil, err
+		}
+		// Multisig transactions rely on the transaction authentication handler to verify that
+		// the signers satisfy the multisig descriptor.
+		if mux.state.txAuthHandler == nil || tx.Method.IsCritical() {
+			ctx.Logger().Error("multisig transaction not allowed",
+				"tx", base64.StdEncoding.EncodeToString(rawTx),
+				"method", tx.Method,
+			)
+			return nil, fmt.Errorf("mux: multisig transactions not allowed for method: %s", tx.Method)
+		}
+
+		// Set authenticated multisig transaction signers.
+		ctx.SetTxMultisig(&multiSigTx.Descriptor, multiSigTx.Signers())
 	}
 	if err := tx.SanityCheck(); err != nil {
 		ctx.Logger().Error("bad transaction",
 			"tx", base64.StdEncoding.EncodeToString(rawTx),
 		)
-		return nil, nil, err
+		return nil, err
 	}
 
-	return &tx, &sigTx, nil
+	return &tx, nil
 }
 
 func (mux *abciMux) processTx(ctx *api.Context, tx *transaction.Transaction, txSize int) error {
@@ -569,14 +638,11 @@ func (mux *abciMux) processTx(ctx *api.Context, tx *transaction.Transaction, txS
 }
 
 func (mux *abciMux) executeTx(ctx *api.Context, rawTx []byte) error {
-	tx, sigTx, err := mux.decodeTx(ctx, rawTx)
+	tx, err := mux.decodeTx(ctx, rawTx)
 	if err != nil {
 		return err
 	}
 
-	// Set authenticated transaction signer.
-	ctx.SetTxSigner(sigTx.Signature.PublicKey)
-
 	return mux.processTx(ctx, tx, len(rawTx))
 }
 
@@ -672,6 +738,10 @@ func (mux *abciMux) DeliverTx(req types.RequestDeliverTx) types.ResponseDeliverT
 	ctx := mux.state.NewContext(api.ContextDeliverTx, mux.currentTime)
 	defer ctx.Close()
 
+	if mux.txIndex != nil {
+		mux.txIndex.deliverTx(req.Tx)
+	}
+
 	if err := mux.executeTx(ctx, req.Tx); err != nil {
 		if api.IsUnavailableStateError(err) {
 			// Make sure to not commit any transactions which include results based on unavailable
@@ -759,6 +829,20 @@ func (mux *abciMux) Commit() types.ResponseCommit {
 		// this failed.
 		panic(err)
 	}
+	if mux.txIndex != nil {
+		if err = mux.txIndex.commit(int64(mux.state.BlockHeight())); err != nil {
+			mux.logger.Error("Commit: failed to index transactions",
+				"err", err,
+			)
+			panic(err)
+		}
+		// Remove entries of transactions in blocks that are no longer retained.
+		if err = mux.txIndex.prune(int64(lastRetainedVersion)); err != nil {
+			mux.logger.Error("Commit: failed to prune transaction index",
+				"err", err,
+			)
+		}
+	}
 
 	mux.logger.Debug("Commit",
 		"block_height", mux.state.BlockHeight(),
@@ -993,6 +1077,11 @@ func (mux *abciMux) ApplySnapshotChunk(req types.RequestApplySnapshotChunk) type
 func (mux *abciMux) doCleanup() {
 	mux.state.doCleanup()
 
+	if mux.txIndex != nil {
+		mux.txIndex.close()
+		mux.txIndex = nil
+	}
+
 	for _, v := range mux.appsByLexOrder {
 		v.OnCleanup()
 	}
@@ -1072,6 +1161,14 @@ func newABCIMux(ctx context.Context, upgrader upgrade.Backend, cfg *ApplicationC
 		lastBeginBlock: blockHeightInvalid,
 	}
 
+	if cfg.TxIndex {
+		mux.txIndex, err = newTxIndex(filepath.Join(cfg.DataDir, appStateDir, txIndexFileName), cfg.MemoryOnlyStorage)
+		if err != nil {
+			state.doCleanup()
+			return nil, fmt.Errorf("mux: failed to create transaction index: %w", err)
+		}
+	}
+
 	mux.logger.Debug("ABCI multiplexer initialized",
 		"block_height", state.BlockHeight(),
 		"block_hash", hex.EncodeToString(state.BlockHash()),
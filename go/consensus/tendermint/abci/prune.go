@@ -14,8 +14,9 @@ const (
 	// PruneDefault is the default PruneStrategy.
 	PruneDefault = pruneNone
 
-	pruneNone  = "none"
-	pruneKeepN = "keep_n"
+	pruneNone               = "none"
+	pruneKeepN              = "keep_n"
+	pruneArchiveCheckpoints = "archive_checkpoints"
 
 	// LogEventABCIPruneDelete is a log event value that signals an ABCI pruning
 	// delete event.
@@ -31,6 +32,10 @@ const (
 
 	// PruneKeepN retains the last N latest versions.
 	PruneKeepN
+
+	// PruneArchiveCheckpoints retains the last N latest versions and archives
+	// older versions so that they can still be queried.
+	PruneArchiveCheckpoints
 )
 
 func (s PruneStrategy) String() string {
@@ -39,6 +44,8 @@ func (s PruneStrategy) String() string {
 		return pruneNone
 	case PruneKeepN:
 		return pruneKeepN
+	case PruneArchiveCheckpoints:
+		return pruneArchiveCheckpoints
 	default:
 		return "[unknown]"
 	}
@@ -50,6 +57,8 @@ func (s *PruneStrategy) FromString(str string) error {
 		*s = PruneNone
 	case pruneKeepN:
 		*s = PruneKeepN
+	case pruneArchiveCheckpoints:
+		*s = PruneArchiveCheckpoints
 	default:
 		return fmt.Errorf("abci/pruner: unknown pruning strategy: '%v'", str)
 	}
@@ -64,6 +73,10 @@ type PruneConfig struct {
 
 	// NumKept is the number of versions retained when applicable.
 	NumKept uint64
+
+	// ArchiveInterval is the interval (in versions) at which the full state
+	// is archived when applicable.
+	ArchiveInterval uint64
 }
 
 // StatePruner is a concrete ABCI mux state pruner implementation.
@@ -106,6 +119,11 @@ type genericPruner struct {
 	earliestVersion     uint64
 	keepN               uint64
 	lastRetainedVersion uint64
+
+	// archive is the state archive that versions are archived into before
+	// they are pruned, if any.
+	archive         *stateArchive
+	archiveInterval uint64
 }
 
 func (p *genericPruner) Initialize(latestVersion uint64) error {
@@ -151,6 +169,12 @@ func (p *genericPruner) doPrune(ctx context.Context, latestVersion uint64) error
 			break
 		}
 
+		if p.archive != nil {
+			if err := p.archive.Archive(ctx, p.ndb, i, p.archiveInterval); err != nil {
+				return fmt.Errorf("failed to archive version %d: %w", i, err)
+			}
+		}
+
 		p.logger.Debug("Prune: Delete",
 			"latest_version", latestVersion,
 			"pruned_version", i,
@@ -176,10 +200,13 @@ func (p *genericPruner) doPrune(ctx context.Context, latestVersion uint64) error
 		return fmt.Errorf("failed to sync state database: %w", err)
 	}
 
-	// We can discard everything below the earliest version.
-	p.Lock()
-	p.lastRetainedVersion = p.earliestVersion
-	p.Unlock()
+	// We can discard everything below the earliest version. When archiving,
+	// all blocks need to be retained so that archived versions can be queried.
+	if p.archive == nil {
+		p.Lock()
+		p.lastRetainedVersion = p.earliestVersion
+		p.Unlock()
+	}
 
 	p.logger.Debug("Prune: Finish",
 		"latest_version", latestVersion,
@@ -189,7 +216,7 @@ func (p *genericPruner) doPrune(ctx context.Context, latestVersion uint64) error
 	return nil
 }
 
-func newStatePruner(cfg *PruneConfig, ndb nodedb.NodeDB, latestVersion uint64) (StatePruner, error) {
+func newStatePruner(cfg *PruneConfig, ndb nodedb.NodeDB, archive *stateArchive, latestVersion uint64) (StatePruner, error) {
 	// The roothash checkCommittees call requires at least 1 previous block
 	// for timekeeping purposes.
 	const minKept = 1
@@ -210,6 +237,24 @@ func newStatePruner(cfg *PruneConfig, ndb nodedb.NodeDB, latestVersion uint64) (
 			ndb:    ndb,
 			keepN:  cfg.NumKept,
 		}
+	case PruneArchiveCheckpoints:
+		if cfg.NumKept < minKept {
+			return nil, fmt.Errorf("abci/pruner: invalid number of versions retained: %v", cfg.NumKept)
+		}
+		if cfg.ArchiveInterval == 0 {
+			return nil, fmt.Errorf("abci/pruner: invalid archive interval: %v", cfg.ArchiveInterval)
+		}
+		if archive == nil {
+			return nil, fmt.Errorf("abci/pruner: state archive required for strategy: %v", cfg.Strategy)
+		}
+
+		statePruner = &genericPruner{
+			logger:          logger,
+			ndb:             ndb,
+			keepN:           cfg.NumKept,
+			archive:         archive,
+			archiveInterval: cfg.ArchiveInterval,
+		}
 	default:
 		return nil, fmt.Errorf("abci/pruner: unsupported pruning strategy: %v", cfg.Strategy)
 	}
@@ -223,6 +268,7 @@ func newStatePruner(cfg *PruneConfig, ndb nodedb.NodeDB, latestVersion uint64) (
 	logger.Debug("ABCI state pruner initialized",
 		"strategy", cfg.Strategy,
 		"num_kept", cfg.NumKept,
+		"archive_interval", cfg.ArchiveInterval,
 	)
 
 	return statePruner, nil
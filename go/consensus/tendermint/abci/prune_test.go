@@ -11,10 +11,12 @@ import (
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
 	mkvsDB "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
 	mkvsBadgerDB "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/badger"
 	mkvsNode "github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+	mkvsWriteLog "github.com/oasisprotocol/oasis-core/go/storage/mkvs/writelog"
 )
 
 func TestPruneKeepN(t *testing.T) {
@@ -56,7 +58,7 @@ func TestPruneKeepN(t *testing.T) {
 	pruner, err := newStatePruner(&PruneConfig{
 		Strategy: PruneKeepN,
 		NumKept:  2,
-	}, ndb, 10)
+	}, ndb, nil, 10)
 	require.NoError(err, "newStatePruner failed")
 
 	earliestVersion, err = ndb.GetEarliestVersion(ctx)
@@ -82,3 +84,90 @@ func TestPruneKeepN(t *testing.T) {
 	lastRetainedVersion = pruner.GetLastRetainedVersion()
 	require.EqualValues(9, lastRetainedVersion, "last retained version should be correct")
 }
+
+func TestPruneArchiveCheckpoints(t *testing.T) {
+	require := require.New(t)
+
+	// Create a new random temporary directory under /tmp.
+	dir, err := ioutil.TempDir("", "abci-prune.test.badger")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dir)
+
+	// Create a Badger-backed Node DB.
+	ndb, err := mkvsBadgerDB.New(&mkvsDB.Config{
+		DB:           dir,
+		NoFsync:      true,
+		MaxCacheSize: 16 * 1024 * 1024,
+	})
+	require.NoError(err, "New")
+	tree := mkvs.New(nil, ndb, mkvsNode.RootTypeState)
+
+	archive, err := newStateArchive("", true)
+	require.NoError(err, "newStateArchive")
+	defer archive.close()
+
+	ctx := context.Background()
+	for i := uint64(1); i <= 11; i++ {
+		err = tree.Insert(ctx, []byte(fmt.Sprintf("key:%d", i)), []byte(fmt.Sprintf("value:%d", i)))
+		require.NoError(err, "Insert")
+		if i > 1 {
+			err = tree.Remove(ctx, []byte(fmt.Sprintf("key:%d", i-1)))
+			require.NoError(err, "Remove")
+		}
+
+		var (
+			writeLog mkvsWriteLog.WriteLog
+			rootHash hash.Hash
+		)
+		writeLog, rootHash, err = tree.Commit(ctx, common.Namespace{}, i)
+		require.NoError(err, "Commit")
+		err = ndb.Finalize(ctx, []mkvsNode.Root{{Namespace: common.Namespace{}, Version: i, Type: mkvsNode.RootTypeState, Hash: rootHash}})
+		require.NoError(err, "Finalize")
+
+		// Skip archiving one of the write logs to make sure a checkpoint is created instead.
+		if i == 6 {
+			continue
+		}
+		err = archive.PutWriteLog(i, rootHash, writeLog)
+		require.NoError(err, "PutWriteLog")
+	}
+
+	_, err = newStatePruner(&PruneConfig{
+		Strategy: PruneArchiveCheckpoints,
+		NumKept:  2,
+	}, ndb, archive, 10)
+	require.Error(err, "newStatePruner should fail without an archive interval")
+
+	pruner, err := newStatePruner(&PruneConfig{
+		Strategy:        PruneArchiveCheckpoints,
+		NumKept:         2,
+		ArchiveInterval: 4,
+	}, ndb, archive, 10)
+	require.NoError(err, "newStatePruner failed")
+
+	earliestVersion, err := ndb.GetEarliestVersion(ctx)
+	require.NoError(err, "GetEarliestVersion")
+	require.EqualValues(8, earliestVersion, "earliest version should be correct")
+
+	lastRetainedVersion := pruner.GetLastRetainedVersion()
+	require.EqualValues(0, lastRetainedVersion, "all blocks should be retained")
+
+	// All pruned versions should be reconstructed from the archive.
+	for i := uint64(1); i < earliestVersion; i++ {
+		var state mkvs.ImmutableKeyValueTree
+		state, err = archive.State(ctx, i)
+		require.NoError(err, "State(%d)", i)
+
+		var value []byte
+		value, err = state.Get(ctx, []byte(fmt.Sprintf("key:%d", i)))
+		require.NoError(err, "Get")
+		require.EqualValues([]byte(fmt.Sprintf("value:%d", i)), value, "archived state should be correct")
+		value, err = state.Get(ctx, []byte(fmt.Sprintf("key:%d", i-1)))
+		require.NoError(err, "Get")
+		require.Nil(value, "archived state should be correct")
+	}
+
+	// Versions that have not yet been archived should not be available.
+	_, err = archive.State(ctx, 0)
+	require.Equal(consensus.ErrVersionNotFound, err, "State should fail for versions before the first checkpoint")
+}
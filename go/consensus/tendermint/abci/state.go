@@ -17,6 +17,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	consensusGenesis "github.com/oasisprotocol/oasis-core/go/consensus/genesis"
 	abciState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/abci/state"
 	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
@@ -28,7 +29,10 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
 )
 
-var _ api.ApplicationState = (*applicationState)(nil)
+var (
+	_ api.ApplicationState  = (*applicationState)(nil)
+	_ api.ArchiveQueryState = (*applicationState)(nil)
+)
 
 // appStateDir is the subdirectory which contains ABCI state.
 const appStateDir = "abci-state"
@@ -52,6 +56,8 @@ type applicationState struct { // nolint: maligned
 
 	checkpointer checkpoint.Checkpointer
 
+	archive *stateArchive
+
 	blockLock   sync.RWMutex
 	blockTime   time.Time
 	blockCtx    *api.BlockContext
@@ -114,6 +120,13 @@ func (s *applicationState) LastRetainedVersion() (int64, error) {
 	return int64(s.statePruner.GetLastRetainedVersion()), nil
 }
 
+func (s *applicationState) ArchivedState(ctx context.Context, version int64) (mkvs.ImmutableKeyValueTree, error) {
+	if s.archive == nil || version <= 0 {
+		return nil, consensus.ErrVersionNotFound
+	}
+	return s.archive.State(ctx, uint64(version))
+}
+
 func (s *applicationState) Storage() storage.LocalBackend {
 	return s.storage
 }
@@ -266,7 +279,7 @@ func (s *applicationState) doApplyStateSync(root storage.Root) error {
 	s.stateRoot = root
 
 	s.deliverTxTree.Close()
-	s.deliverTxTree = mkvs.NewWithRoot(nil, s.storage.NodeDB(), root, mkvs.WithoutWriteLog())
+	s.deliverTxTree = newDeliverTxTree(s.storage.NodeDB(), root, s.archive != nil)
 	s.checkTxTree.Close()
 	s.checkTxTree = mkvs.NewWithRoot(nil, s.storage.NodeDB(), root, mkvs.WithoutWriteLog())
 
@@ -281,7 +294,7 @@ func (s *applicationState) doCommit(now time.Time) (uint64, error) {
 	s.blockLock.Lock()
 	defer s.blockLock.Unlock()
 
-	_, stateRootHash, err := s.deliverTxTree.Commit(s.ctx, s.stateRoot.Namespace, s.stateRoot.Version+1)
+	writeLog, stateRootHash, err := s.deliverTxTree.Commit(s.ctx, s.stateRoot.Namespace, s.stateRoot.Version+1)
 	if err != nil {
 		return 0, fmt.Errorf("failed to commit: %w", err)
 	}
@@ -294,6 +307,11 @@ func (s *applicationState) doCommit(now time.Time) (uint64, error) {
 	if err = s.storage.NodeDB().Finalize(s.ctx, []storage.Root{newStateRoot}); err != nil {
 		return 0, fmt.Errorf("failed to finalize height %d: %w", newStateRoot.Version, err)
 	}
+	if s.archive != nil {
+		if err = s.archive.PutWriteLog(newStateRoot.Version, stateRootHash, writeLog); err != nil {
+			return 0, fmt.Errorf("failed to archive write log for height %d: %w", newStateRoot.Version, err)
+		}
+	}
 
 	s.stateRoot.Hash = stateRootHash
 	s.stateRoot.Version++
@@ -345,6 +363,10 @@ func (s *applicationState) doCleanup() {
 		s.storage.Cleanup()
 		s.storage = nil
 	}
+	if s.archive != nil {
+		s.archive.close()
+		s.archive = nil
+	}
 }
 
 func (s *applicationState) updateMetrics() error {
@@ -503,13 +525,25 @@ func newApplicationState(ctx context.Context, cfg *ApplicationConfig) (*applicat
 	}
 	latestVersion := stateRoot.Version
 
+	// Initialize the state archive if needed.
+	var archive *stateArchive
+	if cfg.Pruning.Strategy == PruneArchiveCheckpoints {
+		archive, err = newStateArchive(filepath.Join(cfg.DataDir, appStateDir, archiveFileName), cfg.MemoryOnlyStorage)
+		if err != nil {
+			return nil, fmt.Errorf("state: failed to create state archive: %w", err)
+		}
+	}
+
 	// Use the node database directly to avoid going through the syncer interface.
-	deliverTxTree := mkvs.NewWithRoot(nil, ndb, *stateRoot, mkvs.WithoutWriteLog())
+	deliverTxTree := newDeliverTxTree(ndb, *stateRoot, archive != nil)
 	checkTxTree := mkvs.NewWithRoot(nil, ndb, *stateRoot, mkvs.WithoutWriteLog())
 
 	// Initialize the state pruner.
-	statePruner, err := newStatePruner(&cfg.Pruning, ndb, latestVersion)
+	statePruner, err := newStatePruner(&cfg.Pruning, ndb, archive, latestVersion)
 	if err != nil {
+		if archive != nil {
+			archive.close()
+		}
 		return nil, fmt.Errorf("state: failed to create pruner: %w", err)
 	}
 
@@ -530,6 +564,7 @@ func newApplicationState(ctx context.Context, cfg *ApplicationConfig) (*applicat
 		stateRoot:          *stateRoot,
 		storage:            ldb,
 		statePruner:        statePruner,
+		archive:            archive,
 		prunerClosedCh:     make(chan struct{}),
 		prunerNotifyCh:     channels.NewRingChannel(1),
 		haltEpochHeight:    cfg.HaltEpochHeight,
@@ -573,6 +608,16 @@ func newApplicationState(ctx context.Context, cfg *ApplicationConfig) (*applicat
 	return s, nil
 }
 
+// newDeliverTxTree creates a new DeliverTx state tree with the given root.
+//
+// In case the block write logs are needed (e.g., for archiving), the tree will build a write log.
+func newDeliverTxTree(ndb storage.NodeDB, root storage.Root, withWriteLog bool) mkvs.Tree {
+	if withWriteLog {
+		return mkvs.NewWithRoot(nil, ndb, root)
+	}
+	return mkvs.NewWithRoot(nil, ndb, root, mkvs.WithoutWriteLog())
+}
+
 func parseGenesisAppState(req types.RequestInitChain) (*genesis.Document, error) {
 	var st genesis.Document
 	if err := json.Unmarshal(req.AppStateBytes, &st); err != nil {
@@ -0,0 +1,376 @@
+package abci
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/dgraph-io/badger/v2/options"
+
+	cmnBadger "github.com/oasisprotocol/oasis-core/go/common/badger"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/keyformat"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+)
+
+const (
+	txIndexDBVersion = 2
+
+	// txIndexFileName is the name of the transaction index database file.
+	txIndexFileName = "txindex.badger.db"
+)
+
+var (
+	// txIndexMetadataKeyFmt is the transaction index metadata key format.
+	//
+	// Value is CBOR-serialized txIndexMetadata.
+	txIndexMetadataKeyFmt = keyformat.New(0x01)
+	// txIndexEntryKeyFmt is the transaction index entry key format (transaction hash).
+	//
+	// Value is CBOR-serialized TxIndexEntry.
+	txIndexEntryKeyFmt = keyformat.New(0x02, &hash.Hash{})
+	// txIndexHeightKeyFmt is the transaction index height key format (height, transaction hash).
+	//
+	// Value is empty. It is used to remove the entries of pruned heights.
+	txIndexHeightKeyFmt = keyformat.New(0x03, uint64(0), &hash.Hash{})
+)
+
+type txIndexMetadata struct {
+	// Version is the database schema version.
+	Version uint64 `json:"version"`
+
+	// LastHeight is the height of the last indexed block.
+	LastHeight int64 `json:"last_height"`
+
+	// Gaps are the ranges of blocks that were skipped by non-contiguous commits and still need
+	// to be backfilled.
+	Gaps []txIndexGap `json:"gaps,omitempty"`
+}
+
+// txIndexGap is an inclusive range of block heights that have not been indexed.
+type txIndexGap struct {
+	FromHeight int64 `json:"from_height"`
+	ToHeight   int64 `json:"to_height"`
+}
+
+// TxIndexEntry is the location of a transaction in the chain.
+type TxIndexEntry struct {
+	// Height is the height of the block that includes the transaction.
+	Height int64 `json:"height"`
+	// Index is the index of the transaction within the block.
+	Index uint32 `json:"index"`
+}
+
+// txIndex is an index of transactions included in finalized blocks, keyed by transaction hash.
+type txIndex struct {
+	logger *logging.Logger
+
+	db *badger.DB
+	gc *cmnBadger.GCWorker
+
+	// lastHeight is the height of the last indexed block.
+	lastHeight int64
+	// gaps are the ranges of blocks before lastHeight that have not been indexed.
+	gaps []txIndexGap
+
+	// pending are the hashes of transactions delivered in the current block.
+	pending []hash.Hash
+}
+
+func newTxIndex(fn string, memoryOnly bool) (*txIndex, error) {
+	logger := logging.GetLogger("abci-mux/txindex").With("path", fn)
+
+	opts := badger.DefaultOptions(fn)
+	opts = opts.WithLogger(cmnBadger.NewLogAdapter(logger))
+	opts = opts.WithSyncWrites(true)
+	// Allow value log truncation if required (this is needed to recover the
+	// value log file which can get corrupted in crashes).
+	opts = opts.WithTruncate(true)
+	opts = opts.WithCompression(options.Snappy)
+	if memoryOnly {
+		opts = opts.WithInMemory(true).WithDir("").WithValueDir("")
+	}
+
+	db, err := badger.Open(opts)
+	if err != nil {
+		return nil, fmt.Errorf("abci/txindex: failed to open database: %w", err)
+	}
+
+	idx := &txIndex{
+		logger: logger,
+		db:     db,
+		gc:     cmnBadger.NewGCWorker(logger, db),
+	}
+
+	// Ensure metadata is valid.
+	if err = idx.ensureMetadata(); err != nil {
+		idx.close()
+		return nil, err
+	}
+
+	return idx, nil
+}
+
+func (idx *txIndex) ensureMetadata() error {
+	return idx.db.Update(func(tx *badger.Txn) error {
+		item, err := tx.Get(txIndexMetadataKeyFmt.Encode())
+		switch err {
+		case nil:
+		case badger.ErrKeyNotFound:
+			// Create new metadata section.
+			meta := txIndexMetadata{
+				Version: txIndexDBVersion,
+			}
+			return tx.Set(txIndexMetadataKeyFmt.Encode(), cbor.Marshal(meta))
+		default:
+			return err
+		}
+
+		// Verify metadata section.
+		var meta txIndexMetadata
+		if err = item.Value(func(val []byte) error {
+			return cbor.Unmarshal(val, &meta)
+		}); err != nil {
+			return err
+		}
+		if meta.Version != txIndexDBVersion {
+			return fmt.Errorf("abci/txindex: unsupported database version (expected: %d got: %d)",
+				txIndexDBVersion,
+				meta.Version,
+			)
+		}
+		idx.lastHeight = meta.LastHeight
+		idx.gaps = meta.Gaps
+		return nil
+	})
+}
+
+// deliverTx records a transaction delivered in the current block.
+func (idx *txIndex) deliverTx(rawTx []byte) {
+	idx.pending = append(idx.pending, hash.NewFromBytes(rawTx))
+}
+
+// discardBlock discards any transactions recorded for the current block.
+func (idx *txIndex) discardBlock() {
+	idx.pending = nil
+}
+
+// commit indexes all transactions delivered in the block at the given height.
+func (idx *txIndex) commit(height int64) error {
+	defer idx.discardBlock()
+
+	if idx.lastHeight != 0 && height > idx.lastHeight+1 {
+		// This should only happen in case the index was disabled for a while. Remember the
+		// skipped blocks so that they are indexed by a backfill on the next startup.
+		idx.logger.Warn("indexed blocks are not contiguous",
+			"height", height,
+			"last_height", idx.lastHeight,
+		)
+		idx.gaps = append(idx.gaps, txIndexGap{
+			FromHeight: idx.lastHeight + 1,
+			ToHeight:   height - 1,
+		})
+	}
+	return idx.indexBlock(height, idx.pending)
+}
+
+// backfill indexes the transactions of all blocks that were skipped by non-contiguous commits and
+// of all blocks following the last indexed block up to and including the given height. Blocks
+// before fromHeight are never indexed.
+//
+// The getTxs function should return the raw transactions of the block at the given height or
+// consensus.ErrVersionNotFound in case the block is not available.
+func (idx *txIndex) backfill(fromHeight, toHeight int64, getTxs func(height int64) ([][]byte, error)) error {
+	for len(idx.gaps) > 0 {
+		gap := idx.gaps[0]
+		gapFrom, gapTo := gap.FromHeight, gap.ToHeight
+		if gapFrom < fromHeight {
+			gapFrom = fromHeight
+		}
+		if gapTo > toHeight {
+			gapTo = toHeight
+		}
+		if err := idx.backfillRange(gapFrom, gapTo, getTxs); err != nil {
+			return err
+		}
+
+		idx.gaps = idx.gaps[1:]
+		if err := idx.db.Update(func(tx *badger.Txn) error {
+			return idx.updateMetadata(tx, idx.lastHeight)
+		}); err != nil {
+			return fmt.Errorf("abci/txindex: failed to update metadata: %w", err)
+		}
+	}
+
+	if idx.lastHeight+1 > fromHeight {
+		fromHeight = idx.lastHeight + 1
+	}
+	return idx.backfillRange(fromHeight, toHeight, getTxs)
+}
+
+func (idx *txIndex) backfillRange(fromHeight, toHeight int64, getTxs func(height int64) ([][]byte, error)) error {
+	if fromHeight > toHeight {
+		return nil
+	}
+
+	idx.logger.Info("indexing transactions of past blocks",
+		"from_height", fromHeight,
+		"to_height", toHeight,
+	)
+
+	for height := fromHeight; height <= toHeight; height++ {
+		txs, err := getTxs(height)
+		switch {
+		case err == nil:
+		case errors.Is(err, consensus.ErrVersionNotFound):
+			// Block is not available, so its transactions could not be queried anyway.
+			idx.logger.Debug("skipping unavailable block",
+				"height", height,
+			)
+			continue
+		default:
+			return fmt.Errorf("abci/txindex: failed to get transactions at height %d: %w", height, err)
+		}
+
+		txHashes := make([]hash.Hash, 0, len(txs))
+		for _, rawTx := range txs {
+			txHashes = append(txHashes, hash.NewFromBytes(rawTx))
+		}
+		if err = idx.indexBlock(height, txHashes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (idx *txIndex) indexBlock(height int64, txHashes []hash.Hash) error {
+	// Backfilled gaps must not move the last indexed height backwards.
+	lastHeight := idx.lastHeight
+	if height > lastHeight {
+		lastHeight = height
+	}
+
+	err := idx.db.Update(func(tx *badger.Txn) error {
+		seen := make(map[hash.Hash]bool)
+		for i, txHash := range txHashes {
+			// In case the same transaction is included multiple times, the first occurrence wins as
+			// all later ones will fail due to invalid nonces.
+			if seen[txHash] {
+				continue
+			}
+			seen[txHash] = true
+
+			_, err := tx.Get(txIndexEntryKeyFmt.Encode(&txHash))
+			switch err {
+			case nil:
+				continue
+			case badger.ErrKeyNotFound:
+			default:
+				return err
+			}
+
+			entry := TxIndexEntry{
+				Height: height,
+				Index:  uint32(i),
+			}
+			if err = tx.Set(txIndexEntryKeyFmt.Encode(&txHash), cbor.Marshal(&entry)); err != nil {
+				return err
+			}
+			if err = tx.Set(txIndexHeightKeyFmt.Encode(uint64(height), &txHash), []byte{}); err != nil {
+				return err
+			}
+		}
+
+		return idx.updateMetadata(tx, lastHeight)
+	})
+	if err != nil {
+		return fmt.Errorf("abci/txindex: failed to index transactions: %w", err)
+	}
+
+	idx.lastHeight = lastHeight
+	return nil
+}
+
+func (idx *txIndex) updateMetadata(tx *badger.Txn, lastHeight int64) error {
+	return tx.Set(txIndexMetadataKeyFmt.Encode(), cbor.Marshal(&txIndexMetadata{
+		Version:    txIndexDBVersion,
+		LastHeight: lastHeight,
+		Gaps:       idx.gaps,
+	}))
+}
+
+// prune removes the entries of all transactions included in blocks before the given height.
+func (idx *txIndex) prune(height int64) error {
+	var keys [][]byte
+	err := idx.db.View(func(tx *badger.Txn) error {
+		it := tx.NewIterator(badger.IteratorOptions{Prefix: txIndexHeightKeyFmt.Encode()})
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			var (
+				entryHeight uint64
+				txHash      hash.Hash
+			)
+			if !txIndexHeightKeyFmt.Decode(it.Item().Key(), &entryHeight, &txHash) {
+				return fmt.Errorf("abci/txindex: malformed height key")
+			}
+			if int64(entryHeight) >= height {
+				break
+			}
+			keys = append(keys, it.Item().KeyCopy(nil), txIndexEntryKeyFmt.Encode(&txHash))
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("abci/txindex: failed to find pruned transactions: %w", err)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	wb := idx.db.NewWriteBatch()
+	defer wb.Cancel()
+	for _, key := range keys {
+		if err = wb.Delete(key); err != nil {
+			return fmt.Errorf("abci/txindex: failed to prune transactions: %w", err)
+		}
+	}
+	if err = wb.Flush(); err != nil {
+		return fmt.Errorf("abci/txindex: failed to flush write batch: %w", err)
+	}
+	return nil
+}
+
+// lookup returns the location of the transaction with the given hash.
+func (idx *txIndex) lookup(txHash hash.Hash) (*TxIndexEntry, error) {
+	var entry TxIndexEntry
+	err := idx.db.View(func(tx *badger.Txn) error {
+		item, err := tx.Get(txIndexEntryKeyFmt.Encode(&txHash))
+		switch err {
+		case nil:
+		case badger.ErrKeyNotFound:
+			return consensus.ErrNoSuchTransaction
+		default:
+			return err
+		}
+		return item.Value(func(val []byte) error {
+			return cbor.Unmarshal(val, &entry)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (idx *txIndex) close() {
+	idx.gc.Close()
+
+	if err := idx.db.Close(); err != nil {
+		idx.logger.Error("failed to close transaction index",
+			"err", err,
+		)
+	}
+}
@@ -0,0 +1,169 @@
+package abci
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+)
+
+func TestTxIndex(t *testing.T) {
+	require := require.New(t)
+
+	idx, err := newTxIndex("", true)
+	require.NoError(err, "newTxIndex")
+	defer idx.close()
+
+	tx1, tx2, tx3 := []byte("tx 1"), []byte("tx 2"), []byte("tx 3")
+
+	_, err = idx.lookup(hash.NewFromBytes(tx1))
+	require.ErrorIs(err, consensus.ErrNoSuchTransaction, "lookup should fail for unknown transactions")
+
+	idx.deliverTx(tx1)
+	idx.deliverTx(tx2)
+	err = idx.commit(10)
+	require.NoError(err, "commit")
+
+	entry, err := idx.lookup(hash.NewFromBytes(tx2))
+	require.NoError(err, "lookup")
+	require.EqualValues(&TxIndexEntry{Height: 10, Index: 1}, entry, "entry should point to the transaction")
+
+	// Transactions delivered in a discarded block should not be indexed.
+	idx.deliverTx(tx3)
+	idx.discardBlock()
+	err = idx.commit(11)
+	require.NoError(err, "commit")
+	_, err = idx.lookup(hash.NewFromBytes(tx3))
+	require.ErrorIs(err, consensus.ErrNoSuchTransaction, "lookup should fail for discarded transactions")
+
+	// Duplicate transactions should keep pointing to the first occurrence.
+	idx.deliverTx(tx3)
+	idx.deliverTx(tx1)
+	err = idx.commit(12)
+	require.NoError(err, "commit")
+
+	entry, err = idx.lookup(hash.NewFromBytes(tx1))
+	require.NoError(err, "lookup")
+	require.EqualValues(&TxIndexEntry{Height: 10, Index: 0}, entry, "entry should point to the first occurrence")
+	entry, err = idx.lookup(hash.NewFromBytes(tx3))
+	require.NoError(err, "lookup")
+	require.EqualValues(&TxIndexEntry{Height: 12, Index: 0}, entry, "entry should point to the transaction")
+}
+
+func TestTxIndexBackfillAndPrune(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "abci-txindex.test.badger")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dir)
+	fn := filepath.Join(dir, txIndexFileName)
+
+	blockTx := func(height int64, i int) []byte {
+		return []byte(fmt.Sprintf("tx %d/%d", height, i))
+	}
+	getTxs := func(height int64) ([][]byte, error) {
+		if height == 4 {
+			return nil, consensus.ErrVersionNotFound
+		}
+		return [][]byte{blockTx(height, 0), blockTx(height, 1)}, nil
+	}
+
+	idx, err := newTxIndex(fn, false)
+	require.NoError(err, "newTxIndex")
+	for height := int64(1); height <= 2; height++ {
+		txs, _ := getTxs(height)
+		for _, tx := range txs {
+			idx.deliverTx(tx)
+		}
+		err = idx.commit(height)
+		require.NoError(err, "commit")
+	}
+	idx.close()
+
+	// The last indexed height should be persisted.
+	idx, err = newTxIndex(fn, false)
+	require.NoError(err, "newTxIndex")
+	defer idx.close()
+	require.EqualValues(2, idx.lastHeight, "last indexed height should be persisted")
+
+	// Backfill should only index blocks following the last indexed block and skip unavailable ones.
+	var queried []int64
+	err = idx.backfill(1, 5, func(height int64) ([][]byte, error) {
+		queried = append(queried, height)
+		return getTxs(height)
+	})
+	require.NoError(err, "backfill")
+	require.EqualValues([]int64{3, 4, 5}, queried, "backfill should only query missing blocks")
+	require.EqualValues(5, idx.lastHeight, "last indexed height should be updated")
+
+	entry, err := idx.lookup(hash.NewFromBytes(blockTx(5, 1)))
+	require.NoError(err, "lookup")
+	require.EqualValues(&TxIndexEntry{Height: 5, Index: 1}, entry, "backfilled entry should be correct")
+
+	// Pruning should remove entries of blocks before the given height.
+	err = idx.prune(3)
+	require.NoError(err, "prune")
+	for height := int64(1); height <= 5; height++ {
+		if height == 4 {
+			continue
+		}
+		_, err = idx.lookup(hash.NewFromBytes(blockTx(height, 0)))
+		if height < 3 {
+			require.ErrorIs(err, consensus.ErrNoSuchTransaction, "pruned entries should be removed")
+		} else {
+			require.NoError(err, "entries of retained blocks should be kept")
+		}
+	}
+}
+
+func TestTxIndexBackfillGap(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "abci-txindex.test.badger")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dir)
+	fn := filepath.Join(dir, txIndexFileName)
+
+	blockTx := func(height int64) []byte {
+		return []byte(fmt.Sprintf("tx %d", height))
+	}
+
+	// Index heights 1, 2 and 5, skipping 3 and 4.
+	idx, err := newTxIndex(fn, false)
+	require.NoError(err, "newTxIndex")
+	for _, height := range []int64{1, 2, 5} {
+		idx.deliverTx(blockTx(height))
+		err = idx.commit(height)
+		require.NoError(err, "commit")
+	}
+	require.EqualValues(5, idx.lastHeight, "last indexed height should be updated")
+	idx.close()
+
+	// The gap should be persisted and backfilled after a restart.
+	idx, err = newTxIndex(fn, false)
+	require.NoError(err, "newTxIndex")
+	defer idx.close()
+	require.EqualValues([]txIndexGap{{FromHeight: 3, ToHeight: 4}}, idx.gaps, "gap should be persisted")
+
+	var queried []int64
+	err = idx.backfill(1, 6, func(height int64) ([][]byte, error) {
+		queried = append(queried, height)
+		return [][]byte{blockTx(height)}, nil
+	})
+	require.NoError(err, "backfill")
+	require.EqualValues([]int64{3, 4, 6}, queried, "backfill should query the gap and following blocks")
+	require.EqualValues(6, idx.lastHeight, "last indexed height should be updated")
+	require.Empty(idx.gaps, "gap should be removed after backfill")
+
+	for height := int64(1); height <= 6; height++ {
+		entry, err := idx.lookup(hash.NewFromBytes(blockTx(height)))
+		require.NoError(err, "lookup")
+		require.EqualValues(&TxIndexEntry{Height: height, Index: 0}, entry, "entry should point to the transaction")
+	}
+}
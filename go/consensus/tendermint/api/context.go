@@ -10,6 +10,7 @@ import (
 
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
 )
@@ -69,8 +70,10 @@ type Context struct {
 	events        []types.Event
 	gasAccountant GasAccountant
 
-	txSigner      signature.PublicKey
-	callerAddress staking.Address
+	txSigner       signature.PublicKey
+	txMultisig     *transaction.MultisigDescriptor
+	txMultisigners []signature.PublicKey
+	callerAddress  staking.Address
 
 	appState      ApplicationState
 	state         mkvs.Tree
@@ -185,6 +188,8 @@ func (c *Context) SetTxSigner(txSigner signature.PublicKey) {
 	switch c.mode {
 	case ContextCheckTx, ContextDeliverTx, ContextSimulateTx:
 		c.txSigner = txSigner
+		c.txMultisig = nil
+		c.txMultisigners = nil
 		// By default, the caller is the transaction signer.
 		c.callerAddress = staking.NewAddress(txSigner)
 	default:
@@ -192,6 +197,42 @@ func (c *Context) SetTxSigner(txSigner signature.PublicKey) {
 	}
 }
 
+// TxMultisig returns the multisig descriptor and the authenticated signers of a multisig
+// transaction or nil in case the transaction is not a multisig transaction.
+//
+// Note that the signers are only authenticated to have produced valid signatures, it is up to
+// the transaction authentication handler to verify that they satisfy the descriptor.
+//
+// In case the method is called on a non-transaction context, this method
+// will panic.
+func (c *Context) TxMultisig() (*transaction.MultisigDescriptor, []signature.PublicKey) {
+	switch c.mode {
+	case ContextCheckTx, ContextDeliverTx, ContextSimulateTx:
+		return c.txMultisig, c.txMultisigners
+	default:
+		panic("context: only available in transaction context")
+	}
+}
+
+// SetTxMultisig sets the multisig descriptor and the authenticated signers of a multisig
+// transaction. The caller is the multisig account and the transaction signer is left unset.
+//
+// This must only be done after verifying the transaction signatures.
+//
+// In case the method is called on a non-transaction context, this method
+// will panic.
+func (c *Context) SetTxMultisig(descriptor *transaction.MultisigDescriptor, signers []signature.PublicKey) {
+	switch c.mode {
+	case ContextCheckTx, ContextDeliverTx, ContextSimulateTx:
+		c.txSigner = signature.PublicKey{}
+		c.txMultisig = descriptor
+		c.txMultisigners = signers
+		c.callerAddress = staking.NewMultisigAddress(descriptor)
+	default:
+		panic("context: only available in transaction context")
+	}
+}
+
 // CallerAddress returns the authenticated address representing the caller.
 func (c *Context) CallerAddress() staking.Address {
 	return c.callerAddress
@@ -205,6 +246,8 @@ func (c *Context) NewChild() *Context {
 		currentTime:     c.currentTime,
 		gasAccountant:   c.gasAccountant,
 		txSigner:        c.txSigner,
+		txMultisig:      c.txMultisig,
+		txMultisigners:  c.txMultisigners,
 		callerAddress:   c.callerAddress,
 		appState:        c.appState,
 		state:           c.state,
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+)
+
+// ConsensusParameters is the interface implemented by the consensus parameters of a module.
+type ConsensusParameters interface {
+	// SanityCheck performs a sanity check on the consensus parameters.
+	SanityCheck() error
+}
+
+// ConsensusParameterChanges is the interface implemented by the consensus parameter changes of
+// a module.
+type ConsensusParameterChanges interface {
+	// SanityCheck performs a sanity check on the consensus parameter changes.
+	SanityCheck() error
+}
+
+// ConsensusParametersModule provides access to the consensus parameters of a module that can be
+// changed by change parameters proposals.
+type ConsensusParametersModule interface {
+	// NewChanges returns empty consensus parameter changes of the module.
+	NewChanges() ConsensusParameterChanges
+
+	// Load loads the current consensus parameters of the module.
+	Load(ctx *Context) (ConsensusParameters, error)
+
+	// Apply applies the given changes to the given consensus parameters.
+	Apply(params ConsensusParameters, changes ConsensusParameterChanges) error
+
+	// Set persists the given consensus parameters of the module.
+	Set(ctx *Context, params ConsensusParameters) error
+}
+
+// ChangeParameters handles a change parameters proposal message for the given module.
+//
+// The changes carried by the proposal are decoded and sanity checked, after which they are
+// applied to the module's current consensus parameters and the result is sanity checked. In case
+// apply is set, the new consensus parameters are also persisted.
+//
+// A nil result is returned in case the proposal is not targeting the given module.
+func ChangeParameters(
+	ctx *Context,
+	msg interface{},
+	module string,
+	m ConsensusParametersModule,
+	apply bool,
+) (interface{}, error) {
+	proposal, ok := msg.(*governance.ChangeParametersProposal)
+	if !ok {
+		return nil, fmt.Errorf("%s: failed to type assert change parameters proposal", module)
+	}
+
+	if proposal.Module != module {
+		return nil, nil
+	}
+
+	changes := m.NewChanges()
+	if err := cbor.Unmarshal(proposal.Changes, changes); err != nil {
+		return nil, fmt.Errorf("%s: failed to unmarshal consensus parameter changes: %w", module, err)
+	}
+	if err := changes.SanityCheck(); err != nil {
+		return nil, fmt.Errorf("%s: failed to validate consensus parameter changes: %w", module, err)
+	}
+
+	params, err := m.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to load consensus parameters: %w", module, err)
+	}
+	if err = m.Apply(params, changes); err != nil {
+		return nil, fmt.Errorf("%s: failed to apply consensus parameter changes: %w", module, err)
+	}
+	if err = params.SanityCheck(); err != nil {
+		return nil, fmt.Errorf("%s: failed to validate consensus parameters: %w", module, err)
+	}
+	if apply {
+		if err = m.Set(ctx, params); err != nil {
+			return nil, fmt.Errorf("%s: failed to set consensus parameters: %w", module, err)
+		}
+	}
+
+	// A non-nil result signals that the changes are valid and were applied (if requested).
+	return struct{}{}, nil
+}
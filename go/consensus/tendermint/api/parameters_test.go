@@ -0,0 +1,117 @@
+package api
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+)
+
+type testParameters struct {
+	Value uint64
+}
+
+func (p *testParameters) SanityCheck() error {
+	if p.Value > 100 {
+		return errors.New("consensus parameter value too large")
+	}
+	return nil
+}
+
+type testParameterChanges struct {
+	Value *uint64 `json:"value,omitempty"`
+}
+
+func (c *testParameterChanges) SanityCheck() error {
+	if c.Value == nil {
+		return errors.New("consensus parameter changes should not be empty")
+	}
+	return nil
+}
+
+type testParametersModule struct {
+	params   testParameters
+	applyErr error
+}
+
+func (m *testParametersModule) NewChanges() ConsensusParameterChanges {
+	return &testParameterChanges{}
+}
+
+func (m *testParametersModule) Load(ctx *Context) (ConsensusParameters, error) {
+	params := m.params
+	return &params, nil
+}
+
+func (m *testParametersModule) Apply(params ConsensusParameters, changes ConsensusParameterChanges) error {
+	if m.applyErr != nil {
+		return m.applyErr
+	}
+	params.(*testParameters).Value = *changes.(*testParameterChanges).Value
+	return nil
+}
+
+func (m *testParametersModule) Set(ctx *Context, params ConsensusParameters) error {
+	m.params = *params.(*testParameters)
+	return nil
+}
+
+func TestChangeParameters(t *testing.T) {
+	require := require.New(t)
+
+	appState := NewMockApplicationState(&MockApplicationStateConfig{})
+	ctx := appState.NewContext(ContextEndBlock, time.Now())
+	defer ctx.Close()
+
+	value := uint64(42)
+	proposal := &governance.ChangeParametersProposal{
+		Module:  "test",
+		Changes: cbor.Marshal(testParameterChanges{Value: &value}),
+	}
+	m := &testParametersModule{}
+
+	// Invalid message.
+	_, err := ChangeParameters(ctx, proposal.Changes, "test", m, false)
+	require.Error(err, "ChangeParameters should fail with an invalid message")
+
+	// Proposal targeting a different module.
+	res, err := ChangeParameters(ctx, proposal, "other", m, true)
+	require.NoError(err, "ChangeParameters")
+	require.Nil(res, "ChangeParameters should ignore proposals for other modules")
+	require.EqualValues(0, m.params.Value, "changes for other modules should not be applied")
+
+	// Valid proposal.
+	res, err = ChangeParameters(ctx, proposal, "test", m, false)
+	require.NoError(err, "ChangeParameters")
+	require.NotNil(res, "ChangeParameters should return a result")
+	require.EqualValues(0, m.params.Value, "changes should not be persisted unless applied")
+
+	res, err = ChangeParameters(ctx, proposal, "test", m, true)
+	require.NoError(err, "ChangeParameters")
+	require.NotNil(res, "ChangeParameters should return a result")
+	require.EqualValues(42, m.params.Value, "changes should be persisted when applied")
+
+	// Invalid changes.
+	proposal.Changes = cbor.Marshal(testParameterChanges{})
+	_, err = ChangeParameters(ctx, proposal, "test", m, true)
+	require.Error(err, "ChangeParameters should fail with invalid changes")
+
+	// Changes resulting in invalid consensus parameters.
+	value = 1000
+	proposal.Changes = cbor.Marshal(testParameterChanges{Value: &value})
+	_, err = ChangeParameters(ctx, proposal, "test", m, true)
+	require.Error(err, "ChangeParameters should fail with invalid consensus parameters")
+	require.EqualValues(42, m.params.Value, "invalid consensus parameters should not be persisted")
+
+	// Changes failing to apply.
+	value = 10
+	proposal.Changes = cbor.Marshal(testParameterChanges{Value: &value})
+	m.applyErr = errors.New("bad changes")
+	_, err = ChangeParameters(ctx, proposal, "test", m, true)
+	require.Error(err, "ChangeParameters should fail when changes fail to apply")
+	require.EqualValues(42, m.params.Value, "changes failing to apply should not be persisted")
+}
@@ -84,6 +84,15 @@ type ApplicationQueryState interface {
 	LastRetainedVersion() (int64, error)
 }
 
+// ArchiveQueryState is an application query state that can also reconstruct state versions which
+// have been pruned from the state storage.
+type ArchiveQueryState interface {
+	// ArchivedState returns the state at the given version reconstructed from the state archive.
+	//
+	// In case the version cannot be reconstructed, consensus.ErrVersionNotFound is returned.
+	ArchivedState(ctx context.Context, version int64) (mkvs.ImmutableKeyValueTree, error)
+}
+
 // MockApplicationState is the mock application state interface.
 type MockApplicationState interface {
 	ApplicationState
@@ -286,7 +295,14 @@ func NewImmutableState(ctx context.Context, state ApplicationQueryState, version
 	}
 	switch len(roots) {
 	case 0:
-		// No roots for that state -- it may have been pruned.
+		// No roots for that state -- it may have been pruned, try the state archive if any.
+		if as, ok := state.(ArchiveQueryState); ok {
+			var tree mkvs.ImmutableKeyValueTree
+			if tree, err = as.ArchivedState(ctx, version); err != nil {
+				return nil, err
+			}
+			return &ImmutableState{tree}, nil
+		}
 		return nil, consensus.ErrVersionNotFound
 	case 1:
 		// A single root.
@@ -14,6 +14,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
 	beaconState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/beacon/state"
+	governanceApi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/governance/api"
 )
 
 var (
@@ -53,6 +54,10 @@ func (app *beaconApplication) Dependencies() []string {
 
 func (app *beaconApplication) OnRegister(state api.ApplicationState, md api.MessageDispatcher) {
 	app.state = state
+
+	// Subscribe to messages emitted by other apps.
+	md.Subscribe(governanceApi.MessageChangeParameters, app)
+	md.Subscribe(governanceApi.MessageValidateParameterChanges, app)
 }
 
 func (app *beaconApplication) OnCleanup() {
@@ -73,8 +78,13 @@ func (app *beaconApplication) BeginBlock(ctx *api.Context, req types.RequestBegi
 	return app.backend.OnBeginBlock(ctx, state, params, req)
 }
 
-func (app *beaconApplication) ExecuteMessage(ctx *api.Context, kind, msg interface{}) error {
-	return fmt.Errorf("beacon: unexpected message")
+func (app *beaconApplication) ExecuteMessage(ctx *api.Context, kind, msg interface{}) (interface{}, error) {
+	switch kind {
+	case governanceApi.MessageValidateParameterChanges, governanceApi.MessageChangeParameters:
+		return app.changeParameters(ctx, msg, kind == governanceApi.MessageChangeParameters)
+	default:
+		return nil, fmt.Errorf("beacon: unexpected message")
+	}
 }
 
 func (app *beaconApplication) ExecuteTx(ctx *api.Context, tx *transaction.Transaction) error {
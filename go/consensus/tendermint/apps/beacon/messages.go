@@ -0,0 +1,38 @@
+package beacon
+
+import (
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	beaconState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/beacon/state"
+)
+
+// consensusParameters implements api.ConsensusParametersModule for the beacon module.
+type consensusParameters struct {
+	state *beaconState.MutableState
+}
+
+func (p *consensusParameters) NewChanges() api.ConsensusParameterChanges {
+	return &beacon.ConsensusParameterChanges{}
+}
+
+func (p *consensusParameters) Load(ctx *api.Context) (api.ConsensusParameters, error) {
+	params, err := p.state.ConsensusParameters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+func (p *consensusParameters) Apply(params api.ConsensusParameters, changes api.ConsensusParameterChanges) error {
+	return changes.(*beacon.ConsensusParameterChanges).Apply(params.(*beacon.ConsensusParameters))
+}
+
+func (p *consensusParameters) Set(ctx *api.Context, params api.ConsensusParameters) error {
+	return p.state.SetConsensusParameters(ctx, params.(*beacon.ConsensusParameters))
+}
+
+// changeParameters handles a change parameters proposal targeting the beacon module.
+func (app *beaconApplication) changeParameters(ctx *api.Context, msg interface{}, apply bool) (interface{}, error) {
+	params := &consensusParameters{state: beaconState.NewMutableState(ctx.State())}
+	return api.ChangeParameters(ctx, msg, beacon.ModuleName, params, apply)
+}
@@ -32,4 +32,7 @@ var (
 	// KeyProposalExecuted is an ABCI event attribute key for executed proposals
 	// (value is a CBOR serialized ProposalExecutedEvent).
 	KeyProposalExecuted = []byte("proposal-executed")
+	// KeyParametersChanged is an ABCI event attribute key for consensus parameter changes
+	// (value is a CBOR serialized ParametersChangedEvent).
+	KeyParametersChanged = []byte("parameters-changed")
 )
@@ -0,0 +1,21 @@
+// Package api defines the governance application API for other applications.
+package api
+
+type messageKind uint8
+
+var (
+	// MessageValidateParameterChanges is the message kind for validating consensus parameter
+	// changes. The message is the change parameters proposal that is being submitted.
+	//
+	// The subscriber owning the module targeted by the proposal should validate the changes
+	// against the current consensus parameters and return a non-nil result if they are valid.
+	// Other subscribers should ignore the message.
+	MessageValidateParameterChanges = messageKind(0)
+
+	// MessageChangeParameters is the message kind for applying consensus parameter changes. The
+	// message is the change parameters proposal that has been accepted.
+	//
+	// The subscriber owning the module targeted by the proposal should validate and apply the
+	// changes and return a non-nil result. Other subscribers should ignore the message.
+	MessageChangeParameters = messageKind(1)
+)
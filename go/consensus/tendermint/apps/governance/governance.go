@@ -1,6 +1,7 @@
 package governance
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/tendermint/tendermint/abci/types"
@@ -10,14 +11,17 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	governanceApi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/governance/api"
 	governanceState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/governance/state"
 	registryapp "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry/state"
+	roothashApi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/roothash/api"
 	schedulerapp "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/scheduler"
 	schedulerState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/scheduler/state"
 	stakingapp "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking"
 	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking/state"
 	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/message"
 	stakingAPI "github.com/oasisprotocol/oasis-core/go/staking/api"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 )
@@ -26,6 +30,7 @@ var _ api.Application = (*governanceApplication)(nil)
 
 type governanceApplication struct {
 	state api.ApplicationState
+	md    api.MessageDispatcher
 }
 
 func (app *governanceApplication) Name() string {
@@ -50,6 +55,10 @@ func (app *governanceApplication) Dependencies() []string {
 
 func (app *governanceApplication) OnRegister(state api.ApplicationState, md api.MessageDispatcher) {
 	app.state = state
+	app.md = md
+
+	// Subscribe to messages emitted by other apps.
+	md.Subscribe(roothashApi.RuntimeMessageGovernance, app)
 }
 
 func (app *governanceApplication) OnCleanup() {
@@ -80,8 +89,21 @@ func (app *governanceApplication) ExecuteTx(ctx *api.Context, tx *transaction.Tr
 	}
 }
 
-func (app *governanceApplication) ExecuteMessage(ctx *api.Context, kind, msg interface{}) error {
-	return governance.ErrInvalidArgument
+func (app *governanceApplication) ExecuteMessage(ctx *api.Context, kind, msg interface{}) (interface{}, error) {
+	state := governanceState.NewMutableState(ctx.State())
+
+	switch kind {
+	case roothashApi.RuntimeMessageGovernance:
+		m := msg.(*message.GovernanceMessage)
+		switch {
+		case m.CastVote != nil:
+			return nil, app.castRuntimeVote(ctx, state, m.CastVote)
+		default:
+			return nil, governance.ErrInvalidArgument
+		}
+	default:
+		return nil, governance.ErrInvalidArgument
+	}
 }
 
 func (app *governanceApplication) BeginBlock(ctx *api.Context, request types.RequestBeginBlock) error {
@@ -183,6 +205,24 @@ func (app *governanceApplication) executeProposal(ctx *api.Context, state *gover
 		if err != nil {
 			return fmt.Errorf("failed to remove pending upgrade: %w", err)
 		}
+	case proposal.Content.ChangeParameters != nil:
+		// To apply parameter changes, the module for which the changes are proposed must
+		// subscribe to the message and execute it.
+		res, err := app.md.Publish(ctx, governanceApi.MessageChangeParameters, proposal.Content.ChangeParameters)
+		if err != nil {
+			return fmt.Errorf("failed to change consensus parameters: %w", err)
+		}
+		// Exactly one module should apply the changes.
+		if res == nil {
+			return fmt.Errorf("failed to change consensus parameters: %w", governance.ErrInvalidArgument)
+		}
+
+		evt := &governance.ParametersChangedEvent{
+			ProposalID: proposal.ID,
+			Module:     proposal.Content.ChangeParameters.Module,
+			Changes:    proposal.Content.ChangeParameters.Changes,
+		}
+		ctx.EmitEvent(api.NewEventBuilder(app.Name()).Attribute(KeyParametersChanged, cbor.Marshal(evt)))
 	default:
 		return governance.ErrInvalidArgument
 	}
@@ -197,11 +237,13 @@ func (app *governanceApplication) executeProposal(ctx *api.Context, state *gover
 	return nil
 }
 
-func (app *governanceApplication) validatorsEscrow(
-	ctx *api.Context,
-	stakingState *stakingState.MutableState,
-	registryState *registryState.MutableState,
-	schedulerState *schedulerState.MutableState,
+// validatorsEscrow returns the total escrow of all current validator entities together with
+// the escrow balance of each validator entity.
+func validatorsEscrow(
+	ctx context.Context,
+	stakingState *stakingState.ImmutableState,
+	registryState *registryState.ImmutableState,
+	schedulerState *schedulerState.ImmutableState,
 ) (*quantity.Quantity, map[stakingAPI.Address]*quantity.Quantity, error) {
 	currentValidators, err := schedulerState.CurrentValidators(ctx)
 	if err != nil {
@@ -241,6 +283,84 @@ func (app *governanceApplication) validatorsEscrow(
 	return totalVotingStake, validatorEntitiesEscrow, nil
 }
 
+// voteWeights computes the voting power of each valid vote under the given voting mode.
+//
+// Voters that are not present in the returned map have cast invalid votes.
+func voteWeights(
+	ctx context.Context,
+	stakingState *stakingState.ImmutableState,
+	mode governance.VotingMode,
+	validatorEntitiesEscrow map[stakingAPI.Address]*quantity.Quantity,
+	votes []*governance.VoteEntry,
+) (map[stakingAPI.Address]*quantity.Quantity, error) {
+	weights := make(map[stakingAPI.Address]*quantity.Quantity)
+	switch mode {
+	case governance.VotingModeValidators:
+		// Only validator entities can vote and they vote with their whole escrow.
+		for _, vote := range votes {
+			if escrow, ok := validatorEntitiesEscrow[vote.Voter]; ok {
+				weights[vote.Voter] = escrow.Clone()
+			}
+		}
+	case governance.VotingModeDelegatorOverride:
+		voted := make(map[stakingAPI.Address]bool)
+		for _, vote := range votes {
+			voted[vote.Voter] = true
+		}
+		addWeight := func(addr stakingAPI.Address, q *quantity.Quantity) error {
+			w := weights[addr]
+			if w == nil {
+				w = quantity.NewQuantity()
+				weights[addr] = w
+			}
+			return w.Add(q)
+		}
+
+		for valAddr, escrow := range validatorEntitiesEscrow {
+			acct, err := stakingState.Account(ctx, valAddr)
+			if err != nil {
+				return nil, fmt.Errorf("failed to query validator account: %w", err)
+			}
+
+			// Delegators that voted themselves override the validator entity's vote for the
+			// stake they delegated, the validator entity votes with the remainder. Only the
+			// delegations of voters are looked up to avoid scanning all delegations.
+			remaining := escrow.Clone()
+			for _, vote := range votes {
+				delAddr := vote.Voter
+				if delAddr.Equal(valAddr) {
+					continue
+				}
+				var delegation *stakingAPI.Delegation
+				if delegation, err = stakingState.Delegation(ctx, delAddr, valAddr); err != nil {
+					return nil, fmt.Errorf("failed to query delegation: %w", err)
+				}
+				if delegation.Shares.IsZero() {
+					continue
+				}
+				var stake *quantity.Quantity
+				if stake, err = acct.Escrow.Active.StakeForShares(&delegation.Shares); err != nil {
+					return nil, fmt.Errorf("failed to compute delegated stake: %w", err)
+				}
+				if err = addWeight(delAddr, stake); err != nil {
+					return nil, fmt.Errorf("failed to add delegator weight: %w", err)
+				}
+				if err = remaining.Sub(stake); err != nil {
+					return nil, fmt.Errorf("failed to subtract delegator weight: %w", err)
+				}
+			}
+			if voted[valAddr] {
+				if err = addWeight(valAddr, remaining); err != nil {
+					return nil, fmt.Errorf("failed to add validator weight: %w", err)
+				}
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported voting mode: %s", mode)
+	}
+	return weights, nil
+}
+
 // closeProposal closes an active proposal.
 //
 // This method modifies the passed proposal.
@@ -268,17 +388,28 @@ func (app *governanceApplication) closeProposal(
 		"validator_entities_escrow", validatorEntitiesEscrow,
 		"votes", votes,
 	)
+	weights, err := voteWeights(
+		ctx,
+		stakingState.NewMutableState(ctx.State()).ImmutableState,
+		params.VotingMode,
+		validatorEntitiesEscrow,
+		votes,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to compute vote weights: %w", err)
+	}
+
 	// Tally the votes.
 	for _, vote := range votes {
-		escrow, ok := validatorEntitiesEscrow[vote.Voter]
+		weight, ok := weights[vote.Voter]
 		if !ok {
-			// Voter not in current validator set - invalid vote.
+			// Voter not eligible under the current voting mode - invalid vote.
 			proposal.InvalidVotes++
 			continue
 		}
 
 		currentVotes := proposal.Results[vote.Vote]
-		newVotes := escrow.Clone()
+		newVotes := weight.Clone()
 		if err := newVotes.Add(&currentVotes); err != nil {
 			return fmt.Errorf("failed to add votes: %w", err)
 		}
@@ -338,11 +469,11 @@ func (app *governanceApplication) EndBlock(ctx *api.Context, request types.Reque
 
 	// Prepare validator set entities state.
 	stakingState := stakingState.NewMutableState(ctx.State())
-	totalVotingStake, validatorEntitiesEscrow, err := app.validatorsEscrow(
+	totalVotingStake, validatorEntitiesEscrow, err := validatorsEscrow(
 		ctx,
-		stakingState,
-		registryState.NewMutableState(ctx.State()),
-		schedulerState.NewMutableState(ctx.State()),
+		stakingState.ImmutableState,
+		registryState.NewMutableState(ctx.State()).ImmutableState,
+		schedulerState.NewMutableState(ctx.State()).ImmutableState,
 	)
 	if err != nil {
 		return types.ResponseEndBlock{}, fmt.Errorf("consensus/governance: failed to compute validators escrow: %w", err)
@@ -19,6 +19,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	governanceApi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/governance/api"
 	governanceState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/governance/state"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry/state"
 	schedulerState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/scheduler/state"
@@ -33,6 +34,38 @@ const numTestAccounts = 5
 
 var testAccountsStake = quantity.NewFromUint64(100)
 
+const testParametersModule = "test"
+
+var errInvalidTestParameters = errors.New("invalid test parameters")
+
+type testMsgDispatcher struct{}
+
+// Implements MessageDispatcher.
+func (nd *testMsgDispatcher) Subscribe(interface{}, abciAPI.MessageSubscriber) {
+}
+
+// Implements MessageDispatcher.
+func (nd *testMsgDispatcher) Publish(ctx *abciAPI.Context, kind, msg interface{}) (interface{}, error) {
+	switch kind {
+	case governanceApi.MessageValidateParameterChanges, governanceApi.MessageChangeParameters:
+		proposal := msg.(*governance.ChangeParametersProposal)
+		if proposal.Module != testParametersModule {
+			return nil, nil
+		}
+
+		var changes int
+		if err := cbor.Unmarshal(proposal.Changes, &changes); err != nil {
+			return nil, err
+		}
+		if changes < 0 {
+			return nil, errInvalidTestParameters
+		}
+		return struct{}{}, nil
+	default:
+		return nil, abciAPI.ErrNoSubscribers
+	}
+}
+
 func initValidatorsEscrowState(
 	t *testing.T,
 	stakingState *stakingState.MutableState,
@@ -144,11 +177,8 @@ func TestValidatorsEscrow(t *testing.T) {
 	_, _, expectedValidatorsEscrow := initValidatorsEscrowState(t, stakeState, registryState, schedulerState)
 
 	// Test validatorsEscrow.
-	app := &governanceApplication{
-		state: appState,
-	}
-	totalStake, validatorsEscrow, err := app.validatorsEscrow(ctx, stakeState, registryState, schedulerState)
-	require.NoError(err, "app.validatorsEscrow()")
+	totalStake, validatorsEscrow, err := validatorsEscrow(ctx, stakeState.ImmutableState, registryState.ImmutableState, schedulerState.ImmutableState)
+	require.NoError(err, "validatorsEscrow()")
 	require.EqualValues(expectedTotalStake, totalStake, "total stake should match expected")
 	require.EqualValues(expectedValidatorsEscrow, validatorsEscrow, "validators escrow should match expected")
 }
@@ -171,6 +201,38 @@ func TestCloseProposal(t *testing.T) {
 	addr3 := staking.NewAddress(pk3)
 	pk4 := signature.NewPublicKey("dddfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
 	addr4 := staking.NewAddress(pk4)
+	pk5 := signature.NewPublicKey("eeefffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr5 := staking.NewAddress(pk5)
+
+	// Validator entities with addr4 delegating to addr2 and addr3 (used by delegator override
+	// voting mode).
+	stakeState := stakingState.NewMutableState(ctx.State())
+	for _, d := range []struct {
+		escrow     staking.Address
+		delegators map[staking.Address]uint64
+	}{
+		{addr1, map[staking.Address]uint64{addr1: 5}},
+		{addr2, map[staking.Address]uint64{addr2: 40, addr4: 20}},
+		{addr3, map[staking.Address]uint64{addr3: 25, addr4: 10}},
+	} {
+		var total uint64
+		for delegator, shares := range d.delegators {
+			err = stakeState.SetDelegation(ctx, delegator, d.escrow, &staking.Delegation{
+				Shares: *quantity.NewFromUint64(shares),
+			})
+			require.NoError(err, "SetDelegation")
+			total += shares
+		}
+		err = stakeState.SetAccount(ctx, d.escrow, &staking.Account{
+			Escrow: staking.EscrowAccount{
+				Active: staking.SharePool{
+					Balance:     *quantity.NewFromUint64(total),
+					TotalShares: *quantity.NewFromUint64(total),
+				},
+			},
+		})
+		require.NoError(err, "SetAccount")
+	}
 
 	// Setup governance state.
 	state := governanceState.NewMutableState(ctx.State())
@@ -188,6 +250,8 @@ func TestCloseProposal(t *testing.T) {
 		UpgradeMinEpochDiff:       beacon.EpochTime(100),
 		VotingPeriod:              beacon.EpochTime(50),
 	}
+	delegatorConsParams := *baseConsParams
+	delegatorConsParams.VotingMode = governance.VotingModeDelegatorOverride
 
 	baseValidatorEntitiesEscrow := map[staking.Address]*quantity.Quantity{
 		addr1: quantity.NewFromUint64(5),
@@ -301,6 +365,68 @@ func TestCloseProposal(t *testing.T) {
 				governance.VoteNo:  *quantity.NewFromUint64(5),
 			},
 		},
+		{
+			"delegator votes should override validator votes",
+			&delegatorConsParams,
+			quantity.NewFromUint64(100),
+			baseValidatorEntitiesEscrow,
+			&governance.Proposal{
+				ID:    6,
+				State: governance.StateActive,
+			},
+			[]*governance.VoteEntry{
+				{Voter: addr1, Vote: governance.VoteNo},
+				{Voter: addr2, Vote: governance.VoteYes},
+				{Voter: addr3, Vote: governance.VoteYes},
+				{Voter: addr4, Vote: governance.VoteNo},
+				{Voter: addr5, Vote: governance.VoteYes},
+			},
+			governance.StateRejected,
+			1, // addr5 - is invalid vote as it's neither a validator nor a delegator.
+			map[governance.Vote]quantity.Quantity{
+				governance.VoteYes: *quantity.NewFromUint64(65),
+				governance.VoteNo:  *quantity.NewFromUint64(35),
+			},
+		},
+		{
+			"delegator votes should count without validator votes",
+			&delegatorConsParams,
+			quantity.NewFromUint64(100),
+			baseValidatorEntitiesEscrow,
+			&governance.Proposal{
+				ID:    7,
+				State: governance.StateActive,
+			},
+			[]*governance.VoteEntry{
+				{Voter: addr1, Vote: governance.VoteYes},
+				{Voter: addr4, Vote: governance.VoteYes},
+			},
+			governance.StateRejected,
+			0,
+			map[governance.Vote]quantity.Quantity{
+				governance.VoteYes: *quantity.NewFromUint64(35),
+			},
+		},
+		{
+			"should pass with delegator votes",
+			&delegatorConsParams,
+			quantity.NewFromUint64(100),
+			baseValidatorEntitiesEscrow,
+			&governance.Proposal{
+				ID:    8,
+				State: governance.StateActive,
+			},
+			[]*governance.VoteEntry{
+				{Voter: addr2, Vote: governance.VoteYes},
+				{Voter: addr3, Vote: governance.VoteYes},
+				{Voter: addr4, Vote: governance.VoteYes},
+			},
+			governance.StatePassed,
+			0,
+			map[governance.Vote]quantity.Quantity{
+				governance.VoteYes: *quantity.NewFromUint64(95),
+			},
+		},
 	} {
 		err = state.SetConsensusParameters(ctx, tc.params)
 		require.NoError(err, "setting governance consensus parameters should not error")
@@ -340,6 +466,7 @@ func TestExecuteProposal(t *testing.T) {
 	state := governanceState.NewMutableState(ctx.State())
 	app := &governanceApplication{
 		state: appState,
+		md:    &testMsgDispatcher{},
 	}
 	// Consensus parameters.
 	err = state.SetConsensusParameters(ctx, &governance.ConsensusParameters{
@@ -466,6 +593,39 @@ func TestExecuteProposal(t *testing.T) {
 			},
 			nil,
 		},
+		{
+			"executing change parameters proposal should fail for unknown module",
+			&governance.Proposal{
+				ID: 13,
+				Content: governance.ProposalContent{ChangeParameters: &governance.ChangeParametersProposal{
+					Module:  "unknown",
+					Changes: cbor.Marshal(42),
+				}},
+			},
+			governance.ErrInvalidArgument,
+		},
+		{
+			"executing change parameters proposal should fail for invalid changes",
+			&governance.Proposal{
+				ID: 14,
+				Content: governance.ProposalContent{ChangeParameters: &governance.ChangeParametersProposal{
+					Module:  testParametersModule,
+					Changes: cbor.Marshal(-1),
+				}},
+			},
+			errInvalidTestParameters,
+		},
+		{
+			"executing change parameters proposal should work",
+			&governance.Proposal{
+				ID: 15,
+				Content: governance.ProposalContent{ChangeParameters: &governance.ChangeParametersProposal{
+					Module:  testParametersModule,
+					Changes: cbor.Marshal(42),
+				}},
+			},
+			nil,
+		},
 		{
 			"executing upgrade proposal work with existing upgrade far enough from the upgrade epoch",
 			&governance.Proposal{
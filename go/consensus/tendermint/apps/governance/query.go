@@ -5,6 +5,9 @@ import (
 
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
 	governanceState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/governance/state"
+	registryState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry/state"
+	schedulerState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/scheduler/state"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking/state"
 	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 )
@@ -31,11 +34,29 @@ func (qf *QueryFactory) QueryAt(ctx context.Context, height int64) (Query, error
 	if err != nil {
 		return nil, err
 	}
-	return &governanceQuerier{state}, nil
+
+	// Vote weight queries need access to the staking, registry and scheduler state.
+	stakeState, err := stakingState.NewImmutableState(ctx, qf.state, height)
+	if err != nil {
+		return nil, err
+	}
+	regState, err := registryState.NewImmutableState(ctx, qf.state, height)
+	if err != nil {
+		return nil, err
+	}
+	schedState, err := schedulerState.NewImmutableState(ctx, qf.state, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return &governanceQuerier{state, stakeState, regState, schedState}, nil
 }
 
 type governanceQuerier struct {
-	state *governanceState.ImmutableState
+	state      *governanceState.ImmutableState
+	stakeState *stakingState.ImmutableState
+	regState   *registryState.ImmutableState
+	schedState *schedulerState.ImmutableState
 }
 
 func (gq *governanceQuerier) ActiveProposals(ctx context.Context) ([]*governance.Proposal, error) {
@@ -51,7 +72,30 @@ func (gq *governanceQuerier) Proposal(ctx context.Context, id uint64) (*governan
 }
 
 func (gq *governanceQuerier) Votes(ctx context.Context, id uint64) ([]*governance.VoteEntry, error) {
-	return gq.state.Votes(ctx, id)
+	votes, err := gq.state.Votes(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(votes) == 0 {
+		return votes, nil
+	}
+
+	params, err := gq.state.ConsensusParameters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	_, validatorEntitiesEscrow, err := validatorsEscrow(ctx, gq.stakeState, gq.regState, gq.schedState)
+	if err != nil {
+		return nil, err
+	}
+	weights, err := voteWeights(ctx, gq.stakeState, params.VotingMode, validatorEntitiesEscrow, votes)
+	if err != nil {
+		return nil, err
+	}
+	for _, vote := range votes {
+		vote.Weight = weights[vote.Voter]
+	}
+	return votes, nil
 }
 
 func (gq *governanceQuerier) PendingUpgrades(ctx context.Context) ([]*upgrade.Descriptor, error) {
@@ -6,6 +6,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	governanceApi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/governance/api"
 	governanceState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/governance/state"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry/state"
 	schedulerState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/scheduler/state"
@@ -128,6 +129,26 @@ func (app *governanceApplication) submitProposal(
 		if upgrade.Descriptor.Epoch < params.UpgradeCancelMinEpochDiff+epoch {
 			return governance.ErrUpgradeTooSoon
 		}
+
+	case proposalContent.ChangeParameters != nil:
+		// To validate parameter changes, the module for which the changes are proposed must
+		// subscribe to the message and validate the changes against its current parameters.
+		var res interface{}
+		res, err = app.md.Publish(ctx, governanceApi.MessageValidateParameterChanges, proposalContent.ChangeParameters)
+		if err != nil {
+			ctx.Logger().Error("governance: invalid consensus parameter changes",
+				"module", proposalContent.ChangeParameters.Module,
+				"err", err,
+			)
+			return fmt.Errorf("%w: invalid consensus parameter changes: %v", governance.ErrInvalidArgument, err)
+		}
+		// Exactly one module should validate the changes.
+		if res == nil {
+			ctx.Logger().Error("governance: consensus parameter changes for an unknown module",
+				"module", proposalContent.ChangeParameters.Module,
+			)
+			return fmt.Errorf("%w: unknown module: %s", governance.ErrInvalidArgument, proposalContent.ChangeParameters.Module)
+		}
 	}
 
 	// Deposit proposal funds.
@@ -210,41 +231,157 @@ func (app *governanceApplication) castVote(
 		return stakingAPI.ErrForbidden
 	}
 
-	// Query signer entity descriptor.
 	registryState := registryState.NewMutableState(ctx.State())
+	schedulerState := schedulerState.NewMutableState(ctx.State())
+	eligible, err := app.isValidatorEntity(ctx, registryState, schedulerState)
+	if err != nil {
+		return err
+	}
+	if !eligible && params.VotingMode == governance.VotingModeDelegatorOverride {
+		// In delegator override mode, delegators to validator entities are also eligible.
+		stakeState := stakingState.NewMutableState(ctx.State())
+		eligible, err = app.isValidatorDelegator(ctx, submitterAddr, stakeState, registryState, schedulerState)
+		if err != nil {
+			return err
+		}
+	}
+	if !eligible {
+		ctx.Logger().Error("governance: submitter not eligible to vote",
+			"submitter", ctx.TxSigner(),
+		)
+		return governance.ErrNotEligible
+	}
+
+	return app.saveVote(ctx, state, submitterAddr, proposalVote)
+}
+
+// isValidatorEntity checks whether the transaction signer is an entity with any of its nodes
+// being part of the current validator committee.
+func (app *governanceApplication) isValidatorEntity(
+	ctx *api.Context,
+	registryState *registryState.MutableState,
+	schedulerState *schedulerState.MutableState,
+) (bool, error) {
+	// Query signer entity descriptor.
 	submitterEntity, err := registryState.Entity(ctx, ctx.TxSigner())
 	switch err {
 	case nil:
 	case registryAPI.ErrNoSuchEntity:
-		return governance.ErrNotEligible
+		return false, nil
 	default:
-		return fmt.Errorf("governance: failed to query entity: %w", err)
+		return false, fmt.Errorf("governance: failed to query entity: %w", err)
 	}
-	schedulerState := schedulerState.NewMutableState(ctx.State())
 	currentValidators, err := schedulerState.CurrentValidators(ctx)
 	if err != nil {
-		return fmt.Errorf("governance: failed to query current validators: %w", err)
+		return false, fmt.Errorf("governance: failed to query current validators: %w", err)
 	}
 	// Submitter is eligible if any of its nodes is part of the current validator committee.
-	var eligible bool
 	for _, nID := range submitterEntity.Nodes {
 		var node *node.Node
 		node, err = registryState.Node(ctx, nID)
 		if err != nil {
-			return fmt.Errorf("governance: failed to query entity node: %w", err)
+			return false, fmt.Errorf("governance: failed to query entity node: %w", err)
 		}
 		if _, ok := currentValidators[node.Consensus.ID]; ok {
-			eligible = true
-			break
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// isValidatorDelegator checks whether the given account has a non-zero delegation to any of the
+// current validator entities.
+func (app *governanceApplication) isValidatorDelegator(
+	ctx *api.Context,
+	addr stakingAPI.Address,
+	stakeState *stakingState.MutableState,
+	registryState *registryState.MutableState,
+	schedulerState *schedulerState.MutableState,
+) (bool, error) {
+	_, validatorEntitiesEscrow, err := validatorsEscrow(
+		ctx,
+		stakeState.ImmutableState,
+		registryState.ImmutableState,
+		schedulerState.ImmutableState,
+	)
+	if err != nil {
+		return false, fmt.Errorf("governance: failed to compute validators escrow: %w", err)
+	}
+	// Only look up the delegations to validator entities to avoid scanning all delegations.
+	for escrowAddr := range validatorEntitiesEscrow {
+		if escrowAddr.Equal(addr) {
+			continue
+		}
+		var delegation *stakingAPI.Delegation
+		if delegation, err = stakeState.Delegation(ctx, addr, escrowAddr); err != nil {
+			return false, fmt.Errorf("governance: failed to query delegation: %w", err)
+		}
+		if !delegation.Shares.IsZero() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// castRuntimeVote casts a vote on behalf of the runtime that emitted the runtime message.
+func (app *governanceApplication) castRuntimeVote(
+	ctx *api.Context,
+	state *governanceState.MutableState,
+	proposalVote *governance.ProposalVote,
+) error {
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("governance: failed to fetch consensus parameters: %w", err)
+	}
+
+	// Charge gas for this message.
+	if err = ctx.Gas().UseGas(1, governance.GasOpCastVote, params.GasCosts); err != nil {
+		return err
+	}
+
+	// Return early for simulation as we only need gas accounting.
+	if ctx.IsSimulation() {
+		return nil
+	}
+
+	submitterAddr := ctx.CallerAddress()
+	if !submitterAddr.IsValid() || submitterAddr.IsReserved() {
+		return stakingAPI.ErrForbidden
+	}
+
+	// Runtimes cannot be validator entities, so they are only eligible to vote as delegators to
+	// validator entities which requires the delegator override voting mode.
+	var eligible bool
+	if params.VotingMode == governance.VotingModeDelegatorOverride {
+		eligible, err = app.isValidatorDelegator(
+			ctx,
+			submitterAddr,
+			stakingState.NewMutableState(ctx.State()),
+			registryState.NewMutableState(ctx.State()),
+			schedulerState.NewMutableState(ctx.State()),
+		)
+		if err != nil {
+			return err
 		}
 	}
 	if !eligible {
-		ctx.Logger().Error("governance: submitter not eligible to vote",
-			"submitter", ctx.TxSigner(),
+		ctx.Logger().Error("governance: runtime not eligible to vote",
+			"runtime_address", submitterAddr,
+			"voting_mode", params.VotingMode,
 		)
 		return governance.ErrNotEligible
 	}
 
+	return app.saveVote(ctx, state, submitterAddr, proposalVote)
+}
+
+// saveVote records the vote of the given submitter for an active proposal.
+func (app *governanceApplication) saveVote(
+	ctx *api.Context,
+	state *governanceState.MutableState,
+	submitterAddr stakingAPI.Address,
+	proposalVote *governance.ProposalVote,
+) error {
 	// Load proposal.
 	proposal, err := state.Proposal(ctx, proposalVote.ID)
 	switch err {
@@ -259,6 +396,7 @@ func (app *governanceApplication) castVote(
 			"err", err,
 			"proposal_id", proposalVote.ID,
 		)
+		return err
 	}
 	// Ensure proposal is active.
 	if proposal.State != governance.StateActive {
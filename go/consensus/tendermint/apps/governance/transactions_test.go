@@ -8,14 +8,18 @@ import (
 	"github.com/stretchr/testify/require"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
 	governanceState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/governance/state"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry/state"
+	roothashApi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/roothash/api"
 	schedulerState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/scheduler/state"
 	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking/state"
 	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/message"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 )
@@ -49,6 +53,7 @@ func TestSubmitProposal(t *testing.T) {
 	state := governanceState.NewMutableState(ctx.State())
 	app := &governanceApplication{
 		state: appState,
+		md:    &testMsgDispatcher{},
 	}
 
 	minProposalDeposit := quantity.NewFromUint64(100)
@@ -254,6 +259,39 @@ func TestSubmitProposal(t *testing.T) {
 			},
 			governance.ErrUpgradeAlreadyPending,
 		},
+		{
+			"should fail with change parameters proposal for unknown module",
+			baseConsParams,
+			pk1,
+			&governance.ProposalContent{ChangeParameters: &governance.ChangeParametersProposal{
+				Module:  "unknown",
+				Changes: cbor.Marshal(42),
+			}},
+			func() {},
+			governance.ErrInvalidArgument,
+		},
+		{
+			"should fail with invalid change parameters proposal",
+			baseConsParams,
+			pk1,
+			&governance.ProposalContent{ChangeParameters: &governance.ChangeParametersProposal{
+				Module:  testParametersModule,
+				Changes: cbor.Marshal(-1),
+			}},
+			func() {},
+			governance.ErrInvalidArgument,
+		},
+		{
+			"should work with valid change parameters proposal",
+			baseConsParams,
+			pk1,
+			&governance.ProposalContent{ChangeParameters: &governance.ChangeParametersProposal{
+				Module:  testParametersModule,
+				Changes: cbor.Marshal(42),
+			}},
+			func() {},
+			nil,
+		},
 	} {
 		err = state.SetConsensusParameters(ctx, tc.params)
 		require.NoError(err, "setting governance consensus parameters should not error")
@@ -454,4 +492,165 @@ func TestCastVote(t *testing.T) {
 
 		tc.check()
 	}
+
+	// In delegator override mode, delegators to validator entities should also be eligible.
+	err = stakeState.SetDelegation(ctx, staking.NewAddress(pk1), addresses[1], &staking.Delegation{
+		Shares: *quantity.NewFromUint64(10),
+	})
+	require.NoError(err, "SetDelegation")
+	err = stakeState.SetDelegation(ctx, addresses[0], addresses[0], &staking.Delegation{
+		Shares: *quantity.NewFromUint64(10),
+	})
+	require.NoError(err, "SetDelegation")
+
+	vote := &governance.ProposalVote{ID: p1.ID, Vote: governance.VoteYes}
+	ctx.SetTxSigner(pk1)
+	err = app.castVote(ctx, state, vote)
+	require.Equal(governance.ErrNotEligible, err, "delegator should not be eligible in validators voting mode")
+
+	params.VotingMode = governance.VotingModeDelegatorOverride
+	err = state.SetConsensusParameters(ctx, params)
+	require.NoError(err, "setting governance consensus parameters should not error")
+
+	ctx.SetTxSigner(signers[0].Public())
+	err = app.castVote(ctx, state, vote)
+	require.Equal(governance.ErrNotEligible, err, "delegator to a non-validator should not be eligible")
+
+	ctx.SetTxSigner(pk1)
+	err = app.castVote(ctx, state, vote)
+	require.NoError(err, "delegator to a validator should be eligible in delegator override voting mode")
+	votes, err := state.Votes(ctx, p1.ID)
+	require.NoError(err, "Votes()")
+	require.Len(votes, 3, "three votes should exist")
+}
+
+func TestCastRuntimeVote(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	now := time.Unix(1580461674, 0)
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock, now)
+	defer ctx.Close()
+
+	// Setup state.
+	registryState := registryState.NewMutableState(ctx.State())
+	stakeState := stakingState.NewMutableState(ctx.State())
+	schedulerState := schedulerState.NewMutableState(ctx.State())
+	_, addresses, validatorEntitiesEscrow := initValidatorsEscrowState(t, stakeState, registryState, schedulerState)
+
+	// Setup governance state.
+	state := governanceState.NewMutableState(ctx.State())
+	app := &governanceApplication{
+		state: appState,
+	}
+	params := &governance.ConsensusParameters{
+		GasCosts:                  governance.DefaultGasCosts,
+		MinProposalDeposit:        *quantity.NewFromUint64(100),
+		Quorum:                    90,
+		Threshold:                 90,
+		UpgradeCancelMinEpochDiff: beacon.EpochTime(100),
+		UpgradeMinEpochDiff:       beacon.EpochTime(100),
+		VotingPeriod:              beacon.EpochTime(50),
+	}
+	err = state.SetConsensusParameters(ctx, params)
+	require.NoError(err, "setting governance consensus parameters should not error")
+
+	p1 := &governance.Proposal{ID: 1, State: governance.StateActive}
+	err = state.SetActiveProposal(ctx, p1)
+	require.NoError(err, "SetActiveProposal")
+	p2 := &governance.Proposal{ID: 2, State: governance.StateRejected}
+	err = state.SetProposal(ctx, p2)
+	require.NoError(err, "SetProposal")
+
+	rtAddr := staking.NewRuntimeAddress(common.Namespace{1})
+	reservedAddr := staking.NewReservedAddress(signature.NewPublicKey("badcbfffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+
+	castVote := func(caller staking.Address, vote *governance.ProposalVote) error {
+		msgCtx := ctx.WithCallerAddress(caller)
+		defer msgCtx.Close()
+
+		_, err := app.ExecuteMessage(msgCtx, roothashApi.RuntimeMessageGovernance, &message.GovernanceMessage{
+			CastVote: vote,
+		})
+		return err
+	}
+
+	// Runtime delegating to a validator entity.
+	err = stakeState.SetDelegation(ctx, rtAddr, addresses[1], &staking.Delegation{
+		Shares: *quantity.NewFromUint64(10),
+	})
+	require.NoError(err, "SetDelegation")
+
+	err = castVote(rtAddr, &governance.ProposalVote{ID: p1.ID, Vote: governance.VoteNo})
+	require.True(errors.Is(err, governance.ErrNotEligible), "runtimes should not be eligible in validators voting mode")
+
+	params.VotingMode = governance.VotingModeDelegatorOverride
+	err = state.SetConsensusParameters(ctx, params)
+	require.NoError(err, "setting governance consensus parameters should not error")
+
+	for _, tc := range []struct {
+		msg    string
+		caller staking.Address
+		vote   *governance.ProposalVote
+		err    error
+	}{
+		{
+			"should fail with reserved caller",
+			reservedAddr,
+			&governance.ProposalVote{ID: p1.ID, Vote: governance.VoteYes},
+			staking.ErrForbidden,
+		},
+		{
+			"should fail with runtime not delegating to a validator",
+			staking.NewRuntimeAddress(common.Namespace{2}),
+			&governance.ProposalVote{ID: p1.ID, Vote: governance.VoteYes},
+			governance.ErrNotEligible,
+		},
+		{
+			"should fail for missing proposals",
+			rtAddr,
+			&governance.ProposalVote{ID: 99, Vote: governance.VoteYes},
+			governance.ErrNoSuchProposal,
+		},
+		{
+			"should fail for closed proposals",
+			rtAddr,
+			&governance.ProposalVote{ID: p2.ID, Vote: governance.VoteYes},
+			governance.ErrVotingIsClosed,
+		},
+		{
+			"should work",
+			rtAddr,
+			&governance.ProposalVote{ID: p1.ID, Vote: governance.VoteNo},
+			nil,
+		},
+	} {
+		err = castVote(tc.caller, tc.vote)
+		require.True(errors.Is(err, tc.err), tc.msg)
+	}
+
+	// Ensure vote exists.
+	votes, err := state.Votes(ctx, p1.ID)
+	require.NoError(err, "Votes()")
+	require.Len(votes, 1, "one vote should exist")
+	require.EqualValues(governance.VoteNo, votes[0].Vote, "vote should match submitted vote")
+	require.EqualValues(rtAddr, votes[0].Voter, "vote should be cast on behalf of the runtime")
+
+	// The runtime vote should override the validator entity's vote for the delegated stake.
+	err = state.SetVote(ctx, p1.ID, addresses[1], governance.VoteYes)
+	require.NoError(err, "SetVote")
+
+	totalVotingStake := quantity.NewQuantity()
+	for _, escrow := range validatorEntitiesEscrow {
+		err = totalVotingStake.Add(escrow)
+		require.NoError(err, "Add")
+	}
+	err = app.closeProposal(ctx, state, *totalVotingStake, validatorEntitiesEscrow, p1)
+	require.NoError(err, "closeProposal")
+	require.EqualValues(0, p1.InvalidVotes, "runtime vote should be valid")
+	require.Equal(map[governance.Vote]quantity.Quantity{
+		governance.VoteYes: *quantity.NewFromUint64(90),
+		governance.VoteNo:  *quantity.NewFromUint64(10),
+	}, p1.Results, "runtime vote should be tallied with the delegated stake")
 }
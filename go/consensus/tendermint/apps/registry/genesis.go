@@ -155,6 +155,17 @@ func (rq *registryQuerier) Genesis(ctx context.Context) (*registry.Genesis, erro
 			continue
 		}
 
+		// Skip nodes that have been forcibly expired as the signed descriptor does not reflect
+		// that and the nodes would not be admitted again anyway.
+		var forcedExpiration *uint64
+		forcedExpiration, err = rq.state.NodeForcedExpiration(ctx, n.ID)
+		if err != nil {
+			return nil, err
+		}
+		if forcedExpiration != nil {
+			continue
+		}
+
 		var status *registry.NodeStatus
 		status, err = rq.state.NodeStatus(ctx, n.ID)
 		if err != nil {
@@ -0,0 +1,87 @@
+package registry
+
+import (
+	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	registryState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry/state"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/message"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+func (app *registryApplication) processRuntimeMessage(ctx *api.Context, msg interface{}) error {
+	m, ok := msg.(*message.RegistryMessage)
+	if !ok {
+		return registry.ErrInvalidArgument
+	}
+
+	state := registryState.NewMutableState(ctx.State())
+
+	switch {
+	case m.UpdateRuntime != nil:
+		return app.updateRuntimeFromMessage(ctx, state, m.UpdateRuntime)
+	default:
+		return registry.ErrInvalidArgument
+	}
+}
+
+// updateRuntimeFromMessage updates the descriptor of the runtime that emitted the message.
+//
+// Only runtimes using the runtime governance model are allowed to update their own descriptor.
+func (app *registryApplication) updateRuntimeFromMessage(
+	ctx *api.Context,
+	state *registryState.MutableState,
+	rt *registry.Runtime,
+) error {
+	// A runtime can only update its own descriptor.
+	if !ctx.CallerAddress().Equal(staking.NewRuntimeAddress(rt.ID)) {
+		ctx.Logger().Error("UpdateRuntime: caller must be the runtime itself",
+			"runtime_id", rt.ID,
+		)
+		return registry.ErrForbidden
+	}
+
+	existingRt, err := state.Runtime(ctx, rt.ID)
+	if err != nil {
+		return err
+	}
+	if existingRt.GovernanceModel != registry.GovernanceRuntime {
+		ctx.Logger().Error("UpdateRuntime: runtime is not using runtime governance",
+			"runtime_id", rt.ID,
+			"governance_model", existingRt.GovernanceModel,
+		)
+		return registry.ErrForbidden
+	}
+
+	return app.registerRuntime(ctx, state, rt)
+}
+
+// consensusParameters implements api.ConsensusParametersModule for the registry module.
+type consensusParameters struct {
+	state *registryState.MutableState
+}
+
+func (p *consensusParameters) NewChanges() api.ConsensusParameterChanges {
+	return &registry.ConsensusParameterChanges{}
+}
+
+func (p *consensusParameters) Load(ctx *api.Context) (api.ConsensusParameters, error) {
+	params, err := p.state.ConsensusParameters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+func (p *consensusParameters) Apply(params api.ConsensusParameters, changes api.ConsensusParameterChanges) error {
+	return changes.(*registry.ConsensusParameterChanges).Apply(params.(*registry.ConsensusParameters))
+}
+
+func (p *consensusParameters) Set(ctx *api.Context, params api.ConsensusParameters) error {
+	return p.state.SetConsensusParameters(ctx, params.(*registry.ConsensusParameters))
+}
+
+// changeParameters handles a change parameters proposal targeting the registry module.
+func (app *registryApplication) changeParameters(ctx *api.Context, msg interface{}, apply bool) (interface{}, error) {
+	params := &consensusParameters{state: registryState.NewMutableState(ctx.State())}
+	return api.ChangeParameters(ctx, msg, registry.ModuleName, params, apply)
+}
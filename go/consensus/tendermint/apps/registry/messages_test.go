@@ -0,0 +1,76 @@
+package registry
+
+import (
+	"testing"
+	"time"
+
+	requirePkg "github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	registryState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry/state"
+	roothashApi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/roothash/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/message"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+func TestUpdateRuntimeMessage(t *testing.T) {
+	require := requirePkg.New(t)
+
+	now := time.Unix(1580461674, 0)
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock, now)
+	defer ctx.Close()
+
+	var md abciAPI.NoopMessageDispatcher
+	app := registryApplication{appState, &md}
+	state := registryState.NewMutableState(ctx.State())
+
+	rtID1 := common.NewTestNamespaceFromSeed([]byte("runtime 1"), 0)
+	rtID2 := common.NewTestNamespaceFromSeed([]byte("runtime 2"), 0)
+	rtID3 := common.NewTestNamespaceFromSeed([]byte("runtime 3"), 0)
+
+	entityRt := &registry.Runtime{
+		ID:              rtID1,
+		GovernanceModel: registry.GovernanceEntity,
+	}
+	err := state.SetRuntime(ctx, entityRt, false)
+	require.NoError(err, "SetRuntime")
+
+	for _, tc := range []struct {
+		msg    string
+		caller staking.Address
+		rt     *registry.Runtime
+		err    error
+	}{
+		{
+			"should fail when not updating own descriptor",
+			staking.NewRuntimeAddress(rtID2),
+			entityRt,
+			registry.ErrForbidden,
+		},
+		{
+			"should fail for runtimes not using runtime governance",
+			staking.NewRuntimeAddress(entityRt.ID),
+			entityRt,
+			registry.ErrForbidden,
+		},
+		{
+			"should fail for unknown runtimes",
+			staking.NewRuntimeAddress(rtID3),
+			&registry.Runtime{
+				ID:              rtID3,
+				GovernanceModel: registry.GovernanceRuntime,
+			},
+			registry.ErrNoSuchRuntime,
+		},
+	} {
+		msgCtx := ctx.WithCallerAddress(tc.caller)
+		_, err = app.ExecuteMessage(msgCtx, roothashApi.RuntimeMessageRegistry, &message.RegistryMessage{
+			UpdateRuntime: tc.rt,
+		})
+		msgCtx.Close()
+		require.ErrorIs(err, tc.err, tc.msg)
+	}
+}
@@ -2,6 +2,7 @@
 package registry
 
 import (
+	"errors"
 	"fmt"
 	"math"
 
@@ -13,7 +14,9 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	governanceApi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/governance/api"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry/state"
+	roothashApi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/roothash/api"
 	stakingapp "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking"
 	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking/state"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
@@ -50,6 +53,11 @@ func (app *registryApplication) Dependencies() []string {
 func (app *registryApplication) OnRegister(state api.ApplicationState, md api.MessageDispatcher) {
 	app.state = state
 	app.md = md
+
+	// Subscribe to messages emitted by other apps.
+	md.Subscribe(roothashApi.RuntimeMessageRegistry, app)
+	md.Subscribe(governanceApi.MessageChangeParameters, app)
+	md.Subscribe(governanceApi.MessageValidateParameterChanges, app)
 }
 
 func (app *registryApplication) OnCleanup() {
@@ -63,8 +71,15 @@ func (app *registryApplication) BeginBlock(ctx *api.Context, request types.Reque
 	return nil
 }
 
-func (app *registryApplication) ExecuteMessage(ctx *api.Context, kind, msg interface{}) error {
-	return registry.ErrInvalidArgument
+func (app *registryApplication) ExecuteMessage(ctx *api.Context, kind, msg interface{}) (interface{}, error) {
+	switch kind {
+	case roothashApi.RuntimeMessageRegistry:
+		return nil, app.processRuntimeMessage(ctx, msg)
+	case governanceApi.MessageValidateParameterChanges, governanceApi.MessageChangeParameters:
+		return app.changeParameters(ctx, msg, kind == governanceApi.MessageChangeParameters)
+	default:
+		return nil, registry.ErrInvalidArgument
+	}
 }
 
 func (app *registryApplication) ExecuteTx(ctx *api.Context, tx *transaction.Transaction) error {
@@ -152,7 +167,23 @@ func (app *registryApplication) onRegistryEpochChanged(ctx *api.Context, registr
 	var expiredNodes []*node.Node
 	for _, node := range nodes {
 		if !node.IsExpired(uint64(registryEpoch)) {
-			continue
+			// Nodes that no longer satisfy the admission policy of any of their runtimes are
+			// expired early.
+			var admitted bool
+			if admitted, err = app.isNodeAdmitted(ctx, state, node); err != nil {
+				return fmt.Errorf("registry: onRegistryEpochChanged: couldn't verify node admission: %w", err)
+			}
+			if admitted {
+				continue
+			}
+
+			ctx.Logger().Debug("expiring node no longer admitted by runtime admission policy",
+				"node_id", node.ID,
+			)
+			if err = state.ExpireNode(ctx, node.ID, uint64(registryEpoch)); err != nil {
+				return fmt.Errorf("registry: onRegistryEpochChanged: couldn't expire node: %w", err)
+			}
+			node.Expiration = uint64(registryEpoch) - 1
 		}
 
 		// Fetch node status to check whether we have already processed the
@@ -217,6 +248,35 @@ func (app *registryApplication) onRegistryEpochChanged(ctx *api.Context, registr
 	return nil
 }
 
+// isNodeAdmitted checks whether the node is still admitted by the admission policies of all the
+// runtimes it is registered for.
+func (app *registryApplication) isNodeAdmitted(
+	ctx *api.Context,
+	state *registryState.MutableState,
+	n *node.Node,
+) (bool, error) {
+	for _, nrt := range n.Runtimes {
+		rt, err := state.AnyRuntime(ctx, nrt.ID)
+		switch err {
+		case nil:
+		case registry.ErrNoSuchRuntime:
+			continue
+		default:
+			return false, err
+		}
+
+		err = verifyNodeAdmission(ctx, rt, n)
+		switch {
+		case err == nil:
+		case errors.Is(err, registry.ErrForbidden):
+			return false, nil
+		default:
+			return false, err
+		}
+	}
+	return true, nil
+}
+
 // New constructs a new registry application instance.
 func New() api.Application {
 	return &registryApplication{}
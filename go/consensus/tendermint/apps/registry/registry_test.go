@@ -0,0 +1,175 @@
+package registry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/entity"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	registryState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry/state"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking/state"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+func TestOnRegistryEpochChangedAdmissionPolicy(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1580461674, 0)
+	cfg := abciAPI.MockApplicationStateConfig{CurrentEpoch: 1}
+	appState := abciAPI.NewMockApplicationState(&cfg)
+	ctx := appState.NewContext(abciAPI.ContextDeliverTx, now)
+	defer ctx.Close()
+
+	var md abciAPI.NoopMessageDispatcher
+	app := registryApplication{appState, &md}
+	state := registryState.NewMutableState(ctx.State())
+	stakeState := stakingState.NewMutableState(ctx.State())
+
+	err := state.SetConsensusParameters(ctx, &registry.ConsensusParameters{
+		MaxNodeExpiration: 5,
+	})
+	require.NoError(err, "registry.SetConsensusParameters")
+	err = stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{
+		Thresholds: map[staking.ThresholdKind]quantity.Quantity{
+			staking.KindEntity:            *quantity.NewFromUint64(0),
+			staking.KindNodeValidator:     *quantity.NewFromUint64(0),
+			staking.KindNodeCompute:       *quantity.NewFromUint64(0),
+			staking.KindNodeStorage:       *quantity.NewFromUint64(0),
+			staking.KindNodeKeyManager:    *quantity.NewFromUint64(0),
+			staking.KindRuntimeCompute:    *quantity.NewFromUint64(0),
+			staking.KindRuntimeKeyManager: *quantity.NewFromUint64(0),
+		},
+		DebondingInterval: 1,
+	})
+	require.NoError(err, "staking.SetConsensusParameters")
+
+	entitySigner := memorySigner.NewTestSigner("consensus/tendermint/apps/registry: entity signer")
+	nodeSigner := memorySigner.NewTestSigner("consensus/tendermint/apps/registry: node signer")
+	consensusSigner := memorySigner.NewTestSigner("consensus/tendermint/apps/registry: consensus signer")
+	p2pSigner := memorySigner.NewTestSigner("consensus/tendermint/apps/registry: p2p signer")
+	tlsSigner := memorySigner.NewTestSigner("consensus/tendermint/apps/registry: tls signer")
+
+	ent := entity.Entity{
+		Versioned: cbor.NewVersioned(entity.LatestEntityDescriptorVersion),
+		ID:        entitySigner.Public(),
+		Nodes:     []signature.PublicKey{nodeSigner.Public()},
+	}
+	sigEnt, err := entity.SignEntity(entitySigner, registry.RegisterEntitySignatureContext, &ent)
+	require.NoError(err, "SignEntity")
+	err = state.SetEntity(ctx, &ent, sigEnt)
+	require.NoError(err, "SetEntity")
+
+	rt := registry.Runtime{
+		Versioned: cbor.NewVersioned(registry.LatestRuntimeDescriptorVersion),
+		ID:        common.NewTestNamespaceFromSeed([]byte("consensus/tendermint/apps/registry: runtime: admission policy"), 0),
+		Kind:      registry.KindCompute,
+		AdmissionPolicy: registry.RuntimeAdmissionPolicy{
+			EntityStakeThreshold: &registry.EntityStakeThresholdRuntimeAdmissionPolicy{
+				MinEscrow: *quantity.NewFromUint64(1000),
+			},
+		},
+		GovernanceModel: registry.GovernanceEntity,
+	}
+	err = state.SetRuntime(ctx, &rt, false)
+	require.NoError(err, "SetRuntime")
+
+	setEscrow := func(amount uint64) {
+		// Add bonded stake (hacky, without a self-delegation).
+		err = stakeState.SetAccount(ctx, staking.NewAddress(ent.ID), &staking.Account{
+			Escrow: staking.EscrowAccount{
+				Active: staking.SharePool{
+					Balance: *quantity.NewFromUint64(amount),
+				},
+			},
+		})
+		require.NoError(err, "SetAccount")
+	}
+
+	var address node.Address
+	err = address.UnmarshalText([]byte("8.8.8.8:1234"))
+	require.NoError(err, "address.UnmarshalText")
+
+	registerNode := func(expiration uint64) {
+		n := node.Node{
+			Versioned:  cbor.NewVersioned(node.LatestNodeDescriptorVersion),
+			ID:         nodeSigner.Public(),
+			EntityID:   ent.ID,
+			Expiration: expiration,
+			P2P: node.P2PInfo{
+				ID:        p2pSigner.Public(),
+				Addresses: []node.Address{address},
+			},
+			Consensus: node.ConsensusInfo{
+				ID: consensusSigner.Public(),
+				Addresses: []node.ConsensusAddress{
+					{ID: consensusSigner.Public(), Address: address},
+				},
+			},
+			TLS: node.TLSInfo{
+				PubKey: tlsSigner.Public(),
+				Addresses: []node.TLSAddress{
+					{PubKey: tlsSigner.Public(), Address: address},
+				},
+			},
+			Runtimes: []*node.Runtime{
+				{ID: rt.ID},
+			},
+		}
+		n.AddRoles(node.RoleComputeWorker)
+
+		signers := []signature.Signer{nodeSigner, p2pSigner, consensusSigner, tlsSigner}
+		sigNode, grr := node.MultiSignNode(signers, registry.RegisterNodeSignatureContext, &n)
+		require.NoError(grr, "MultiSignNode")
+
+		ctx.SetTxSigner(nodeSigner.Public())
+		err = app.registerNode(ctx, state, sigNode)
+		require.NoError(err, "node registration should succeed")
+	}
+
+	setEscrow(1000)
+	registerNode(5)
+
+	// Node should stay registered while it satisfies the admission policy.
+	err = app.onRegistryEpochChanged(ctx, 2)
+	require.NoError(err, "onRegistryEpochChanged")
+	n, err := state.Node(ctx, nodeSigner.Public())
+	require.NoError(err, "Node")
+	require.False(n.IsExpired(2), "node should not be expired")
+	require.False(ctx.HasEvent(app.Name(), KeyNodesExpired), "nodes expired event should not be emitted")
+
+	// Node should be expired once its entity falls below the stake threshold.
+	setEscrow(999)
+	err = app.onRegistryEpochChanged(ctx, 3)
+	require.NoError(err, "onRegistryEpochChanged")
+	n, err = state.Node(ctx, nodeSigner.Public())
+	require.NoError(err, "Node")
+	require.True(n.IsExpired(3), "node should be expired")
+	require.True(ctx.HasEvent(app.Name(), KeyNodesExpired), "nodes expired event should be emitted")
+	nodes, err := state.Nodes(ctx)
+	require.NoError(err, "Nodes")
+	require.Len(nodes, 1, "expired node should be kept during the debonding interval")
+	require.True(nodes[0].IsExpired(3), "node should be expired")
+	status, err := state.NodeStatus(ctx, nodeSigner.Public())
+	require.NoError(err, "NodeStatus")
+	require.True(status.ExpirationProcessed, "node expiration should be processed")
+
+	// Node should be able to re-register once it satisfies the admission policy again.
+	setEscrow(1000)
+	cfg.CurrentEpoch = 3
+	registerNode(6)
+	n, err = state.Node(ctx, nodeSigner.Public())
+	require.NoError(err, "Node")
+	require.EqualValues(6, n.Expiration, "re-registration should clear the forced expiration")
+	forcedExpiration, err := state.NodeForcedExpiration(ctx, nodeSigner.Public())
+	require.NoError(err, "NodeForcedExpiration")
+	require.Nil(forcedExpiration, "re-registration should clear the forced expiration")
+}
@@ -76,6 +76,14 @@ var (
 	//
 	// Value is binary signature.PublicKey (node ID).
 	beaconPointMapKeyFmt = keyformat.New(0x1a, keyformat.H(&pvss.Point{}))
+	// nodeForcedExpirationKeyFmt is the key format used for forced node expirations.
+	//
+	// Signed node descriptors cannot be modified, so when a node needs to be expired before its
+	// descriptor's expiration (e.g., because it no longer satisfies a runtime's admission policy)
+	// the overriding expiration is stored separately and applied when looking up nodes.
+	//
+	// Value is CBOR-serialized expiration epoch.
+	nodeForcedExpirationKeyFmt = keyformat.New(0x1b, keyformat.H(&signature.PublicKey{}))
 )
 
 // ImmutableState is the immutable registry state wrapper.
@@ -184,9 +192,40 @@ func (s *ImmutableState) Node(ctx context.Context, id signature.PublicKey) (*nod
 	if err = cbor.Unmarshal(signedNode.Blob, &node); err != nil {
 		return nil, abciAPI.UnavailableStateError(err)
 	}
+	if err = s.applyForcedExpiration(ctx, &node); err != nil {
+		return nil, err
+	}
 	return &node, nil
 }
 
+// NodeForcedExpiration returns the forced expiration of a node (if any).
+func (s *ImmutableState) NodeForcedExpiration(ctx context.Context, id signature.PublicKey) (*uint64, error) {
+	raw, err := s.is.Get(ctx, nodeForcedExpirationKeyFmt.Encode(&id))
+	if err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	if raw == nil {
+		return nil, nil
+	}
+
+	var expiration uint64
+	if err = cbor.Unmarshal(raw, &expiration); err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	return &expiration, nil
+}
+
+func (s *ImmutableState) applyForcedExpiration(ctx context.Context, n *node.Node) error {
+	expiration, err := s.NodeForcedExpiration(ctx, n.ID)
+	if err != nil {
+		return err
+	}
+	if expiration != nil && *expiration < n.Expiration {
+		n.Expiration = *expiration
+	}
+	return nil
+}
+
 // NodeIDByConsensusAddress looks up a specific node ID by its consensus address.
 //
 // If you need to get the actual node descriptor, use NodeByConsensusAddress instead.
@@ -234,6 +273,9 @@ func (s *ImmutableState) Nodes(ctx context.Context) ([]*node.Node, error) {
 		if err := cbor.Unmarshal(signedNode.Blob, &node); err != nil {
 			return nil, abciAPI.UnavailableStateError(err)
 		}
+		if err := s.applyForcedExpiration(ctx, &node); err != nil {
+			return nil, err
+		}
 
 		nodes = append(nodes, &node)
 	}
@@ -425,6 +467,9 @@ func (s *ImmutableState) GetEntityNodes(ctx context.Context, id signature.Public
 		if err = cbor.Unmarshal(signedNode.Blob, &node); err != nil {
 			return nil, abciAPI.UnavailableStateError(err)
 		}
+		if err = s.applyForcedExpiration(ctx, &node); err != nil {
+			return nil, err
+		}
 
 		nodes = append(nodes, &node)
 	}
@@ -571,6 +616,10 @@ func (s *MutableState) SetNode(ctx context.Context, existingNode, node *node.Nod
 	if err = s.ms.Insert(ctx, signedNodeByEntityKeyFmt.Encode(&node.EntityID, &node.ID), []byte("")); err != nil {
 		return abciAPI.UnavailableStateError(err)
 	}
+	// A new descriptor supersedes any forced expiration.
+	if err = s.ms.Remove(ctx, nodeForcedExpirationKeyFmt.Encode(&node.ID)); err != nil {
+		return abciAPI.UnavailableStateError(err)
+	}
 
 	// Update indices mapping various keys to nodes.
 
@@ -644,6 +693,9 @@ func (s *MutableState) RemoveNode(ctx context.Context, node *node.Node) error {
 	if err := s.ms.Remove(ctx, nodeStatusKeyFmt.Encode(&node.ID)); err != nil {
 		return abciAPI.UnavailableStateError(err)
 	}
+	if err := s.ms.Remove(ctx, nodeForcedExpirationKeyFmt.Encode(&node.ID)); err != nil {
+		return abciAPI.UnavailableStateError(err)
+	}
 
 	address := []byte(tmcrypto.PublicKeyToTendermint(&node.Consensus.ID).Address())
 	if err := s.ms.Remove(ctx, nodeByConsAddressKeyFmt.Encode(address)); err != nil {
@@ -669,6 +721,18 @@ func (s *MutableState) RemoveNode(ctx context.Context, node *node.Node) error {
 	return nil
 }
 
+// ExpireNode forces a registered node to expire so that it is considered expired in the given
+// epoch, regardless of the expiration in its signed descriptor.
+//
+// The forced expiration is cleared once the node re-registers.
+func (s *MutableState) ExpireNode(ctx context.Context, id signature.PublicKey, epoch uint64) error {
+	if epoch == 0 {
+		return errors.New("tendermint/registry: cannot expire node in epoch zero")
+	}
+	err := s.ms.Insert(ctx, nodeForcedExpirationKeyFmt.Encode(&id), cbor.Marshal(epoch-1))
+	return abciAPI.UnavailableStateError(err)
+}
+
 // SetRuntime sets a runtime descriptor for a registered runtime.
 func (s *MutableState) SetRuntime(ctx context.Context, rt *registry.Runtime, suspended bool) error {
 	if err := s.ms.Insert(ctx, runtimeByEntityKeyFmt.Encode(&rt.EntityID, &rt.ID), []byte("")); err != nil {
@@ -157,6 +157,33 @@ func (app *registryApplication) deregisterEntity(ctx *api.Context, state *regist
 	return nil
 }
 
+// verifyNodeAdmission verifies that the node is admitted by the admission policy of the given
+// runtime.
+//
+// Note that this does not check the per-entity node limits of the entity whitelist policy.
+func verifyNodeAdmission(ctx *api.Context, rt *registry.Runtime, n *node.Node) error {
+	policy := rt.AdmissionPolicy
+	switch {
+	case policy.EntityWhitelist != nil:
+		if _, ok := policy.EntityWhitelist.Entities[n.EntityID]; !ok {
+			return fmt.Errorf("%w: node's entity not in runtime's entity whitelist", registry.ErrForbidden)
+		}
+	case policy.NodeWhitelist != nil:
+		if !policy.NodeWhitelist.Nodes[n.ID] {
+			return fmt.Errorf("%w: node not in runtime's node whitelist", registry.ErrForbidden)
+		}
+	case policy.EntityStakeThreshold != nil:
+		acct, err := stakingState.NewMutableState(ctx.State()).Account(ctx, staking.NewAddress(n.EntityID))
+		if err != nil {
+			return fmt.Errorf("failed to fetch entity account: %w", err)
+		}
+		if acct.Escrow.Active.Balance.Cmp(&policy.EntityStakeThreshold.MinEscrow) < 0 {
+			return fmt.Errorf("%w: entity's escrow below runtime's stake threshold", registry.ErrForbidden)
+		}
+	}
+	return nil
+}
+
 func (app *registryApplication) registerNode( // nolint: gocyclo
 	ctx *api.Context,
 	state *registryState.MutableState,
@@ -240,19 +267,21 @@ func (app *registryApplication) registerNode( // nolint: gocyclo
 		}
 	}
 
-	// Check runtime's whitelist.
+	// Check runtime's admission policy.
 	for _, rt := range paidRuntimes {
-		if rt.AdmissionPolicy.EntityWhitelist == nil {
-			continue
-		}
-		wcfg, entIsWhitelisted := rt.AdmissionPolicy.EntityWhitelist.Entities[newNode.EntityID]
-		if !entIsWhitelisted {
-			ctx.Logger().Error("RegisterNode: node's entity not in a runtime's whitelist",
+		if err = verifyNodeAdmission(ctx, rt, newNode); err != nil {
+			ctx.Logger().Error("RegisterNode: node not admitted by runtime's admission policy",
+				"err", err,
+				"node_id", newNode.ID,
 				"entity", newNode.EntityID,
 				"runtime", rt.ID,
 			)
-			return registry.ErrForbidden
+			return err
+		}
+		if rt.AdmissionPolicy.EntityWhitelist == nil {
+			continue
 		}
+		wcfg := rt.AdmissionPolicy.EntityWhitelist.Entities[newNode.EntityID]
 		if len(wcfg.MaxNodes) == 0 {
 			continue
 		}
@@ -477,7 +506,7 @@ func (app *registryApplication) registerNode( // nolint: gocyclo
 			)
 
 			// Notify other interested applications about the resumed runtime.
-			if err = app.md.Publish(ctx, registryApi.MessageRuntimeResumed, rt); err != nil {
+			if _, err = app.md.Publish(ctx, registryApi.MessageRuntimeResumed, rt); err != nil {
 				ctx.Logger().Error("RegisterNode: failed to dispatch runtime resumption message",
 					"err", err,
 				)
@@ -618,6 +647,11 @@ func (app *registryApplication) registerRuntime( // nolint: gocyclo
 		return err
 	}
 
+	// Return early for simulation as we only need gas accounting.
+	if ctx.IsSimulation() {
+		return nil
+	}
+
 	// Make sure the runtime doesn't exist yet.
 	var suspended bool
 	existingRt, err := state.Runtime(ctx, rt.ID)
@@ -715,7 +749,7 @@ func (app *registryApplication) registerRuntime( // nolint: gocyclo
 
 	// Notify other interested applications about the new runtime.
 	if existingRt == nil {
-		if err = app.md.Publish(ctx, registryApi.MessageNewRuntimeRegistered, rt); err != nil {
+		if _, err = app.md.Publish(ctx, registryApi.MessageNewRuntimeRegistered, rt); err != nil {
 			ctx.Logger().Error("RegisterRuntime: failed to dispatch message",
 				"err", err,
 			)
@@ -723,7 +757,7 @@ func (app *registryApplication) registerRuntime( // nolint: gocyclo
 		}
 	}
 
-	if err = app.md.Publish(ctx, registryApi.MessageRuntimeUpdated, rt); err != nil {
+	if _, err = app.md.Publish(ctx, registryApi.MessageRuntimeUpdated, rt); err != nil {
 		ctx.Logger().Error("RegisterRuntime: failed to dispatch message",
 			"err", err,
 		)
@@ -290,6 +290,132 @@ func TestRegisterNode(t *testing.T) {
 			false,
 			false,
 		},
+		// Compute node of an entity with enough stake for the runtime's stake threshold.
+		{
+			"ComputeNodeWithEntityStakeThreshold",
+			func(tcd *testCaseData) {
+				rt := registry.Runtime{
+					Versioned: cbor.NewVersioned(registry.LatestRuntimeDescriptorVersion),
+					ID:        common.NewTestNamespaceFromSeed([]byte("consensus/tendermint/apps/registry: runtime: ComputeNodeWithEntityStakeThreshold"), 0),
+					Kind:      registry.KindCompute,
+					AdmissionPolicy: registry.RuntimeAdmissionPolicy{
+						EntityStakeThreshold: &registry.EntityStakeThresholdRuntimeAdmissionPolicy{
+							MinEscrow: *quantity.NewFromUint64(1000),
+						},
+					},
+					GovernanceModel: registry.GovernanceEntity,
+				}
+				_ = state.SetRuntime(ctx, &rt, false)
+
+				// Add bonded stake (hacky, without a self-delegation).
+				_ = stakeState.SetAccount(ctx, staking.NewAddress(tcd.node.EntityID), &staking.Account{
+					Escrow: staking.EscrowAccount{
+						Active: staking.SharePool{
+							Balance: *quantity.NewFromUint64(1000),
+						},
+					},
+				})
+
+				tcd.node.AddRoles(node.RoleComputeWorker)
+				tcd.node.Runtimes = []*node.Runtime{
+					{ID: rt.ID},
+				}
+			},
+			nil,
+			true,
+			true,
+		},
+		// Compute node of an entity without enough stake for the runtime's stake threshold.
+		{
+			"ComputeNodeWithoutEntityStakeThreshold",
+			func(tcd *testCaseData) {
+				rt := registry.Runtime{
+					Versioned: cbor.NewVersioned(registry.LatestRuntimeDescriptorVersion),
+					ID:        common.NewTestNamespaceFromSeed([]byte("consensus/tendermint/apps/registry: runtime: ComputeNodeWithoutEntityStakeThreshold"), 0),
+					Kind:      registry.KindCompute,
+					AdmissionPolicy: registry.RuntimeAdmissionPolicy{
+						EntityStakeThreshold: &registry.EntityStakeThresholdRuntimeAdmissionPolicy{
+							MinEscrow: *quantity.NewFromUint64(1000),
+						},
+					},
+					GovernanceModel: registry.GovernanceEntity,
+				}
+				_ = state.SetRuntime(ctx, &rt, false)
+
+				// Add bonded stake (hacky, without a self-delegation).
+				_ = stakeState.SetAccount(ctx, staking.NewAddress(tcd.node.EntityID), &staking.Account{
+					Escrow: staking.EscrowAccount{
+						Active: staking.SharePool{
+							Balance: *quantity.NewFromUint64(999),
+						},
+					},
+				})
+
+				tcd.node.AddRoles(node.RoleComputeWorker)
+				tcd.node.Runtimes = []*node.Runtime{
+					{ID: rt.ID},
+				}
+			},
+			nil,
+			false,
+			false,
+		},
+		// Compute node in the runtime's node whitelist.
+		{
+			"ComputeNodeWhitelisted",
+			func(tcd *testCaseData) {
+				rt := registry.Runtime{
+					Versioned: cbor.NewVersioned(registry.LatestRuntimeDescriptorVersion),
+					ID:        common.NewTestNamespaceFromSeed([]byte("consensus/tendermint/apps/registry: runtime: ComputeNodeWhitelisted"), 0),
+					Kind:      registry.KindCompute,
+					AdmissionPolicy: registry.RuntimeAdmissionPolicy{
+						NodeWhitelist: &registry.NodeWhitelistRuntimeAdmissionPolicy{
+							Nodes: map[signature.PublicKey]bool{
+								tcd.node.ID: true,
+							},
+						},
+					},
+					GovernanceModel: registry.GovernanceEntity,
+				}
+				_ = state.SetRuntime(ctx, &rt, false)
+
+				tcd.node.AddRoles(node.RoleComputeWorker)
+				tcd.node.Runtimes = []*node.Runtime{
+					{ID: rt.ID},
+				}
+			},
+			nil,
+			true,
+			true,
+		},
+		// Compute node not in the runtime's node whitelist.
+		{
+			"ComputeNodeNotWhitelisted",
+			func(tcd *testCaseData) {
+				rt := registry.Runtime{
+					Versioned: cbor.NewVersioned(registry.LatestRuntimeDescriptorVersion),
+					ID:        common.NewTestNamespaceFromSeed([]byte("consensus/tendermint/apps/registry: runtime: ComputeNodeNotWhitelisted"), 0),
+					Kind:      registry.KindCompute,
+					AdmissionPolicy: registry.RuntimeAdmissionPolicy{
+						NodeWhitelist: &registry.NodeWhitelistRuntimeAdmissionPolicy{
+							Nodes: map[signature.PublicKey]bool{
+								tcData["Validator"].node.ID: true,
+							},
+						},
+					},
+					GovernanceModel: registry.GovernanceEntity,
+				}
+				_ = state.SetRuntime(ctx, &rt, false)
+
+				tcd.node.AddRoles(node.RoleComputeWorker)
+				tcd.node.Runtimes = []*node.Runtime{
+					{ID: rt.ID},
+				}
+			},
+			nil,
+			false,
+			false,
+		},
 		// Updating a node should be allowed.
 		{
 			"UpdateValidator",
@@ -9,4 +9,11 @@ var (
 
 	// RuntimeMessageStaking is the message kind used when dispatching Staking runtime messages.
 	RuntimeMessageStaking = messageKind(1)
+
+	// RuntimeMessageRegistry is the message kind used when dispatching Registry runtime messages.
+	RuntimeMessageRegistry = messageKind(2)
+
+	// RuntimeMessageGovernance is the message kind used when dispatching Governance runtime
+	// messages.
+	RuntimeMessageGovernance = messageKind(3)
 )
@@ -1,10 +1,14 @@
 package roothash
 
 import (
+	"fmt"
+
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/errors"
 	tmapi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
 	roothashApi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/roothash/api"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking/state"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/message"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
@@ -35,10 +39,21 @@ func (app *rootHashApplication) processRuntimeMessages(
 			"body", msg,
 		)
 
-		var err error
+		var (
+			res interface{}
+			err error
+		)
 		switch {
 		case msg.Staking != nil:
-			err = app.md.Publish(ctx, roothashApi.RuntimeMessageStaking, msg.Staking)
+			if err = checkStakingMessagePermissions(ctx, rtState.Runtime, msg.Staking); err != nil {
+				break
+			}
+
+			res, err = app.md.Publish(ctx, roothashApi.RuntimeMessageStaking, msg.Staking)
+		case msg.Registry != nil:
+			res, err = app.md.Publish(ctx, roothashApi.RuntimeMessageRegistry, msg.Registry)
+		case msg.Governance != nil:
+			res, err = app.md.Publish(ctx, roothashApi.RuntimeMessageGovernance, msg.Governance)
 		default:
 			// Unsupported message.
 			err = roothash.ErrInvalidArgument
@@ -65,6 +80,9 @@ func (app *rootHashApplication) processRuntimeMessages(
 				Code:   code,
 			},
 		}
+		if err == nil && res != nil {
+			evV.Event.Result = cbor.Marshal(res)
+		}
 		ctx.EmitEvent(
 			tmapi.NewEventBuilder(app.Name()).
 				Attribute(KeyMessage, cbor.Marshal(evV)).
@@ -73,3 +91,36 @@ func (app *rootHashApplication) processRuntimeMessages(
 	}
 	return nil
 }
+
+// checkStakingMessagePermissions checks whether the runtime is allowed to perform the staking
+// operation requested by the given runtime message.
+func checkStakingMessagePermissions(ctx *tmapi.Context, rt *registry.Runtime, msg *message.StakingMessage) error {
+	switch {
+	case msg.IsEscrowMessage():
+		// Escrow operations must be explicitly allowed for the runtime.
+		if !rt.Staking.AllowEscrowMessages {
+			return staking.ErrForbidden
+		}
+	case msg.IsAllowanceMessage():
+		// Allowance operations must be explicitly allowed for the runtime and the number of
+		// allowances that the runtime can configure is limited.
+		if rt.Staking.MaxAllowances == 0 {
+			return staking.ErrForbidden
+		}
+		if msg.Allow.Negative {
+			return nil
+		}
+
+		acct, err := stakingState.NewMutableState(ctx.State()).Account(ctx, ctx.CallerAddress())
+		if err != nil {
+			return fmt.Errorf("failed to fetch runtime account: %w", err)
+		}
+		if _, exists := acct.General.Allowances[msg.Allow.Beneficiary]; exists {
+			return nil
+		}
+		if uint32(len(acct.General.Allowances)) >= rt.Staking.MaxAllowances {
+			return staking.ErrTooManyAllowances
+		}
+	}
+	return nil
+}
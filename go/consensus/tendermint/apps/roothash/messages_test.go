@@ -0,0 +1,126 @@
+package roothash
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/errors"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking/state"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/message"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+func TestProcessRuntimeMessages(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1580461674, 0)
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextDeliverTx, now)
+	defer ctx.Close()
+
+	var md testMsgDispatcher
+	app := rootHashApplication{appState, &md}
+
+	runtime := registry.Runtime{
+		Executor: registry.ExecutorParameters{
+			MaxMessages: 32,
+		},
+	}
+	rtState := &roothash.RuntimeState{
+		Runtime: &runtime,
+	}
+
+	amount := quantity.NewFromUint64(100)
+	msgs := []message.Message{
+		{Staking: &message.StakingMessage{Transfer: &staking.Transfer{Amount: *amount}}},
+		{Staking: &message.StakingMessage{AddEscrow: &staking.Escrow{}}},
+		{Staking: &message.StakingMessage{ReclaimEscrow: &staking.ReclaimEscrow{}}},
+		{Staking: &message.StakingMessage{Allow: &staking.Allow{}}},
+	}
+
+	decodeEvents := func(ctx *abciAPI.Context) []*roothash.MessageEvent {
+		var evs []*roothash.MessageEvent
+		for _, ev := range ctx.GetEvents() {
+			for _, pair := range ev.GetAttributes() {
+				if string(pair.GetKey()) != string(KeyMessage) {
+					continue
+				}
+				var val ValueMessage
+				err := cbor.Unmarshal(pair.GetValue(), &val)
+				require.NoError(err, "cbor.Unmarshal(ValueMessage)")
+				evs = append(evs, &val.Event)
+			}
+		}
+		return evs
+	}
+
+	// Escrow messages should be rejected unless explicitly allowed for the runtime.
+	msgCtx := ctx.NewChild()
+	err := app.processRuntimeMessages(msgCtx, rtState, msgs)
+	require.NoError(err, "processRuntimeMessages")
+	evs := decodeEvents(msgCtx)
+	msgCtx.Close()
+	require.Len(evs, len(msgs), "an event should be emitted for each message")
+
+	require.True(evs[0].IsSuccess(), "transfer should succeed")
+	var xferResult staking.TransferResult
+	err = cbor.Unmarshal(evs[0].Result, &xferResult)
+	require.NoError(err, "cbor.Unmarshal(TransferResult)")
+	require.Equal(*amount, xferResult.Amount, "transfer result should be reported")
+
+	module, code := errors.Code(staking.ErrForbidden)
+	for _, ev := range evs[1:] {
+		require.False(ev.IsSuccess(), "escrow and allowance messages should fail when not allowed")
+		require.Equal(module, ev.Module, "escrow and allowance messages should fail with the correct module")
+		require.Equal(code, ev.Code, "escrow and allowance messages should fail with the correct code")
+		require.Nil(ev.Result, "failed messages should not have a result")
+	}
+
+	// Allow escrow and allowance messages, but make the runtime account reach its allowance limit.
+	runtime.Staking.AllowEscrowMessages = true
+	runtime.Staking.MaxAllowances = 1
+
+	rtAddr := staking.NewRuntimeAddress(runtime.ID)
+	err = stakingState.NewMutableState(ctx.State()).SetAccount(ctx, rtAddr, &staking.Account{
+		General: staking.GeneralAccount{
+			Allowances: map[staking.Address]quantity.Quantity{
+				staking.CommonPoolAddress: *quantity.NewFromUint64(1),
+			},
+		},
+	})
+	require.NoError(err, "SetAccount")
+
+	msgCtx = ctx.NewChild()
+	err = app.processRuntimeMessages(msgCtx, rtState, msgs)
+	require.NoError(err, "processRuntimeMessages")
+	evs = decodeEvents(msgCtx)
+	msgCtx.Close()
+	require.Len(evs, len(msgs), "an event should be emitted for each message")
+	for i, ev := range evs[:3] {
+		require.True(ev.IsSuccess(), "message %d should succeed", i)
+	}
+	module, code = errors.Code(staking.ErrTooManyAllowances)
+	require.False(evs[3].IsSuccess(), "allowance messages should fail when over the runtime limit")
+	require.Equal(module, evs[3].Module, "allowance messages should fail with the correct module")
+	require.Equal(code, evs[3].Code, "allowance messages should fail with the correct code")
+
+	// Raise the runtime's allowance limit.
+	runtime.Staking.MaxAllowances = 2
+
+	msgCtx = ctx.NewChild()
+	err = app.processRuntimeMessages(msgCtx, rtState, msgs)
+	require.NoError(err, "processRuntimeMessages")
+	evs = decodeEvents(msgCtx)
+	msgCtx.Close()
+	require.Len(evs, len(msgs), "an event should be emitted for each message")
+	for i, ev := range evs {
+		require.True(ev.IsSuccess(), "message %d should succeed", i)
+	}
+}
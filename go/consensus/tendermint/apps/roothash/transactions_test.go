@@ -40,15 +40,18 @@ func (nd *testMsgDispatcher) Subscribe(interface{}, abciAPI.MessageSubscriber) {
 }
 
 // Implements MessageDispatcher.
-func (nd *testMsgDispatcher) Publish(ctx *abciAPI.Context, kind, msg interface{}) error {
+func (nd *testMsgDispatcher) Publish(ctx *abciAPI.Context, kind, msg interface{}) (interface{}, error) {
 	// Either we need to be in simulation mode or the gas accountant must be a no-op one.
 	if !ctx.IsSimulation() && ctx.Gas() != abciAPI.NewNopGasAccountant() {
 		panic("gas estimation should always use simulation mode")
 	}
 
 	gasCosts := transaction.Costs{
-		"transfer": 1000,
-		"withdraw": 2000,
+		"transfer":       1000,
+		"withdraw":       2000,
+		"add_escrow":     3000,
+		"reclaim_escrow": 4000,
+		"allow":          500,
 	}
 
 	switch kind {
@@ -57,19 +60,34 @@ func (nd *testMsgDispatcher) Publish(ctx *abciAPI.Context, kind, msg interface{}
 		switch {
 		case m.Transfer != nil:
 			if err := ctx.Gas().UseGas(1, "transfer", gasCosts); err != nil {
-				return err
+				return nil, err
 			}
-			return nil
+			return &staking.TransferResult{To: m.Transfer.To, Amount: m.Transfer.Amount}, nil
 		case m.Withdraw != nil:
 			if err := ctx.Gas().UseGas(1, "withdraw", gasCosts); err != nil {
-				return err
+				return nil, err
 			}
-			return nil
+			return nil, nil
+		case m.AddEscrow != nil:
+			if err := ctx.Gas().UseGas(1, "add_escrow", gasCosts); err != nil {
+				return nil, err
+			}
+			return nil, nil
+		case m.ReclaimEscrow != nil:
+			if err := ctx.Gas().UseGas(1, "reclaim_escrow", gasCosts); err != nil {
+				return nil, err
+			}
+			return nil, nil
+		case m.Allow != nil:
+			if err := ctx.Gas().UseGas(1, "allow", gasCosts); err != nil {
+				return nil, err
+			}
+			return nil, nil
 		default:
-			return staking.ErrInvalidArgument
+			return nil, staking.ErrInvalidArgument
 		}
 	default:
-		return staking.ErrInvalidArgument
+		return nil, staking.ErrInvalidArgument
 	}
 }
 
@@ -102,6 +120,10 @@ func TestMessagesGasEstimation(t *testing.T) {
 		Executor: registry.ExecutorParameters{
 			MaxMessages: 32,
 		},
+		Staking: registry.RuntimeStakingParameters{
+			AllowEscrowMessages: true,
+			MaxAllowances:       1,
+		},
 	}
 
 	// Initialize scheduler state.
@@ -163,6 +185,12 @@ func TestMessagesGasEstimation(t *testing.T) {
 		{Staking: &message.StakingMessage{Transfer: &staking.Transfer{}}},
 		// Each withdraw message costs 2000 gas.
 		{Staking: &message.StakingMessage{Withdraw: &staking.Withdraw{}}},
+		// Each add escrow message costs 3000 gas.
+		{Staking: &message.StakingMessage{AddEscrow: &staking.Escrow{}}},
+		// Each reclaim escrow message costs 4000 gas.
+		{Staking: &message.StakingMessage{ReclaimEscrow: &staking.ReclaimEscrow{}}},
+		// Each allow message costs 500 gas.
+		{Staking: &message.StakingMessage{Allow: &staking.Allow{}}},
 	}
 	msgsHash := message.MessagesHash(msgs)
 
@@ -210,7 +238,7 @@ func TestMessagesGasEstimation(t *testing.T) {
 
 	err = app.executorCommit(ctx, roothashState, cc)
 	require.NoError(err, "ExecutorCommit")
-	require.EqualValues(5000, ctx.Gas().GasUsed(), "gas amount should be correct")
+	require.EqualValues(12500, ctx.Gas().GasUsed(), "gas amount should be correct")
 }
 
 func TestEvidence(t *testing.T) {
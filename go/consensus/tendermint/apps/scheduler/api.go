@@ -23,4 +23,9 @@ var (
 	// KeyElected is the ABCI event attribute key for the elected
 	// committee types.
 	KeyElected = []byte("elected")
+
+	// KeyElectionFailed is the ABCI event attribute key for committee
+	// elections that have been skipped (value is a CBOR-serialized
+	// scheduler.ElectionFailedEvent).
+	KeyElectionFailed = []byte("election_failed")
 )
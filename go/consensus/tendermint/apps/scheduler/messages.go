@@ -0,0 +1,38 @@
+package scheduler
+
+import (
+	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	schedulerState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/scheduler/state"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+)
+
+// consensusParameters implements api.ConsensusParametersModule for the scheduler module.
+type consensusParameters struct {
+	state *schedulerState.MutableState
+}
+
+func (p *consensusParameters) NewChanges() api.ConsensusParameterChanges {
+	return &scheduler.ConsensusParameterChanges{}
+}
+
+func (p *consensusParameters) Load(ctx *api.Context) (api.ConsensusParameters, error) {
+	params, err := p.state.ConsensusParameters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+func (p *consensusParameters) Apply(params api.ConsensusParameters, changes api.ConsensusParameterChanges) error {
+	return changes.(*scheduler.ConsensusParameterChanges).Apply(params.(*scheduler.ConsensusParameters))
+}
+
+func (p *consensusParameters) Set(ctx *api.Context, params api.ConsensusParameters) error {
+	return p.state.SetConsensusParameters(ctx, params.(*scheduler.ConsensusParameters))
+}
+
+// changeParameters handles a change parameters proposal targeting the scheduler module.
+func (app *schedulerApplication) changeParameters(ctx *api.Context, msg interface{}, apply bool) (interface{}, error) {
+	params := &consensusParameters{state: schedulerState.NewMutableState(ctx.State())}
+	return api.ChangeParameters(ctx, msg, scheduler.ModuleName, params, apply)
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"crypto"
 	"fmt"
+	"math/big"
 	"math/rand"
 	"sort"
 
@@ -22,6 +23,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
 	beaconapp "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/beacon"
 	beaconState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/beacon/state"
+	governanceApi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/governance/api"
 	registryapp "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry/state"
 	schedulerState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/scheduler/state"
@@ -69,6 +71,10 @@ func (app *schedulerApplication) Dependencies() []string {
 
 func (app *schedulerApplication) OnRegister(state api.ApplicationState, md api.MessageDispatcher) {
 	app.state = state
+
+	// Subscribe to messages emitted by other apps.
+	md.Subscribe(governanceApi.MessageChangeParameters, app)
+	md.Subscribe(governanceApi.MessageValidateParameterChanges, app)
 }
 
 func (app *schedulerApplication) OnCleanup() {}
@@ -172,12 +178,19 @@ func (app *schedulerApplication) BeginBlock(ctx *api.Context, request types.Requ
 			}
 		}
 
+		// Determine the entities with nodes in the validator set for the validator set scheduling
+		// constraints.
+		validatorEntities, err := app.validatorEntities(ctx, nodes)
+		if err != nil {
+			return fmt.Errorf("tendermint/scheduler: couldn't get validator entities: %w", err)
+		}
+
 		kinds := []scheduler.CommitteeKind{
 			scheduler.KindComputeExecutor,
 			scheduler.KindStorage,
 		}
 		for _, kind := range kinds {
-			if err = app.electAllCommittees(ctx, request, epoch, entropy, stakeAcc, entitiesEligibleForReward, runtimes, committeeNodes, kind); err != nil {
+			if err = app.electAllCommittees(ctx, request, epoch, entropy, stakeAcc, entitiesEligibleForReward, validatorEntities, runtimes, committeeNodes, kind); err != nil {
 				return fmt.Errorf("tendermint/scheduler: couldn't elect %s committees: %w", kind, err)
 			}
 		}
@@ -208,8 +221,13 @@ func (app *schedulerApplication) BeginBlock(ctx *api.Context, request types.Requ
 	return nil
 }
 
-func (app *schedulerApplication) ExecuteMessage(ctx *api.Context, kind, msg interface{}) error {
-	return fmt.Errorf("scheduler: unexpected message")
+func (app *schedulerApplication) ExecuteMessage(ctx *api.Context, kind, msg interface{}) (interface{}, error) {
+	switch kind {
+	case governanceApi.MessageValidateParameterChanges, governanceApi.MessageChangeParameters:
+		return app.changeParameters(ctx, msg, kind == governanceApi.MessageChangeParameters)
+	default:
+		return nil, fmt.Errorf("scheduler: unexpected message")
+	}
 }
 
 func (app *schedulerApplication) ExecuteTx(ctx *api.Context, tx *transaction.Transaction) error {
@@ -346,6 +364,80 @@ func GetPerm(beacon []byte, runtimeID common.Namespace, rngCtx []byte, nrNodes i
 	return rng.Perm(nrNodes), nil
 }
 
+// GetWeightedPerm generates a permutation that we use to choose nodes from a list of eligible nodes
+// to elect, where the probability of a node being placed before the remaining nodes is proportional
+// to its weight. All weights must be non-zero.
+func GetWeightedPerm(beacon []byte, runtimeID common.Namespace, rngCtx []byte, weights []*quantity.Quantity) ([]int, error) {
+	drbg, err := drbg.New(crypto.SHA512, beacon, runtimeID[:], rngCtx)
+	if err != nil {
+		return nil, fmt.Errorf("tendermint/scheduler: couldn't instantiate DRBG: %w", err)
+	}
+	rng := rand.New(mathrand.New(drbg))
+
+	var total big.Int
+	remaining := make([]int, 0, len(weights))
+	for i, w := range weights {
+		if w.IsZero() {
+			return nil, fmt.Errorf("tendermint/scheduler: zero weight for node %d", i)
+		}
+		total.Add(&total, w.ToBigInt())
+		remaining = append(remaining, i)
+	}
+
+	// Repeatedly draw one of the remaining nodes with probability proportional to its weight.
+	perm := make([]int, 0, len(weights))
+	for len(remaining) > 0 {
+		r := new(big.Int).Rand(rng, &total)
+		for i, idx := range remaining {
+			w := weights[idx].ToBigInt()
+			if r.Cmp(w) >= 0 {
+				r.Sub(r, w)
+				continue
+			}
+
+			perm = append(perm, idx)
+			total.Sub(&total, w)
+			remaining = append(remaining[:i], remaining[i+1:]...)
+			break
+		}
+	}
+	return perm, nil
+}
+
+// nodeStakeWeights returns the election weights of the given nodes. The escrow balance of each
+// entity is split evenly between its nodes and each node has a weight of at least one.
+//
+// In case stake is bypassed, all nodes have the same weight.
+func nodeStakeWeights(stakeAcc *stakingState.StakeAccumulatorCache, nodes []*node.Node) ([]*quantity.Quantity, error) {
+	weights := make([]*quantity.Quantity, 0, len(nodes))
+	if stakeAcc == nil {
+		for range nodes {
+			weights = append(weights, quantity.NewFromUint64(1))
+		}
+		return weights, nil
+	}
+
+	entityNodes := make(map[staking.Address]uint64)
+	for _, n := range nodes {
+		entityNodes[staking.NewAddress(n.EntityID)]++
+	}
+	for _, n := range nodes {
+		entAddr := staking.NewAddress(n.EntityID)
+		weight, err := stakeAcc.GetEscrowBalance(entAddr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch escrow balance for account %s: %w", entAddr, err)
+		}
+		if err = weight.Quo(quantity.NewFromUint64(entityNodes[entAddr])); err != nil {
+			return nil, err
+		}
+		if weight.IsZero() {
+			weight = quantity.NewFromUint64(1)
+		}
+		weights = append(weights, weight)
+	}
+	return weights, nil
+}
+
 // Operates on consensus connection.
 // Return error if node should crash.
 // For non-fatal problems, save a problem condition to the state and return successfully.
@@ -355,6 +447,7 @@ func (app *schedulerApplication) electCommittee(
 	beacon []byte,
 	stakeAcc *stakingState.StakeAccumulatorCache,
 	entitiesEligibleForReward map[staking.Address]bool,
+	validatorEntities map[signature.PublicKey]bool,
 	rt *registry.Runtime,
 	nodes []*node.Node,
 	kind scheduler.CommitteeKind,
@@ -374,6 +467,7 @@ func (app *schedulerApplication) electCommittee(
 		isSuitableFn func(*api.Context, *node.Node, *registry.Runtime) bool
 
 		workerSize, backupSize, minPoolSize int
+		electionAlgorithm                   string
 	)
 
 	switch kind {
@@ -383,11 +477,13 @@ func (app *schedulerApplication) electCommittee(
 		workerSize = int(rt.Executor.GroupSize)
 		backupSize = int(rt.Executor.GroupBackupSize)
 		minPoolSize = int(rt.Executor.MinPoolSize)
+		electionAlgorithm = rt.Executor.ElectionAlgorithm
 	case scheduler.KindStorage:
 		rngCtx = RNGContextStorage
 		isSuitableFn = app.isSuitableStorageWorker
 		workerSize = int(rt.Storage.GroupSize)
 		minPoolSize = int(rt.Storage.MinPoolSize)
+		electionAlgorithm = rt.Storage.ElectionAlgorithm
 	default:
 		return fmt.Errorf("tendermint/scheduler: invalid committee type: %v", kind)
 	}
@@ -414,10 +510,7 @@ func (app *schedulerApplication) electCommittee(
 			"kind", kind,
 			"runtime_id", rt.ID,
 		)
-		if err = schedulerState.NewMutableState(ctx.State()).DropCommittee(ctx, kind, rt.ID); err != nil {
-			return fmt.Errorf("failed to drop committee: %w", err)
-		}
-		return nil
+		return app.dropCommittee(ctx, kind, rt.ID, "empty committee not allowed")
 	}
 
 	nrNodes := len(nodeList)
@@ -429,10 +522,7 @@ func (app *schedulerApplication) electCommittee(
 			"nr_nodes", nrNodes,
 			"min_pool_size", minPoolSize,
 		)
-		if err = schedulerState.NewMutableState(ctx.State()).DropCommittee(ctx, kind, rt.ID); err != nil {
-			return fmt.Errorf("failed to drop committee: %w", err)
-		}
-		return nil
+		return app.dropCommittee(ctx, kind, rt.ID, "not enough eligible nodes")
 	}
 
 	wantedNodes := workerSize + backupSize
@@ -444,27 +534,88 @@ func (app *schedulerApplication) electCommittee(
 			"backup_size", backupSize,
 			"nr_nodes", nrNodes,
 		)
-		if err = schedulerState.NewMutableState(ctx.State()).DropCommittee(ctx, kind, rt.ID); err != nil {
-			return fmt.Errorf("failed to drop committee: %w", err)
+		return app.dropCommittee(ctx, kind, rt.ID, "committee size exceeds available nodes")
+	}
+
+	// Ensure that the per-role candidate pools satisfy the scheduling constraints.
+	constraints := rt.Constraints[kind]
+	roleSizes := []struct {
+		role scheduler.Role
+		size int
+	}{
+		{scheduler.RoleWorker, workerSize},
+		{scheduler.RoleBackupWorker, backupSize},
+	}
+	for _, rs := range roleSizes {
+		sc, ok := constraints[rs.role]
+		if !ok || rs.size == 0 || sc.MinPoolSize == nil {
+			continue
+		}
+
+		var poolSize int
+		for _, n := range nodeList {
+			if isEligibleForRole(n, &sc, validatorEntities, nil) {
+				poolSize++
+			}
+		}
+		if poolSize < int(sc.MinPoolSize.Limit) {
+			ctx.Logger().Error("not enough eligible nodes for role",
+				"kind", kind,
+				"runtime_id", rt.ID,
+				"role", rs.role,
+				"pool_size", poolSize,
+				"min_pool_size", sc.MinPoolSize.Limit,
+			)
+			return app.dropCommittee(ctx, kind, rt.ID, fmt.Sprintf("not enough eligible nodes for role %s", rs.role))
 		}
-		return nil
 	}
 
 	// Do the actual election.
-	idxs, err := GetPerm(beacon, rt.ID, rngCtx, nrNodes)
+	var idxs []int
+	switch electionAlgorithm {
+	case registry.ElectionAlgorithmStakeWeighted:
+		var weights []*quantity.Quantity
+		if weights, err = nodeStakeWeights(stakeAcc, nodeList); err != nil {
+			return err
+		}
+		idxs, err = GetWeightedPerm(beacon, rt.ID, rngCtx, weights)
+	default:
+		idxs, err = GetPerm(beacon, rt.ID, rngCtx, nrNodes)
+	}
 	if err != nil {
 		return err
 	}
 
-	var members []*scheduler.CommitteeNode
+	// Go through the permuted node list and fill the worker slots first and the backup worker
+	// slots afterwards, skipping nodes that do not satisfy the per-role scheduling constraints.
+	var (
+		members              []*scheduler.CommitteeNode
+		nrWorkers, nrBackups int
+	)
+	workerConstraints := constraints[scheduler.RoleWorker]
+	backupConstraints := constraints[scheduler.RoleBackupWorker]
+	workerEntityNodes := make(map[signature.PublicKey]uint16)
+	backupEntityNodes := make(map[signature.PublicKey]uint16)
 	for i := 0; i < len(idxs); i++ {
-		role := scheduler.RoleWorker
-		if i >= workerSize {
+		n := nodeList[idxs[i]]
+
+		var role scheduler.Role
+		switch {
+		case nrWorkers < workerSize && isEligibleForRole(n, &workerConstraints, validatorEntities, workerEntityNodes):
+			role = scheduler.RoleWorker
+			nrWorkers++
+			workerEntityNodes[n.EntityID]++
+		case nrBackups < backupSize && isEligibleForRole(n, &backupConstraints, validatorEntities, backupEntityNodes):
 			role = scheduler.RoleBackupWorker
+			nrBackups++
+			backupEntityNodes[n.EntityID]++
+		default:
+			continue
 		}
+
 		members = append(members, &scheduler.CommitteeNode{
 			Role:      role,
-			PublicKey: nodeList[idxs[i]].ID,
+			PublicKey: n.ID,
 		})
 		if len(members) >= wantedNodes {
 			break
@@ -472,17 +623,14 @@ func (app *schedulerApplication) electCommittee(
 	}
 
 	if len(members) != wantedNodes {
-		ctx.Logger().Error("insufficient nodes with adequate stake to elect",
+		ctx.Logger().Error("insufficient nodes satisfying the scheduling constraints to elect",
 			"kind", kind,
 			"runtime_id", rt.ID,
 			"worker_size", workerSize,
 			"backup_size", backupSize,
 			"available", len(members),
 		)
-		if err = schedulerState.NewMutableState(ctx.State()).DropCommittee(ctx, kind, rt.ID); err != nil {
-			return fmt.Errorf("failed to drop committee: %w", err)
-		}
-		return nil
+		return app.dropCommittee(ctx, kind, rt.ID, "insufficient nodes satisfying the scheduling constraints")
 	}
 
 	err = schedulerState.NewMutableState(ctx.State()).PutCommittee(ctx, &scheduler.Committee{
@@ -497,6 +645,66 @@ func (app *schedulerApplication) electCommittee(
 	return nil
 }
 
+// isEligibleForRole returns true iff the node satisfies the given per-role scheduling
+// constraints. In case entityNodes is non-nil, it is used to enforce the maximum number of nodes
+// per entity.
+func isEligibleForRole(
+	n *node.Node,
+	sc *registry.SchedulingConstraints,
+	validatorEntities map[signature.PublicKey]bool,
+	entityNodes map[signature.PublicKey]uint16,
+) bool {
+	if sc.ValidatorSet != nil && !validatorEntities[n.EntityID] {
+		return false
+	}
+	if sc.MaxNodes != nil && entityNodes != nil && entityNodes[n.EntityID] >= sc.MaxNodes.Limit {
+		return false
+	}
+	return true
+}
+
+func (app *schedulerApplication) dropCommittee(
+	ctx *api.Context,
+	kind scheduler.CommitteeKind,
+	runtimeID common.Namespace,
+	reason string,
+) error {
+	if err := schedulerState.NewMutableState(ctx.State()).DropCommittee(ctx, kind, runtimeID); err != nil {
+		return fmt.Errorf("failed to drop committee: %w", err)
+	}
+
+	evt := &scheduler.ElectionFailedEvent{
+		RuntimeID: runtimeID,
+		Kind:      kind,
+		Reason:    reason,
+	}
+	ctx.EmitEvent(api.NewEventBuilder(app.Name()).Attribute(KeyElectionFailed, cbor.Marshal(evt)))
+	return nil
+}
+
+// validatorEntities returns the set of entities that have a node in the validator set. In case a
+// new validator set has been elected in the current block, the new validator set is used.
+func (app *schedulerApplication) validatorEntities(ctx *api.Context, nodes []*node.Node) (map[signature.PublicKey]bool, error) {
+	state := schedulerState.NewMutableState(ctx.State())
+	validators, err := state.PendingValidators(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pending validators: %w", err)
+	}
+	if validators == nil {
+		if validators, err = state.CurrentValidators(ctx); err != nil {
+			return nil, fmt.Errorf("failed to query current validators: %w", err)
+		}
+	}
+
+	entities := make(map[signature.PublicKey]bool)
+	for _, n := range nodes {
+		if _, ok := validators[n.Consensus.ID]; ok {
+			entities[n.EntityID] = true
+		}
+	}
+	return entities, nil
+}
+
 // Operates on consensus connection.
 func (app *schedulerApplication) electAllCommittees(
 	ctx *api.Context,
@@ -505,12 +713,13 @@ func (app *schedulerApplication) electAllCommittees(
 	beacon []byte,
 	stakeAcc *stakingState.StakeAccumulatorCache,
 	entitiesEligibleForReward map[staking.Address]bool,
+	validatorEntities map[signature.PublicKey]bool,
 	runtimes []*registry.Runtime,
 	nodes []*node.Node,
 	kind scheduler.CommitteeKind,
 ) error {
 	for _, runtime := range runtimes {
-		if err := app.electCommittee(ctx, epoch, beacon, stakeAcc, entitiesEligibleForReward, runtime, nodes, kind); err != nil {
+		if err := app.electCommittee(ctx, epoch, beacon, stakeAcc, entitiesEligibleForReward, validatorEntities, runtime, nodes, kind); err != nil {
 			return err
 		}
 	}
@@ -2,13 +2,22 @@ package scheduler
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/abci/types"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	schedulerState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/scheduler/state"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking/state"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 )
 
 func TestDiffValidators(t *testing.T) {
@@ -59,3 +68,205 @@ func TestDiffValidators(t *testing.T) {
 		require.Equal(t, tt.result, diffValidators(logger, tt.current, tt.pending), tt.msg)
 	}
 }
+
+func TestElectCommitteeConstraints(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1580461674, 0)
+	appState := api.NewMockApplicationState(&api.MockApplicationStateConfig{})
+	ctx := appState.NewContext(api.ContextBeginBlock, now)
+	defer ctx.Close()
+
+	app := &schedulerApplication{
+		state: appState,
+	}
+	state := schedulerState.NewMutableState(ctx.State())
+
+	var runtimeID common.Namespace
+	require.NoError(runtimeID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000000"), "UnmarshalHex")
+
+	entityA := signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	entityB := signature.NewPublicKey("bbbfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	var nodes []*node.Node
+	for i, entityID := range []signature.PublicKey{entityA, entityA, entityA, entityB} {
+		var nodeID signature.PublicKey
+		nodeID[0] = byte(i + 1)
+		nodes = append(nodes, &node.Node{
+			ID:       nodeID,
+			EntityID: entityID,
+			Roles:    node.RoleComputeWorker,
+			Runtimes: []*node.Runtime{{ID: runtimeID}},
+		})
+	}
+	entityOf := make(map[signature.PublicKey]signature.PublicKey)
+	for _, n := range nodes {
+		entityOf[n.ID] = n.EntityID
+	}
+
+	rt := &registry.Runtime{
+		ID:   runtimeID,
+		Kind: registry.KindCompute,
+		Executor: registry.ExecutorParameters{
+			GroupSize:   2,
+			MinPoolSize: 2,
+		},
+		Constraints: map[scheduler.CommitteeKind]map[scheduler.Role]registry.SchedulingConstraints{
+			scheduler.KindComputeExecutor: {
+				scheduler.RoleWorker: {
+					MaxNodes: &registry.MaxNodesConstraint{Limit: 1},
+				},
+			},
+		},
+	}
+
+	// With at most one node per entity, both entities must always be part of the committee.
+	for i := byte(0); i < 16; i++ {
+		beacon := make([]byte, 32)
+		beacon[0] = i
+		err := app.electCommittee(ctx, 1, beacon, nil, nil, nil, rt, nodes, scheduler.KindComputeExecutor)
+		require.NoError(err, "electCommittee")
+
+		committee, err := state.Committee(ctx, scheduler.KindComputeExecutor, runtimeID)
+		require.NoError(err, "Committee")
+		require.NotNil(committee, "committee should be elected")
+		require.Len(committee.Members, 2, "committee should have the correct size")
+		require.NotEqual(
+			entityOf[committee.Members[0].PublicKey],
+			entityOf[committee.Members[1].PublicKey],
+			"committee members should belong to different entities",
+		)
+	}
+	require.False(ctx.HasEvent(app.Name(), KeyElectionFailed), "no election should fail")
+
+	// Only entity B is in the validator set, so the minimum pool size cannot be satisfied.
+	rt.Constraints[scheduler.KindComputeExecutor][scheduler.RoleWorker] = registry.SchedulingConstraints{
+		ValidatorSet: &registry.ValidatorSetConstraint{},
+		MinPoolSize:  &registry.MinPoolSizeConstraint{Limit: 2},
+	}
+	validatorEntities := map[signature.PublicKey]bool{entityB: true}
+	err := app.electCommittee(ctx, 1, make([]byte, 32), nil, nil, validatorEntities, rt, nodes, scheduler.KindComputeExecutor)
+	require.NoError(err, "electCommittee")
+
+	committee, err := state.Committee(ctx, scheduler.KindComputeExecutor, runtimeID)
+	require.NoError(err, "Committee")
+	require.Nil(committee, "committee should be dropped")
+	require.True(ctx.HasEvent(app.Name(), KeyElectionFailed), "failed election should emit an event")
+}
+
+func TestGetWeightedPerm(t *testing.T) {
+	require := require.New(t)
+
+	var runtimeID common.Namespace
+	beacon := make([]byte, 32)
+	weights := []*quantity.Quantity{
+		quantity.NewFromUint64(1),
+		quantity.NewFromUint64(10),
+		quantity.NewFromUint64(100),
+		quantity.NewFromUint64(1000),
+	}
+
+	perm, err := GetWeightedPerm(beacon, runtimeID, RNGContextExecutor, weights)
+	require.NoError(err, "GetWeightedPerm")
+	require.ElementsMatch([]int{0, 1, 2, 3}, perm, "result should be a permutation")
+
+	perm2, err := GetWeightedPerm(beacon, runtimeID, RNGContextExecutor, weights)
+	require.NoError(err, "GetWeightedPerm")
+	require.Equal(perm, perm2, "result should be deterministic")
+
+	var heaviestFirst int
+	for i := byte(0); i < 64; i++ {
+		beacon[0] = i
+		perm, err = GetWeightedPerm(beacon, runtimeID, RNGContextExecutor, weights)
+		require.NoError(err, "GetWeightedPerm")
+		if perm[0] == 3 {
+			heaviestFirst++
+		}
+	}
+	require.True(heaviestFirst > 48, "heaviest node should usually be first (got %d/64)", heaviestFirst)
+
+	_, err = GetWeightedPerm(beacon, runtimeID, RNGContextExecutor, []*quantity.Quantity{quantity.NewQuantity()})
+	require.Error(err, "zero weights should be rejected")
+}
+
+func TestElectCommitteeStakeWeighted(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1580461674, 0)
+	appState := api.NewMockApplicationState(&api.MockApplicationStateConfig{})
+	ctx := appState.NewContext(api.ContextBeginBlock, now)
+	defer ctx.Close()
+
+	app := &schedulerApplication{
+		state: appState,
+	}
+	state := schedulerState.NewMutableState(ctx.State())
+	stakeState := stakingState.NewMutableState(ctx.State())
+	err := stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{})
+	require.NoError(err, "SetConsensusParameters")
+
+	var runtimeID common.Namespace
+	require.NoError(runtimeID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000000"), "UnmarshalHex")
+
+	// Entity A has a lot more stake than entity B.
+	entityA := signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	entityB := signature.NewPublicKey("bbbfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	var nodes []*node.Node
+	for i, ent := range []struct {
+		id     signature.PublicKey
+		escrow uint64
+	}{
+		{entityA, 1_000_000},
+		{entityB, 1_000},
+	} {
+		var acct staking.Account
+		acct.Escrow.Active.Balance = *quantity.NewFromUint64(ent.escrow)
+		err = stakeState.SetAccount(ctx, staking.NewAddress(ent.id), &acct)
+		require.NoError(err, "SetAccount")
+
+		var nodeID signature.PublicKey
+		nodeID[0] = byte(i + 1)
+		nodes = append(nodes, &node.Node{
+			ID:       nodeID,
+			EntityID: ent.id,
+			Roles:    node.RoleComputeWorker,
+			Runtimes: []*node.Runtime{{ID: runtimeID}},
+		})
+	}
+
+	stakeAcc, err := stakingState.NewStakeAccumulatorCache(ctx)
+	require.NoError(err, "NewStakeAccumulatorCache")
+	defer stakeAcc.Discard()
+
+	weights, err := nodeStakeWeights(stakeAcc, nodes)
+	require.NoError(err, "nodeStakeWeights")
+	require.Zero(weights[0].Cmp(quantity.NewFromUint64(1_000_000)), "node weight should be the entity escrow balance")
+	require.Zero(weights[1].Cmp(quantity.NewFromUint64(1_000)), "node weight should be the entity escrow balance")
+
+	rt := &registry.Runtime{
+		ID:   runtimeID,
+		Kind: registry.KindCompute,
+		Executor: registry.ExecutorParameters{
+			GroupSize:         1,
+			MinPoolSize:       1,
+			ElectionAlgorithm: registry.ElectionAlgorithmStakeWeighted,
+		},
+	}
+
+	var electedA int
+	for i := byte(0); i < 32; i++ {
+		beacon := make([]byte, 32)
+		beacon[0] = i
+		err = app.electCommittee(ctx, 1, beacon, stakeAcc, nil, nil, rt, nodes, scheduler.KindComputeExecutor)
+		require.NoError(err, "electCommittee")
+
+		var committee *scheduler.Committee
+		committee, err = state.Committee(ctx, scheduler.KindComputeExecutor, runtimeID)
+		require.NoError(err, "Committee")
+		require.NotNil(committee, "committee should be elected")
+		require.Len(committee.Members, 1, "committee should have the correct size")
+		if committee.Members[0].PublicKey.Equal(nodes[0].ID) {
+			electedA++
+		}
+	}
+	require.True(electedA >= 30, "node of entity with more stake should usually be elected (got %d/32)", electedA)
+}
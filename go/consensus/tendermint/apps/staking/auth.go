@@ -2,11 +2,14 @@ package staking
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
 	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking/state"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 )
 
 var _ api.TransactionAuthHandler = (*stakingApplication)(nil)
@@ -27,5 +30,33 @@ func (app *stakingApplication) GetSignerNonce(ctx context.Context, req *consensu
 
 // Implements api.TransactionAuthHandler.
 func (app *stakingApplication) AuthenticateTx(ctx *api.Context, tx *transaction.Transaction) error {
-	return stakingState.AuthenticateAndPayFees(ctx, ctx.TxSigner(), tx.Nonce, tx.Fee)
+	if descriptor, signers := ctx.TxMultisig(); descriptor != nil {
+		if err := authenticateMultisig(tx, descriptor, signers); err != nil {
+			return err
+		}
+	}
+	return stakingState.AuthenticateAndPayFees(ctx, ctx.CallerAddress(), tx.Nonce, tx.Fee)
+}
+
+// authenticateMultisig verifies that the signers of a multisig transaction satisfy the multisig
+// descriptor and that the transaction is allowed to be submitted from a multisig account.
+func authenticateMultisig(
+	tx *transaction.Transaction,
+	descriptor *transaction.MultisigDescriptor,
+	signers []signature.PublicKey,
+) error {
+	// Multisig accounts have no associated public key so they can only be used for staking
+	// transactions which only rely on the caller address.
+	var isStakingMethod bool
+	for _, m := range staking.Methods {
+		if m == tx.Method {
+			isStakingMethod = true
+			break
+		}
+	}
+	if !isStakingMethod {
+		return fmt.Errorf("staking: multisig accounts can only submit staking transactions (method: %s)", tx.Method)
+	}
+
+	return descriptor.VerifySigners(signers)
 }
@@ -0,0 +1,67 @@
+package staking
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking/state"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+func TestAuthenticateMultisig(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1580461674, 0)
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextDeliverTx, now)
+	defer ctx.Close()
+
+	app := &stakingApplication{
+		state: appState,
+	}
+	stakeState := stakingState.NewMutableState(ctx.State())
+
+	pk1 := memorySigner.NewTestSigner("staking multisig test signer 1").Public()
+	pk2 := memorySigner.NewTestSigner("staking multisig test signer 2").Public()
+	pk3 := memorySigner.NewTestSigner("staking multisig test signer 3").Public()
+	descriptor := &transaction.MultisigDescriptor{
+		Threshold: 2,
+		Signers:   []signature.PublicKey{pk1, pk2, pk3},
+	}
+	multisigAddr := staking.NewMultisigAddress(descriptor)
+
+	transferTx := staking.NewTransferTx(0, nil, &staking.Transfer{})
+
+	for _, tc := range []struct {
+		msg     string
+		tx      *transaction.Transaction
+		signers []signature.PublicKey
+		valid   bool
+	}{
+		{"threshold not met", transferTx, []signature.PublicKey{pk1}, false},
+		{"duplicate signers", transferTx, []signature.PublicKey{pk2, pk2}, false},
+		{"non-staking method", governance.NewCastVoteTx(0, nil, &governance.ProposalVote{}), []signature.PublicKey{pk1, pk2}, false},
+		{"threshold met", transferTx, []signature.PublicKey{pk1, pk3}, true},
+	} {
+		ctx.SetTxMultisig(descriptor, tc.signers)
+		require.EqualValues(multisigAddr, ctx.CallerAddress(), "caller should be the multisig account")
+
+		err := app.AuthenticateTx(ctx, tc.tx)
+		if !tc.valid {
+			require.Error(err, tc.msg)
+			continue
+		}
+		require.NoError(err, tc.msg)
+	}
+
+	acct, err := stakeState.Account(ctx, multisigAddr)
+	require.NoError(err, "Account")
+	require.EqualValues(1, acct.General.Nonce, "multisig account nonce should be incremented")
+}
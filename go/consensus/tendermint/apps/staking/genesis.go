@@ -124,6 +124,16 @@ func (app *stakingApplication) initLedger(
 			return fmt.Errorf("tendermint/staking: non-empty stake accumulator in genesis for account %s", addr)
 		}
 
+		if acct.General.Vesting != nil {
+			if err := acct.General.Vesting.ValidateBasic(); err != nil {
+				ctx.Logger().Error("InitChain: invalid genesis vesting schedule",
+					"address", addr,
+					"err", err,
+				)
+				return fmt.Errorf("tendermint/staking: invalid genesis vesting schedule for account %s: %w", addr, err)
+			}
+		}
+
 		if err := totalSupply.Add(&acct.General.Balance); err != nil {
 			ctx.Logger().Error("InitChain: failed to add general balance",
 				"err", err,
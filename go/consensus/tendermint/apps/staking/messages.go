@@ -0,0 +1,38 @@
+package staking
+
+import (
+	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking/state"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+// consensusParameters implements api.ConsensusParametersModule for the staking module.
+type consensusParameters struct {
+	state *stakingState.MutableState
+}
+
+func (p *consensusParameters) NewChanges() api.ConsensusParameterChanges {
+	return &staking.ConsensusParameterChanges{}
+}
+
+func (p *consensusParameters) Load(ctx *api.Context) (api.ConsensusParameters, error) {
+	params, err := p.state.ConsensusParameters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+func (p *consensusParameters) Apply(params api.ConsensusParameters, changes api.ConsensusParameterChanges) error {
+	return changes.(*staking.ConsensusParameterChanges).Apply(params.(*staking.ConsensusParameters))
+}
+
+func (p *consensusParameters) Set(ctx *api.Context, params api.ConsensusParameters) error {
+	return p.state.SetConsensusParameters(ctx, params.(*staking.ConsensusParameters))
+}
+
+// changeParameters handles a change parameters proposal targeting the staking module.
+func (app *stakingApplication) changeParameters(ctx *api.Context, msg interface{}, apply bool) (interface{}, error) {
+	params := &consensusParameters{state: stakingState.NewMutableState(ctx.State())}
+	return api.ChangeParameters(ctx, msg, staking.ModuleName, params, apply)
+}
@@ -0,0 +1,235 @@
+package staking
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	roothashApi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/roothash/api"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking/state"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/message"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+func TestChangeParameters(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1580461674, 0)
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock, now)
+	defer ctx.Close()
+
+	// Setup state.
+	state := stakingState.NewMutableState(ctx.State())
+	app := &stakingApplication{
+		state: appState,
+	}
+	params := &staking.ConsensusParameters{
+		Thresholds: map[staking.ThresholdKind]quantity.Quantity{
+			staking.KindEntity:            *quantity.NewQuantity(),
+			staking.KindNodeValidator:     *quantity.NewQuantity(),
+			staking.KindNodeCompute:       *quantity.NewQuantity(),
+			staking.KindNodeStorage:       *quantity.NewQuantity(),
+			staking.KindNodeKeyManager:    *quantity.NewQuantity(),
+			staking.KindRuntimeCompute:    *quantity.NewQuantity(),
+			staking.KindRuntimeKeyManager: *quantity.NewQuantity(),
+		},
+		DebondingInterval:  1,
+		FeeSplitWeightVote: *quantity.NewFromUint64(1),
+	}
+	err := state.SetConsensusParameters(ctx, params)
+	require.NoError(err, "SetConsensusParameters")
+
+	// Prepare proposal.
+	debondingInterval := beacon.EpochTime(42)
+	changes := staking.ConsensusParameterChanges{
+		DebondingInterval: &debondingInterval,
+	}
+	proposal := governance.ChangeParametersProposal{
+		Module:  staking.ModuleName,
+		Changes: cbor.Marshal(changes),
+	}
+
+	// Run validations first.
+	res, err := app.changeParameters(ctx, &proposal, false)
+	require.NoError(err, "validation of consensus parameter changes should succeed")
+	require.NotNil(res, "validation of consensus parameter changes should return a result")
+
+	state = stakingState.NewMutableState(ctx.State())
+	params, err = state.ConsensusParameters(ctx)
+	require.NoError(err, "ConsensusParameters")
+	require.EqualValues(1, params.DebondingInterval, "validation should not change consensus parameters")
+
+	// Apply changes.
+	res, err = app.changeParameters(ctx, &proposal, true)
+	require.NoError(err, "changing consensus parameters should succeed")
+	require.NotNil(res, "changing consensus parameters should return a result")
+
+	state = stakingState.NewMutableState(ctx.State())
+	params, err = state.ConsensusParameters(ctx)
+	require.NoError(err, "ConsensusParameters")
+	require.EqualValues(42, params.DebondingInterval, "consensus parameters should be changed")
+
+	// Changes for another module should be ignored.
+	proposal.Module = "foo"
+	res, err = app.changeParameters(ctx, &proposal, true)
+	require.NoError(err, "changes for another module should be ignored")
+	require.Nil(res, "changes for another module should not return a result")
+
+	// Invalid changes should fail.
+	feeSplitWeightVote := quantity.NewQuantity()
+	changes = staking.ConsensusParameterChanges{
+		FeeSplitWeightVote: feeSplitWeightVote,
+	}
+	proposal = governance.ChangeParametersProposal{
+		Module:  staking.ModuleName,
+		Changes: cbor.Marshal(changes),
+	}
+	_, err = app.changeParameters(ctx, &proposal, false)
+	require.Error(err, "changes resulting in invalid consensus parameters should fail")
+
+	// Empty changes should fail.
+	proposal.Changes = cbor.Marshal(staking.ConsensusParameterChanges{})
+	_, err = app.changeParameters(ctx, &proposal, false)
+	require.Error(err, "empty consensus parameter changes should fail")
+}
+
+func TestRuntimeMessages(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1580461674, 0)
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{
+		CurrentEpoch: 42,
+	})
+	ctx := appState.NewContext(abciAPI.ContextDeliverTx, now)
+	defer ctx.Close()
+
+	// Setup state.
+	state := stakingState.NewMutableState(ctx.State())
+	app := &stakingApplication{
+		state: appState,
+	}
+	err := state.SetConsensusParameters(ctx, &staking.ConsensusParameters{
+		DebondingInterval: 10,
+		MaxAllowances:     1,
+	})
+	require.NoError(err, "SetConsensusParameters")
+
+	rtAddr := staking.NewRuntimeAddress(common.Namespace{})
+	escrowAddr := staking.NewAddress(signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+	rtAcct := &staking.Account{
+		General: staking.GeneralAccount{
+			Balance: *quantity.NewFromUint64(1000),
+		},
+	}
+	err = state.SetAccount(ctx, rtAddr, rtAcct)
+	require.NoError(err, "SetAccount")
+
+	msgCtx := ctx.WithCallerAddress(rtAddr)
+	defer msgCtx.Close()
+
+	// Add escrow.
+	res, err := app.ExecuteMessage(msgCtx, roothashApi.RuntimeMessageStaking, &message.StakingMessage{
+		AddEscrow: &staking.Escrow{
+			Account: escrowAddr,
+			Amount:  *quantity.NewFromUint64(300),
+		},
+	})
+	require.NoError(err, "AddEscrow")
+	require.Equal(&staking.AddEscrowResult{
+		Owner:     rtAddr,
+		Escrow:    escrowAddr,
+		Amount:    *quantity.NewFromUint64(300),
+		NewShares: *quantity.NewFromUint64(300),
+	}, res, "AddEscrow result should be correct")
+
+	// Reclaim part of the escrow.
+	res, err = app.ExecuteMessage(msgCtx, roothashApi.RuntimeMessageStaking, &message.StakingMessage{
+		ReclaimEscrow: &staking.ReclaimEscrow{
+			Account: escrowAddr,
+			Shares:  *quantity.NewFromUint64(100),
+		},
+	})
+	require.NoError(err, "ReclaimEscrow")
+	require.Equal(&staking.ReclaimEscrowResult{
+		Owner:           rtAddr,
+		Escrow:          escrowAddr,
+		Amount:          *quantity.NewFromUint64(100),
+		RemainingShares: *quantity.NewFromUint64(200),
+		DebondingShares: *quantity.NewFromUint64(100),
+		DebondEndTime:   52,
+	}, res, "ReclaimEscrow result should be correct")
+
+	// Configure an allowance.
+	res, err = app.ExecuteMessage(msgCtx, roothashApi.RuntimeMessageStaking, &message.StakingMessage{
+		Allow: &staking.Allow{
+			Beneficiary:  escrowAddr,
+			AmountChange: *quantity.NewFromUint64(50),
+		},
+	})
+	require.NoError(err, "Allow")
+	require.Equal(&staking.AllowResult{
+		Owner:        rtAddr,
+		Beneficiary:  escrowAddr,
+		Allowance:    *quantity.NewFromUint64(50),
+		AmountChange: *quantity.NewFromUint64(50),
+	}, res, "Allow result should be correct")
+
+	// Transfer.
+	res, err = app.ExecuteMessage(msgCtx, roothashApi.RuntimeMessageStaking, &message.StakingMessage{
+		Transfer: &staking.Transfer{
+			To:     escrowAddr,
+			Amount: *quantity.NewFromUint64(10),
+		},
+	})
+	require.NoError(err, "Transfer")
+	require.Equal(&staking.TransferResult{
+		From:   rtAddr,
+		To:     escrowAddr,
+		Amount: *quantity.NewFromUint64(10),
+	}, res, "Transfer result should be correct")
+
+	rtAcct, err = state.Account(ctx, rtAddr)
+	require.NoError(err, "Account")
+	require.Equal(*quantity.NewFromUint64(690), rtAcct.General.Balance, "runtime account balance should be correct")
+}
+
+func TestRuntimeMessagesNoResult(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1580461674, 0)
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	app := &stakingApplication{
+		state: appState,
+	}
+
+	msgs := []*message.StakingMessage{
+		{Transfer: &staking.Transfer{}},
+		{Withdraw: &staking.Withdraw{}},
+		{AddEscrow: &staking.Escrow{}},
+		{ReclaimEscrow: &staking.ReclaimEscrow{Shares: *quantity.NewFromUint64(1)}},
+		{Allow: &staking.Allow{}},
+	}
+	for _, kind := range []abciAPI.ContextMode{abciAPI.ContextCheckTx, abciAPI.ContextSimulateTx} {
+		ctx := appState.NewContext(kind, now)
+		err := stakingState.NewMutableState(ctx.State()).SetConsensusParameters(ctx, &staking.ConsensusParameters{})
+		require.NoError(err, "SetConsensusParameters")
+		ctx.SetGasAccountant(abciAPI.NewNopGasAccountant())
+
+		for i, msg := range msgs {
+			res, err := app.ExecuteMessage(ctx, roothashApi.RuntimeMessageStaking, msg)
+			require.NoError(err, "ExecuteMessage (mode: %s, message: %d)", kind, i)
+			// NOTE: Do not use require.Nil as it also treats typed nils as nil.
+			require.True(res == nil, "message should not return a result (mode: %s, message: %d)", kind, i)
+		}
+		ctx.Close()
+	}
+}
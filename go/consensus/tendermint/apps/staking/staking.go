@@ -11,6 +11,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	governanceApi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/governance/api"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/registry/state"
 	roothashApi "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/roothash/api"
 	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/apps/staking/state"
@@ -49,6 +50,8 @@ func (app *stakingApplication) OnRegister(state api.ApplicationState, md api.Mes
 
 	// Subscribe to messages emitted by other apps.
 	md.Subscribe(roothashApi.RuntimeMessageStaking, app)
+	md.Subscribe(governanceApi.MessageChangeParameters, app)
+	md.Subscribe(governanceApi.MessageValidateParameterChanges, app)
 }
 
 func (app *stakingApplication) OnCleanup() {
@@ -109,22 +112,53 @@ func (app *stakingApplication) BeginBlock(ctx *api.Context, request types.Reques
 	return nil
 }
 
-func (app *stakingApplication) ExecuteMessage(ctx *api.Context, kind, msg interface{}) error {
+func (app *stakingApplication) ExecuteMessage(ctx *api.Context, kind, msg interface{}) (interface{}, error) {
 	state := stakingState.NewMutableState(ctx.State())
 
 	switch kind {
 	case roothashApi.RuntimeMessageStaking:
 		m := msg.(*message.StakingMessage)
+
+		// NOTE: Operations return typed results which are nil in check-only and simulation mode.
+		//       Make sure to never return a typed nil as the message dispatcher checks for nil.
+		var (
+			res interface{}
+			err error
+		)
 		switch {
 		case m.Transfer != nil:
-			return app.transfer(ctx, state, m.Transfer)
+			var r *staking.TransferResult
+			if r, err = app.transfer(ctx, state, m.Transfer); r != nil {
+				res = r
+			}
 		case m.Withdraw != nil:
-			return app.withdraw(ctx, state, m.Withdraw)
+			var r *staking.WithdrawResult
+			if r, err = app.withdraw(ctx, state, m.Withdraw); r != nil {
+				res = r
+			}
+		case m.AddEscrow != nil:
+			var r *staking.AddEscrowResult
+			if r, err = app.addEscrow(ctx, state, m.AddEscrow); r != nil {
+				res = r
+			}
+		case m.ReclaimEscrow != nil:
+			var r *staking.ReclaimEscrowResult
+			if r, err = app.reclaimEscrow(ctx, state, m.ReclaimEscrow); r != nil {
+				res = r
+			}
+		case m.Allow != nil:
+			var r *staking.AllowResult
+			if r, err = app.allow(ctx, state, m.Allow); r != nil {
+				res = r
+			}
 		default:
-			return staking.ErrInvalidArgument
+			return nil, staking.ErrInvalidArgument
 		}
+		return res, err
+	case governanceApi.MessageValidateParameterChanges, governanceApi.MessageChangeParameters:
+		return app.changeParameters(ctx, msg, kind == governanceApi.MessageChangeParameters)
 	default:
-		return staking.ErrInvalidArgument
+		return nil, staking.ErrInvalidArgument
 	}
 }
 
@@ -138,7 +172,8 @@ func (app *stakingApplication) ExecuteTx(ctx *api.Context, tx *transaction.Trans
 			return err
 		}
 
-		return app.transfer(ctx, state, &xfer)
+		_, err := app.transfer(ctx, state, &xfer)
+		return err
 	case staking.MethodBurn:
 		var burn staking.Burn
 		if err := cbor.Unmarshal(tx.Body, &burn); err != nil {
@@ -152,14 +187,16 @@ func (app *stakingApplication) ExecuteTx(ctx *api.Context, tx *transaction.Trans
 			return err
 		}
 
-		return app.addEscrow(ctx, state, &escrow)
+		_, err := app.addEscrow(ctx, state, &escrow)
+		return err
 	case staking.MethodReclaimEscrow:
 		var reclaim staking.ReclaimEscrow
 		if err := cbor.Unmarshal(tx.Body, &reclaim); err != nil {
 			return err
 		}
 
-		return app.reclaimEscrow(ctx, state, &reclaim)
+		_, err := app.reclaimEscrow(ctx, state, &reclaim)
+		return err
 	case staking.MethodAmendCommissionSchedule:
 		var amend staking.AmendCommissionSchedule
 		if err := cbor.Unmarshal(tx.Body, &amend); err != nil {
@@ -173,14 +210,16 @@ func (app *stakingApplication) ExecuteTx(ctx *api.Context, tx *transaction.Trans
 			return err
 		}
 
-		return app.allow(ctx, state, &allow)
+		_, err := app.allow(ctx, state, &allow)
+		return err
 	case staking.MethodWithdraw:
 		var withdraw staking.Withdraw
 		if err := cbor.Unmarshal(tx.Body, &withdraw); err != nil {
 			return err
 		}
 
-		return app.withdraw(ctx, state, &withdraw)
+		_, err := app.withdraw(ctx, state, &withdraw)
+		return err
 	default:
 		return staking.ErrInvalidArgument
 	}
@@ -25,14 +25,35 @@ type feeAccumulator struct {
 	balance quantity.Quantity
 }
 
-// AuthenticateAndPayFees authenticates the message signer and makes sure that
+// CheckVestedBalance checks that the given amount does not exceed the part of the account's
+// general balance that is not locked by the account's vesting schedule. It is used for fees,
+// transfers, burns, withdrawals and allowances, while escrow may use locked tokens.
+//
+// In case the amount exceeds the general balance, no error is returned so that the caller can
+// report the insufficient balance. Withdrawals are checked again when an allowance is used.
+func CheckVestedBalance(ctx *abciAPI.Context, acct *staking.Account, amount *quantity.Quantity) error {
+	if acct.General.Vesting == nil || acct.General.Balance.Cmp(amount) < 0 {
+		return nil
+	}
+
+	epoch, err := ctx.AppState().GetEpoch(ctx, ctx.BlockHeight()+1)
+	if err != nil {
+		return err
+	}
+	if acct.General.AvailableBalance(epoch).Cmp(amount) < 0 {
+		return staking.ErrBalanceLocked
+	}
+	return nil
+}
+
+// AuthenticateAndPayFees authenticates the caller account and makes sure that
 // any gas fees are paid.
 //
 // This method transfers the fees to the per-block fee accumulator which is
 // persisted at the end of the block.
 func AuthenticateAndPayFees(
 	ctx *abciAPI.Context,
-	signer signature.PublicKey,
+	addr staking.Address,
 	nonce uint64,
 	fee *transaction.Fee,
 ) error {
@@ -46,8 +67,6 @@ func AuthenticateAndPayFees(
 		return nil
 	}
 
-	// Convert signer's public key to account address.
-	addr := staking.NewAddress(signer)
 	if addr.IsReserved() {
 		return fmt.Errorf("using reserved account address %s is prohibited", addr)
 	}
@@ -70,6 +89,11 @@ func AuthenticateAndPayFees(
 		fee = &transaction.Fee{}
 	}
 
+	// Make sure that fees are not paid from tokens locked by the vesting schedule.
+	if err = CheckVestedBalance(ctx, account, &fee.Amount); err != nil {
+		return err
+	}
+
 	if ctx.IsCheckOnly() {
 		// Configure gas accountant on the context so that we can report gas wanted.
 		ctx.SetGasAccountant(abciAPI.NewGasAccountant(fee.Gas))
@@ -0,0 +1,56 @@
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+func TestAuthenticateAndPayFeesVesting(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1580461674, 0)
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{
+		CurrentEpoch: 15,
+	})
+
+	pk := signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr := staking.NewAddress(pk)
+
+	// At epoch 15, half of the vesting amount is vested.
+	vesting := staking.VestingSchedule{
+		Amount: *quantity.NewFromUint64(100),
+		Start:  10,
+		Cliff:  15,
+		End:    20,
+	}
+
+	for _, mode := range []abciAPI.ContextMode{abciAPI.ContextCheckTx, abciAPI.ContextDeliverTx} {
+		ctx := appState.NewContext(mode, now)
+		ctx.BlockContext().Set(abciAPI.GasAccountantKey{}, abciAPI.NewNopGasAccountant())
+
+		s := NewMutableState(ctx.State())
+		err := s.SetAccount(ctx, addr, &staking.Account{
+			General: staking.GeneralAccount{
+				Balance: *quantity.NewFromUint64(100),
+				Vesting: &vesting,
+			},
+		})
+		require.NoError(err, "SetAccount")
+
+		err = AuthenticateAndPayFees(ctx, addr, 0, &transaction.Fee{Amount: *quantity.NewFromUint64(51)})
+		require.Equal(staking.ErrBalanceLocked, err, "paying fees from locked tokens should fail")
+
+		err = AuthenticateAndPayFees(ctx, addr, 0, &transaction.Fee{Amount: *quantity.NewFromUint64(50)})
+		require.NoError(err, "paying fees from vested tokens should succeed")
+
+		ctx.Close()
+	}
+}
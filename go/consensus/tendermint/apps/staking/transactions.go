@@ -21,33 +21,41 @@ func isTransferPermitted(params *staking.ConsensusParameters, fromAddr staking.A
 	return
 }
 
-func (app *stakingApplication) transfer(ctx *api.Context, state *stakingState.MutableState, xfer *staking.Transfer) error {
+func (app *stakingApplication) transfer(ctx *api.Context, state *stakingState.MutableState, xfer *staking.Transfer) (*staking.TransferResult, error) {
 	if ctx.IsCheckOnly() {
-		return nil
+		return nil, nil
 	}
 
 	// Charge gas for this transaction.
 	params, err := state.ConsensusParameters(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to fetch consensus parameters: %w", err)
+		return nil, fmt.Errorf("failed to fetch consensus parameters: %w", err)
 	}
 	if err = ctx.Gas().UseGas(1, staking.GasOpTransfer, params.GasCosts); err != nil {
-		return err
+		return nil, err
 	}
 
 	// Return early for simulation as we only need gas accounting.
 	if ctx.IsSimulation() {
-		return nil
+		return nil, nil
 	}
 
 	fromAddr := ctx.CallerAddress()
 	if fromAddr.IsReserved() || !isTransferPermitted(params, fromAddr) {
-		return staking.ErrForbidden
+		return nil, staking.ErrForbidden
 	}
 
 	from, err := state.Account(ctx, fromAddr)
 	if err != nil {
-		return fmt.Errorf("failed to fetch account: %w", err)
+		return nil, fmt.Errorf("failed to fetch account: %w", err)
+	}
+	if err = stakingState.CheckVestedBalance(ctx, from, &xfer.Amount); err != nil {
+		ctx.Logger().Error("Transfer: amount exceeds vested balance",
+			"err", err,
+			"from", fromAddr,
+			"amount", xfer.Amount,
+		)
+		return nil, err
 	}
 
 	if fromAddr.Equal(xfer.To) {
@@ -60,7 +68,7 @@ func (app *stakingApplication) transfer(ctx *api.Context, state *stakingState.Mu
 				"to", xfer.To,
 				"amount", xfer.Amount,
 			)
-			return err
+			return nil, err
 		}
 	} else {
 		// Source and destination MUST be separate accounts with how
@@ -68,7 +76,7 @@ func (app *stakingApplication) transfer(ctx *api.Context, state *stakingState.Mu
 		var to *staking.Account
 		to, err = state.Account(ctx, xfer.To)
 		if err != nil {
-			return fmt.Errorf("failed to fetch account: %w", err)
+			return nil, fmt.Errorf("failed to fetch account: %w", err)
 		}
 		if err = quantity.Move(&to.General.Balance, &from.General.Balance, &xfer.Amount); err != nil {
 			ctx.Logger().Error("Transfer: failed to move balance",
@@ -77,16 +85,16 @@ func (app *stakingApplication) transfer(ctx *api.Context, state *stakingState.Mu
 				"to", xfer.To,
 				"amount", xfer.Amount,
 			)
-			return err
+			return nil, err
 		}
 
 		if err = state.SetAccount(ctx, xfer.To, to); err != nil {
-			return fmt.Errorf("failed to set account: %w", err)
+			return nil, fmt.Errorf("failed to set account: %w", err)
 		}
 	}
 
 	if err = state.SetAccount(ctx, fromAddr, from); err != nil {
-		return fmt.Errorf("failed to fetch account: %w", err)
+		return nil, fmt.Errorf("failed to fetch account: %w", err)
 	}
 
 	ctx.Logger().Debug("Transfer: executed transfer",
@@ -102,7 +110,11 @@ func (app *stakingApplication) transfer(ctx *api.Context, state *stakingState.Mu
 	}
 	ctx.EmitEvent(api.NewEventBuilder(app.Name()).Attribute(KeyTransfer, cbor.Marshal(evt)))
 
-	return nil
+	return &staking.TransferResult{
+		From:   fromAddr,
+		To:     xfer.To,
+		Amount: xfer.Amount,
+	}, nil
 }
 
 func (app *stakingApplication) burn(ctx *api.Context, state *stakingState.MutableState, burn *staking.Burn) error {
@@ -133,6 +145,14 @@ func (app *stakingApplication) burn(ctx *api.Context, state *stakingState.Mutabl
 	if err != nil {
 		return fmt.Errorf("failed to fetch account: %w", err)
 	}
+	if err = stakingState.CheckVestedBalance(ctx, from, &burn.Amount); err != nil {
+		ctx.Logger().Error("Burn: amount exceeds vested balance",
+			"err", err,
+			"from", fromAddr,
+			"amount", burn.Amount,
+		)
+		return err
+	}
 
 	if err = from.General.Balance.Sub(&burn.Amount); err != nil {
 		ctx.Logger().Error("Burn: failed to burn stake",
@@ -171,38 +191,38 @@ func (app *stakingApplication) burn(ctx *api.Context, state *stakingState.Mutabl
 	return nil
 }
 
-func (app *stakingApplication) addEscrow(ctx *api.Context, state *stakingState.MutableState, escrow *staking.Escrow) error {
+func (app *stakingApplication) addEscrow(ctx *api.Context, state *stakingState.MutableState, escrow *staking.Escrow) (*staking.AddEscrowResult, error) {
 	if ctx.IsCheckOnly() {
-		return nil
+		return nil, nil
 	}
 
 	// Charge gas for this transaction.
 	params, err := state.ConsensusParameters(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to fetch consensus parameters: %w", err)
+		return nil, fmt.Errorf("failed to fetch consensus parameters: %w", err)
 	}
 	if err = ctx.Gas().UseGas(1, staking.GasOpAddEscrow, params.GasCosts); err != nil {
-		return err
+		return nil, err
 	}
 
 	// Return early for simulation as we only need gas accounting.
 	if ctx.IsSimulation() {
-		return nil
+		return nil, nil
 	}
 
 	// Check if sender provided at least a minimum amount of stake.
 	if escrow.Amount.Cmp(&params.MinDelegationAmount) < 0 {
-		return staking.ErrInvalidArgument
+		return nil, staking.ErrInvalidArgument
 	}
 
 	fromAddr := ctx.CallerAddress()
 	if fromAddr.IsReserved() {
-		return staking.ErrForbidden
+		return nil, staking.ErrForbidden
 	}
 
 	from, err := state.Account(ctx, fromAddr)
 	if err != nil {
-		return fmt.Errorf("failed to fetch account: %w", err)
+		return nil, fmt.Errorf("failed to fetch account: %w", err)
 	}
 
 	// Fetch escrow account.
@@ -214,19 +234,20 @@ func (app *stakingApplication) addEscrow(ctx *api.Context, state *stakingState.M
 		to = from
 	} else {
 		if params.DisableDelegation {
-			return staking.ErrForbidden
+			return nil, staking.ErrForbidden
 		}
 		to, err = state.Account(ctx, escrow.Account)
 		if err != nil {
-			return fmt.Errorf("failed to fetch account: %w", err)
+			return nil, fmt.Errorf("failed to fetch account: %w", err)
 		}
 	}
 
 	// Fetch delegation.
 	delegation, err := state.Delegation(ctx, fromAddr, escrow.Account)
 	if err != nil {
-		return fmt.Errorf("failed to fetch delegation: %w", err)
+		return nil, fmt.Errorf("failed to fetch delegation: %w", err)
 	}
+	oldShares := delegation.Shares.Clone()
 
 	if err = to.Escrow.Active.Deposit(&delegation.Shares, &from.General.Balance, &escrow.Amount); err != nil {
 		ctx.Logger().Error("AddEscrow: failed to escrow stake",
@@ -235,21 +256,21 @@ func (app *stakingApplication) addEscrow(ctx *api.Context, state *stakingState.M
 			"to", escrow.Account,
 			"amount", escrow.Amount,
 		)
-		return err
+		return nil, err
 	}
 
 	// Commit accounts.
 	if err = state.SetAccount(ctx, fromAddr, from); err != nil {
-		return fmt.Errorf("failed to set account: %w", err)
+		return nil, fmt.Errorf("failed to set account: %w", err)
 	}
 	if !fromAddr.Equal(escrow.Account) {
 		if err = state.SetAccount(ctx, escrow.Account, to); err != nil {
-			return fmt.Errorf("failed to set account: %w", err)
+			return nil, fmt.Errorf("failed to set account: %w", err)
 		}
 	}
 	// Commit delegation descriptor.
 	if err = state.SetDelegation(ctx, fromAddr, escrow.Account, delegation); err != nil {
-		return fmt.Errorf("failed to set delegation: %w", err)
+		return nil, fmt.Errorf("failed to set delegation: %w", err)
 	}
 
 	ctx.Logger().Debug("AddEscrow: escrowed stake",
@@ -265,41 +286,49 @@ func (app *stakingApplication) addEscrow(ctx *api.Context, state *stakingState.M
 	}
 	ctx.EmitEvent(api.NewEventBuilder(app.Name()).Attribute(KeyAddEscrow, cbor.Marshal(evt)))
 
-	return nil
+	newShares := delegation.Shares.Clone()
+	_ = newShares.Sub(oldShares)
+
+	return &staking.AddEscrowResult{
+		Owner:     fromAddr,
+		Escrow:    escrow.Account,
+		Amount:    escrow.Amount,
+		NewShares: *newShares,
+	}, nil
 }
 
-func (app *stakingApplication) reclaimEscrow(ctx *api.Context, state *stakingState.MutableState, reclaim *staking.ReclaimEscrow) error {
+func (app *stakingApplication) reclaimEscrow(ctx *api.Context, state *stakingState.MutableState, reclaim *staking.ReclaimEscrow) (*staking.ReclaimEscrowResult, error) {
 	// No sense if there is nothing to reclaim.
 	if reclaim.Shares.IsZero() {
-		return staking.ErrInvalidArgument
+		return nil, staking.ErrInvalidArgument
 	}
 
 	if ctx.IsCheckOnly() {
-		return nil
+		return nil, nil
 	}
 
 	// Charge gas for this transaction.
 	params, err := state.ConsensusParameters(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to fetch consensus parameters: %w", err)
+		return nil, fmt.Errorf("failed to fetch consensus parameters: %w", err)
 	}
 	if err = ctx.Gas().UseGas(1, staking.GasOpReclaimEscrow, params.GasCosts); err != nil {
-		return err
+		return nil, err
 	}
 
 	// Return early for simulation as we only need gas accounting.
 	if ctx.IsSimulation() {
-		return nil
+		return nil, nil
 	}
 
 	toAddr := ctx.CallerAddress()
 	if toAddr.IsReserved() {
-		return staking.ErrForbidden
+		return nil, staking.ErrForbidden
 	}
 
 	to, err := state.Account(ctx, toAddr)
 	if err != nil {
-		return fmt.Errorf("failed to fetch account: %w", err)
+		return nil, fmt.Errorf("failed to fetch account: %w", err)
 	}
 
 	// Fetch escrow account.
@@ -311,18 +340,18 @@ func (app *stakingApplication) reclaimEscrow(ctx *api.Context, state *stakingSta
 		from = to
 	} else {
 		if params.DisableDelegation {
-			return staking.ErrForbidden
+			return nil, staking.ErrForbidden
 		}
 		from, err = state.Account(ctx, reclaim.Account)
 		if err != nil {
-			return fmt.Errorf("failed to fetch account: %w", err)
+			return nil, fmt.Errorf("failed to fetch account: %w", err)
 		}
 	}
 
 	// Fetch delegation.
 	delegation, err := state.Delegation(ctx, toAddr, reclaim.Account)
 	if err != nil {
-		return fmt.Errorf("failed to fetch delegation: %w", err)
+		return nil, fmt.Errorf("failed to fetch delegation: %w", err)
 	}
 
 	// Fetch debonding interval and current epoch.
@@ -331,11 +360,11 @@ func (app *stakingApplication) reclaimEscrow(ctx *api.Context, state *stakingSta
 		ctx.Logger().Error("ReclaimEscrow: failed to query debonding interval",
 			"err", err,
 		)
-		return err
+		return nil, err
 	}
 	epoch, err := app.state.GetEpoch(ctx, ctx.BlockHeight()+1)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	deb := staking.DebondingDelegation{
@@ -351,7 +380,7 @@ func (app *stakingApplication) reclaimEscrow(ctx *api.Context, state *stakingSta
 			"from", reclaim.Account,
 			"shares", reclaim.Shares,
 		)
-		return err
+		return nil, err
 	}
 	stakeAmount := baseUnits.Clone()
 
@@ -363,34 +392,41 @@ func (app *stakingApplication) reclaimEscrow(ctx *api.Context, state *stakingSta
 			"shares", reclaim.Shares,
 			"base_units", stakeAmount,
 		)
-		return err
+		return nil, err
 	}
 
 	if !baseUnits.IsZero() {
 		ctx.Logger().Error("ReclaimEscrow: inconsistency in transferring stake from active escrow to debonding",
 			"remaining_base_units", baseUnits,
 		)
-		return staking.ErrInvalidArgument
+		return nil, staking.ErrInvalidArgument
 	}
 
 	// Include the nonce as the final disambiguator to prevent overwriting debonding delegations.
 	if err = state.SetDebondingDelegation(ctx, toAddr, reclaim.Account, to.General.Nonce, &deb); err != nil {
-		return fmt.Errorf("failed to set debonding delegation: %w", err)
+		return nil, fmt.Errorf("failed to set debonding delegation: %w", err)
 	}
 
 	if err = state.SetDelegation(ctx, toAddr, reclaim.Account, delegation); err != nil {
-		return fmt.Errorf("failed to set delegation: %w", err)
+		return nil, fmt.Errorf("failed to set delegation: %w", err)
 	}
 	if err = state.SetAccount(ctx, toAddr, to); err != nil {
-		return fmt.Errorf("failed to set account: %w", err)
+		return nil, fmt.Errorf("failed to set account: %w", err)
 	}
 	if !toAddr.Equal(reclaim.Account) {
 		if err = state.SetAccount(ctx, reclaim.Account, from); err != nil {
-			return fmt.Errorf("failed to set account: %w", err)
+			return nil, fmt.Errorf("failed to set account: %w", err)
 		}
 	}
 
-	return nil
+	return &staking.ReclaimEscrowResult{
+		Owner:           toAddr,
+		Escrow:          reclaim.Account,
+		Amount:          *stakeAmount,
+		RemainingShares: delegation.Shares,
+		DebondingShares: deb.Shares,
+		DebondEndTime:   deb.DebondEndTime,
+	}, nil
 }
 
 func (app *stakingApplication) amendCommissionSchedule(
@@ -450,42 +486,42 @@ func (app *stakingApplication) allow(
 	ctx *api.Context,
 	state *stakingState.MutableState,
 	allow *staking.Allow,
-) error {
+) (*staking.AllowResult, error) {
 	if ctx.IsCheckOnly() {
-		return nil
+		return nil, nil
 	}
 
 	// Charge gas for this transaction.
 	params, err := state.ConsensusParameters(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to fetch consensus parameters: %w", err)
+		return nil, fmt.Errorf("failed to fetch consensus parameters: %w", err)
 	}
 	if err = ctx.Gas().UseGas(1, staking.GasOpAllow, params.GasCosts); err != nil {
-		return err
+		return nil, err
 	}
 
 	// Return early for simulation as we only need gas accounting.
 	if ctx.IsSimulation() {
-		return nil
+		return nil, nil
 	}
 
 	// Allowances are disabled in case either max allowances is zero or if transfers are disabled.
 	if params.DisableTransfers || params.MaxAllowances == 0 {
-		return staking.ErrForbidden
+		return nil, staking.ErrForbidden
 	}
 
 	// Validate addresses -- if either is reserved or both are equal, the method should fail.
 	addr := ctx.CallerAddress()
 	if addr.IsReserved() || allow.Beneficiary.IsReserved() {
-		return staking.ErrForbidden
+		return nil, staking.ErrForbidden
 	}
 	if addr.Equal(allow.Beneficiary) {
-		return staking.ErrInvalidArgument
+		return nil, staking.ErrInvalidArgument
 	}
 
 	acct, err := state.Account(ctx, addr)
 	if err != nil {
-		return fmt.Errorf("failed to fetch account: %w", err)
+		return nil, fmt.Errorf("failed to fetch account: %w", err)
 	}
 
 	if acct.General.Allowances == nil {
@@ -497,13 +533,21 @@ func (app *stakingApplication) allow(
 	case false:
 		// Add.
 		if err = allowance.Add(&allow.AmountChange); err != nil {
-			return fmt.Errorf("failed to add allowance: %w", err)
+			return nil, fmt.Errorf("failed to add allowance: %w", err)
+		}
+		if err = stakingState.CheckVestedBalance(ctx, acct, &allowance); err != nil {
+			ctx.Logger().Error("Allow: allowance exceeds vested balance",
+				"err", err,
+				"account", addr,
+				"allowance", allowance,
+			)
+			return nil, err
 		}
 		amountChange = allow.AmountChange.Clone()
 	case true:
 		// Subtract.
 		if amountChange, err = allowance.SubUpTo(&allow.AmountChange); err != nil {
-			return fmt.Errorf("failed to subtract allowance: %w", err)
+			return nil, fmt.Errorf("failed to subtract allowance: %w", err)
 		}
 	}
 	if allowance.IsZero() {
@@ -516,11 +560,11 @@ func (app *stakingApplication) allow(
 
 	// If updating allowances would go past the maximum number of allowances, fail.
 	if uint32(len(acct.General.Allowances)) > params.MaxAllowances {
-		return staking.ErrTooManyAllowances
+		return nil, staking.ErrTooManyAllowances
 	}
 
 	if err = state.SetAccount(ctx, addr, acct); err != nil {
-		return fmt.Errorf("failed to set account: %w", err)
+		return nil, fmt.Errorf("failed to set account: %w", err)
 	}
 
 	evt := &staking.AllowanceChangeEvent{
@@ -532,49 +576,58 @@ func (app *stakingApplication) allow(
 	}
 	ctx.EmitEvent(api.NewEventBuilder(app.Name()).Attribute(KeyAllowanceChange, cbor.Marshal(evt)))
 
-	return nil
+	return &staking.AllowResult{
+		Owner:        addr,
+		Beneficiary:  allow.Beneficiary,
+		Allowance:    allowance,
+		Negative:     allow.Negative,
+		AmountChange: *amountChange,
+	}, nil
 }
 
 func (app *stakingApplication) withdraw(
 	ctx *api.Context,
 	state *stakingState.MutableState,
 	withdraw *staking.Withdraw,
-) error {
+) (*staking.WithdrawResult, error) {
 	if ctx.IsCheckOnly() {
-		return nil
+		return nil, nil
 	}
 
 	// Charge gas for this transaction.
 	params, err := state.ConsensusParameters(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to fetch consensus parameters: %w", err)
+		return nil, fmt.Errorf("failed to fetch consensus parameters: %w", err)
 	}
 	if err = ctx.Gas().UseGas(1, staking.GasOpWithdraw, params.GasCosts); err != nil {
-		return err
+		return nil, err
 	}
 
 	// Return early for simulation as we only need gas accounting.
 	if ctx.IsSimulation() {
-		return nil
+		return nil, nil
 	}
 
 	// Allowances are disabled in case either max allowances is zero or if transfers are disabled.
 	if params.DisableTransfers || params.MaxAllowances == 0 {
-		return staking.ErrForbidden
+		return nil, staking.ErrForbidden
 	}
 
 	// Validate addresses -- if either is reserved or both are equal, the method should fail.
 	toAddr := ctx.CallerAddress()
 	if toAddr.IsReserved() || withdraw.From.IsReserved() {
-		return staking.ErrForbidden
+		return nil, staking.ErrForbidden
 	}
 	if toAddr.Equal(withdraw.From) {
-		return staking.ErrInvalidArgument
+		return nil, staking.ErrInvalidArgument
 	}
 
 	from, err := state.Account(ctx, withdraw.From)
 	if err != nil {
-		return fmt.Errorf("failed to fetch account: %w", err)
+		return nil, fmt.Errorf("failed to fetch account: %w", err)
+	}
+	if err = stakingState.CheckVestedBalance(ctx, from, &withdraw.Amount); err != nil {
+		return nil, err
 	}
 	var (
 		allowance quantity.Quantity
@@ -582,10 +635,10 @@ func (app *stakingApplication) withdraw(
 	)
 	if allowance, ok = from.General.Allowances[toAddr]; !ok {
 		// Fail early in case there is no allowance configured.
-		return staking.ErrForbidden
+		return nil, staking.ErrForbidden
 	}
 	if err = allowance.Sub(&withdraw.Amount); err != nil {
-		return staking.ErrForbidden
+		return nil, staking.ErrForbidden
 	}
 	if allowance.IsZero() {
 		// In case the new allowance is equal to zero, remove it.
@@ -598,18 +651,18 @@ func (app *stakingApplication) withdraw(
 	// NOTE: Accounts cannot be the same as we fail above if this were the case.
 	to, err := state.Account(ctx, toAddr)
 	if err != nil {
-		return fmt.Errorf("failed to fetch account: %w", err)
+		return nil, fmt.Errorf("failed to fetch account: %w", err)
 	}
 
 	if err = quantity.Move(&to.General.Balance, &from.General.Balance, &withdraw.Amount); err != nil {
-		return staking.ErrInsufficientBalance
+		return nil, staking.ErrInsufficientBalance
 	}
 
 	if err = state.SetAccount(ctx, toAddr, to); err != nil {
-		return fmt.Errorf("failed to set account: %w", err)
+		return nil, fmt.Errorf("failed to set account: %w", err)
 	}
 	if err = state.SetAccount(ctx, withdraw.From, from); err != nil {
-		return fmt.Errorf("failed to set account: %w", err)
+		return nil, fmt.Errorf("failed to set account: %w", err)
 	}
 
 	xferEvt := &staking.TransferEvent{
@@ -628,5 +681,10 @@ func (app *stakingApplication) withdraw(
 	}
 	ctx.EmitEvent(api.NewEventBuilder(app.Name()).Attribute(KeyAllowanceChange, cbor.Marshal(awEvt)))
 
-	return nil
+	return &staking.WithdrawResult{
+		Owner:        withdraw.From,
+		Beneficiary:  toAddr,
+		Allowance:    allowance,
+		AmountChange: withdraw.Amount,
+	}, nil
 }
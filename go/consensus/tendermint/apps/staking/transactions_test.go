@@ -87,7 +87,7 @@ func TestReservedAddresses(t *testing.T) {
 	_ = staking.NewReservedAddress(testPK)
 
 	// Make sure all transaction types fail for the reserved address.
-	err = app.transfer(ctx, stakeState, nil)
+	_, err = app.transfer(ctx, stakeState, nil)
 	require.EqualError(err, "staking: forbidden by policy", "transfer for reserved address should error")
 
 	err = app.burn(ctx, stakeState, nil)
@@ -97,20 +97,20 @@ func TestReservedAddresses(t *testing.T) {
 	_ = q.FromInt64(1_000)
 
 	// NOTE: We need to specify escrow amount since that is checked before the check for reserved address.
-	err = app.addEscrow(ctx, stakeState, &staking.Escrow{Amount: *q.Clone()})
+	_, err = app.addEscrow(ctx, stakeState, &staking.Escrow{Amount: *q.Clone()})
 	require.EqualError(err, "staking: forbidden by policy", "adding escrow for reserved address should error")
 
 	// NOTE: We need to specify reclaim escrow shares since that is checked before the check for reserved address.
-	err = app.reclaimEscrow(ctx, stakeState, &staking.ReclaimEscrow{Shares: *q.Clone()})
+	_, err = app.reclaimEscrow(ctx, stakeState, &staking.ReclaimEscrow{Shares: *q.Clone()})
 	require.EqualError(err, "staking: forbidden by policy", "reclaim escrow for reserved address should error")
 
 	err = app.amendCommissionSchedule(ctx, stakeState, nil)
 	require.EqualError(err, "staking: forbidden by policy", "amending commission schedule for reserved address should error")
 
-	err = app.allow(ctx, stakeState, &staking.Allow{})
+	_, err = app.allow(ctx, stakeState, &staking.Allow{})
 	require.EqualError(err, "staking: forbidden by policy", "allow for reserved address should error")
 
-	err = app.withdraw(ctx, stakeState, &staking.Withdraw{})
+	_, err = app.withdraw(ctx, stakeState, &staking.Withdraw{})
 	require.EqualError(err, "staking: forbidden by policy", "withdraw for reserved address should error")
 }
 
@@ -272,7 +272,7 @@ func TestAllow(t *testing.T) {
 
 		ctx.SetTxSigner(tc.txSigner)
 
-		err = app.allow(ctx, stakeState, tc.allow)
+		_, err = app.allow(ctx, stakeState, tc.allow)
 		require.Equal(tc.err, err, tc.msg)
 
 		addr := staking.NewAddress(tc.txSigner)
@@ -492,7 +492,7 @@ func TestWithdraw(t *testing.T) {
 			require.NoError(err, "reading account state should not error")
 		}
 
-		err = app.withdraw(ctx, stakeState, tc.withdraw)
+		_, err = app.withdraw(ctx, stakeState, tc.withdraw)
 		require.Equal(tc.err, err, tc.msg)
 
 		if tc.withdraw.From.IsReserved() {
@@ -516,3 +516,91 @@ func TestWithdraw(t *testing.T) {
 		require.Equal(expectedBalance, afterAcct.General.Balance, "general balance should be correct after withdraw")
 	}
 }
+
+func TestVesting(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	now := time.Unix(1580461674, 0)
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{
+		CurrentEpoch: 15,
+	})
+	ctx := appState.NewContext(abciAPI.ContextDeliverTx, now)
+	defer ctx.Close()
+
+	stakeState := stakingState.NewMutableState(ctx.State())
+
+	app := &stakingApplication{
+		state: appState,
+	}
+
+	err = stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{
+		MaxAllowances: 1,
+	})
+	require.NoError(err, "setting staking consensus parameters should not error")
+
+	pk1 := signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr1 := staking.NewAddress(pk1)
+	pk2 := signature.NewPublicKey("bbbfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr2 := staking.NewAddress(pk2)
+	pk3 := signature.NewPublicKey("cccfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr3 := staking.NewAddress(pk3)
+
+	// At epoch 15, half of the vesting amount is vested.
+	vesting := staking.VestingSchedule{
+		Amount: *quantity.NewFromUint64(100),
+		Start:  10,
+		Cliff:  15,
+		End:    20,
+	}
+	for _, addr := range []staking.Address{addr1, addr3} {
+		err = stakeState.SetAccount(ctx, addr, &staking.Account{
+			General: staking.GeneralAccount{
+				Balance: *quantity.NewFromUint64(100),
+				Vesting: &vesting,
+			},
+		})
+		require.NoError(err, "SetAccount")
+	}
+
+	ctx.SetTxSigner(pk1)
+
+	_, err = app.transfer(ctx, stakeState, &staking.Transfer{To: addr2, Amount: *quantity.NewFromUint64(51)})
+	require.Equal(staking.ErrBalanceLocked, err, "transfer of locked tokens should fail")
+	_, err = app.transfer(ctx, stakeState, &staking.Transfer{To: addr2, Amount: *quantity.NewFromUint64(50)})
+	require.NoError(err, "transfer of vested tokens should succeed")
+
+	err = app.burn(ctx, stakeState, &staking.Burn{Amount: *quantity.NewFromUint64(1)})
+	require.Equal(staking.ErrBalanceLocked, err, "burn of locked tokens should fail")
+
+	_, err = app.allow(ctx, stakeState, &staking.Allow{Beneficiary: addr2, AmountChange: *quantity.NewFromUint64(1)})
+	require.Equal(staking.ErrBalanceLocked, err, "allowance exceeding vested tokens should fail")
+
+	_, err = app.addEscrow(ctx, stakeState, &staking.Escrow{Account: addr1, Amount: *quantity.NewFromUint64(50)})
+	require.NoError(err, "escrow of locked tokens should succeed")
+
+	_, err = app.transfer(ctx, stakeState, &staking.Transfer{To: addr2, Amount: *quantity.NewFromUint64(1)})
+	require.Error(err, "transfer exceeding balance should fail")
+	require.NotEqual(staking.ErrBalanceLocked, err, "transfer exceeding balance should not report locked balance")
+
+	acct, err := stakeState.Account(ctx, addr1)
+	require.NoError(err, "Account")
+	require.True(acct.General.Balance.IsZero(), "general balance should be fully spent")
+	require.EqualValues(*quantity.NewFromUint64(50), acct.Escrow.Active.Balance, "locked tokens should be escrowed")
+
+	// Withdrawals by beneficiaries are restricted as well.
+	acct, err = stakeState.Account(ctx, addr3)
+	require.NoError(err, "Account")
+	acct.General.Allowances = map[staking.Address]quantity.Quantity{
+		addr2: *quantity.NewFromUint64(100),
+	}
+	err = stakeState.SetAccount(ctx, addr3, acct)
+	require.NoError(err, "SetAccount")
+
+	ctx.SetTxSigner(pk2)
+
+	_, err = app.withdraw(ctx, stakeState, &staking.Withdraw{From: addr3, Amount: *quantity.NewFromUint64(51)})
+	require.Equal(staking.ErrBalanceLocked, err, "withdrawal of locked tokens should fail")
+	_, err = app.withdraw(ctx, stakeState, &staking.Withdraw{From: addr3, Amount: *quantity.NewFromUint64(50)})
+	require.NoError(err, "withdrawal of vested tokens should succeed")
+}
@@ -0,0 +1,198 @@
+package full
+
+import (
+	"context"
+	"fmt"
+
+	tmabcitypes "github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	consensusAPI "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction/results"
+	tmgovernance "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/governance"
+	tmregistry "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/registry"
+	tmroothash "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/roothash"
+	tmstaking "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/staking"
+)
+
+// eventsFromTendermint decodes consensus service events from the given tendermint events,
+// preserving their order.
+func eventsFromTendermint(
+	tx tmtypes.Tx,
+	height int64,
+	txIndex int32,
+	tmEvents []tmabcitypes.Event,
+) ([]*consensusAPI.Event, error) {
+	var events []*consensusAPI.Event
+	appendEvent := func(ev results.Event) {
+		events = append(events, &consensusAPI.Event{
+			Event: ev,
+			Cursor: consensusAPI.EventCursor{
+				Height:     height,
+				TxIndex:    txIndex,
+				EventIndex: uint32(len(events)),
+			},
+		})
+	}
+
+	for _, tmEv := range tmEvents {
+		evs := []tmabcitypes.Event{tmEv}
+
+		stakingEvents, err := tmstaking.EventsFromTendermint(tx, height, evs)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range stakingEvents {
+			appendEvent(results.Event{Staking: e})
+		}
+
+		registryEvents, _, err := tmregistry.EventsFromTendermint(tx, height, evs)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range registryEvents {
+			appendEvent(results.Event{Registry: e})
+		}
+
+		roothashEvents, err := tmroothash.EventsFromTendermint(tx, height, evs)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range roothashEvents {
+			appendEvent(results.Event{RootHash: e})
+		}
+
+		governanceEvents, err := tmgovernance.EventsFromTendermint(tx, height, evs)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range governanceEvents {
+			appendEvent(results.Event{Governance: e})
+		}
+	}
+	return events, nil
+}
+
+// getEvents returns all consensus service events emitted in the block at the given height, in
+// the order of their cursors.
+func (t *fullService) getEvents(ctx context.Context, height int64) ([]*consensusAPI.Event, error) {
+	blk, err := t.GetTendermintBlock(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	if blk == nil {
+		return nil, consensusAPI.ErrNoCommittedBlocks
+	}
+	res, err := t.GetBlockResults(ctx, blk.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := eventsFromTendermint(nil, blk.Height, consensusAPI.TxIndexBeginBlock, res.BeginBlockEvents)
+	if err != nil {
+		return nil, err
+	}
+	for txIdx, rs := range res.TxsResults {
+		// The order of transactions in the block and the results is supposed to match, so the
+		// same index in both slices refers to the same transaction.
+		evs, txErr := eventsFromTendermint(blk.Data.Txs[txIdx], blk.Height, int32(txIdx), rs.Events)
+		if txErr != nil {
+			return nil, txErr
+		}
+		events = append(events, evs...)
+	}
+	evs, err := eventsFromTendermint(nil, blk.Height, consensusAPI.TxIndexEndBlock, res.EndBlockEvents)
+	if err != nil {
+		return nil, err
+	}
+	return append(events, evs...), nil
+}
+
+func (t *fullService) WatchEventsFrom(ctx context.Context, req *consensusAPI.WatchEventsRequest) (<-chan *consensusAPI.Event, *consensusAPI.EventSubscription, error) {
+	latestBlk, err := t.GetBlock(ctx, consensusAPI.HeightLatest)
+	if err != nil {
+		return nil, nil, err
+	}
+	nextHeight := req.Height
+	if req.After != nil {
+		nextHeight = req.After.Height
+	}
+	switch {
+	case req.After == nil && req.Height == consensusAPI.HeightLatest:
+		nextHeight = latestBlk.Height + 1
+	case nextHeight < 0:
+		return nil, nil, consensusAPI.ErrInvalidArgument
+	default:
+		lastRetainedHeight, lrErr := t.GetLastRetainedVersion(ctx)
+		if lrErr != nil {
+			return nil, nil, fmt.Errorf("failed to get last retained height: %w", lrErr)
+		}
+		if nextHeight < lastRetainedHeight || nextHeight < t.genesis.Height {
+			return nil, nil, consensusAPI.ErrVersionNotFound
+		}
+	}
+
+	// Subscribe to new blocks before replaying past blocks so that no blocks are missed.
+	blkCh, blkSub := t.WatchTendermintBlocks()
+	ctx, csub := pubsub.NewContextSubscription(ctx)
+	sub := consensusAPI.NewEventSubscription(csub)
+
+	ch := make(chan *consensusAPI.Event)
+	go func() {
+		defer close(ch)
+		defer blkSub.Close()
+
+		emitUntil := func(toHeight int64) error {
+			for ; nextHeight <= toHeight; nextHeight++ {
+				events, evErr := t.getEvents(ctx, nextHeight)
+				if evErr != nil {
+					t.Logger.Error("failed to get events",
+						"err", evErr,
+						"height", nextHeight,
+					)
+					return fmt.Errorf("failed to get events at height %d: %w", nextHeight, evErr)
+				}
+
+				for _, ev := range events {
+					// Skip events up to and including the cursor we are resuming from.
+					if req.After != nil && !req.After.Less(&ev.Cursor) {
+						continue
+					}
+
+					select {
+					case ch <- ev:
+					case <-ctx.Done():
+						return nil
+					}
+				}
+			}
+			return nil
+		}
+
+		// Replay events of already finalized blocks.
+		if err := emitUntil(latestBlk.Height); err != nil {
+			sub.SetErr(err)
+			return
+		}
+
+		// Stream events of new blocks.
+		for {
+			select {
+			case tmBlk, ok := <-blkCh:
+				if !ok {
+					sub.SetErr(fmt.Errorf("block stream closed"))
+					return
+				}
+				if err := emitUntil(tmBlk.Height); err != nil {
+					sub.SetErr(err)
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, sub, nil
+}
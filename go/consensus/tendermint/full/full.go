@@ -79,6 +79,11 @@ const (
 	CfgABCIPruneStrategy = "consensus.tendermint.abci.prune.strategy"
 	// CfgABCIPruneNumKept configures the amount of kept heights if pruning is enabled.
 	CfgABCIPruneNumKept = "consensus.tendermint.abci.prune.num_kept"
+	// CfgABCIPruneArchiveInterval configures the interval at which full state is archived if the
+	// archive checkpoints pruning strategy is enabled.
+	CfgABCIPruneArchiveInterval = "consensus.tendermint.abci.prune.archive_interval"
+	// CfgABCITxIndex enables indexing of transactions by their hash.
+	CfgABCITxIndex = "consensus.tendermint.abci.tx_index"
 
 	// CfgCheckpointerDisabled disables the ABCI state checkpointer.
 	CfgCheckpointerDisabled = "consensus.tendermint.checkpointer.disabled"
@@ -209,6 +214,10 @@ func (t *fullService) Start() error {
 		if err := t.mux.Start(); err != nil {
 			return err
 		}
+		// Make sure that the transaction index covers all blocks applied before the node stopped.
+		if err := t.mux.BackfillTransactionIndex(t.getBlockTxs); err != nil {
+			return fmt.Errorf("tendermint: failed to backfill transaction index: %w", err)
+		}
 		if err := t.startFn(); err != nil {
 			return err
 		}
@@ -416,18 +425,88 @@ func (t *fullService) RegisterHaltHook(hook func(context.Context, int64, beaconA
 }
 
 func (t *fullService) SubmitTx(ctx context.Context, tx *transaction.SignedTransaction) error {
+	return t.submitTxAndCheckResult(ctx, cbor.Marshal(tx))
+}
+
+func (t *fullService) SubmitMultiSignedTx(ctx context.Context, tx *transaction.MultiSignedTransaction) error {
+	return t.submitTxAndCheckResult(ctx, cbor.Marshal(tx))
+}
+
+// submitTxAndCheckResult broadcasts the given raw transaction, waits for it to be included in a
+// block and returns an error in case its execution failed.
+func (t *fullService) submitTxAndCheckResult(ctx context.Context, data []byte) error {
+	txData, err := t.submitTx(ctx, data)
+	if err != nil {
+		return err
+	}
+	if result := txData.Result; !result.IsOK() {
+		err = errors.FromCode(result.GetCodespace(), result.GetCode())
+		if err == nil {
+			// Fallback to an ordinary error.
+			err = fmt.Errorf(result.GetLog())
+		}
+		return err
+	}
+	return nil
+}
+
+func (t *fullService) SubmitTxWithProof(ctx context.Context, tx *transaction.SignedTransaction) (*consensusAPI.TransactionWithProof, error) {
+	txData, err := t.submitTx(ctx, cbor.Marshal(tx))
+	if err != nil {
+		return nil, err
+	}
+
+	// Transaction result.
+	result := &results.Result{
+		Error: results.Error{
+			Module:  txData.Result.GetCodespace(),
+			Code:    txData.Result.GetCode(),
+			Message: txData.Result.GetLog(),
+		},
+	}
+	events, err := eventsFromTendermint(txData.Tx, txData.Height, int32(txData.Index), txData.Result.Events)
+	if err != nil {
+		return nil, err
+	}
+	for _, ev := range events {
+		result.Events = append(result.Events, &ev.Event)
+	}
+
+	// Proof of inclusion of the transaction in the block.
+	blk, err := t.GetTendermintBlock(ctx, txData.Height)
+	if err != nil {
+		return nil, err
+	}
+	if blk == nil || int(txData.Index) >= len(blk.Data.Txs) {
+		return nil, fmt.Errorf("tendermint: block %d including the transaction not found", txData.Height)
+	}
+	protoProof := blk.Data.Txs.Proof(int(txData.Index)).ToProto()
+	proof, err := protoProof.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("tendermint: failed to marshal transaction proof: %w", err)
+	}
+
+	return &consensusAPI.TransactionWithProof{
+		Height: txData.Height,
+		Index:  txData.Index,
+		Result: result,
+		Proof:  proof,
+	}, nil
+}
+
+// submitTx broadcasts the given raw transaction and waits for it to be included in a block.
+func (t *fullService) submitTx(ctx context.Context, data []byte) (*tmtypes.EventDataTx, error) {
 	// Subscribe to the transaction being included in a block.
-	data := cbor.Marshal(tx)
 	query := tmtypes.EventQueryTxFor(data)
 	subID := t.newSubscriberID()
 	txSub, err := t.subscribe(subID, query)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if ptrSub, ok := txSub.(*tendermintPubsubBuffer).tmSubscription.(*tmpubsub.Subscription); ok && ptrSub == nil {
 		t.Logger.Debug("broadcastTx: service has shut down. Cancel our context to recover")
 		<-ctx.Done()
-		return ctx.Err()
+		return nil, ctx.Err()
 	}
 
 	defer t.unsubscribe(subID, query) // nolint: errcheck
@@ -437,33 +516,26 @@ func (t *fullService) SubmitTx(ctx context.Context, tx *transaction.SignedTransa
 
 	recheckCh, recheckSub, err := t.mux.WatchInvalidatedTx(txHash)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer recheckSub.Close()
 
 	// First try to broadcast.
 	if err := t.broadcastTxRaw(data); err != nil {
-		return err
+		return nil, err
 	}
 
 	// Wait for the transaction to be included in a block.
 	select {
 	case v := <-recheckCh:
-		return v
+		return nil, v
 	case v := <-txSub.Out():
-		if result := v.Data().(tmtypes.EventDataTx).Result; !result.IsOK() {
-			err := errors.FromCode(result.GetCodespace(), result.GetCode())
-			if err == nil {
-				// Fallback to an ordinary error.
-				err = fmt.Errorf(result.GetLog())
-			}
-			return err
-		}
-		return nil
+		txData := v.Data().(tmtypes.EventDataTx)
+		return &txData, nil
 	case <-txSub.Cancelled():
-		return context.Canceled
+		return nil, context.Canceled
 	case <-ctx.Done():
-		return ctx.Err()
+		return nil, ctx.Err()
 	}
 }
 
@@ -735,6 +807,45 @@ func (t *fullService) GetTransactionsWithResults(ctx context.Context, height int
 	return &txsWithResults, nil
 }
 
+func (t *fullService) GetTransactionByHash(ctx context.Context, txHash hash.Hash) (*consensusAPI.TransactionWithResult, error) {
+	if err := t.ensureStarted(ctx); err != nil {
+		return nil, err
+	}
+
+	entry, err := t.mux.GetTransactionIndexEntry(txHash)
+	if err != nil {
+		return nil, err
+	}
+	txsWithResults, err := t.GetTransactionsWithResults(ctx, entry.Height)
+	if err != nil {
+		return nil, err
+	}
+	if int(entry.Index) >= len(txsWithResults.Transactions) {
+		return nil, fmt.Errorf("tendermint: corrupted transaction index entry for %s", txHash)
+	}
+
+	return &consensusAPI.TransactionWithResult{
+		Height:      entry.Height,
+		Index:       entry.Index,
+		Transaction: txsWithResults.Transactions[entry.Index],
+		Result:      txsWithResults.Results[entry.Index],
+	}, nil
+}
+
+// getBlockTxs returns the raw transactions of the block at the given height from the local block
+// store.
+func (t *fullService) getBlockTxs(height int64) ([][]byte, error) {
+	blk := t.node.BlockStore().LoadBlock(height)
+	if blk == nil {
+		return nil, consensusAPI.ErrVersionNotFound
+	}
+	txs := make([][]byte, 0, len(blk.Data.Txs))
+	for _, tx := range blk.Data.Txs {
+		txs = append(txs, tx)
+	}
+	return txs, nil
+}
+
 func (t *fullService) GetUnconfirmedTransactions(ctx context.Context) ([][]byte, error) {
 	mempoolTxs := t.node.Mempool().ReapMaxTxs(-1)
 	txs := make([][]byte, 0, len(mempoolTxs))
@@ -1068,6 +1179,7 @@ func (t *fullService) lazyInit() error {
 		return err
 	}
 	pruneCfg.NumKept = viper.GetUint64(CfgABCIPruneNumKept)
+	pruneCfg.ArchiveInterval = viper.GetUint64(CfgABCIPruneArchiveInterval)
 
 	appConfig := &abci.ApplicationConfig{
 		DataDir:                   filepath.Join(t.dataDir, tmcommon.StateDir),
@@ -1079,6 +1191,7 @@ func (t *fullService) lazyInit() error {
 		DisableCheckpointer:       viper.GetBool(CfgCheckpointerDisabled),
 		CheckpointerCheckInterval: viper.GetDuration(CfgCheckpointerCheckInterval),
 		InitialHeight:             uint64(t.genesis.Height),
+		TxIndex:                   viper.GetBool(CfgABCITxIndex),
 	}
 	t.mux, err = abci.NewApplicationServer(t.ctx, t.upgrader, appConfig)
 	if err != nil {
@@ -1460,6 +1573,8 @@ func New(
 func init() {
 	Flags.String(CfgABCIPruneStrategy, abci.PruneDefault, "ABCI state pruning strategy")
 	Flags.Uint64(CfgABCIPruneNumKept, 3600, "ABCI state versions kept (when applicable)")
+	Flags.Uint64(CfgABCIPruneArchiveInterval, 10000, "ABCI state archive checkpoint interval (when applicable)")
+	Flags.Bool(CfgABCITxIndex, false, "Enable indexing of transactions by their hash")
 	Flags.Bool(CfgCheckpointerDisabled, false, "Disable the ABCI state checkpointer")
 	Flags.Duration(CfgCheckpointerCheckInterval, 1*time.Minute, "ABCI state checkpointer check interval")
 	Flags.StringSlice(CfgSentryUpstreamAddress, []string{}, "Tendermint nodes for which we act as sentry of the form ID@ip:port")
@@ -251,6 +251,16 @@ func EventsFromTendermint(
 
 				evt := &api.Event{Height: height, TxHash: txHash, Vote: &e}
 				events = append(events, evt)
+			case bytes.Equal(key, app.KeyParametersChanged):
+				// Parameters changed event.
+				var e api.ParametersChangedEvent
+				if err := cbor.Unmarshal(val, &e); err != nil {
+					errs = multierror.Append(errs, fmt.Errorf("governance: corrupt ParametersChanged event: %w", err))
+					continue
+				}
+
+				evt := &api.Event{Height: height, TxHash: txHash, ParametersChanged: &e}
+				events = append(events, evt)
 			default:
 				errs = multierror.Append(errs, fmt.Errorf("governance: unknown event type: key: %s, val: %s", key, val))
 			}
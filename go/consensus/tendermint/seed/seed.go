@@ -17,6 +17,7 @@ import (
 	tmversion "github.com/tendermint/tendermint/version"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
@@ -203,6 +204,21 @@ func (srv *seedService) GetTransactionsWithResults(ctx context.Context, height i
 	return nil, consensus.ErrUnsupported
 }
 
+// Implements Backend.
+func (srv *seedService) SubmitMultiSignedTx(ctx context.Context, tx *transaction.MultiSignedTransaction) error {
+	return consensus.ErrUnsupported
+}
+
+// Implements Backend.
+func (srv *seedService) SubmitTxWithProof(ctx context.Context, tx *transaction.SignedTransaction) (*consensus.TransactionWithProof, error) {
+	return nil, consensus.ErrUnsupported
+}
+
+// Implements Backend.
+func (srv *seedService) GetTransactionByHash(ctx context.Context, txHash hash.Hash) (*consensus.TransactionWithResult, error) {
+	return nil, consensus.ErrUnsupported
+}
+
 // Implements Backend.
 func (srv *seedService) GetUnconfirmedTransactions(ctx context.Context) ([][]byte, error) {
 	return nil, consensus.ErrUnsupported
@@ -213,6 +229,11 @@ func (srv *seedService) WatchBlocks(ctx context.Context) (<-chan *consensus.Bloc
 	return nil, nil, consensus.ErrUnsupported
 }
 
+// Implements Backend.
+func (srv *seedService) WatchEventsFrom(ctx context.Context, req *consensus.WatchEventsRequest) (<-chan *consensus.Event, *consensus.EventSubscription, error) {
+	return nil, nil, consensus.ErrUnsupported
+}
+
 // Implements Backend.
 func (srv *seedService) GetSignerNonce(ctx context.Context, req *consensus.GetSignerNonceRequest) (uint64, error) {
 	return 0, consensus.ErrUnsupported
@@ -9,11 +9,17 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	tmtypes "github.com/tendermint/tendermint/types"
 
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	tmAPI "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
 )
@@ -133,6 +139,9 @@ func ConsensusImplementationTests(t *testing.T, backend consensus.ClientBackend)
 	err = backend.SubmitTxNoWait(ctx, &transaction.SignedTransaction{})
 	require.Error(err, "SubmitTxNoWait should fail with invalid transaction")
 
+	_, err = backend.SubmitTxWithProof(ctx, &transaction.SignedTransaction{})
+	require.Error(err, "SubmitTxWithProof should fail with invalid transaction")
+
 	testTx := transaction.NewTransaction(0, nil, staking.MethodTransfer, &staking.Transfer{})
 	testSigner := memorySigner.NewTestSigner(fmt.Sprintf("consensus tests tx signer: %T", backend))
 	testSigTx, err := transaction.Sign(testSigner, testTx)
@@ -179,4 +188,141 @@ func ConsensusImplementationTests(t *testing.T, backend consensus.ClientBackend)
 
 	err = state.PrefetchPrefixes(ctx, keys[:1], 10)
 	require.NoError(err, "state.PrefetchPrefixes")
+
+	testWatchEventsFrom(t, backend, status.GenesisHeight, blk.Height)
+	testSubmitTxWithProof(t, backend)
+}
+
+func testSubmitTxWithProof(t *testing.T, backend consensus.ClientBackend) {
+	require := require.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), recvTimeout)
+	defer cancel()
+
+	tx := transaction.NewTransaction(0, nil, staking.MethodTransfer, &staking.Transfer{})
+	signer := memorySigner.NewTestSigner(fmt.Sprintf("consensus tests tx with proof signer: %T", backend))
+	sigTx, err := transaction.Sign(signer, tx)
+	require.NoError(err, "transaction.Sign")
+	rawTx := cbor.Marshal(sigTx)
+
+	rsp, err := backend.SubmitTxWithProof(ctx, sigTx)
+	require.NoError(err, "SubmitTxWithProof")
+	require.NotNil(rsp.Result, "SubmitTxWithProof should return the transaction result")
+
+	// The returned result should match the result stored in the block.
+	txsWithResults, err := backend.GetTransactionsWithResults(ctx, rsp.Height)
+	require.NoError(err, "GetTransactionsWithResults")
+	require.True(int(rsp.Index) < len(txsWithResults.Transactions), "transaction index should be within the block")
+	require.EqualValues(rawTx, txsWithResults.Transactions[rsp.Index], "transaction should be at the returned index")
+	require.EqualValues(txsWithResults.Results[rsp.Index], rsp.Result, "result should match the result in the block")
+
+	// The proof should prove the inclusion of the transaction in the block.
+	blk, err := backend.GetBlock(ctx, rsp.Height)
+	require.NoError(err, "GetBlock")
+	var meta tmAPI.BlockMeta
+	err = cbor.Unmarshal(blk.Meta, &meta)
+	require.NoError(err, "cbor.Unmarshal(BlockMeta)")
+
+	var protoProof tmproto.TxProof
+	err = protoProof.Unmarshal(rsp.Proof)
+	require.NoError(err, "TxProof.Unmarshal")
+	proof, err := tmtypes.TxProofFromProto(protoProof)
+	require.NoError(err, "TxProofFromProto")
+	require.EqualValues(rawTx, proof.Data, "proof should be for the submitted transaction")
+	require.NoError(proof.Validate(meta.Header.DataHash), "proof should be valid for the block data hash")
+}
+
+func testWatchEventsFrom(t *testing.T, backend consensus.ClientBackend, fromHeight, toHeight int64) {
+	require := require.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), recvTimeout)
+	defer cancel()
+
+	evCh, evSub, err := backend.WatchEventsFrom(ctx, &consensus.WatchEventsRequest{Height: -1})
+	if err == nil {
+		// Remote backends only report errors once the stream ends.
+		for range evCh {
+		}
+		err = evSub.Err()
+		evSub.Close()
+	}
+	require.ErrorIs(err, consensus.ErrInvalidArgument, "WatchEventsFrom with negative height should fail")
+
+	// Collect the events of all blocks up to and including the given height.
+	watchEvents := func(req *consensus.WatchEventsRequest) []*consensus.Event {
+		evCtx, evCancel := context.WithTimeout(context.Background(), recvTimeout)
+		defer evCancel()
+
+		evCh, evSub, werr := backend.WatchEventsFrom(evCtx, req)
+		require.NoError(werr, "WatchEventsFrom")
+		defer evSub.Close()
+
+		var events []*consensus.Event
+		for {
+			select {
+			case ev, ok := <-evCh:
+				require.True(ok, "event stream should not end prematurely (err: %v)", evSub.Err())
+				if ev.Cursor.Height > toHeight {
+					return events
+				}
+				if len(events) > 0 {
+					require.True(events[len(events)-1].Cursor.Less(&ev.Cursor), "event cursors should be increasing")
+				}
+				events = append(events, ev)
+			case <-evCtx.Done():
+				// No events have been emitted after the given height in the meantime.
+				return events
+			}
+		}
+	}
+	events := watchEvents(&consensus.WatchEventsRequest{Height: fromHeight})
+	require.NotEmpty(events, "WatchEventsFrom should return some events")
+
+	// Make sure that the streamed events match the events reported by the individual services.
+	ctx = context.Background()
+	var (
+		stakingEvents    []*staking.Event
+		registryEvents   []*registry.Event
+		governanceEvents []*governance.Event
+	)
+	for height := fromHeight; height <= toHeight; height++ {
+		stakingEvs, gerr := backend.Staking().GetEvents(ctx, height)
+		require.NoError(gerr, "Staking.GetEvents(%d)", height)
+		stakingEvents = append(stakingEvents, stakingEvs...)
+
+		registryEvs, gerr := backend.Registry().GetEvents(ctx, height)
+		require.NoError(gerr, "Registry.GetEvents(%d)", height)
+		registryEvents = append(registryEvents, registryEvs...)
+
+		governanceEvs, gerr := backend.Governance().GetEvents(ctx, height)
+		require.NoError(gerr, "Governance.GetEvents(%d)", height)
+		governanceEvents = append(governanceEvents, governanceEvs...)
+	}
+	var (
+		streamedStakingEvents    []*staking.Event
+		streamedRegistryEvents   []*registry.Event
+		streamedGovernanceEvents []*governance.Event
+	)
+	for _, ev := range events {
+		switch {
+		case ev.Staking != nil:
+			streamedStakingEvents = append(streamedStakingEvents, ev.Staking)
+		case ev.Registry != nil:
+			streamedRegistryEvents = append(streamedRegistryEvents, ev.Registry)
+		case ev.Governance != nil:
+			streamedGovernanceEvents = append(streamedGovernanceEvents, ev.Governance)
+		}
+	}
+	require.EqualValues(stakingEvents, streamedStakingEvents, "streamed staking events should match")
+	require.EqualValues(registryEvents, streamedRegistryEvents, "streamed registry events should match")
+	require.EqualValues(governanceEvents, streamedGovernanceEvents, "streamed governance events should match")
+
+	// Resuming from the cursor of an event in the middle of the stream should return exactly the
+	// events following it.
+	mid := len(events) / 2
+	resumed := watchEvents(&consensus.WatchEventsRequest{After: &events[mid].Cursor})
+	require.Len(resumed, len(events)-mid-1, "resumed events should follow the cursor")
+	for i, ev := range resumed {
+		require.EqualValues(events[mid+1+i], ev, "resumed events should match")
+	}
 }
@@ -14,12 +14,26 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	scheduling "github.com/oasisprotocol/oasis-core/go/runtime/scheduling/api"
 	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 	commonWorker "github.com/oasisprotocol/oasis-core/go/worker/common/api"
 	storageWorker "github.com/oasisprotocol/oasis-core/go/worker/storage/api"
 )
 
+// ModuleName is the module name for the controller service.
+const ModuleName = "control"
+
+var (
+	// ErrTxPoolNotAvailable is the error returned when the node does not maintain a transaction
+	// pool for the given runtime.
+	ErrTxPoolNotAvailable = errors.New(ModuleName, 1, "control: transaction pool not available")
+
+	// ErrTxNotFound is the error returned when a transaction is not queued in the transaction
+	// pool.
+	ErrTxNotFound = errors.New(ModuleName, 2, "control: transaction not found")
+)
+
 // NodeController is a node controller interface.
 type NodeController interface {
 	// RequestShutdown requests the node to shut down gracefully.
@@ -50,6 +64,16 @@ type NodeController interface {
 
 	// GetStatus returns the current status overview of the node.
 	GetStatus(ctx context.Context) (*Status, error)
+
+	// GetTxPoolTransactions returns the transactions queued in the given runtime's transaction
+	// pool. The transaction data is omitted from the returned transactions.
+	GetTxPoolTransactions(ctx context.Context, runtimeID common.Namespace) ([]*TxPoolTransaction, error)
+
+	// GetTxPoolTransaction returns a specific transaction queued in a runtime's transaction pool.
+	GetTxPoolTransaction(ctx context.Context, query *TxPoolTransactionQuery) (*TxPoolTransaction, error)
+
+	// RemoveTxPoolTransactions removes the given transactions from a runtime's transaction pool.
+	RemoveTxPoolTransactions(ctx context.Context, request *RemoveTxPoolTransactionsRequest) error
 }
 
 // Status is the current status overview.
@@ -126,6 +150,49 @@ type RuntimeStatus struct {
 	Storage *storageWorker.Status `json:"storage"`
 }
 
+// TxPoolTransaction is a transaction queued in a runtime's transaction pool.
+type TxPoolTransaction struct {
+	// Hash is the hash of the transaction.
+	Hash hash.Hash `json:"hash"`
+	// Size is the size of the transaction in bytes.
+	Size uint64 `json:"size"`
+	// QueuedAt is the time at which the transaction was queued.
+	QueuedAt time.Time `json:"queued_at"`
+	// Data is the raw transaction.
+	Data []byte `json:"data,omitempty"`
+}
+
+// TxPoolTransactionQuery is a query for a transaction queued in a runtime's transaction pool.
+type TxPoolTransactionQuery struct {
+	// RuntimeID is the runtime identifier.
+	RuntimeID common.Namespace `json:"runtime_id"`
+	// Hash is the hash of the transaction.
+	Hash hash.Hash `json:"hash"`
+}
+
+// RemoveTxPoolTransactionsRequest is a request to remove transactions from a runtime's
+// transaction pool.
+type RemoveTxPoolTransactionsRequest struct {
+	// RuntimeID is the runtime identifier.
+	RuntimeID common.Namespace `json:"runtime_id"`
+	// Hashes are the hashes of the transactions to remove.
+	Hashes []hash.Hash `json:"hashes"`
+}
+
+// TxPool is the transaction pool interface that the controlled node exposes for each runtime.
+type TxPool interface {
+	// GetQueuedTransactions returns all transactions queued for scheduling.
+	GetQueuedTransactions() ([]*scheduling.QueuedTx, error)
+
+	// GetQueuedTransaction returns the transaction with the given hash queued for scheduling. In
+	// case the transaction is not queued, nil is returned.
+	GetQueuedTransaction(txHash hash.Hash) (*scheduling.QueuedTx, error)
+
+	// RemoveQueuedTransactions removes the transactions with the given hashes from the
+	// scheduling queue.
+	RemoveQueuedTransactions(hashes []hash.Hash) error
+}
+
 // ControlledNode is an internal interface that the controlled oasis-node must provide.
 type ControlledNode interface {
 	// RequestShutdown is the method called by the control server to trigger node shutdown.
@@ -145,6 +212,12 @@ type ControlledNode interface {
 
 	// GetPendingUpgrade returns the node's pending upgrades.
 	GetPendingUpgrades(ctx context.Context) ([]*upgrade.PendingUpgrade, error)
+
+	// GetRuntimeTxPool returns the transaction pool of the given runtime.
+	//
+	// In case the node does not maintain a transaction pool for the runtime,
+	// ErrTxPoolNotAvailable is returned.
+	GetRuntimeTxPool(runtimeID common.Namespace) (TxPool, error)
 }
 
 // DebugModuleName is the module name for the debug controller service.
@@ -5,6 +5,7 @@ import (
 
 	"google.golang.org/grpc"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
 	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
 	upgradeApi "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 )
@@ -29,6 +30,12 @@ var (
 	methodCancelUpgrade = serviceName.NewMethod("CancelUpgrade", nil)
 	// methodGetStatus is the GetStatus method.
 	methodGetStatus = serviceName.NewMethod("GetStatus", nil)
+	// methodGetTxPoolTransactions is the GetTxPoolTransactions method.
+	methodGetTxPoolTransactions = serviceName.NewMethod("GetTxPoolTransactions", common.Namespace{})
+	// methodGetTxPoolTransaction is the GetTxPoolTransaction method.
+	methodGetTxPoolTransaction = serviceName.NewMethod("GetTxPoolTransaction", TxPoolTransactionQuery{})
+	// methodRemoveTxPoolTransactions is the RemoveTxPoolTransactions method.
+	methodRemoveTxPoolTransactions = serviceName.NewMethod("RemoveTxPoolTransactions", RemoveTxPoolTransactionsRequest{})
 
 	// serviceDesc is the gRPC service descriptor.
 	serviceDesc = grpc.ServiceDesc{
@@ -67,6 +74,18 @@ var (
 				MethodName: methodGetStatus.ShortName(),
 				Handler:    handlerGetStatus,
 			},
+			{
+				MethodName: methodGetTxPoolTransactions.ShortName(),
+				Handler:    handlerGetTxPoolTransactions,
+			},
+			{
+				MethodName: methodGetTxPoolTransaction.ShortName(),
+				Handler:    handlerGetTxPoolTransaction,
+			},
+			{
+				MethodName: methodRemoveTxPoolTransactions.ShortName(),
+				Handler:    handlerRemoveTxPoolTransactions,
+			},
 		},
 		Streams: []grpc.StreamDesc{},
 	}
@@ -236,6 +255,75 @@ func handlerGetStatus( // nolint: golint
 	return interceptor(ctx, nil, info, handler)
 }
 
+func handlerGetTxPoolTransactions( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var runtimeID common.Namespace
+	if err := dec(&runtimeID); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeController).GetTxPoolTransactions(ctx, runtimeID)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetTxPoolTransactions.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeController).GetTxPoolTransactions(ctx, req.(common.Namespace))
+	}
+	return interceptor(ctx, runtimeID, info, handler)
+}
+
+func handlerGetTxPoolTransaction( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query TxPoolTransactionQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeController).GetTxPoolTransaction(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetTxPoolTransaction.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeController).GetTxPoolTransaction(ctx, req.(*TxPoolTransactionQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
+func handlerRemoveTxPoolTransactions( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var request RemoveTxPoolTransactionsRequest
+	if err := dec(&request); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return nil, srv.(NodeController).RemoveTxPoolTransactions(ctx, &request)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodRemoveTxPoolTransactions.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, srv.(NodeController).RemoveTxPoolTransactions(ctx, req.(*RemoveTxPoolTransactionsRequest))
+	}
+	return interceptor(ctx, &request, info, handler)
+}
+
 // RegisterService registers a new node controller service with the given gRPC server.
 func RegisterService(server *grpc.Server, service NodeController) {
 	server.RegisterService(&serviceDesc, service)
@@ -289,6 +377,26 @@ func (c *nodeControllerClient) GetStatus(ctx context.Context) (*Status, error) {
 	return &rsp, nil
 }
 
+func (c *nodeControllerClient) GetTxPoolTransactions(ctx context.Context, runtimeID common.Namespace) ([]*TxPoolTransaction, error) {
+	var rsp []*TxPoolTransaction
+	if err := c.conn.Invoke(ctx, methodGetTxPoolTransactions.FullName(), runtimeID, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+func (c *nodeControllerClient) GetTxPoolTransaction(ctx context.Context, query *TxPoolTransactionQuery) (*TxPoolTransaction, error) {
+	var rsp TxPoolTransaction
+	if err := c.conn.Invoke(ctx, methodGetTxPoolTransaction.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (c *nodeControllerClient) RemoveTxPoolTransactions(ctx context.Context, request *RemoveTxPoolTransactionsRequest) error {
+	return c.conn.Invoke(ctx, methodRemoveTxPoolTransactions.FullName(), request, nil)
+}
+
 // NewNodeControllerClient creates a new gRPC node controller client service.
 func NewNodeControllerClient(c *grpc.ClientConn) NodeController {
 	return &nodeControllerClient{c}
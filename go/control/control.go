@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	control "github.com/oasisprotocol/oasis-core/go/control/api"
@@ -119,6 +120,55 @@ func (c *nodeController) GetStatus(ctx context.Context) (*control.Status, error)
 	}, nil
 }
 
+func (c *nodeController) GetTxPoolTransactions(ctx context.Context, runtimeID common.Namespace) ([]*control.TxPoolTransaction, error) {
+	txPool, err := c.node.GetRuntimeTxPool(runtimeID)
+	if err != nil {
+		return nil, err
+	}
+	queuedTxs, err := txPool.GetQueuedTransactions()
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]*control.TxPoolTransaction, 0, len(queuedTxs))
+	for _, qtx := range queuedTxs {
+		txs = append(txs, &control.TxPoolTransaction{
+			Hash:     qtx.Hash,
+			Size:     uint64(len(qtx.Tx)),
+			QueuedAt: qtx.QueuedAt,
+		})
+	}
+	return txs, nil
+}
+
+func (c *nodeController) GetTxPoolTransaction(ctx context.Context, query *control.TxPoolTransactionQuery) (*control.TxPoolTransaction, error) {
+	txPool, err := c.node.GetRuntimeTxPool(query.RuntimeID)
+	if err != nil {
+		return nil, err
+	}
+	qtx, err := txPool.GetQueuedTransaction(query.Hash)
+	if err != nil {
+		return nil, err
+	}
+	if qtx == nil {
+		return nil, control.ErrTxNotFound
+	}
+	return &control.TxPoolTransaction{
+		Hash:     qtx.Hash,
+		Size:     uint64(len(qtx.Tx)),
+		QueuedAt: qtx.QueuedAt,
+		Data:     qtx.Tx,
+	}, nil
+}
+
+func (c *nodeController) RemoveTxPoolTransactions(ctx context.Context, request *control.RemoveTxPoolTransactionsRequest) error {
+	txPool, err := c.node.GetRuntimeTxPool(request.RuntimeID)
+	if err != nil {
+		return err
+	}
+	return txPool.RemoveQueuedTransactions(request.Hashes)
+}
+
 // New creates a new oasis-node controller.
 func New(node control.ControlledNode, consensus consensus.Backend, upgrader upgrade.Backend) control.NodeController {
 	return &nodeController{
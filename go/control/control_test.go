@@ -0,0 +1,165 @@
+package control
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
+	control "github.com/oasisprotocol/oasis-core/go/control/api"
+	scheduling "github.com/oasisprotocol/oasis-core/go/runtime/scheduling/api"
+)
+
+type testTxPool struct {
+	sync.Mutex
+
+	txs map[hash.Hash]*scheduling.QueuedTx
+}
+
+func (p *testTxPool) GetQueuedTransactions() ([]*scheduling.QueuedTx, error) {
+	p.Lock()
+	defer p.Unlock()
+
+	txs := make([]*scheduling.QueuedTx, 0, len(p.txs))
+	for _, qtx := range p.txs {
+		txs = append(txs, qtx)
+	}
+	return txs, nil
+}
+
+func (p *testTxPool) GetQueuedTransaction(txHash hash.Hash) (*scheduling.QueuedTx, error) {
+	p.Lock()
+	defer p.Unlock()
+
+	return p.txs[txHash], nil
+}
+
+func (p *testTxPool) RemoveQueuedTransactions(hashes []hash.Hash) error {
+	p.Lock()
+	defer p.Unlock()
+
+	for _, h := range hashes {
+		delete(p.txs, h)
+	}
+	return nil
+}
+
+type testControlledNode struct {
+	control.ControlledNode
+
+	txPools map[common.Namespace]control.TxPool
+}
+
+func (n *testControlledNode) GetRuntimeTxPool(runtimeID common.Namespace) (control.TxPool, error) {
+	txPool, ok := n.txPools[runtimeID]
+	if !ok {
+		return nil, control.ErrTxPoolNotAvailable
+	}
+	return txPool, nil
+}
+
+func TestTxPool(t *testing.T) {
+	require := require.New(t)
+
+	var runtimeID, otherRuntimeID common.Namespace
+	_ = runtimeID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000000")
+	_ = otherRuntimeID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000001")
+
+	queuedAt := time.Unix(1580461674, 0)
+	txPool := &testTxPool{txs: make(map[hash.Hash]*scheduling.QueuedTx)}
+	for _, tx := range [][]byte{[]byte("tx 1"), []byte("tx 2"), []byte("tx 3")} {
+		h := hash.NewFromBytes(tx)
+		txPool.txs[h] = &scheduling.QueuedTx{Tx: tx, Hash: h, QueuedAt: queuedAt}
+	}
+	node := &testControlledNode{
+		txPools: map[common.Namespace]control.TxPool{
+			runtimeID: txPool,
+		},
+	}
+
+	// Generate temporary filename for the socket.
+	f, err := ioutil.TempFile("", "oasis-control-test-socket")
+	require.NoError(err, "TempFile")
+	// Remove the file as we only need the name.
+	f.Close()
+	os.Remove(f.Name())
+
+	grpcServer, err := cmnGrpc.NewServer(&cmnGrpc.ServerConfig{
+		Path: f.Name(),
+	})
+	require.NoError(err, "NewServer")
+	defer os.Remove(f.Name())
+
+	control.RegisterService(grpcServer.Server(), New(node, nil, nil))
+	err = grpcServer.Start()
+	require.NoError(err, "Start")
+	defer grpcServer.Stop()
+
+	conn, err := cmnGrpc.Dial("unix:"+f.Name(), grpc.WithInsecure())
+	require.NoError(err, "Dial")
+	defer conn.Close()
+	client := control.NewNodeControllerClient(conn)
+
+	ctx := context.Background()
+
+	txs, err := client.GetTxPoolTransactions(ctx, runtimeID)
+	require.NoError(err, "GetTxPoolTransactions")
+	require.Len(txs, 3, "all queued transactions should be returned")
+	for _, tx := range txs {
+		require.Contains(txPool.txs, tx.Hash, "queued transaction should be returned")
+		require.EqualValues(4, tx.Size, "transaction size should be correct")
+		require.True(queuedAt.Equal(tx.QueuedAt), "transaction queued time should be correct")
+		require.Nil(tx.Data, "transaction data should be omitted")
+	}
+
+	txHash := hash.NewFromBytes([]byte("tx 2"))
+	tx, err := client.GetTxPoolTransaction(ctx, &control.TxPoolTransactionQuery{
+		RuntimeID: runtimeID,
+		Hash:      txHash,
+	})
+	require.NoError(err, "GetTxPoolTransaction")
+	require.EqualValues(txHash, tx.Hash, "transaction hash should be correct")
+	require.EqualValues([]byte("tx 2"), tx.Data, "transaction data should be returned")
+
+	_, err = client.GetTxPoolTransaction(ctx, &control.TxPoolTransactionQuery{
+		RuntimeID: runtimeID,
+		Hash:      hash.NewFromBytes([]byte("not queued")),
+	})
+	require.ErrorIs(err, control.ErrTxNotFound, "GetTxPoolTransaction should fail for transactions that are not queued")
+
+	err = client.RemoveTxPoolTransactions(ctx, &control.RemoveTxPoolTransactionsRequest{
+		RuntimeID: runtimeID,
+		Hashes:    []hash.Hash{txHash, hash.NewFromBytes([]byte("not queued"))},
+	})
+	require.NoError(err, "RemoveTxPoolTransactions")
+	txs, err = client.GetTxPoolTransactions(ctx, runtimeID)
+	require.NoError(err, "GetTxPoolTransactions")
+	require.Len(txs, 2, "removed transaction should no longer be queued")
+	_, err = client.GetTxPoolTransaction(ctx, &control.TxPoolTransactionQuery{
+		RuntimeID: runtimeID,
+		Hash:      txHash,
+	})
+	require.ErrorIs(err, control.ErrTxNotFound, "GetTxPoolTransaction should fail for removed transactions")
+
+	// Nodes without a transaction pool for the runtime.
+	_, err = client.GetTxPoolTransactions(ctx, otherRuntimeID)
+	require.ErrorIs(err, control.ErrTxPoolNotAvailable, "GetTxPoolTransactions should fail without a transaction pool")
+	_, err = client.GetTxPoolTransaction(ctx, &control.TxPoolTransactionQuery{
+		RuntimeID: otherRuntimeID,
+		Hash:      txHash,
+	})
+	require.ErrorIs(err, control.ErrTxPoolNotAvailable, "GetTxPoolTransaction should fail without a transaction pool")
+	err = client.RemoveTxPoolTransactions(ctx, &control.RemoveTxPoolTransactionsRequest{
+		RuntimeID: otherRuntimeID,
+		Hashes:    []hash.Hash{txHash},
+	})
+	require.ErrorIs(err, control.ErrTxPoolNotAvailable, "RemoveTxPoolTransactions should fail without a transaction pool")
+}
@@ -2,10 +2,12 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
@@ -48,20 +50,34 @@ var (
 
 // ProposalContent is a consensus layer governance proposal content.
 type ProposalContent struct {
-	Upgrade       *UpgradeProposal       `json:"upgrade,omitempty"`
-	CancelUpgrade *CancelUpgradeProposal `json:"cancel_upgrade,omitempty"`
+	Upgrade          *UpgradeProposal          `json:"upgrade,omitempty"`
+	CancelUpgrade    *CancelUpgradeProposal    `json:"cancel_upgrade,omitempty"`
+	ChangeParameters *ChangeParametersProposal `json:"change_parameters,omitempty"`
 }
 
 // ValidateBasic performs basic proposal content validity checks.
 func (p *ProposalContent) ValidateBasic() error {
+	var numFields int
+	if p.Upgrade != nil {
+		numFields++
+	}
+	if p.CancelUpgrade != nil {
+		numFields++
+	}
+	if p.ChangeParameters != nil {
+		numFields++
+	}
+
 	switch {
-	case p.Upgrade != nil && p.CancelUpgrade != nil:
+	case numFields > 1:
 		return fmt.Errorf("proposal content has multiple fields set")
 	case p.Upgrade != nil:
 		return p.Upgrade.ValidateBasic()
 	case p.CancelUpgrade != nil:
 		// No validation at this time.
 		return nil
+	case p.ChangeParameters != nil:
+		return p.ChangeParameters.ValidateBasic()
 	default:
 		return fmt.Errorf("proposal content has no fields set")
 	}
@@ -77,6 +93,8 @@ func (p *ProposalContent) Equals(other *ProposalContent) bool {
 		return p.CancelUpgrade.ProposalID == other.CancelUpgrade.ProposalID
 	case p.Upgrade != nil && other.Upgrade != nil:
 		return p.Upgrade.Descriptor.Equals(&other.Upgrade.Descriptor)
+	case p.ChangeParameters != nil && other.ChangeParameters != nil:
+		return p.ChangeParameters.Equals(other.ChangeParameters)
 	default:
 		return false
 	}
@@ -93,6 +111,30 @@ type CancelUpgradeProposal struct {
 	ProposalID uint64 `json:"proposal_id"`
 }
 
+// ChangeParametersProposal is a consensus change parameters proposal.
+type ChangeParametersProposal struct {
+	// Module identifies the consensus backend module to which changes should be applied.
+	Module string `json:"module"`
+	// Changes are consensus parameter changes that should be applied to the module.
+	Changes cbor.RawMessage `json:"changes"`
+}
+
+// ValidateBasic performs a basic validation on the change parameters proposal.
+func (p *ChangeParametersProposal) ValidateBasic() error {
+	if p.Module == "" {
+		return fmt.Errorf("change parameters proposal must specify a module")
+	}
+	if len(p.Changes) == 0 {
+		return fmt.Errorf("change parameters proposal must specify parameter changes")
+	}
+	return nil
+}
+
+// Equals checks if change parameters proposals are equal.
+func (p *ChangeParametersProposal) Equals(other *ChangeParametersProposal) bool {
+	return p.Module == other.Module && bytes.Equal(p.Changes, other.Changes)
+}
+
 // ProposalVote is a vote for a proposal.
 type ProposalVote struct {
 	// ID is the unique identifier of a proposal.
@@ -144,6 +186,10 @@ type ProposalQuery struct {
 type VoteEntry struct {
 	Voter staking.Address `json:"voter"`
 	Vote  Vote            `json:"vote"`
+
+	// Weight is the stake weight of the vote under the current voting mode in case it has been
+	// computed. A vote without weight would be considered invalid if tallied at the same height.
+	Weight *quantity.Quantity `json:"weight,omitempty"`
 }
 
 // Genesis is the initial governance state for use in the genesis block.
@@ -190,6 +236,9 @@ type ConsensusParameters struct {
 	// UpgradeCancelMinEpochDiff is the minimum number of epochs between the current
 	// epoch and the proposed upgrade epoch for the upgrade cancellation proposal to be valid.
 	UpgradeCancelMinEpochDiff beacon.EpochTime `json:"upgrade_cancel_min_epoch_diff,omitempty"`
+
+	// VotingMode is the mode used when tallying votes.
+	VotingMode VotingMode `json:"voting_mode,omitempty"`
 }
 
 // Event signifies a governance event, returned via GetEvents.
@@ -201,6 +250,7 @@ type Event struct {
 	ProposalExecuted  *ProposalExecutedEvent  `json:"proposal_executed,omitempty"`
 	ProposalFinalized *ProposalFinalizedEvent `json:"proposal_finalized,omitempty"`
 	Vote              *VoteEvent              `json:"vote,omitempty"`
+	ParametersChanged *ParametersChangedEvent `json:"parameters_changed,omitempty"`
 }
 
 // ProposalSubmittedEvent is the event emitted when a new proposal is submitted.
@@ -235,6 +285,17 @@ type VoteEvent struct {
 	Vote Vote `json:"vote"`
 }
 
+// ParametersChangedEvent is the event emitted when consensus parameters of
+// a module are changed by an executed change parameters proposal.
+type ParametersChangedEvent struct {
+	// ProposalID is the unique identifier of the executed proposal.
+	ProposalID uint64 `json:"proposal_id"`
+	// Module is the consensus backend module whose parameters were changed.
+	Module string `json:"module"`
+	// Changes are the consensus parameter changes that were applied.
+	Changes cbor.RawMessage `json:"changes"`
+}
+
 // NewSubmitProposalTx creates a new submit proposal transaction.
 func NewSubmitProposalTx(nonce uint64, fee *transaction.Fee, proposal *ProposalContent) *transaction.Transaction {
 	return transaction.NewTransaction(nonce, fee, MethodSubmitProposal, proposal)
@@ -56,6 +56,35 @@ func TestValidateBasic(t *testing.T) {
 			},
 			shouldErr: false,
 		},
+		{
+			msg: "only one of CancelUpgrade/ChangeParameters fields should be set",
+			p: &ProposalContent{
+				CancelUpgrade:    &CancelUpgradeProposal{},
+				ChangeParameters: &ChangeParametersProposal{Module: "test", Changes: cbor.Marshal(42)},
+			},
+			shouldErr: true,
+		},
+		{
+			msg: "change parameters proposal without a module should fail",
+			p: &ProposalContent{
+				ChangeParameters: &ChangeParametersProposal{Changes: cbor.Marshal(42)},
+			},
+			shouldErr: true,
+		},
+		{
+			msg: "change parameters proposal without changes should fail",
+			p: &ProposalContent{
+				ChangeParameters: &ChangeParametersProposal{Module: "test"},
+			},
+			shouldErr: true,
+		},
+		{
+			msg: "change parameters proposal content should not fail",
+			p: &ProposalContent{
+				ChangeParameters: &ChangeParametersProposal{Module: "test", Changes: cbor.Marshal(42)},
+			},
+			shouldErr: false,
+		},
 	} {
 		err := tc.p.ValidateBasic()
 		if tc.shouldErr {
@@ -133,6 +162,36 @@ func TestProposalContentEquals(t *testing.T) {
 			},
 			equals: false,
 		},
+		{
+			msg: "change parameters proposals should be equal",
+			p1: &ProposalContent{
+				ChangeParameters: &ChangeParametersProposal{Module: "test", Changes: cbor.Marshal(42)},
+			},
+			p2: &ProposalContent{
+				ChangeParameters: &ChangeParametersProposal{Module: "test", Changes: cbor.Marshal(42)},
+			},
+			equals: true,
+		},
+		{
+			msg: "change parameters proposals for different modules should not be equal",
+			p1: &ProposalContent{
+				ChangeParameters: &ChangeParametersProposal{Module: "test", Changes: cbor.Marshal(42)},
+			},
+			p2: &ProposalContent{
+				ChangeParameters: &ChangeParametersProposal{Module: "test2", Changes: cbor.Marshal(42)},
+			},
+			equals: false,
+		},
+		{
+			msg: "change parameters proposals with different changes should not be equal",
+			p1: &ProposalContent{
+				ChangeParameters: &ChangeParametersProposal{Module: "test", Changes: cbor.Marshal(42)},
+			},
+			p2: &ProposalContent{
+				ChangeParameters: &ChangeParametersProposal{Module: "test", Changes: cbor.Marshal(24)},
+			},
+			equals: false,
+		},
 	} {
 		require.Equal(t, tc.equals, tc.p1.Equals(tc.p2), tc.msg)
 	}
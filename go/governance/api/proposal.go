@@ -243,6 +243,59 @@ func (v *Vote) UnmarshalText(text []byte) error {
 	return nil
 }
 
+// VotingMode is the mode used when tallying governance votes.
+type VotingMode uint8
+
+// Voting modes.
+const (
+	// VotingModeValidators is the voting mode where only votes of validator entities are
+	// tallied, each weighted by the entity's active escrow balance.
+	VotingModeValidators VotingMode = 0
+	// VotingModeDelegatorOverride is the voting mode where delegators of validator entities can
+	// cast their own vote which overrides the validator entity's vote for their delegated share.
+	VotingModeDelegatorOverride VotingMode = 1
+
+	VotingModeValidatorsName        = "validators"
+	VotingModeDelegatorOverrideName = "delegator_override"
+)
+
+// String returns a string representation of a VotingMode.
+func (m VotingMode) String() string {
+	switch m {
+	case VotingModeValidators:
+		return VotingModeValidatorsName
+	case VotingModeDelegatorOverride:
+		return VotingModeDelegatorOverrideName
+	default:
+		return fmt.Sprintf("[unknown voting mode: %d]", m)
+	}
+}
+
+// MarshalText encodes a VotingMode into text form.
+func (m VotingMode) MarshalText() ([]byte, error) {
+	switch m {
+	case VotingModeValidators:
+		return []byte(VotingModeValidatorsName), nil
+	case VotingModeDelegatorOverride:
+		return []byte(VotingModeDelegatorOverrideName), nil
+	default:
+		return nil, fmt.Errorf("invalid voting mode: %d", m)
+	}
+}
+
+// UnmarshalText decodes a text slice into a VotingMode.
+func (m *VotingMode) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case VotingModeValidatorsName:
+		*m = VotingModeValidators
+	case VotingModeDelegatorOverrideName:
+		*m = VotingModeDelegatorOverride
+	default:
+		return fmt.Errorf("invalid voting mode: %s", string(text))
+	}
+	return nil
+}
+
 // PendingUpgradesFromProposals computes pending upgrades proposals state.
 //
 // Returns pending upgrades and corresponding proposal IDs.
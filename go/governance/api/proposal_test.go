@@ -75,6 +75,35 @@ func TestVote(t *testing.T) {
 	require.Error(err, "unmarshal on invalid vote")
 }
 
+func TestVotingMode(t *testing.T) {
+	require := require.New(t)
+
+	// Test valid voting modes.
+	for _, m := range []VotingMode{
+		VotingModeValidators,
+		VotingModeDelegatorOverride,
+	} {
+		enc, err := m.MarshalText()
+		require.NoError(err, "MarshalText")
+
+		var vm VotingMode
+		err = vm.UnmarshalText(enc)
+		require.NoError(err, "UnmarshalText")
+		require.Equal(m, vm, "voting mode should round-trip")
+		require.EqualValues([]byte(vm.String()), enc, "marshalled voting mode should match")
+	}
+
+	// Test invalid voting mode.
+	m := VotingMode(42)
+	_, err := m.MarshalText()
+	require.Error(err, "MarshalText on invalid voting mode")
+	require.Contains(m.String(), "unknown voting mode", "String() on invalid voting mode")
+
+	var vm VotingMode
+	err = vm.UnmarshalText([]byte{})
+	require.Error(err, "unmarshal on invalid voting mode")
+}
+
 func TestVotedSum(t *testing.T) {
 	for _, tc := range []struct {
 		msg      string
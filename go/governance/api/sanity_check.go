@@ -34,6 +34,12 @@ func (p *ConsensusParameters) SanityCheck() error {
 	if p.VotingPeriod >= p.UpgradeCancelMinEpochDiff {
 		return fmt.Errorf("voting_period should be less than upgrade_cancel_min_epoch_diff")
 	}
+	// Voting mode must be known.
+	switch p.VotingMode {
+	case VotingModeValidators, VotingModeDelegatorOverride:
+	default:
+		return fmt.Errorf("unknown voting_mode: %d", p.VotingMode)
+	}
 	return nil
 }
 
@@ -39,11 +39,16 @@ const (
 
 	// CfgTxUnsigned makes SaveTx save an unsigned transaction.
 	CfgTxUnsigned = "transaction.unsigned"
+
+	// CfgTxMultisigDescriptor configures the path to the multisig account descriptor. If set,
+	// SaveTx saves a partially signed multisig transaction.
+	CfgTxMultisigDescriptor = "transaction.multisig.descriptor"
 )
 
 var (
-	TxFlags     = flag.NewFlagSet("", flag.ContinueOnError)
-	TxFileFlags = flag.NewFlagSet("", flag.ContinueOnError)
+	TxFlags                 = flag.NewFlagSet("", flag.ContinueOnError)
+	TxFileFlags             = flag.NewFlagSet("", flag.ContinueOnError)
+	MultisigDescriptorFlags = flag.NewFlagSet("", flag.ContinueOnError)
 
 	logger = logging.GetLogger("cmd/common/consensus")
 )
@@ -81,6 +86,55 @@ func InitGenesis() *genesisAPI.Document {
 	return genesisDoc
 }
 
+// LoadMultisigDescriptor loads the multisig account descriptor if configured.
+func LoadMultisigDescriptor() *transaction.MultisigDescriptor {
+	fn := viper.GetString(CfgTxMultisigDescriptor)
+	if fn == "" {
+		return nil
+	}
+
+	rawDescriptor, err := ioutil.ReadFile(fn)
+	if err != nil {
+		logger.Error("failed to read multisig descriptor",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	var descriptor transaction.MultisigDescriptor
+	if err = json.Unmarshal(rawDescriptor, &descriptor); err != nil {
+		logger.Error("failed to parse multisig descriptor",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	if err = descriptor.ValidateBasic(); err != nil {
+		logger.Error("invalid multisig descriptor",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	return &descriptor
+}
+
+// SaveMultiSignedTx saves a (partially) signed multisig transaction.
+func SaveMultiSignedTx(tx *transaction.MultiSignedTransaction) {
+	rawTx, err := json.Marshal(tx)
+	if err != nil {
+		logger.Error("failed to marshal transaction",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	if err = ioutil.WriteFile(viper.GetString(CfgTxFile), rawTx, 0o600); err != nil {
+		logger.Error("failed to save transaction",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+}
+
 func GetTxNonceAndFee() (uint64, *transaction.Fee) {
 	var fee transaction.Fee
 	nonce := viper.GetUint64(CfgTxNonce)
@@ -134,6 +188,18 @@ func SignAndSaveTx(ctx context.Context, tx *transaction.Transaction, signer sign
 		}
 	}
 
+	if descriptor := LoadMultisigDescriptor(); descriptor != nil {
+		multiSigTx, err := transaction.SignMultisig(signer, descriptor, tx)
+		if err != nil {
+			logger.Error("failed to sign multisig transaction",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+		SaveMultiSignedTx(multiSigTx)
+		return
+	}
+
 	sigTx, err := transaction.Sign(signer, tx)
 	if err != nil {
 		logger.Error("failed to sign transaction",
@@ -161,12 +227,16 @@ func init() {
 	TxFileFlags.String(CfgTxFile, "", "path to the transaction")
 	_ = viper.BindPFlags(TxFileFlags)
 
+	MultisigDescriptorFlags.String(CfgTxMultisigDescriptor, "", "path to the multisig account descriptor")
+	_ = viper.BindPFlags(MultisigDescriptorFlags)
+
 	TxFlags.Uint64(CfgTxNonce, 0, "nonce of the signing account")
 	TxFlags.Uint64(CfgTxFeeAmount, 0, "transaction fee in base units")
 	TxFlags.String(CfgTxFeeGas, "0", "maximum transaction gas limit")
 	TxFlags.Bool(CfgTxUnsigned, false, "generate an unsigned transaction")
 	_ = viper.BindPFlags(TxFlags)
 	TxFlags.AddFlagSet(TxFileFlags)
+	TxFlags.AddFlagSet(MultisigDescriptorFlags)
 	TxFlags.AddFlagSet(cmdFlags.DebugTestEntityFlags)
 	TxFlags.AddFlagSet(cmdSigner.Flags)
 	TxFlags.AddFlagSet(cmdSigner.CLIFlags)
@@ -13,6 +13,7 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/prettyprint"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
@@ -26,10 +27,14 @@ import (
 const (
 	// CfgSignerPub is the public key of the account that will sign an unsigned transaction in estimate gas.
 	CfgSignerPub = "consensus.signer_pub"
+
+	// CfgTxHash is the hash of the transaction to look up in show_tx.
+	CfgTxHash = "hash"
 )
 
 var (
 	signerPub string
+	txHash    string
 
 	consensusCmd = &cobra.Command{
 		Use:   "consensus",
@@ -44,7 +49,7 @@ var (
 
 	showTxCmd = &cobra.Command{
 		Use:   "show_tx",
-		Short: "Show the content a pre-signed transaction",
+		Short: "Show the content a pre-signed transaction or of a transaction with the given hash",
 		Run:   doShowTx,
 	}
 
@@ -70,7 +75,9 @@ func doConnect(cmd *cobra.Command) (*grpc.ClientConn, consensus.ClientBackend) {
 	return conn, client
 }
 
-func loadTx() *transaction.SignedTransaction {
+// loadTx loads either a signed or a multisig transaction, exactly one of the returned
+// transactions is non-nil.
+func loadTx() (*transaction.SignedTransaction, *transaction.MultiSignedTransaction) {
 	rawTx, err := ioutil.ReadFile(viper.GetString(cmdConsensus.CfgTxFile))
 	if err != nil {
 		logger.Error("failed to read raw serialized transaction",
@@ -79,6 +86,11 @@ func loadTx() *transaction.SignedTransaction {
 		os.Exit(1)
 	}
 
+	var multiSigTx transaction.MultiSignedTransaction
+	if err = json.Unmarshal(rawTx, &multiSigTx); err == nil && len(multiSigTx.Signatures) > 0 {
+		return nil, &multiSigTx
+	}
+
 	var tx transaction.SignedTransaction
 	if err = json.Unmarshal(rawTx, &tx); err != nil {
 		logger.Error("failed to parse serialized transaction",
@@ -87,7 +99,7 @@ func loadTx() *transaction.SignedTransaction {
 		os.Exit(1)
 	}
 
-	return &tx
+	return &tx, nil
 }
 
 func loadUnsignedTx() *transaction.Transaction {
@@ -118,9 +130,14 @@ func doSubmitTx(cmd *cobra.Command, args []string) {
 	conn, client := doConnect(cmd)
 	defer conn.Close()
 
-	tx := loadTx()
-
-	if err := client.SubmitTx(context.Background(), tx); err != nil {
+	var err error
+	switch tx, multiSigTx := loadTx(); {
+	case multiSigTx != nil:
+		err = client.SubmitMultiSignedTx(context.Background(), multiSigTx)
+	default:
+		err = client.SubmitTx(context.Background(), tx)
+	}
+	if err != nil {
 		logger.Error("failed to submit transaction",
 			"err", err,
 		)
@@ -140,8 +157,75 @@ func doShowTx(cmd *cobra.Command, args []string) {
 	ctx = context.WithValue(ctx, prettyprint.ContextKeyTokenValueExponent, genesis.Staking.TokenValueExponent)
 	ctx = context.WithValue(ctx, prettyprint.ContextKeyGenesisHash, genesis.Hash())
 
-	sigTx := loadTx()
-	sigTx.PrettyPrint(ctx, "", os.Stdout)
+	if txHash == "" {
+		switch sigTx, multiSigTx := loadTx(); {
+		case multiSigTx != nil:
+			multiSigTx.PrettyPrint(ctx, "", os.Stdout)
+		default:
+			sigTx.PrettyPrint(ctx, "", os.Stdout)
+		}
+		return
+	}
+
+	var h hash.Hash
+	if err := h.UnmarshalHex(txHash); err != nil {
+		logger.Error("failed to parse transaction hash",
+			"err", err,
+			"hash", txHash,
+		)
+		os.Exit(1)
+	}
+
+	conn, client := doConnect(cmd)
+	defer conn.Close()
+
+	txWithResult, err := client.GetTransactionByHash(ctx, h)
+	if err != nil {
+		logger.Error("failed to get transaction",
+			"err", err,
+			"hash", h,
+		)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Height: %d\n", txWithResult.Height)
+	fmt.Printf("Index: %d\n", txWithResult.Index)
+
+	var (
+		sigTx      transaction.SignedTransaction
+		multiSigTx transaction.MultiSignedTransaction
+	)
+	switch {
+	case cbor.Unmarshal(txWithResult.Transaction, &sigTx) == nil:
+		sigTx.PrettyPrint(ctx, "", os.Stdout)
+	case cbor.Unmarshal(txWithResult.Transaction, &multiSigTx) == nil:
+		multiSigTx.PrettyPrint(ctx, "", os.Stdout)
+	default:
+		logger.Error("failed to parse transaction")
+		os.Exit(1)
+	}
+
+	fmt.Println("Result:")
+	if txWithResult.Result.IsSuccess() {
+		fmt.Println("  Status: success")
+	} else {
+		fmt.Println("  Status: failed")
+		fmt.Printf("  Error: module: %s code: %d message: %s\n",
+			txWithResult.Result.Error.Module,
+			txWithResult.Result.Error.Code,
+			txWithResult.Result.Error.Message,
+		)
+	}
+	if len(txWithResult.Result.Events) > 0 {
+		events, mErr := json.MarshalIndent(txWithResult.Result.Events, "  ", "  ")
+		if mErr != nil {
+			logger.Error("failed to marshal events",
+				"err", mErr,
+			)
+			os.Exit(1)
+		}
+		fmt.Printf("  Events: %s\n", events)
+	}
 }
 
 func doEstimateGas(cmd *cobra.Command, args []string) {
@@ -185,8 +269,10 @@ func Register(parentCmd *cobra.Command) {
 	submitTxCmd.Flags().AddFlagSet(cmdConsensus.TxFileFlags)
 	submitTxCmd.Flags().AddFlagSet(cmdGrpc.ClientFlags)
 
+	showTxCmd.Flags().StringVar(&txHash, CfgTxHash, "", "hash of the transaction to look up on the node, in hex")
 	showTxCmd.Flags().AddFlagSet(cmdConsensus.TxFileFlags)
 	showTxCmd.Flags().AddFlagSet(cmdFlags.GenesisFileFlags)
+	showTxCmd.Flags().AddFlagSet(cmdGrpc.ClientFlags)
 
 	estimateGasCmd.Flags().StringVar(&signerPub, CfgSignerPub, "", "public key of the signer, in base64")
 	estimateGasCmd.Flags().AddFlagSet(cmdConsensus.TxFileFlags)
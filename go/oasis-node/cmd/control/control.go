@@ -242,5 +242,11 @@ func Register(parentCmd *cobra.Command) {
 	controlCmd.AddCommand(controlUpgradeBinaryCmd)
 	controlCmd.AddCommand(controlCancelUpgradeCmd)
 	controlCmd.AddCommand(controlStatusCmd)
+
+	controlTxPoolCmd.AddCommand(controlTxPoolListCmd)
+	controlTxPoolCmd.AddCommand(controlTxPoolGetCmd)
+	controlTxPoolCmd.AddCommand(controlTxPoolRemoveCmd)
+	controlCmd.AddCommand(controlTxPoolCmd)
+	controlCmd.AddCommand(controlStorageUsageCmd)
 	parentCmd.AddCommand(controlCmd)
 }
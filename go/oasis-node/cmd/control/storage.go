@@ -0,0 +1,45 @@
+package control
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	storageWorker "github.com/oasisprotocol/oasis-core/go/worker/storage/api"
+)
+
+var controlStorageUsageCmd = &cobra.Command{
+	Use:   "storage-usage <runtime-id>",
+	Short: "show runtime node database disk usage",
+	Args:  cobra.ExactArgs(1),
+	Run:   doStorageUsage,
+}
+
+func doStorageUsage(cmd *cobra.Command, args []string) {
+	runtimeID := parseRuntimeID(args[0])
+
+	conn, _ := DoConnect(cmd)
+	defer conn.Close()
+
+	client := storageWorker.NewStorageWorkerClient(conn)
+	usage, err := client.GetStorageUsage(context.Background(), &storageWorker.GetStorageUsageRequest{
+		RuntimeID: runtimeID,
+	})
+	if err != nil {
+		logger.Error("failed to query storage usage",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	formatted, err := json.MarshalIndent(usage, "", "  ")
+	if err != nil {
+		logger.Error("failed to format storage usage",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	fmt.Println(string(formatted))
+}
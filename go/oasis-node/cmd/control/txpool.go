@@ -0,0 +1,154 @@
+package control
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	control "github.com/oasisprotocol/oasis-core/go/control/api"
+)
+
+var (
+	controlTxPoolCmd = &cobra.Command{
+		Use:   "txpool",
+		Short: "runtime transaction pool introspection",
+	}
+
+	controlTxPoolListCmd = &cobra.Command{
+		Use:   "list <runtime-id>",
+		Short: "list transactions queued in the runtime transaction pool",
+		Args:  cobra.ExactArgs(1),
+		Run:   doTxPoolList,
+	}
+
+	controlTxPoolGetCmd = &cobra.Command{
+		Use:   "get <runtime-id> <tx-hash>",
+		Short: "show a transaction queued in the runtime transaction pool",
+		Args:  cobra.ExactArgs(2),
+		Run:   doTxPoolGet,
+	}
+
+	controlTxPoolRemoveCmd = &cobra.Command{
+		Use:   "remove <runtime-id> <tx-hash>...",
+		Short: "remove transactions from the runtime transaction pool",
+		Args:  cobra.MinimumNArgs(2),
+		Run:   doTxPoolRemove,
+	}
+)
+
+func parseRuntimeID(raw string) common.Namespace {
+	var runtimeID common.Namespace
+	if err := runtimeID.UnmarshalHex(raw); err != nil {
+		logger.Error("malformed runtime identifier",
+			"err", err,
+			"runtime_id", raw,
+		)
+		os.Exit(1)
+	}
+	return runtimeID
+}
+
+func parseTxHashes(raw []string) []hash.Hash {
+	hashes := make([]hash.Hash, 0, len(raw))
+	for _, v := range raw {
+		var h hash.Hash
+		if err := h.UnmarshalHex(v); err != nil {
+			logger.Error("malformed transaction hash",
+				"err", err,
+				"tx_hash", v,
+			)
+			os.Exit(1)
+		}
+		hashes = append(hashes, h)
+	}
+	return hashes
+}
+
+func doTxPoolList(cmd *cobra.Command, args []string) {
+	runtimeID := parseRuntimeID(args[0])
+
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	txs, err := client.GetTxPoolTransactions(context.Background(), runtimeID)
+	if err != nil {
+		logger.Error("failed to query transaction pool",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	writeTxPoolTransactions(os.Stdout, txs, time.Now())
+}
+
+// writeTxPoolTransactions writes a table of the given transaction pool transactions.
+func writeTxPoolTransactions(w io.Writer, txs []*control.TxPoolTransaction, now time.Time) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "HASH\tSIZE\tAGE")
+	for _, tx := range txs {
+		fmt.Fprintf(tw, "%s\t%d\t%s\n", tx.Hash, tx.Size, now.Sub(tx.QueuedAt).Truncate(time.Second))
+	}
+	tw.Flush()
+}
+
+func doTxPoolGet(cmd *cobra.Command, args []string) {
+	runtimeID := parseRuntimeID(args[0])
+	txHash := parseTxHashes(args[1:])[0]
+
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	tx, err := client.GetTxPoolTransaction(context.Background(), &control.TxPoolTransactionQuery{
+		RuntimeID: runtimeID,
+		Hash:      txHash,
+	})
+	if err != nil {
+		logger.Error("failed to query transaction",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	if err = writeTxPoolTransaction(os.Stdout, tx); err != nil {
+		logger.Error("failed to format transaction",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+}
+
+// writeTxPoolTransaction writes the given transaction pool transaction as JSON.
+func writeTxPoolTransaction(w io.Writer, tx *control.TxPoolTransaction) error {
+	formatted, err := json.MarshalIndent(tx, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(formatted))
+	return err
+}
+
+func doTxPoolRemove(cmd *cobra.Command, args []string) {
+	runtimeID := parseRuntimeID(args[0])
+	hashes := parseTxHashes(args[1:])
+
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	err := client.RemoveTxPoolTransactions(context.Background(), &control.RemoveTxPoolTransactionsRequest{
+		RuntimeID: runtimeID,
+		Hashes:    hashes,
+	})
+	if err != nil {
+		logger.Error("failed to remove transactions",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,61 @@
+package control
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	controlAPI "github.com/oasisprotocol/oasis-core/go/control/api"
+)
+
+func TestTxPoolCmd(t *testing.T) {
+	require := require.New(t)
+
+	queuedAt := time.Unix(1580461674, 0)
+	now := queuedAt.Add(90*time.Second + 500*time.Millisecond)
+
+	txHash1 := hash.NewFromBytes([]byte("tx 1"))
+	txHash2 := hash.NewFromBytes([]byte("tx 2"))
+	txs := []*controlAPI.TxPoolTransaction{
+		{Hash: txHash1, Size: 4, QueuedAt: queuedAt},
+		{Hash: txHash2, Size: 4, QueuedAt: queuedAt},
+	}
+
+	// List all queued transactions.
+	var out bytes.Buffer
+	writeTxPoolTransactions(&out, txs, now)
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(lines, 3, "list should output a header and all queued transactions")
+	require.Equal([]string{"HASH", "SIZE", "AGE"}, strings.Fields(lines[0]), "list should output a header")
+	var listed []string
+	for _, line := range lines[1:] {
+		fields := strings.Fields(line)
+		require.Equal([]string{fields[0], "4", "1m30s"}, fields, "list should output hash, size and age")
+		listed = append(listed, fields[0])
+	}
+	require.Equal([]string{txHash1.String(), txHash2.String()}, listed, "list should output all queued transactions")
+
+	// Show a queued transaction.
+	out.Reset()
+	err := writeTxPoolTransaction(&out, &controlAPI.TxPoolTransaction{
+		Hash:     txHash2,
+		Data:     []byte("tx 2"),
+		Size:     4,
+		QueuedAt: queuedAt,
+	})
+	require.NoError(err, "writeTxPoolTransaction")
+	var decoded controlAPI.TxPoolTransaction
+	err = json.Unmarshal(out.Bytes(), &decoded)
+	require.NoError(err, "get should output a JSON transaction")
+	require.EqualValues(txHash2, decoded.Hash, "get should output the transaction hash")
+	require.EqualValues([]byte("tx 2"), decoded.Data, "get should output the transaction data")
+
+	// Parse transaction hashes to remove.
+	hashes := parseTxHashes([]string{txHash1.String(), txHash2.String()})
+	require.Equal([]hash.Hash{txHash1, txHash2}, hashes, "remove should parse all transaction hashes")
+}
@@ -101,6 +101,14 @@ func (w *storageWorker) SyncIterate(ctx context.Context, request *syncer.Iterate
 	return w.backend.SyncIterate(ctx, request)
 }
 
+func (w *storageWorker) SyncGetRange(ctx context.Context, request *syncer.GetRangeRequest) (*syncer.ProofResponse, error) {
+	if w.failReadRequests {
+		return nil, errByzantine
+	}
+
+	return w.backend.SyncGetRange(ctx, request)
+}
+
 func (w *storageWorker) Apply(ctx context.Context, request *storage.ApplyRequest) ([]*storage.Receipt, error) {
 	w.Lock()
 	defer w.Unlock()
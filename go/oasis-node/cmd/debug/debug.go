@@ -10,6 +10,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/dumpdb"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/fixgenesis"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/storage"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/tagindexer"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/txsource"
 )
 
@@ -27,6 +28,7 @@ func Register(parentCmd *cobra.Command) {
 	control.Register(debugCmd)
 	consim.Register(debugCmd)
 	dumpdb.Register(debugCmd)
+	tagindexer.Register(debugCmd)
 
 	parentCmd.AddCommand(debugCmd)
 }
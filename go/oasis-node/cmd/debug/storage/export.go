@@ -87,7 +87,7 @@ func exportRuntime(dataDir, destDir string, id common.Namespace, rtg *roothash.G
 	dataDir = filepath.Join(dataDir, runtimeRegistry.RuntimesDir, id.String())
 
 	// Initialize the storage backend.
-	storageBackend, err := newDirectStorageBackend(dataDir, id)
+	storageBackend, err := NewDirectStorageBackend(dataDir, id)
 	if err != nil {
 		logger.Error("failed to construct storage backend",
 			"err", err,
@@ -156,7 +156,9 @@ func exportIterator(fn string, root *storageAPI.Root, it mkvs.Iterator) error {
 	return nil
 }
 
-func newDirectStorageBackend(dataDir string, namespace common.Namespace) (storageAPI.Backend, error) {
+// NewDirectStorageBackend creates a storage backend operating directly on the local storage
+// database of a runtime, which must not be in use by a running node.
+func NewDirectStorageBackend(dataDir string, namespace common.Namespace) (storageAPI.Backend, error) {
 	// The right thing to do will be to use storage.New, but the backend config
 	// assumes that identity is valid, and we don't have one.
 	cfg := &storageAPI.Config{
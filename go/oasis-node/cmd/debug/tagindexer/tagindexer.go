@@ -0,0 +1,149 @@
+// Package tagindexer implements the tag indexer debug sub-commands.
+package tagindexer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	cmdStorage "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/storage"
+	"github.com/oasisprotocol/oasis-core/go/runtime/history"
+	runtimeRegistry "github.com/oasisprotocol/oasis-core/go/runtime/registry"
+	"github.com/oasisprotocol/oasis-core/go/runtime/tagindexer"
+	"github.com/oasisprotocol/oasis-core/go/worker/storage"
+)
+
+const (
+	cfgReindexBackend    = "tagindexer.reindex.backend"
+	cfgReindexStartRound = "tagindexer.reindex.start_round"
+)
+
+var (
+	tagIndexerCmd = &cobra.Command{
+		Use:   "tagindexer",
+		Short: "runtime tag indexer utilities",
+	}
+
+	tagIndexerReindexCmd = &cobra.Command{
+		Use:   "reindex <runtime-id>",
+		Short: "rebuild the runtime tag index from local runtime history and storage",
+		Long: "Rebuild the runtime tag index from local runtime history and storage.\n\n" +
+			"The node must not be running while the index is being rebuilt.",
+		Args: cobra.ExactArgs(1),
+		Run:  doReindex,
+	}
+
+	tagIndexerReindexFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
+	logger = logging.GetLogger("cmd/debug/tagindexer")
+)
+
+func newBackendFactory(name string) (tagindexer.BackendFactory, error) {
+	switch strings.ToLower(name) {
+	case tagindexer.BleveBackendName:
+		return tagindexer.NewBleveBackend(), nil
+	case tagindexer.SQLiteBackendName:
+		return tagindexer.NewSQLiteBackend(), nil
+	default:
+		return nil, fmt.Errorf("unsupported tag indexer backend: %s", name)
+	}
+}
+
+func doReindex(cmd *cobra.Command, args []string) {
+	var ok bool
+	defer func() {
+		if !ok {
+			os.Exit(1)
+		}
+	}()
+
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	dataDir := cmdCommon.DataDir()
+	if dataDir == "" {
+		logger.Error("data directory must be set")
+		return
+	}
+
+	var runtimeID common.Namespace
+	if err := runtimeID.UnmarshalHex(args[0]); err != nil {
+		logger.Error("malformed runtime identifier",
+			"err", err,
+			"runtime_id", args[0],
+		)
+		return
+	}
+
+	backendFactory, err := newBackendFactory(viper.GetString(cfgReindexBackend))
+	if err != nil {
+		logger.Error("failed to configure tag indexer backend",
+			"err", err,
+		)
+		return
+	}
+
+	rtDataDir := runtimeRegistry.GetRuntimeStateDir(dataDir, runtimeID)
+
+	hist, err := history.New(rtDataDir, runtimeID, nil)
+	if err != nil {
+		logger.Error("failed to open runtime history",
+			"err", err,
+		)
+		return
+	}
+	defer hist.Close()
+
+	storageBackend, err := cmdStorage.NewDirectStorageBackend(rtDataDir, runtimeID)
+	if err != nil {
+		logger.Error("failed to construct storage backend",
+			"err", err,
+		)
+		return
+	}
+	<-storageBackend.Initialized()
+	defer storageBackend.Cleanup()
+
+	backend, err := backendFactory(rtDataDir, runtimeID)
+	if err != nil {
+		logger.Error("failed to open tag indexer backend",
+			"err", err,
+		)
+		return
+	}
+	defer backend.Close()
+
+	startRound := viper.GetUint64(cfgReindexStartRound)
+	if err = tagindexer.Reindex(context.Background(), backend, hist, storageBackend, startRound); err != nil {
+		logger.Error("failed to reindex",
+			"err", err,
+		)
+		return
+	}
+
+	ok = true
+}
+
+// Register registers the tagindexer sub-command and all of it's children.
+func Register(parentCmd *cobra.Command) {
+	tagIndexerReindexCmd.Flags().AddFlagSet(storage.Flags)
+	tagIndexerReindexCmd.Flags().AddFlagSet(tagIndexerReindexFlags)
+
+	tagIndexerCmd.AddCommand(tagIndexerReindexCmd)
+	parentCmd.AddCommand(tagIndexerCmd)
+}
+
+func init() {
+	tagIndexerReindexFlags.String(cfgReindexBackend, tagindexer.SQLiteBackendName, "tag indexer backend to (re)build the index in")
+	tagIndexerReindexFlags.Uint64(cfgReindexStartRound, 0, "first round to reindex")
+	_ = viper.BindPFlags(tagIndexerReindexFlags)
+}
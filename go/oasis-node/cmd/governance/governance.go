@@ -13,6 +13,8 @@ import (
 	"github.com/spf13/viper"
 	"google.golang.org/grpc"
 
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
@@ -22,6 +24,9 @@ import (
 	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
 	cmdGrpc "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/grpc"
 	cmdSigner "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/signer"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 )
 
@@ -29,6 +34,9 @@ const (
 	cfgProposalCancelUpgradeID   = "proposal.cancel_upgrade.id"
 	cfgProposalUpgradeDescriptor = "proposal.upgrade.descriptor"
 
+	cfgProposalChangeParametersModule  = "proposal.change_parameters.module"
+	cfgProposalChangeParametersChanges = "proposal.change_parameters.changes"
+
 	cfgVote           = "vote"
 	cfgVoteProposalID = "vote.proposal.id"
 
@@ -80,8 +88,22 @@ var (
 	}
 
 	logger = logging.GetLogger("cmd/governance")
+
+	// parameterChangesModules are the consensus modules that support consensus parameter changes
+	// together with a constructor for their parameter changes.
+	parameterChangesModules = map[string]func() consensusParameterChanges{
+		beacon.ModuleName:    func() consensusParameterChanges { return &beacon.ConsensusParameterChanges{} },
+		registry.ModuleName:  func() consensusParameterChanges { return &registry.ConsensusParameterChanges{} },
+		scheduler.ModuleName: func() consensusParameterChanges { return &scheduler.ConsensusParameterChanges{} },
+		staking.ModuleName:   func() consensusParameterChanges { return &staking.ConsensusParameterChanges{} },
+	}
 )
 
+type consensusParameterChanges interface {
+	// SanityCheck performs a sanity check on the consensus parameter changes.
+	SanityCheck() error
+}
+
 func doConnect(cmd *cobra.Command) (*grpc.ClientConn, governance.Backend) {
 	conn, err := cmdGrpc.NewClient(cmd)
 	if err != nil {
@@ -142,9 +164,49 @@ func doGenSubmitProposal(cmd *cobra.Command, args []string) {
 				ProposalID: viper.GetUint64(cfgProposalCancelUpgradeID),
 			},
 		})
+	// Consensus parameter changes.
+	case viper.GetString(cfgProposalChangeParametersModule) != "":
+		module := viper.GetString(cfgProposalChangeParametersModule)
+		newChanges, ok := parameterChangesModules[module]
+		if !ok {
+			logger.Error("consensus parameter changes are not supported for module",
+				"module", module,
+			)
+			os.Exit(1)
+		}
+
+		changesBytes, err := ioutil.ReadFile(viper.GetString(cfgProposalChangeParametersChanges))
+		if err != nil {
+			logger.Error("failed to read consensus parameter changes",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+
+		changes := newChanges()
+		if err = json.Unmarshal(changesBytes, changes); err != nil {
+			logger.Error("can't parse consensus parameter changes",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+		if err = changes.SanityCheck(); err != nil {
+			logger.Error("submitted consensus parameter changes are not valid",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+
+		proposal := &governance.ChangeParametersProposal{
+			Module:  module,
+			Changes: cbor.Marshal(changes),
+		}
+		tx = governance.NewSubmitProposalTx(nonce, fee, &governance.ProposalContent{
+			ChangeParameters: proposal,
+		})
 	default:
-		logger.Error(fmt.Sprintf("missing required arguments: either '%v' or '%v' required",
-			cfgProposalUpgradeDescriptor, cfgProposalCancelUpgradeID,
+		logger.Error(fmt.Sprintf("missing required arguments: either '%v', '%v' or '%v' required",
+			cfgProposalUpgradeDescriptor, cfgProposalCancelUpgradeID, cfgProposalChangeParametersModule,
 		))
 		os.Exit(1)
 	}
@@ -293,6 +355,8 @@ func init() {
 
 	submitProposalFlags.String(cfgProposalUpgradeDescriptor, "", "Path to the proposal upgrade descriptor")
 	submitProposalFlags.Uint64(cfgProposalCancelUpgradeID, 0, "Cancel upgrade proposal ID")
+	submitProposalFlags.String(cfgProposalChangeParametersModule, "", "Consensus module whose parameters should be changed")
+	submitProposalFlags.String(cfgProposalChangeParametersChanges, "", "Path to the JSON-encoded consensus parameter changes")
 	_ = viper.BindPFlags(submitProposalFlags)
 	submitProposalFlags.AddFlagSet(cmdConsensus.TxFlags)
 	submitProposalFlags.AddFlagSet(cmdFlags.AssumeYesFlag)
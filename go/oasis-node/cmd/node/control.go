@@ -155,3 +155,16 @@ func (n *Node) GetRuntimeStatus(ctx context.Context) (map[common.Namespace]contr
 func (n *Node) GetPendingUpgrades(ctx context.Context) ([]*upgrade.PendingUpgrade, error) {
 	return n.Upgrader.PendingUpgrades(ctx)
 }
+
+// Implements control.ControlledNode.
+func (n *Node) GetRuntimeTxPool(runtimeID common.Namespace) (control.TxPool, error) {
+	// Only executor nodes maintain a transaction pool.
+	if n.ExecutorWorker == nil || !n.ExecutorWorker.Enabled() {
+		return nil, control.ErrTxPoolNotAvailable
+	}
+	rtNode := n.ExecutorWorker.GetRuntime(runtimeID)
+	if rtNode == nil {
+		return nil, control.ErrTxPoolNotAvailable
+	}
+	return rtNode, nil
+}
@@ -56,6 +56,7 @@ const (
 	CfgExecutorRoundTimeout      = "runtime.executor.round_timeout"
 	CfgExecutorMaxMessages       = "runtime.executor.max_messages"
 	CfgExecutorMinPoolSize       = "runtime.executor.min_pool_size"
+	CfgExecutorElectionAlgorithm = "runtime.executor.election_algorithm"
 
 	// Storage committee flags.
 	CfgStorageGroupSize               = "runtime.storage.group_size"
@@ -66,6 +67,7 @@ const (
 	CfgStorageCheckpointNumKept       = "runtime.storage.checkpoint_num_kept"
 	CfgStorageCheckpointChunkSize     = "runtime.storage.checkpoint_chunk_size"
 	CfgStorageMinPoolSize             = "runtime.storage.min_pool_size"
+	CfgStorageElectionAlgorithm       = "runtime.storage.election_algorithm"
 
 	// Transaction scheduler flags.
 	CfgTxnSchedulerAlgorithm         = "runtime.txn_scheduler.algorithm"
@@ -75,10 +77,14 @@ const (
 	CfgTxnSchedulerProposerTimeout   = "runtime.txn_scheduler.proposer_timeout"
 
 	// Admission policy flags.
-	CfgAdmissionPolicy                 = "runtime.admission_policy"
-	CfgAdmissionPolicyEntityWhitelist  = "runtime.admission_policy_entity_whitelist"
-	AdmissionPolicyNameAnyNode         = "any-node"
-	AdmissionPolicyNameEntityWhitelist = "entity-whitelist"
+	CfgAdmissionPolicy                      = "runtime.admission_policy"
+	CfgAdmissionPolicyEntityWhitelist       = "runtime.admission_policy_entity_whitelist"
+	CfgAdmissionPolicyEntityStakeThreshold  = "runtime.admission_policy_entity_stake_threshold"
+	CfgAdmissionPolicyNodeWhitelist         = "runtime.admission_policy_node_whitelist"
+	AdmissionPolicyNameAnyNode              = "any-node"
+	AdmissionPolicyNameEntityWhitelist      = "entity-whitelist"
+	AdmissionPolicyNameEntityStakeThreshold = "entity-stake-threshold"
+	AdmissionPolicyNameNodeWhitelist        = "node-whitelist"
 
 	// Staking parameters flags.
 	CfgStakingThreshold = "runtime.staking.threshold"
@@ -371,6 +377,7 @@ func runtimeFromFlags() (*registry.Runtime, error) { // nolint: gocyclo
 			RoundTimeout:      viper.GetInt64(CfgExecutorRoundTimeout),
 			MaxMessages:       viper.GetUint32(CfgExecutorMaxMessages),
 			MinPoolSize:       viper.GetUint64(CfgExecutorMinPoolSize),
+			ElectionAlgorithm: viper.GetString(CfgExecutorElectionAlgorithm),
 		},
 		TxnScheduler: registry.TxnSchedulerParameters{
 			Algorithm:         viper.GetString(CfgTxnSchedulerAlgorithm),
@@ -388,6 +395,7 @@ func runtimeFromFlags() (*registry.Runtime, error) { // nolint: gocyclo
 			CheckpointNumKept:       viper.GetUint64(CfgStorageCheckpointNumKept),
 			CheckpointChunkSize:     uint64(viper.GetSizeInBytes(CfgStorageCheckpointChunkSize)),
 			MinPoolSize:             viper.GetUint64(CfgStorageMinPoolSize),
+			ElectionAlgorithm:       viper.GetString(CfgStorageElectionAlgorithm),
 		},
 		GovernanceModel: govModel,
 	}
@@ -429,6 +437,34 @@ func runtimeFromFlags() (*registry.Runtime, error) { // nolint: gocyclo
 		rt.AdmissionPolicy.EntityWhitelist = &registry.EntityWhitelistRuntimeAdmissionPolicy{
 			Entities: entities,
 		}
+	case AdmissionPolicyNameEntityStakeThreshold:
+		var minEscrow quantity.Quantity
+		if err = minEscrow.UnmarshalText([]byte(viper.GetString(CfgAdmissionPolicyEntityStakeThreshold))); err != nil {
+			logger.Error("failed to parse minimum escrow",
+				"err", err,
+				CfgAdmissionPolicyEntityStakeThreshold, viper.GetString(CfgAdmissionPolicyEntityStakeThreshold),
+			)
+			return nil, fmt.Errorf("entity stake threshold runtime admission policy parse minimum escrow: %w", err)
+		}
+		rt.AdmissionPolicy.EntityStakeThreshold = &registry.EntityStakeThresholdRuntimeAdmissionPolicy{
+			MinEscrow: minEscrow,
+		}
+	case AdmissionPolicyNameNodeWhitelist:
+		nodes := make(map[signature.PublicKey]bool)
+		for _, sn := range viper.GetStringSlice(CfgAdmissionPolicyNodeWhitelist) {
+			var n signature.PublicKey
+			if err = n.UnmarshalText([]byte(sn)); err != nil {
+				logger.Error("failed to parse node ID",
+					"err", err,
+					CfgAdmissionPolicyNodeWhitelist, sn,
+				)
+				return nil, fmt.Errorf("node whitelist runtime admission policy parse node ID: %w", err)
+			}
+			nodes[n] = true
+		}
+		rt.AdmissionPolicy.NodeWhitelist = &registry.NodeWhitelistRuntimeAdmissionPolicy{
+			Nodes: nodes,
+		}
 	default:
 		logger.Error("invalid runtime admission policy",
 			CfgAdmissionPolicy, sap,
@@ -539,9 +575,10 @@ func init() {
 	runtimeFlags.Int64(CfgExecutorRoundTimeout, 5, "Executor committee round timeout for this runtime (in consensus blocks)")
 	runtimeFlags.Uint32(CfgExecutorMaxMessages, 32, "Maximum number of runtime messages that can be emitted in a round")
 	runtimeFlags.Uint64(CfgExecutorMinPoolSize, 1, "Minimum required candidate compute node pool size (should be >= GroupSize+GroupBackupSize)")
+	runtimeFlags.String(CfgExecutorElectionAlgorithm, registry.ElectionAlgorithmUniform, "Executor committee election algorithm (uniform, stake_weighted)")
 
 	// Init Transaction scheduler flags.
-	runtimeFlags.String(CfgTxnSchedulerAlgorithm, registry.TxnSchedulerSimple, "Transaction scheduling algorithm")
+	runtimeFlags.String(CfgTxnSchedulerAlgorithm, registry.TxnSchedulerSimple, "Transaction scheduling algorithm (simple, priority)")
 	runtimeFlags.Duration(CfgTxnSchedulerBatchFlushTimeout, 1*time.Second, "Maximum amount of time to wait for a scheduled batch")
 	runtimeFlags.Uint64(CfgTxnSchedulerMaxBatchSize, 1000, "Maximum size of a batch of runtime requests")
 	runtimeFlags.String(CfgTxnSchedulerMaxBatchSizeBytes, "16mb", "Maximum size (in bytes) of a batch of runtime requests")
@@ -556,10 +593,13 @@ func init() {
 	runtimeFlags.Uint64(CfgStorageCheckpointNumKept, 2, "Number of storage checkpoints to keep")
 	runtimeFlags.String(CfgStorageCheckpointChunkSize, "8mb", "Storage checkpoint chunk size")
 	runtimeFlags.Uint64(CfgStorageMinPoolSize, 1, "Minimum required candidate storage node pool size (should be >= GroupSize)")
+	runtimeFlags.String(CfgStorageElectionAlgorithm, registry.ElectionAlgorithmUniform, "Storage committee election algorithm (uniform, stake_weighted)")
 
 	// Init Admission policy flags.
 	runtimeFlags.String(CfgAdmissionPolicy, "", "What type of node admission policy to have")
 	runtimeFlags.StringSlice(CfgAdmissionPolicyEntityWhitelist, nil, "For entity whitelist node admission policies, the IDs (hex) of the entities in the whitelist")
+	runtimeFlags.String(CfgAdmissionPolicyEntityStakeThreshold, "0", "For entity stake threshold node admission policies, the minimum escrow balance of the node's entity")
+	runtimeFlags.StringSlice(CfgAdmissionPolicyNodeWhitelist, nil, "For node whitelist node admission policies, the IDs (hex) of the nodes in the whitelist")
 
 	// Init Staking flags.
 	runtimeFlags.StringToString(CfgStakingThreshold, nil, "Additional staking threshold for this runtime (<kind>=<value>)")
@@ -2,7 +2,9 @@ package stake
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"math/big"
 	"os"
 
@@ -10,7 +12,10 @@ import (
 	flag "github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/prettyprint"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	genesisAPI "github.com/oasisprotocol/oasis-core/go/genesis/api"
 	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
 	cmdConsensus "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/consensus"
@@ -49,6 +54,9 @@ const (
 
 	// CfgWithdrawSource configures the withdrawal source address.
 	CfgWithdrawSource = "stake.withdraw.source"
+
+	// CfgMultisigPartial configures the partially signed multisig transactions to combine.
+	CfgMultisigPartial = "stake.multisig.partial"
 )
 
 var (
@@ -61,6 +69,7 @@ var (
 	accountBurnFlags        = flag.NewFlagSet("", flag.ContinueOnError)
 	accountAllowFlags       = flag.NewFlagSet("", flag.ContinueOnError)
 	accountWithdrawFlags    = flag.NewFlagSet("", flag.ContinueOnError)
+	accountCombineFlags     = flag.NewFlagSet("", flag.ContinueOnError)
 
 	accountCmd = &cobra.Command{
 		Use:   "account",
@@ -126,6 +135,12 @@ var (
 		Short: "generate a withdraw transaction",
 		Run:   doAccountWithdraw,
 	}
+
+	accountCombineMultisigCmd = &cobra.Command{
+		Use:   "combine_multisig",
+		Short: "combine partially signed multisig transactions",
+		Run:   doAccountCombineMultisig,
+	}
 )
 
 // getCtxWithInfo returns a new context with values that contain additional
@@ -160,6 +175,17 @@ func doAccountInfo(cmd *cobra.Command, args []string) {
 	exp := getTokenValueExponent(ctx, cmd, client)
 	ctx = context.WithValue(ctx, prettyprint.ContextKeyTokenSymbol, symbol)
 	ctx = context.WithValue(ctx, prettyprint.ContextKeyTokenValueExponent, exp)
+	if acct.General.Vesting != nil {
+		// Show how much of the balance is still locked by the vesting schedule.
+		epoch, err := beacon.NewBeaconClient(conn).GetEpoch(ctx, consensus.HeightLatest)
+		if err != nil {
+			logger.Error("failed to query current epoch",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+		ctx = context.WithValue(ctx, prettyprint.ContextKeyCurrentEpoch, epoch)
+	}
 	acct.PrettyPrint(ctx, "", os.Stdout)
 }
 
@@ -456,6 +482,60 @@ func doAccountWithdraw(cmd *cobra.Command, args []string) {
 	cmdConsensus.SignAndSaveTx(getCtxWithInfo(genesis), tx, nil)
 }
 
+func loadMultiSignedTx(fn string) *transaction.MultiSignedTransaction {
+	rawTx, err := ioutil.ReadFile(fn)
+	if err != nil {
+		logger.Error("failed to read partially signed multisig transaction",
+			"err", err,
+			"file", fn,
+		)
+		os.Exit(1)
+	}
+
+	var tx transaction.MultiSignedTransaction
+	if err = json.Unmarshal(rawTx, &tx); err != nil {
+		logger.Error("failed to parse partially signed multisig transaction",
+			"err", err,
+			"file", fn,
+		)
+		os.Exit(1)
+	}
+	return &tx
+}
+
+func doAccountCombineMultisig(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	genesis := cmdConsensus.InitGenesis()
+	cmdConsensus.AssertTxFileOK()
+
+	partials := viper.GetStringSlice(CfgMultisigPartial)
+	if len(partials) == 0 {
+		logger.Error("no partially signed multisig transactions to combine")
+		os.Exit(1)
+	}
+
+	tx := loadMultiSignedTx(partials[0])
+	for _, fn := range partials[1:] {
+		if err := tx.Combine(loadMultiSignedTx(fn)); err != nil {
+			logger.Error("failed to combine partially signed multisig transactions",
+				"err", err,
+				"file", fn,
+			)
+			os.Exit(1)
+		}
+	}
+
+	tx.PrettyPrint(getCtxWithInfo(genesis), "", os.Stdout)
+	if err := tx.Descriptor.VerifySigners(tx.Signers()); err != nil {
+		fmt.Printf("\nWARNING: The combined transaction is not yet fully signed: %s\n", err)
+	}
+
+	cmdConsensus.SaveMultiSignedTx(tx)
+}
+
 func registerAccountCmd() {
 	for _, v := range []*cobra.Command{
 		accountInfoCmd,
@@ -468,6 +548,7 @@ func registerAccountCmd() {
 		accountAmendCommissionScheduleCmd,
 		accountAllowCmd,
 		accountWithdrawCmd,
+		accountCombineMultisigCmd,
 	} {
 		accountCmd.AddCommand(v)
 	}
@@ -485,6 +566,7 @@ func registerAccountCmd() {
 	accountAmendCommissionScheduleCmd.Flags().AddFlagSet(commissionScheduleFlags)
 	accountAllowCmd.Flags().AddFlagSet(accountAllowFlags)
 	accountWithdrawCmd.Flags().AddFlagSet(accountWithdrawFlags)
+	accountCombineMultisigCmd.Flags().AddFlagSet(accountCombineFlags)
 }
 
 func init() {
@@ -539,4 +621,9 @@ func init() {
 	accountWithdrawFlags.AddFlagSet(cmdConsensus.TxFlags)
 	accountWithdrawFlags.AddFlagSet(amountFlags)
 	accountWithdrawFlags.AddFlagSet(cmdFlags.AssumeYesFlag)
+
+	accountCombineFlags.StringSlice(CfgMultisigPartial, nil, "path to a partially signed multisig transaction. Multiple of this flag is allowed")
+	_ = viper.BindPFlags(accountCombineFlags)
+	accountCombineFlags.AddFlagSet(cmdConsensus.TxFileFlags)
+	accountCombineFlags.AddFlagSet(cmdFlags.GenesisFileFlags)
 }
@@ -18,6 +18,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/prettyprint"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	cmdConsensus "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/consensus"
 	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
 	cmdGrpc "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/grpc"
 	"github.com/oasisprotocol/oasis-core/go/staking/api"
@@ -55,6 +56,12 @@ var (
 
 	infoFlags           = flag.NewFlagSet("", flag.ContinueOnError)
 	listFlags           = flag.NewFlagSet("", flag.ContinueOnError)
+	multisig2AddressCmd = &cobra.Command{
+		Use:   "multisig2address",
+		Short: "convert a multisig account descriptor to an account address",
+		Run:   doMultisig2Address,
+	}
+
 	pubkey2AddressFlags = flag.NewFlagSet("", flag.ContinueOnError)
 )
 
@@ -253,6 +260,20 @@ func doPubkey2Address(cmd *cobra.Command, args []string) {
 	fmt.Printf("%v\n", api.NewAddress(pk))
 }
 
+func doMultisig2Address(cmd *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	descriptor := cmdConsensus.LoadMultisigDescriptor()
+	if descriptor == nil {
+		logger.Error("cannot convert an empty multisig descriptor")
+		os.Exit(1)
+	}
+
+	fmt.Printf("%v\n", api.NewMultisigAddress(descriptor))
+}
+
 // Register registers the stake sub-command and all of it's children.
 func Register(parentCmd *cobra.Command) {
 	registerAccountCmd()
@@ -260,6 +281,7 @@ func Register(parentCmd *cobra.Command) {
 		infoCmd,
 		listCmd,
 		pubkey2AddressCmd,
+		multisig2AddressCmd,
 		accountCmd,
 	} {
 		stakeCmd.AddCommand(v)
@@ -268,6 +290,7 @@ func Register(parentCmd *cobra.Command) {
 	infoCmd.Flags().AddFlagSet(infoFlags)
 	listCmd.Flags().AddFlagSet(listFlags)
 	pubkey2AddressCmd.Flags().AddFlagSet(pubkey2AddressFlags)
+	multisig2AddressCmd.Flags().AddFlagSet(cmdConsensus.MultisigDescriptorFlags)
 
 	parentCmd.AddCommand(stakeCmd)
 }
@@ -0,0 +1,298 @@
+package storage
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/persistent"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/history"
+	"github.com/oasisprotocol/oasis-core/go/runtime/registry"
+	storageAPI "github.com/oasisprotocol/oasis-core/go/storage/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/database"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
+	workerStorage "github.com/oasisprotocol/oasis-core/go/worker/storage"
+)
+
+const (
+	cfgCheckpointRound     = "storage.checkpoint.round"
+	cfgCheckpointIORoot    = "storage.checkpoint.io_root"
+	cfgCheckpointStateRoot = "storage.checkpoint.state_root"
+)
+
+var (
+	checkpointFlags       = flag.NewFlagSet("", flag.ContinueOnError)
+	checkpointImportFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
+	storageCheckpointCmd = &cobra.Command{
+		Use:   "checkpoint",
+		Short: "storage checkpoint archive utilities",
+	}
+
+	storageCheckpointExportCmd = &cobra.Command{
+		Use:   "export runtime-id (hex) archive",
+		Short: "export local storage checkpoints of a runtime into an archive",
+		Args:  cobra.ExactArgs(2),
+		RunE:  doCheckpointExport,
+	}
+
+	storageCheckpointImportCmd = &cobra.Command{
+		Use:   "import runtime-id (hex) archive",
+		Short: "restore the storage of a runtime from a checkpoint archive",
+		Args:  cobra.ExactArgs(2),
+		RunE:  doCheckpointImport,
+	}
+)
+
+func openLocalStorage(runtimeID common.Namespace, readOnly bool) (storageAPI.LocalBackend, error) {
+	runtimeDir, err := registry.EnsureRuntimeStateDir(cmdCommon.DataDir(), runtimeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create runtime state directory: %w", err)
+	}
+	backend := strings.ToLower(viper.GetString(workerStorage.CfgBackend))
+
+	impl, err := database.New(&storageAPI.Config{
+		Backend:      backend,
+		DB:           workerStorage.GetLocalBackendDBDir(runtimeDir, backend),
+		Namespace:    runtimeID,
+		MaxCacheSize: int64(viper.GetSizeInBytes(workerStorage.CfgMaxCacheSize)),
+		ReadOnly:     readOnly,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open storage database: %w", err)
+	}
+	return impl.(storageAPI.LocalBackend), nil
+}
+
+func doCheckpointExport(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	var runtimeID common.Namespace
+	if err := runtimeID.UnmarshalHex(args[0]); err != nil {
+		return fmt.Errorf("malformed runtime id '%v': %w", args[0], err)
+	}
+
+	localStorage, err := openLocalStorage(runtimeID, true)
+	if err != nil {
+		return err
+	}
+	defer localStorage.Cleanup()
+
+	cps, err := localStorage.GetCheckpoints(ctx, &checkpoint.GetCheckpointsRequest{
+		Version:   1,
+		Namespace: runtimeID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get checkpoints: %w", err)
+	}
+
+	// Export all checkpoints for the requested round, defaulting to the most recent one.
+	round := viper.GetUint64(cfgCheckpointRound)
+	if round == 0 {
+		for _, cp := range cps {
+			if cp.Root.Version > round {
+				round = cp.Root.Version
+			}
+		}
+	}
+	var exported []*checkpoint.Metadata
+	for _, cp := range cps {
+		if cp.Root.Version == round {
+			exported = append(exported, cp)
+		}
+	}
+	if len(exported) == 0 {
+		return fmt.Errorf("no checkpoints available for round %d", round)
+	}
+
+	f, err := os.Create(args[1])
+	if err != nil {
+		return fmt.Errorf("failed to create archive: %w", err)
+	}
+	w := bufio.NewWriter(f)
+	err = checkpoint.ExportArchive(ctx, localStorage, exported, w)
+	if err == nil {
+		err = w.Flush()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = os.Remove(args[1])
+		return fmt.Errorf("failed to export checkpoints: %w", err)
+	}
+
+	for _, cp := range exported {
+		logger.Info("exported checkpoint", "root", cp.Root, "chunks", len(cp.Chunks))
+		if pretty {
+			fmt.Printf("Exported checkpoint %v (%d chunks).\n", cp.Root, len(cp.Chunks))
+		}
+	}
+	return nil
+}
+
+// checkpointImportRoots returns the storage roots that the imported checkpoints must match.
+//
+// The archive is untrusted, so the roots are taken from the runtime block for the round in the
+// local runtime history. If the block is not available (e.g., on a new node), the roots must be
+// specified explicitly.
+func checkpointImportRoots(ctx context.Context, cmd *cobra.Command, dataDir string, runtimeID common.Namespace, round uint64) ([]storageAPI.Root, error) {
+	var expectedRoots []storageAPI.Root
+	switch ioSet, stateSet := cmd.Flags().Changed(cfgCheckpointIORoot), cmd.Flags().Changed(cfgCheckpointStateRoot); {
+	case ioSet && stateSet:
+		for _, v := range []struct {
+			cfg      string
+			rootType storageAPI.RootType
+		}{
+			{cfgCheckpointIORoot, storageAPI.RootTypeIO},
+			{cfgCheckpointStateRoot, storageAPI.RootTypeState},
+		} {
+			root := storageAPI.Root{
+				Namespace: runtimeID,
+				Version:   round,
+				Type:      v.rootType,
+			}
+			if err := root.Hash.UnmarshalHex(viper.GetString(v.cfg)); err != nil {
+				return nil, fmt.Errorf("malformed --%s: %w", v.cfg, err)
+			}
+			expectedRoots = append(expectedRoots, root)
+		}
+	case ioSet || stateSet:
+		return nil, fmt.Errorf("both --%s and --%s must be specified", cfgCheckpointIORoot, cfgCheckpointStateRoot)
+	}
+
+	runtimeDir, err := registry.EnsureRuntimeStateDir(dataDir, runtimeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create runtime state directory: %w", err)
+	}
+	history, err := history.New(runtimeDir, runtimeID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating history provider: %w", err)
+	}
+	defer history.Close()
+
+	blk, err := history.GetBlock(ctx, round)
+	switch {
+	case err == nil:
+	case errors.Is(err, roothash.ErrNotFound):
+		if expectedRoots == nil {
+			return nil, fmt.Errorf("block for round %d not found in runtime history, specify the expected roots using --%s and --%s",
+				round, cfgCheckpointIORoot, cfgCheckpointStateRoot,
+			)
+		}
+		return expectedRoots, nil
+	default:
+		return nil, fmt.Errorf("failed to get block for round %d: %w", round, err)
+	}
+
+	roots := blk.Header.StorageRoots()
+	for i := range expectedRoots {
+		if !expectedRoots[i].Equal(&roots[i]) {
+			return nil, fmt.Errorf("expected root %v does not match runtime block root %v", expectedRoots[i], roots[i])
+		}
+	}
+	return roots, nil
+}
+
+func doCheckpointImport(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	dataDir := cmdCommon.DataDir()
+
+	var runtimeID common.Namespace
+	if err := runtimeID.UnmarshalHex(args[0]); err != nil {
+		return fmt.Errorf("malformed runtime id '%v': %w", args[0], err)
+	}
+	if !cmd.Flags().Changed(cfgCheckpointRound) {
+		return fmt.Errorf("round to import must be specified using --%s", cfgCheckpointRound)
+	}
+	round := viper.GetUint64(cfgCheckpointRound)
+
+	roots, err := checkpointImportRoots(ctx, cmd, dataDir, runtimeID, round)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(args[1])
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer f.Close()
+
+	localStorage, err := openLocalStorage(runtimeID, false)
+	if err != nil {
+		return err
+	}
+	defer localStorage.Cleanup()
+
+	// Only allow importing into an empty database as otherwise the restored state could get mixed
+	// with the existing one.
+	ndb := localStorage.NodeDB()
+	latestVersion, err := ndb.GetLatestVersion(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get latest version: %w", err)
+	}
+	existingRoots, err := ndb.GetRootsForVersion(ctx, latestVersion)
+	if err != nil {
+		return fmt.Errorf("failed to get roots for version %d: %w", latestVersion, err)
+	}
+	if latestVersion != 0 || len(existingRoots) != 0 {
+		return fmt.Errorf("storage database for runtime %v is not empty", runtimeID)
+	}
+
+	if _, err = checkpoint.ImportArchive(ctx, localStorage.Checkpointer(), bufio.NewReader(f), roots); err != nil {
+		return fmt.Errorf("failed to import checkpoints: %w", err)
+	}
+	if err = ndb.Finalize(ctx, roots); err != nil {
+		// Make sure to abort the multipart insert so the restored nodes get discarded.
+		_ = ndb.AbortMultipartInsert()
+		return fmt.Errorf("failed to finalize round %d: %w", round, err)
+	}
+
+	// Make the storage worker continue syncing from the restored round.
+	commonStore, err := persistent.NewCommonStore(dataDir)
+	if err != nil {
+		return fmt.Errorf("failed to open common node store: %w", err)
+	}
+	defer commonStore.Close()
+	if err = workerStorage.SetSyncedState(commonStore, runtimeID, round, roots); err != nil {
+		return fmt.Errorf("failed to update storage worker state: %w", err)
+	}
+
+	for _, root := range roots {
+		logger.Info("imported checkpoint", "root", root)
+		if pretty {
+			fmt.Printf("Imported checkpoint %v.\n", root)
+		}
+	}
+	return nil
+}
+
+func registerCheckpointCmd(parentCmd *cobra.Command) {
+	storageCheckpointExportCmd.Flags().AddFlagSet(checkpointFlags)
+	storageCheckpointExportCmd.Flags().AddFlagSet(workerStorage.Flags)
+	storageCheckpointImportCmd.Flags().AddFlagSet(checkpointFlags)
+	storageCheckpointImportCmd.Flags().AddFlagSet(checkpointImportFlags)
+	storageCheckpointImportCmd.Flags().AddFlagSet(workerStorage.Flags)
+	storageCheckpointCmd.AddCommand(storageCheckpointExportCmd)
+	storageCheckpointCmd.AddCommand(storageCheckpointImportCmd)
+	parentCmd.AddCommand(storageCheckpointCmd)
+}
+
+func init() {
+	checkpointFlags.Uint64(cfgCheckpointRound, 0, "round to export (default: latest) or import checkpoints for")
+	_ = viper.BindPFlags(checkpointFlags)
+
+	checkpointImportFlags.String(cfgCheckpointIORoot, "", "expected I/O root of the imported round (hex, required when the block is not in runtime history)")
+	checkpointImportFlags.String(cfgCheckpointStateRoot, "", "expected state root of the imported round (hex, required when the block is not in runtime history)")
+	_ = viper.BindPFlags(checkpointImportFlags)
+}
@@ -16,8 +16,10 @@ import (
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	"github.com/oasisprotocol/oasis-core/go/runtime/history"
 	"github.com/oasisprotocol/oasis-core/go/runtime/registry"
+	"github.com/oasisprotocol/oasis-core/go/storage/database"
 	db "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/badger"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/bolt"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
 	workerStorage "github.com/oasisprotocol/oasis-core/go/worker/storage"
 )
@@ -34,6 +36,12 @@ var (
 		RunE:  doMigrate,
 	}
 
+	storageMigrateBackendCmd = &cobra.Command{
+		Use:   "migrate-backend",
+		Short: "convert badger node databases to the bolt backend",
+		RunE:  doMigrateBackend,
+	}
+
 	storageCheckCmd = &cobra.Command{
 		Use:   "check",
 		Short: "check node databases for consistency",
@@ -174,6 +182,61 @@ func doMigrate(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func doMigrateBackend(cmd *cobra.Command, args []string) error {
+	dataDir := cmdCommon.DataDir()
+	ctx := context.Background()
+
+	runtimes, err := registry.ParseRuntimeMap(viper.GetStringSlice(registry.CfgSupported))
+	if err != nil {
+		logger.Error("unable to enumerate configured runtimes", "err", err)
+		return fmt.Errorf("unable to enumerate configured runtimes: %w", err)
+	}
+
+	for rt := range runtimes {
+		if pretty {
+			fmt.Printf(" ** Converting storage database for runtime %v...\n", rt)
+		}
+		err := func() error {
+			runtimeDir := registry.GetRuntimeStateDir(dataDir, rt)
+
+			srcCfg := &db.Config{
+				DB:        workerStorage.GetLocalBackendDBDir(runtimeDir, database.BackendNameBadgerDB),
+				Namespace: rt,
+			}
+			dstCfg := &db.Config{
+				DB:        workerStorage.GetLocalBackendDBDir(runtimeDir, database.BackendNameBoltDB),
+				Namespace: rt,
+			}
+
+			display := &displayHelper{}
+
+			if err := bolt.MigrateFromBadger(ctx, srcCfg, dstCfg, display); err != nil {
+				return fmt.Errorf("node database converter returned error: %w", err)
+			}
+			logger.Info("successfully converted node database",
+				"rt", rt,
+				"db", dstCfg.DB,
+			)
+			return nil
+		}()
+		if err != nil {
+			logger.Error("error converting node database", "rt", rt, "err", err)
+			if pretty {
+				fmt.Printf("error converting node database for runtime %v: %v\n", rt, err)
+			}
+			return fmt.Errorf("error converting node database for runtime %v: %w", rt, err)
+		}
+	}
+
+	if pretty {
+		fmt.Printf("Conversion complete, set %s to %s to use the new databases.\n",
+			workerStorage.CfgBackend,
+			database.BackendNameBoltDB,
+		)
+	}
+	return nil
+}
+
 func doCheck(cmd *cobra.Command, args []string) error {
 	dataDir := cmdCommon.DataDir()
 	ctx := context.Background()
@@ -219,8 +282,11 @@ func doCheck(cmd *cobra.Command, args []string) error {
 // Register registers the client sub-command and all of its children.
 func Register(parentCmd *cobra.Command) {
 	storageMigrateCmd.Flags().AddFlagSet(registry.Flags)
+	storageMigrateBackendCmd.Flags().AddFlagSet(registry.Flags)
 	storageCheckCmd.Flags().AddFlagSet(registry.Flags)
 	storageCmd.AddCommand(storageMigrateCmd)
+	storageCmd.AddCommand(storageMigrateBackendCmd)
 	storageCmd.AddCommand(storageCheckCmd)
+	registerCheckpointCmd(storageCmd)
 	parentCmd.AddCommand(storageCmd)
 }
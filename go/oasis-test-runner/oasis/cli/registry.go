@@ -62,6 +62,7 @@ func (r *RegistryHelpers) runRegistryRuntimeSubcommand(
 			"--"+cmdRegRt.CfgExecutorRoundTimeout, strconv.FormatInt(runtime.Executor.RoundTimeout, 10),
 			"--"+cmdRegRt.CfgExecutorMaxMessages, strconv.FormatUint(uint64(runtime.Executor.MaxMessages), 10),
 			"--"+cmdRegRt.CfgExecutorMinPoolSize, strconv.FormatUint(runtime.Executor.MinPoolSize, 10),
+			"--"+cmdRegRt.CfgExecutorElectionAlgorithm, runtime.Executor.ElectionAlgorithm,
 			"--"+cmdRegRt.CfgStorageGroupSize, strconv.FormatUint(runtime.Storage.GroupSize, 10),
 			"--"+cmdRegRt.CfgStorageMinWriteReplication, strconv.FormatUint(runtime.Storage.MinWriteReplication, 10),
 			"--"+cmdRegRt.CfgStorageMaxApplyWriteLogEntries, strconv.FormatUint(runtime.Storage.MaxApplyWriteLogEntries, 10),
@@ -70,6 +71,7 @@ func (r *RegistryHelpers) runRegistryRuntimeSubcommand(
 			"--"+cmdRegRt.CfgStorageCheckpointNumKept, strconv.FormatUint(runtime.Storage.CheckpointNumKept, 10),
 			"--"+cmdRegRt.CfgStorageCheckpointChunkSize, strconv.FormatUint(runtime.Storage.CheckpointChunkSize, 10),
 			"--"+cmdRegRt.CfgStorageMinPoolSize, strconv.FormatUint(runtime.Storage.MinPoolSize, 10),
+			"--"+cmdRegRt.CfgStorageElectionAlgorithm, runtime.Storage.ElectionAlgorithm,
 			"--"+cmdRegRt.CfgTxnSchedulerAlgorithm, runtime.TxnScheduler.Algorithm,
 			"--"+cmdRegRt.CfgTxnSchedulerBatchFlushTimeout, runtime.TxnScheduler.BatchFlushTimeout.String(),
 			"--"+cmdRegRt.CfgTxnSchedulerMaxBatchSize, strconv.FormatUint(runtime.TxnScheduler.MaxBatchSize, 10),
@@ -94,6 +96,23 @@ func (r *RegistryHelpers) runRegistryRuntimeSubcommand(
 				"--"+cmdRegRt.CfgAdmissionPolicyEntityWhitelist, e.String(),
 			)
 		}
+	} else if runtime.AdmissionPolicy.EntityStakeThreshold != nil {
+		args = append(args,
+			"--"+cmdRegRt.CfgAdmissionPolicy, cmdRegRt.AdmissionPolicyNameEntityStakeThreshold,
+			"--"+cmdRegRt.CfgAdmissionPolicyEntityStakeThreshold, runtime.AdmissionPolicy.EntityStakeThreshold.MinEscrow.String(),
+		)
+	} else if runtime.AdmissionPolicy.NodeWhitelist != nil {
+		args = append(args,
+			"--"+cmdRegRt.CfgAdmissionPolicy, cmdRegRt.AdmissionPolicyNameNodeWhitelist,
+		)
+		for n, allowed := range runtime.AdmissionPolicy.NodeWhitelist.Nodes {
+			if !allowed {
+				continue
+			}
+			args = append(args,
+				"--"+cmdRegRt.CfgAdmissionPolicyNodeWhitelist, n.String(),
+			)
+		}
 	} else {
 		return fmt.Errorf("invalid admission policy")
 	}
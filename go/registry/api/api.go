@@ -1030,7 +1030,12 @@ func VerifyRuntime( // nolint: gocyclo
 	}
 
 	// Ensure there's a valid admission policy.
-	if !exactlyOneTrue(rt.AdmissionPolicy.AnyNode != nil, rt.AdmissionPolicy.EntityWhitelist != nil) {
+	if !exactlyOneTrue(
+		rt.AdmissionPolicy.AnyNode != nil,
+		rt.AdmissionPolicy.EntityWhitelist != nil,
+		rt.AdmissionPolicy.EntityStakeThreshold != nil,
+		rt.AdmissionPolicy.NodeWhitelist != nil,
+	) {
 		logger.Error("RegisterRuntime: invalid admission policy. exactly one policy should be non-nil",
 			"admission_policy", rt.AdmissionPolicy,
 		)
@@ -1068,6 +1073,24 @@ func VerifyRuntime( // nolint: gocyclo
 		}
 	}
 
+	// Ensure valid stake threshold if present.
+	if rt.AdmissionPolicy.EntityStakeThreshold != nil && !rt.AdmissionPolicy.EntityStakeThreshold.MinEscrow.IsValid() {
+		logger.Error("RegisterRuntime: invalid minimum escrow in entity stake threshold")
+		return fmt.Errorf("%w: invalid minimum escrow in entity stake threshold", ErrInvalidArgument)
+	}
+
+	// Ensure valid node whitelist if present.
+	if rt.AdmissionPolicy.NodeWhitelist != nil {
+		for id := range rt.AdmissionPolicy.NodeWhitelist.Nodes {
+			if !id.IsValid() {
+				logger.Error("RegisterRuntime: invalid node ID in whitelist",
+					"node_id", id,
+				)
+				return fmt.Errorf("%w: invalid node ID in node whitelist", ErrInvalidArgument)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -1234,6 +1257,56 @@ type ConsensusParameters struct {
 	EnableRuntimeGovernanceModels map[RuntimeGovernanceModel]bool `json:"enable_runtime_governance_models,omitempty"`
 }
 
+// ConsensusParameterChanges are allowed registry consensus parameter changes.
+type ConsensusParameterChanges struct {
+	// DisableRuntimeRegistration is the new disable runtime registration flag.
+	DisableRuntimeRegistration *bool `json:"disable_runtime_registration,omitempty"`
+
+	// DisableKeyManagerRuntimeRegistration the new disable key manager runtime registration flag.
+	DisableKeyManagerRuntimeRegistration *bool `json:"disable_km_runtime_registration,omitempty"`
+
+	// GasCosts are the new gas costs.
+	GasCosts transaction.Costs `json:"gas_costs,omitempty"`
+
+	// MaxNodeExpiration is the maximum node expiration.
+	MaxNodeExpiration *uint64 `json:"max_node_expiration,omitempty"`
+
+	// EnableRuntimeGovernanceModels are the new enabled runtime governance models.
+	EnableRuntimeGovernanceModels map[RuntimeGovernanceModel]bool `json:"enable_runtime_governance_models,omitempty"`
+}
+
+// SanityCheck performs a sanity check on the consensus parameter changes.
+func (c *ConsensusParameterChanges) SanityCheck() error {
+	if c.DisableRuntimeRegistration == nil &&
+		c.DisableKeyManagerRuntimeRegistration == nil &&
+		c.GasCosts == nil &&
+		c.MaxNodeExpiration == nil &&
+		c.EnableRuntimeGovernanceModels == nil {
+		return fmt.Errorf("consensus parameter changes should not be empty")
+	}
+	return nil
+}
+
+// Apply applies changes to the given consensus parameters.
+func (c *ConsensusParameterChanges) Apply(params *ConsensusParameters) error {
+	if c.DisableRuntimeRegistration != nil {
+		params.DisableRuntimeRegistration = *c.DisableRuntimeRegistration
+	}
+	if c.DisableKeyManagerRuntimeRegistration != nil {
+		params.DisableKeyManagerRuntimeRegistration = *c.DisableKeyManagerRuntimeRegistration
+	}
+	if c.GasCosts != nil {
+		params.GasCosts = c.GasCosts
+	}
+	if c.MaxNodeExpiration != nil {
+		params.MaxNodeExpiration = *c.MaxNodeExpiration
+	}
+	if c.EnableRuntimeGovernanceModels != nil {
+		params.EnableRuntimeGovernanceModels = c.EnableRuntimeGovernanceModels
+	}
+	return nil
+}
+
 const (
 	// GasOpRegisterEntity is the gas operation identifier for entity registration.
 	GasOpRegisterEntity transaction.Op = "register_entity"
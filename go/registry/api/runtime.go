@@ -15,6 +15,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
 )
@@ -48,8 +49,27 @@ const (
 
 	// TxnSchedulerSimple is the name of the simple batching algorithm.
 	TxnSchedulerSimple = "simple"
+	// TxnSchedulerPriority is the name of the batching algorithm that orders transactions by
+	// their priority.
+	TxnSchedulerPriority = "priority"
+
+	// ElectionAlgorithmUniform is the name of the committee election algorithm where all eligible
+	// nodes are equally likely to be elected. It is used when no algorithm is specified.
+	ElectionAlgorithmUniform = "uniform"
+	// ElectionAlgorithmStakeWeighted is the name of the committee election algorithm where the
+	// probability of a node being elected is proportional to its entity's escrow balance.
+	ElectionAlgorithmStakeWeighted = "stake_weighted"
 )
 
+func validateElectionAlgorithm(algorithm string) error {
+	switch algorithm {
+	case "", ElectionAlgorithmUniform, ElectionAlgorithmStakeWeighted:
+		return nil
+	default:
+		return fmt.Errorf("invalid election algorithm: %s", algorithm)
+	}
+}
+
 // String returns a string representation of a runtime kind.
 func (k RuntimeKind) String() string {
 	switch k {
@@ -98,6 +118,10 @@ type ExecutorParameters struct {
 
 	// MinPoolSize is the minimum required candidate compute node pool size.
 	MinPoolSize uint64 `json:"min_pool_size"`
+
+	// ElectionAlgorithm is the committee election algorithm. If not specified, the uniform
+	// election algorithm is used.
+	ElectionAlgorithm string `json:"election_algorithm,omitempty"`
 }
 
 // ValidateBasic performs basic executor parameter validity checks.
@@ -117,6 +141,10 @@ func (e *ExecutorParameters) ValidateBasic() error {
 		return fmt.Errorf("minimum pool size too small")
 	}
 
+	if err := validateElectionAlgorithm(e.ElectionAlgorithm); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -143,7 +171,9 @@ type TxnSchedulerParameters struct {
 // ValidateBasic performs basic transaction scheduler parameter validity checks.
 func (t *TxnSchedulerParameters) ValidateBasic() error {
 	// Ensure txnscheduler parameters have sensible values.
-	if t.Algorithm != TxnSchedulerSimple {
+	switch t.Algorithm {
+	case TxnSchedulerSimple, TxnSchedulerPriority:
+	default:
 		return fmt.Errorf("invalid transaction scheduler algorithm")
 	}
 	if t.BatchFlushTimeout < 50*time.Millisecond {
@@ -189,6 +219,10 @@ type StorageParameters struct {
 
 	// MinPoolSize is the minimum required candidate storage node pool size.
 	MinPoolSize uint64 `json:"min_pool_size"`
+
+	// ElectionAlgorithm is the committee election algorithm. If not specified, the uniform
+	// election algorithm is used.
+	ElectionAlgorithm string `json:"election_algorithm,omitempty"`
 }
 
 // ValidateBasic performs basic storage parameter validity checks.
@@ -229,6 +263,10 @@ func (s *StorageParameters) ValidateBasic() error {
 		return fmt.Errorf("minimum pool size too small")
 	}
 
+	if err := validateElectionAlgorithm(s.ElectionAlgorithm); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -249,10 +287,24 @@ type EntityWhitelistConfig struct {
 	MaxNodes map[node.RolesMask]uint16 `json:"max_nodes,omitempty"`
 }
 
+// EntityStakeThresholdRuntimeAdmissionPolicy allows only nodes of entities with enough stake to
+// register.
+type EntityStakeThresholdRuntimeAdmissionPolicy struct {
+	// MinEscrow is the minimum active escrow balance that the node's entity must have.
+	MinEscrow quantity.Quantity `json:"min_escrow"`
+}
+
+// NodeWhitelistRuntimeAdmissionPolicy allows only whitelisted nodes to register.
+type NodeWhitelistRuntimeAdmissionPolicy struct {
+	Nodes map[signature.PublicKey]bool `json:"nodes"`
+}
+
 // RuntimeAdmissionPolicy is a specification of which nodes are allowed to register for a runtime.
 type RuntimeAdmissionPolicy struct {
-	AnyNode         *AnyNodeRuntimeAdmissionPolicy         `json:"any_node,omitempty"`
-	EntityWhitelist *EntityWhitelistRuntimeAdmissionPolicy `json:"entity_whitelist,omitempty"`
+	AnyNode              *AnyNodeRuntimeAdmissionPolicy              `json:"any_node,omitempty"`
+	EntityWhitelist      *EntityWhitelistRuntimeAdmissionPolicy      `json:"entity_whitelist,omitempty"`
+	EntityStakeThreshold *EntityStakeThresholdRuntimeAdmissionPolicy `json:"entity_stake_threshold,omitempty"`
+	NodeWhitelist        *NodeWhitelistRuntimeAdmissionPolicy        `json:"node_whitelist,omitempty"`
 }
 
 // RuntimeStakingParameters are the stake-related parameters for a runtime.
@@ -274,6 +326,14 @@ type RuntimeStakingParameters struct {
 	// RewardSlashBadResultsRuntimePercent is the percentage of the reward obtained when slashing
 	// for incorrect results that is transferred to the runtime's account.
 	RewardSlashBadResultsRuntimePercent uint8 `json:"reward_bad_results,omitempty"`
+
+	// AllowEscrowMessages can be used to allow the runtime to perform AddEscrow and ReclaimEscrow
+	// staking operations via runtime messages.
+	AllowEscrowMessages bool `json:"allow_escrow_messages,omitempty"`
+
+	// MaxAllowances is the maximum number of allowances the runtime's account can have configured
+	// via Allow staking operations performed by runtime messages. Zero disables such operations.
+	MaxAllowances uint32 `json:"max_allowances,omitempty"`
 }
 
 // ValidateBasic performs basic descriptor validity checks.
@@ -305,6 +365,37 @@ func (s *RuntimeStakingParameters) ValidateBasic(runtimeKind RuntimeKind) error
 	return nil
 }
 
+// SchedulingConstraints are the node scheduling constraints.
+//
+// Multiple fields may be set in which case ALL the constraints must be satisfied.
+type SchedulingConstraints struct {
+	ValidatorSet *ValidatorSetConstraint `json:"validator_set,omitempty"`
+	MaxNodes     *MaxNodesConstraint     `json:"max_nodes,omitempty"`
+	MinPoolSize  *MinPoolSizeConstraint  `json:"min_pool_size,omitempty"`
+}
+
+// ValidateBasic performs basic scheduling constraints validity checks.
+func (sc *SchedulingConstraints) ValidateBasic() error {
+	if sc.MaxNodes != nil && sc.MaxNodes.Limit == 0 {
+		return fmt.Errorf("max nodes constraint limit must be positive")
+	}
+	return nil
+}
+
+// ValidatorSetConstraint specifies that the entity must have a node that is part of the validator
+// set. No other options can currently be specified.
+type ValidatorSetConstraint struct{}
+
+// MaxNodesConstraint specifies that only the given number of nodes may be eligible per entity.
+type MaxNodesConstraint struct {
+	Limit uint16 `json:"limit"`
+}
+
+// MinPoolSizeConstraint is the minimum required candidate pool size.
+type MinPoolSizeConstraint struct {
+	Limit uint16 `json:"limit"`
+}
+
 const (
 	// LatestRuntimeDescriptorVersion is the latest entity descriptor version that should be used
 	// for all new descriptors. Using earlier versions may be rejected.
@@ -358,6 +449,9 @@ type Runtime struct { // nolint: maligned
 	// Staking stores the runtime's staking-related parameters.
 	Staking RuntimeStakingParameters `json:"staking,omitempty"`
 
+	// Constraints are the node scheduling constraints.
+	Constraints map[scheduler.CommitteeKind]map[scheduler.Role]SchedulingConstraints `json:"constraints,omitempty"`
+
 	// GovernanceModel specifies the runtime governance model.
 	GovernanceModel RuntimeGovernanceModel `json:"governance_model"`
 }
@@ -481,6 +575,10 @@ func (r *Runtime) ValidateBasic(strictVersion bool) error {
 		return fmt.Errorf("bad staking parameters: %w", err)
 	}
 
+	if err := r.validateConstraints(); err != nil {
+		return fmt.Errorf("bad scheduling constraints: %w", err)
+	}
+
 	if r.GovernanceModel < 1 || r.GovernanceModel > GovernanceMax {
 		return fmt.Errorf("%w: out of range", ErrUnsupportedRuntimeGovernanceModel)
 	}
@@ -488,6 +586,37 @@ func (r *Runtime) ValidateBasic(strictVersion bool) error {
 	return nil
 }
 
+func (r *Runtime) validateConstraints() error {
+	for kind, roles := range r.Constraints {
+		switch kind {
+		case scheduler.KindComputeExecutor:
+		case scheduler.KindStorage:
+			if !r.IsCompute() {
+				return fmt.Errorf("storage committee constraints for non-compute runtime")
+			}
+		default:
+			return fmt.Errorf("unsupported committee kind: %s", kind)
+		}
+
+		for role, sc := range roles {
+			switch role {
+			case scheduler.RoleWorker:
+			case scheduler.RoleBackupWorker:
+				if kind != scheduler.KindComputeExecutor {
+					return fmt.Errorf("backup worker constraints for %s committee", kind)
+				}
+			default:
+				return fmt.Errorf("unsupported role for %s committee: %s", kind, role)
+			}
+
+			if err := sc.ValidateBasic(); err != nil {
+				return fmt.Errorf("%s committee %s: %w", kind, role, err)
+			}
+		}
+	}
+	return nil
+}
+
 // String returns a string representation of itself.
 func (r Runtime) String() string {
 	return "<Runtime id=" + r.ID.String() + ">"
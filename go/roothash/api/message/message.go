@@ -6,19 +6,41 @@ import (
 
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 )
 
 // Message is a message that can be sent by a runtime.
 type Message struct {
-	Staking *StakingMessage `json:"staking,omitempty"`
+	Staking    *StakingMessage    `json:"staking,omitempty"`
+	Registry   *RegistryMessage   `json:"registry,omitempty"`
+	Governance *GovernanceMessage `json:"governance,omitempty"`
 }
 
 // ValidateBasic performs basic validation of the runtime message.
 func (m *Message) ValidateBasic() error {
+	var setFields uint8
+	if m.Staking != nil {
+		setFields++
+	}
+	if m.Registry != nil {
+		setFields++
+	}
+	if m.Governance != nil {
+		setFields++
+	}
+	if setFields > 1 {
+		return fmt.Errorf("runtime message has multiple fields set")
+	}
+
 	switch {
 	case m.Staking != nil:
 		return m.Staking.ValidateBasic()
+	case m.Registry != nil:
+		return m.Registry.ValidateBasic()
+	case m.Governance != nil:
+		return m.Governance.ValidateBasic()
 	default:
 		return fmt.Errorf("runtime message has no fields set")
 	}
@@ -38,22 +60,85 @@ func MessagesHash(msgs []Message) (h hash.Hash) {
 type StakingMessage struct {
 	cbor.Versioned
 
-	Transfer *staking.Transfer `json:"transfer,omitempty"`
-	Withdraw *staking.Withdraw `json:"withdraw,omitempty"`
+	Transfer      *staking.Transfer      `json:"transfer,omitempty"`
+	Withdraw      *staking.Withdraw      `json:"withdraw,omitempty"`
+	AddEscrow     *staking.Escrow        `json:"add_escrow,omitempty"`
+	ReclaimEscrow *staking.ReclaimEscrow `json:"reclaim_escrow,omitempty"`
+	Allow         *staking.Allow         `json:"allow,omitempty"`
 }
 
 // ValidateBasic performs basic validation of the runtime message.
 func (sm *StakingMessage) ValidateBasic() error {
-	switch {
-	case sm.Transfer != nil && sm.Withdraw != nil:
-		return fmt.Errorf("staking runtime message has multiple fields set")
-	case sm.Transfer != nil:
+	var setFields uint8
+	if sm.Transfer != nil {
+		setFields++
+	}
+	if sm.Withdraw != nil {
+		setFields++
+	}
+	if sm.AddEscrow != nil {
+		setFields++
+	}
+	if sm.ReclaimEscrow != nil {
+		setFields++
+	}
+	if sm.Allow != nil {
+		setFields++
+	}
+
+	switch setFields {
+	case 0:
+		return fmt.Errorf("staking runtime message has no fields set")
+	case 1:
 		// No validation at this time.
 		return nil
-	case sm.Withdraw != nil:
+	default:
+		return fmt.Errorf("staking runtime message has multiple fields set")
+	}
+}
+
+// IsEscrowMessage returns true iff the staking runtime message is an escrow operation.
+func (sm *StakingMessage) IsEscrowMessage() bool {
+	return sm.AddEscrow != nil || sm.ReclaimEscrow != nil
+}
+
+// IsAllowanceMessage returns true iff the staking runtime message is an allowance operation.
+func (sm *StakingMessage) IsAllowanceMessage() bool {
+	return sm.Allow != nil
+}
+
+// RegistryMessage is a runtime message that allows a runtime to perform registry operations.
+type RegistryMessage struct {
+	cbor.Versioned
+
+	UpdateRuntime *registry.Runtime `json:"update_runtime,omitempty"`
+}
+
+// ValidateBasic performs basic validation of the runtime message.
+func (rm *RegistryMessage) ValidateBasic() error {
+	switch {
+	case rm.UpdateRuntime != nil:
+		// The runtime descriptor will be verified by the registry.
+		return nil
+	default:
+		return fmt.Errorf("registry runtime message has no fields set")
+	}
+}
+
+// GovernanceMessage is a runtime message that allows a runtime to perform governance operations.
+type GovernanceMessage struct {
+	cbor.Versioned
+
+	CastVote *governance.ProposalVote `json:"cast_vote,omitempty"`
+}
+
+// ValidateBasic performs basic validation of the runtime message.
+func (gm *GovernanceMessage) ValidateBasic() error {
+	switch {
+	case gm.CastVote != nil:
 		// No validation at this time.
 		return nil
 	default:
-		return fmt.Errorf("staking runtime message has no fields set")
+		return fmt.Errorf("governance runtime message has no fields set")
 	}
 }
@@ -2,16 +2,64 @@ package message
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
 )
 
 func TestMessageHash(t *testing.T) {
 	require := require.New(t)
 
+	// NOTE: Empty (rather than nil) slices are used so that the encoding matches the Rust side.
+	updateRuntime := registry.Runtime{
+		Versioned: cbor.NewVersioned(registry.LatestRuntimeDescriptorVersion),
+		Genesis: registry.RuntimeGenesis{
+			State:           storage.WriteLog{},
+			StorageReceipts: []signature.Signature{},
+		},
+		Kind: registry.KindCompute,
+		Version: registry.VersionInfo{
+			Version: version.Version{Major: 1, Minor: 2},
+		},
+		Executor: registry.ExecutorParameters{
+			GroupSize:         3,
+			GroupBackupSize:   5,
+			AllowedStragglers: 1,
+			RoundTimeout:      10,
+			MaxMessages:       32,
+			MinPoolSize:       8,
+		},
+		TxnScheduler: registry.TxnSchedulerParameters{
+			Algorithm:         "simple",
+			BatchFlushTimeout: time.Second,
+			MaxBatchSize:      1000,
+			MaxBatchSizeBytes: 16 * 1024 * 1024,
+			ProposerTimeout:   5,
+		},
+		Storage: registry.StorageParameters{
+			GroupSize:               1,
+			MinWriteReplication:     1,
+			MaxApplyWriteLogEntries: 100_000,
+			MaxApplyOps:             2,
+		},
+		AdmissionPolicy: registry.RuntimeAdmissionPolicy{
+			AnyNode: &registry.AnyNodeRuntimeAdmissionPolicy{},
+		},
+		Staking: registry.RuntimeStakingParameters{
+			MaxAllowances: 10,
+		},
+		GovernanceModel: registry.GovernanceRuntime,
+	}
+
 	for _, tc := range []struct {
 		msgs         []Message
 		expectedHash string
@@ -20,6 +68,11 @@ func TestMessageHash(t *testing.T) {
 		{[]Message{}, "c672b8d1ef56ed28ab87c3622c5114069bdd3ad7b8f9737498d0c01ecef0967a"},
 		{[]Message{{Staking: &StakingMessage{Transfer: &staking.Transfer{}}}}, "a6b91f974b34a9192efd12025659a768520d2f04e1dae9839677456412cdb2be"},
 		{[]Message{{Staking: &StakingMessage{Withdraw: &staking.Withdraw{}}}}, "069b0fda76d804e3fd65d4bbd875c646f15798fb573ac613100df67f5ba4c3fd"},
+		{[]Message{{Staking: &StakingMessage{AddEscrow: &staking.Escrow{}}}}, "65049870b9dae657390e44065df0c78176816876e67b96dac7791ee6a1aa42e2"},
+		{[]Message{{Staking: &StakingMessage{ReclaimEscrow: &staking.ReclaimEscrow{}}}}, "c78547eae2f104268e49827cbe624cf2b350ee59e8d693dec0673a70a4664a2e"},
+		{[]Message{{Staking: &StakingMessage{Allow: &staking.Allow{}}}}, "b89c2537bfbf134ec3e567ae332480912e8af5b6a00ba5c7c5e7e6c56cfc1d64"},
+		{[]Message{{Registry: &RegistryMessage{UpdateRuntime: &updateRuntime}}}, "58c6d0569b27b7e25e54935fb921c2d50ab9bad0912aeb1a3cd0e674c1f66bcc"},
+		{[]Message{{Governance: &GovernanceMessage{CastVote: &governance.ProposalVote{ID: 32, Vote: governance.VoteYes}}}}, "f45e26eb8ace807ad5bd02966cde1f012d1d978d4cbddd59e9bfd742dcf39b90"},
 	} {
 		var h hash.Hash
 		err := h.UnmarshalHex(tc.expectedHash)
@@ -40,7 +93,17 @@ func TestMessageValidateBasic(t *testing.T) {
 		{"NoFieldsSet", Message{}, false},
 		{"StakingNoFieldsSet", Message{Staking: &StakingMessage{}}, false},
 		{"StakingMultipleFieldsSet", Message{Staking: &StakingMessage{Transfer: &staking.Transfer{}, Withdraw: &staking.Withdraw{}}}, false},
+		{"StakingMultipleEscrowFieldsSet", Message{Staking: &StakingMessage{AddEscrow: &staking.Escrow{}, ReclaimEscrow: &staking.ReclaimEscrow{}}}, false},
 		{"ValidStaking", Message{Staking: &StakingMessage{Transfer: &staking.Transfer{}}}, true},
+		{"ValidStakingWithdraw", Message{Staking: &StakingMessage{Withdraw: &staking.Withdraw{}}}, true},
+		{"ValidStakingAddEscrow", Message{Staking: &StakingMessage{AddEscrow: &staking.Escrow{}}}, true},
+		{"ValidStakingReclaimEscrow", Message{Staking: &StakingMessage{ReclaimEscrow: &staking.ReclaimEscrow{}}}, true},
+		{"ValidStakingAllow", Message{Staking: &StakingMessage{Allow: &staking.Allow{}}}, true},
+		{"RegistryNoFieldsSet", Message{Registry: &RegistryMessage{}}, false},
+		{"ValidRegistryUpdateRuntime", Message{Registry: &RegistryMessage{UpdateRuntime: &registry.Runtime{}}}, true},
+		{"GovernanceNoFieldsSet", Message{Governance: &GovernanceMessage{}}, false},
+		{"ValidGovernanceCastVote", Message{Governance: &GovernanceMessage{CastVote: &governance.ProposalVote{}}}, true},
+		{"MultipleFieldsSet", Message{Staking: &StakingMessage{Transfer: &staking.Transfer{}}, Governance: &GovernanceMessage{CastVote: &governance.ProposalVote{}}}, false},
 	} {
 		err := tc.msg.ValidateBasic()
 		if tc.valid {
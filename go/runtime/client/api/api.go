@@ -21,6 +21,13 @@ const (
 
 	// RoundLatest is a special round number always referring to the latest round.
 	RoundLatest uint64 = math.MaxUint64
+
+	// MaxQueryLimit is the maximum number of results returned by a single paginated query.
+	MaxQueryLimit = 1000
+
+	// MaxEventsRangeRounds is the maximum number of rounds scanned by a single GetEventsRange
+	// request.
+	MaxEventsRangeRounds = 100
 )
 
 var (
@@ -46,6 +53,20 @@ type RuntimeClient interface {
 	// SubmitTx submits a transaction to the runtime transaction scheduler.
 	SubmitTx(ctx context.Context, request *SubmitTxRequest) ([]byte, error)
 
+	// SubmitTxNoWait submits a transaction to the runtime transaction scheduler but does not
+	// wait for it to be included in a block.
+	//
+	// The status of the submitted transaction can be queried using GetTxStatus. Submitting a
+	// transaction that is already being submitted or has already been included has no effect.
+	SubmitTxNoWait(ctx context.Context, request *SubmitTxRequest) error
+
+	// GetTxStatus returns the status of a transaction previously submitted via this client.
+	//
+	// In case the transaction was not submitted via this client (or its status is no longer
+	// tracked), the tag indexer is used to check whether the transaction has been included in
+	// a block. If the transaction is not known, ErrNotFound is returned.
+	GetTxStatus(ctx context.Context, request *GetTxStatusRequest) (*TxStatus, error)
+
 	// CheckTx asks the local runtime to check the specified transaction.
 	CheckTx(ctx context.Context, request *CheckTxRequest) error
 
@@ -68,9 +89,15 @@ type RuntimeClient interface {
 	// GetTxs fetches all runtime transactions in a given block.
 	GetTxs(ctx context.Context, request *GetTxsRequest) ([][]byte, error)
 
+	// GetTxsRange fetches a page of runtime transactions in a given range of blocks.
+	GetTxsRange(ctx context.Context, request *GetTxsRangeRequest) (*GetTxsResponse, error)
+
 	// GetEvents returns all events emitted in a given block.
 	GetEvents(ctx context.Context, request *GetEventsRequest) ([]*Event, error)
 
+	// GetEventsRange returns a page of events emitted in a given range of blocks.
+	GetEventsRange(ctx context.Context, request *GetEventsRangeRequest) (*GetEventsResponse, error)
+
 	// Query makes a runtime-specific query.
 	Query(ctx context.Context, request *QueryRequest) (*QueryResponse, error)
 
@@ -80,6 +107,9 @@ type RuntimeClient interface {
 	// QueryTxs queries the indexer for specific runtime transactions.
 	QueryTxs(ctx context.Context, request *QueryTxsRequest) ([]*TxResult, error)
 
+	// QueryTxsPage queries the indexer for a page of specific runtime transactions.
+	QueryTxsPage(ctx context.Context, request *QueryTxsRequest) (*QueryTxsResponse, error)
+
 	// WatchBlocks subscribes to blocks for a specific runtimes.
 	WatchBlocks(ctx context.Context, runtimeID common.Namespace) (<-chan *roothash.AnnotatedBlock, pubsub.ClosableSubscription, error)
 
@@ -99,6 +129,77 @@ type SubmitTxRequest struct {
 	Data      []byte           `json:"data"`
 }
 
+// GetTxStatusRequest is a GetTxStatus request.
+type GetTxStatusRequest struct {
+	RuntimeID common.Namespace `json:"runtime_id"`
+	TxHash    hash.Hash        `json:"tx_hash"`
+}
+
+// TxStatusKind is the kind of a submitted transaction's status.
+type TxStatusKind uint8
+
+const (
+	// TxStatusQueued means that the transaction has been accepted by the client but has not yet
+	// been checked or published.
+	TxStatusQueued TxStatusKind = 0
+	// TxStatusChecked means that the transaction has passed the local check (if any) and has
+	// been published to the transaction schedulers.
+	TxStatusChecked TxStatusKind = 1
+	// TxStatusIncluded means that the transaction has been included in a runtime block.
+	TxStatusIncluded TxStatusKind = 2
+	// TxStatusRejected means that the transaction has been rejected.
+	TxStatusRejected TxStatusKind = 3
+	// TxStatusExpired means that the transaction has not been included in a runtime block in
+	// time and is no longer being watched.
+	TxStatusExpired TxStatusKind = 4
+)
+
+// String returns a string representation of a transaction status kind.
+func (k TxStatusKind) String() string {
+	switch k {
+	case TxStatusQueued:
+		return "queued"
+	case TxStatusChecked:
+		return "checked"
+	case TxStatusIncluded:
+		return "included"
+	case TxStatusRejected:
+		return "rejected"
+	case TxStatusExpired:
+		return "expired"
+	default:
+		return "[unknown transaction status]"
+	}
+}
+
+// IsFinal returns true iff the transaction status can no longer change.
+func (k TxStatusKind) IsFinal() bool {
+	switch k {
+	case TxStatusIncluded, TxStatusRejected, TxStatusExpired:
+		return true
+	default:
+		return false
+	}
+}
+
+// TxStatus is the status of a submitted transaction.
+type TxStatus struct {
+	// Status is the transaction status kind.
+	Status TxStatusKind `json:"status"`
+	// Round is the round of the runtime block that included the transaction.
+	//
+	// Only set when the transaction has been included.
+	Round uint64 `json:"round,omitempty"`
+	// Index is the index of the transaction within the runtime block that included it.
+	//
+	// Only set when the transaction has been included.
+	Index uint32 `json:"index,omitempty"`
+	// Error is the reason for rejecting the transaction.
+	//
+	// Only set when the transaction has been rejected.
+	Error string `json:"error,omitempty"`
+}
+
 // CheckTxRequest is a CheckTx request.
 type CheckTxRequest struct {
 	RuntimeID common.Namespace `json:"runtime_id"`
@@ -146,12 +247,51 @@ type GetTxsRequest struct {
 	IORoot    hash.Hash        `json:"io_root"`
 }
 
+// GetTxsRangeRequest is a GetTxsRange request.
+type GetTxsRangeRequest struct {
+	RuntimeID common.Namespace `json:"runtime_id"`
+	Range     QueryRange       `json:"range"`
+}
+
+// GetTxsResponse is a response to a GetTxsRange request.
+type GetTxsResponse struct {
+	// Txs are the inputs of the returned transactions.
+	Txs [][]byte `json:"txs"`
+
+	// Next is the position that should be used as the range's After field to fetch the next
+	// page of results. It is only set when more results may be available.
+	Next *QueryPosition `json:"next,omitempty"`
+}
+
 // GetEventsRequest is a GetEvents request.
 type GetEventsRequest struct {
 	RuntimeID common.Namespace `json:"runtime_id"`
 	Round     uint64           `json:"round"`
 }
 
+// GetEventsRangeRequest is a GetEventsRange request.
+//
+// Positions used for event pagination refer to the index of the event within the block.
+type GetEventsRangeRequest struct {
+	RuntimeID common.Namespace `json:"runtime_id"`
+	Range     QueryRange       `json:"range"`
+}
+
+// GetEventsResponse is a response to a GetEventsRange request.
+type GetEventsResponse struct {
+	// Events are the returned events.
+	Events []*Event `json:"events"`
+
+	// Next is the position that should be used as the range's After field to fetch the next
+	// page of results. It is only set when more results may be available.
+	//
+	// As at most MaxEventsRangeRounds rounds are scanned by a single request, a page may
+	// contain fewer events than requested (or none at all) even though more events may be
+	// available. In this case Next refers to the last scanned round and no events from that
+	// round will be returned when continuing from it.
+	Next *QueryPosition `json:"next,omitempty"`
+}
+
 // Event is an event emitted by a runtime in the form of a runtime transaction tag.
 //
 // Key and value semantics are runtime-dependent.
@@ -159,6 +299,9 @@ type Event struct {
 	Key    []byte    `json:"key"`
 	Value  []byte    `json:"value"`
 	TxHash hash.Hash `json:"tx_hash"`
+
+	// Round is the round of the block in which the event has been emitted.
+	Round uint64 `json:"round"`
 }
 
 // QueryRequest is a Query request.
@@ -208,8 +351,47 @@ type Query struct {
 
 	// Limit is the maximum number of results to return.
 	//
-	// A zero value means that the `maxQueryLimit` limit is used.
+	// A zero value (or a value above MaxQueryLimit) means that MaxQueryLimit is used.
 	Limit uint64 `json:"limit"`
+
+	// Descending specifies that results should be ordered by descending round and transaction
+	// index instead of the default ascending order.
+	Descending bool `json:"descending,omitempty"`
+
+	// After is an optional position of a transaction. If set, only results that come after the
+	// given position (in the query's order) are returned, which can be used to paginate through
+	// the results.
+	After *QueryPosition `json:"after,omitempty"`
+}
+
+// QueryPosition is the position of a transaction in the index.
+type QueryPosition struct {
+	// Round is the round of the block containing the transaction.
+	Round uint64 `json:"round"`
+	// Index is the index of the transaction within the block.
+	Index uint32 `json:"index"`
+}
+
+// QueryRange is a paginated query over a range of blocks.
+type QueryRange struct {
+	// RoundMin is an optional minimum round (inclusive).
+	RoundMin uint64 `json:"round_min"`
+	// RoundMax is an optional maximum round (inclusive).
+	//
+	// A zero value means that there is no upper limit.
+	RoundMax uint64 `json:"round_max"`
+
+	// Limit is the maximum number of results to return.
+	//
+	// A zero value (or a value above MaxQueryLimit) means that MaxQueryLimit is used.
+	Limit uint64 `json:"limit"`
+
+	// Descending specifies that results should be returned in descending order.
+	Descending bool `json:"descending,omitempty"`
+
+	// After is an optional position. If set, only results that come after the given position
+	// (in the query's order) are returned.
+	After *QueryPosition `json:"after,omitempty"`
 }
 
 // QueryTxsRequest is a QueryTxs request.
@@ -218,6 +400,25 @@ type QueryTxsRequest struct {
 	Query     Query            `json:"query"`
 }
 
+// QueryTxsResponse is a response to a QueryTxsPage request.
+type QueryTxsResponse struct {
+	// Results are the matching transactions in the query's order.
+	Results []*TxResult `json:"results"`
+
+	// Next is the position that should be used as the query's After field to fetch the next
+	// page of results. It is only set when more results may be available.
+	Next *QueryPosition `json:"next,omitempty"`
+}
+
+// EffectiveQueryLimit returns the number of results that will be returned by a query with the
+// given requested limit.
+func EffectiveQueryLimit(limit uint64) uint64 {
+	if limit == 0 || limit > MaxQueryLimit {
+		return MaxQueryLimit
+	}
+	return limit
+}
+
 // WaitBlockIndexedRequest is a WaitBlockIndexed request.
 type WaitBlockIndexedRequest struct {
 	RuntimeID common.Namespace `json:"runtime_id"`
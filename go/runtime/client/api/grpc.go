@@ -22,6 +22,10 @@ var (
 
 	// methodSubmitTx is the SubmitTx method.
 	methodSubmitTx = serviceName.NewMethod("SubmitTx", SubmitTxRequest{})
+	// methodSubmitTxNoWait is the SubmitTxNoWait method.
+	methodSubmitTxNoWait = serviceName.NewMethod("SubmitTxNoWait", SubmitTxRequest{})
+	// methodGetTxStatus is the GetTxStatus method.
+	methodGetTxStatus = serviceName.NewMethod("GetTxStatus", GetTxStatusRequest{})
 	// methodCheckTx is the CheckTx method.
 	methodCheckTx = serviceName.NewMethod("CheckTx", CheckTxRequest{})
 	// methodGetGenesisBlock is the GetGenesisBlock method.
@@ -36,14 +40,20 @@ var (
 	methodGetTxByBlockHash = serviceName.NewMethod("GetTxByBlockHash", GetTxByBlockHashRequest{})
 	// methodGetTxs is the GetTxs method.
 	methodGetTxs = serviceName.NewMethod("GetTxs", GetTxsRequest{})
+	// methodGetTxsRange is the GetTxsRange method.
+	methodGetTxsRange = serviceName.NewMethod("GetTxsRange", GetTxsRangeRequest{})
 	// methodGetEvents is the GetEvents method.
 	methodGetEvents = serviceName.NewMethod("GetEvents", GetEventsRequest{})
+	// methodGetEventsRange is the GetEventsRange method.
+	methodGetEventsRange = serviceName.NewMethod("GetEventsRange", GetEventsRangeRequest{})
 	// methodQuery is the Query method.
 	methodQuery = serviceName.NewMethod("Query", QueryRequest{})
 	// methodQueryTx is the QueryTx method.
 	methodQueryTx = serviceName.NewMethod("QueryTx", QueryTxRequest{})
 	// methodQueryTxs is the QueryTxs method.
 	methodQueryTxs = serviceName.NewMethod("QueryTxs", QueryTxsRequest{})
+	// methodQueryTxsPage is the QueryTxsPage method.
+	methodQueryTxsPage = serviceName.NewMethod("QueryTxsPage", QueryTxsRequest{})
 	// methodWaitBlockIndexed is the WaitBlockIndexed method.
 	methodWaitBlockIndexed = serviceName.NewMethod("WaitBlockIndexed", WaitBlockIndexedRequest{})
 
@@ -59,6 +69,14 @@ var (
 				MethodName: methodSubmitTx.ShortName(),
 				Handler:    handlerSubmitTx,
 			},
+			{
+				MethodName: methodSubmitTxNoWait.ShortName(),
+				Handler:    handlerSubmitTxNoWait,
+			},
+			{
+				MethodName: methodGetTxStatus.ShortName(),
+				Handler:    handlerGetTxStatus,
+			},
 			{
 				MethodName: methodCheckTx.ShortName(),
 				Handler:    handlerCheckTx,
@@ -87,10 +105,18 @@ var (
 				MethodName: methodGetTxs.ShortName(),
 				Handler:    handlerGetTxs,
 			},
+			{
+				MethodName: methodGetTxsRange.ShortName(),
+				Handler:    handlerGetTxsRange,
+			},
 			{
 				MethodName: methodGetEvents.ShortName(),
 				Handler:    handlerGetEvents,
 			},
+			{
+				MethodName: methodGetEventsRange.ShortName(),
+				Handler:    handlerGetEventsRange,
+			},
 			{
 				MethodName: methodQuery.ShortName(),
 				Handler:    handlerQuery,
@@ -103,6 +129,10 @@ var (
 				MethodName: methodQueryTxs.ShortName(),
 				Handler:    handlerQueryTxs,
 			},
+			{
+				MethodName: methodQueryTxsPage.ShortName(),
+				Handler:    handlerQueryTxsPage,
+			},
 			{
 				MethodName: methodWaitBlockIndexed.ShortName(),
 				Handler:    handlerWaitBlockIndexed,
@@ -141,6 +171,52 @@ func handlerSubmitTx( // nolint: golint
 	return interceptor(ctx, &rq, info, handler)
 }
 
+func handlerSubmitTxNoWait( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var rq SubmitTxRequest
+	if err := dec(&rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return nil, srv.(RuntimeClient).SubmitTxNoWait(ctx, &rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodSubmitTxNoWait.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, srv.(RuntimeClient).SubmitTxNoWait(ctx, req.(*SubmitTxRequest))
+	}
+	return interceptor(ctx, &rq, info, handler)
+}
+
+func handlerGetTxStatus( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var rq GetTxStatusRequest
+	if err := dec(&rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeClient).GetTxStatus(ctx, &rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetTxStatus.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeClient).GetTxStatus(ctx, req.(*GetTxStatusRequest))
+	}
+	return interceptor(ctx, &rq, info, handler)
+}
+
 func handlerCheckTx( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -339,6 +415,29 @@ func handlerGetTxs( // nolint: golint
 	return interceptor(ctx, &rq, info, handler)
 }
 
+func handlerGetTxsRange( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var rq GetTxsRangeRequest
+	if err := dec(&rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeClient).GetTxsRange(ctx, &rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetTxsRange.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeClient).GetTxsRange(ctx, req.(*GetTxsRangeRequest))
+	}
+	return interceptor(ctx, &rq, info, handler)
+}
+
 func handlerGetEvents( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -362,6 +461,29 @@ func handlerGetEvents( // nolint: golint
 	return interceptor(ctx, &rq, info, handler)
 }
 
+func handlerGetEventsRange( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var rq GetEventsRangeRequest
+	if err := dec(&rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeClient).GetEventsRange(ctx, &rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetEventsRange.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeClient).GetEventsRange(ctx, req.(*GetEventsRangeRequest))
+	}
+	return interceptor(ctx, &rq, info, handler)
+}
+
 func handlerQuery( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -435,6 +557,29 @@ func handlerQueryTxs( // nolint: golint
 	return interceptor(ctx, &rq, info, handler)
 }
 
+func handlerQueryTxsPage( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var rq QueryTxsRequest
+	if err := dec(&rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeClient).QueryTxsPage(ctx, &rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodQueryTxsPage.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeClient).QueryTxsPage(ctx, req.(*QueryTxsRequest))
+	}
+	return interceptor(ctx, &rq, info, handler)
+}
+
 func handlerWaitBlockIndexed( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -506,6 +651,18 @@ func (c *runtimeClient) SubmitTx(ctx context.Context, request *SubmitTxRequest)
 	return rsp, nil
 }
 
+func (c *runtimeClient) SubmitTxNoWait(ctx context.Context, request *SubmitTxRequest) error {
+	return c.conn.Invoke(ctx, methodSubmitTxNoWait.FullName(), request, nil)
+}
+
+func (c *runtimeClient) GetTxStatus(ctx context.Context, request *GetTxStatusRequest) (*TxStatus, error) {
+	var rsp TxStatus
+	if err := c.conn.Invoke(ctx, methodGetTxStatus.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *runtimeClient) CheckTx(ctx context.Context, request *CheckTxRequest) error {
 	return c.conn.Invoke(ctx, methodCheckTx.FullName(), request, nil)
 }
@@ -558,6 +715,14 @@ func (c *runtimeClient) GetTxs(ctx context.Context, request *GetTxsRequest) ([][
 	return rsp, nil
 }
 
+func (c *runtimeClient) GetTxsRange(ctx context.Context, request *GetTxsRangeRequest) (*GetTxsResponse, error) {
+	var rsp GetTxsResponse
+	if err := c.conn.Invoke(ctx, methodGetTxsRange.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *runtimeClient) GetEvents(ctx context.Context, request *GetEventsRequest) ([]*Event, error) {
 	var rsp []*Event
 	if err := c.conn.Invoke(ctx, methodGetEvents.FullName(), request, &rsp); err != nil {
@@ -566,6 +731,14 @@ func (c *runtimeClient) GetEvents(ctx context.Context, request *GetEventsRequest
 	return rsp, nil
 }
 
+func (c *runtimeClient) GetEventsRange(ctx context.Context, request *GetEventsRangeRequest) (*GetEventsResponse, error) {
+	var rsp GetEventsResponse
+	if err := c.conn.Invoke(ctx, methodGetEventsRange.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *runtimeClient) Query(ctx context.Context, request *QueryRequest) (*QueryResponse, error) {
 	var rsp QueryResponse
 	if err := c.conn.Invoke(ctx, methodQuery.FullName(), request, &rsp); err != nil {
@@ -590,6 +763,14 @@ func (c *runtimeClient) QueryTxs(ctx context.Context, request *QueryTxsRequest)
 	return rsp, nil
 }
 
+func (c *runtimeClient) QueryTxsPage(ctx context.Context, request *QueryTxsRequest) (*QueryTxsResponse, error) {
+	var rsp QueryTxsResponse
+	if err := c.conn.Invoke(ctx, methodQueryTxsPage.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *runtimeClient) WaitBlockIndexed(ctx context.Context, request *WaitBlockIndexedRequest) error {
 	return c.conn.Invoke(ctx, methodWaitBlockIndexed.FullName(), request, nil)
 }
@@ -5,12 +5,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"sort"
 	"sync"
 
 	flag "github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cache/lru"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
@@ -36,6 +39,10 @@ const (
 	// submitted transactions will be considered expired.
 	CfgMaxTransactionAge = "runtime.client.max_transaction_age"
 
+	// CfgTxStatusCacheSize is the maximum number of submitted transactions for which the
+	// status is tracked.
+	CfgTxStatusCacheSize = "runtime.client.tx_status_cache_size"
+
 	minMaxTransactionAge = 30
 )
 
@@ -68,6 +75,7 @@ type runtimeClient struct {
 	kmClients map[common.Namespace]*keymanager.Client
 
 	maxTransactionAge int64
+	txStatuses        *lru.Cache
 
 	logger *logging.Logger
 }
@@ -83,55 +91,229 @@ func (c *runtimeClient) tagIndexer(runtimeID common.Namespace) (tagindexer.Query
 
 // Implements api.RuntimeClient.
 func (c *runtimeClient) SubmitTx(ctx context.Context, request *api.SubmitTxRequest) ([]byte, error) {
+	if err := c.checkCanSubmitTx(ctx); err != nil {
+		return nil, err
+	}
+
+	return c.submitTx(ctx, request)
+}
+
+// Implements api.RuntimeClient.
+func (c *runtimeClient) SubmitTxNoWait(ctx context.Context, request *api.SubmitTxRequest) (err error) {
+	if err = c.checkCanSubmitTx(ctx); err != nil {
+		return err
+	}
+
+	txHash := hash.NewFromBytes(request.Data)
+	if !c.trackSubmitTx(request.RuntimeID, txHash) {
+		return nil
+	}
+	defer func() {
+		if err != nil {
+			c.failTxStatus(ctx, request.RuntimeID, txHash, err)
+		}
+	}()
+
+	if err = c.checkSubmitTx(ctx, request); err != nil {
+		return err
+	}
+
+	watcher, err := c.getWatcher(request.RuntimeID)
+	if err != nil {
+		return err
+	}
+
+	// Hand the transaction over to the watcher which publishes it and reports its status via
+	// the shared detached results channel.
+	req := &watchRequest{
+		id:     txHash,
+		ctx:    c.common.ctx,
+		respCh: watcher.detachedCh,
+		data:   request.Data,
+	}
+	select {
+	case <-ctx.Done():
+		// The context we're working in was canceled, abort.
+		return ctx.Err()
+	case <-c.common.ctx.Done():
+		// Client is shutting down.
+		return fmt.Errorf("client: shutting down")
+	case watcher.newCh <- req:
+		return nil
+	}
+}
+
+// trackSubmitTx starts tracking the status of a transaction submitted via SubmitTxNoWait and
+// returns true iff the transaction should be submitted.
+//
+// Transactions that are already being submitted or have already been included are not submitted
+// again.
+func (c *runtimeClient) trackSubmitTx(runtimeID common.Namespace, txHash hash.Hash) bool {
+	c.Lock()
+	defer c.Unlock()
+
+	if status, ok := c.txStatuses.Peek(txStatusKey{runtimeID, txHash}); ok {
+		switch status.(*api.TxStatus).Status {
+		case api.TxStatusQueued, api.TxStatusChecked, api.TxStatusIncluded:
+			return false
+		}
+	}
+
+	// Record the transaction as queued before returning so that its status is immediately
+	// available to the caller.
+	c.setTxStatus(runtimeID, txHash, &api.TxStatus{Status: api.TxStatusQueued})
+	return true
+}
+
+// detachedTxStatusWorker updates the status of transactions submitted via SubmitTxNoWait based on
+// the results reported by the given watcher.
+func (c *runtimeClient) detachedTxStatusWorker(runtimeID common.Namespace, watcher *blockWatcher) {
+	for {
+		select {
+		case <-watcher.Quit():
+			return
+		case res := <-watcher.detachedCh:
+			switch {
+			case res.err != nil:
+				c.failTxStatus(c.common.ctx, runtimeID, res.id, res.err)
+			case res.result != nil:
+				c.setTxStatus(runtimeID, res.id, &api.TxStatus{
+					Status: api.TxStatusIncluded,
+					Round:  res.round,
+					Index:  res.index,
+				})
+			default:
+				// The transaction has been (re)published.
+				c.setTxStatus(runtimeID, res.id, &api.TxStatus{Status: api.TxStatusChecked})
+			}
+		}
+	}
+}
+
+// Implements api.RuntimeClient.
+func (c *runtimeClient) GetTxStatus(ctx context.Context, request *api.GetTxStatusRequest) (*api.TxStatus, error) {
+	if status, ok := c.txStatuses.Get(txStatusKey{request.RuntimeID, request.TxHash}); ok {
+		return status.(*api.TxStatus), nil
+	}
+
+	// Status is not tracked, check if the transaction has been indexed.
+	tagIndexer, err := c.tagIndexer(request.RuntimeID)
+	if err != nil {
+		return nil, err
+	}
+	round, index, err := tagIndexer.QueryTxnByHash(ctx, request.TxHash)
+	if err != nil {
+		return nil, err
+	}
+	return &api.TxStatus{
+		Status: api.TxStatusIncluded,
+		Round:  round,
+		Index:  index,
+	}, nil
+}
+
+// checkCanSubmitTx checks whether the client is able to submit transactions.
+func (c *runtimeClient) checkCanSubmitTx(ctx context.Context) error {
 	if c.common.p2p == nil {
-		return nil, fmt.Errorf("client: cannot submit transaction, p2p disabled")
+		return fmt.Errorf("client: cannot submit transaction, p2p disabled")
 	}
 
 	// Make sure consensus is synced.
 	select {
 	case <-c.common.consensus.Synced():
+		return nil
 	case <-ctx.Done():
-		return nil, ctx.Err()
+		return ctx.Err()
 	default:
-		return nil, api.ErrNotSynced
+		return api.ErrNotSynced
 	}
+}
 
-	// Perform a local transaction check when a hosted runtime is available.
-	if hrt, ok := c.hosts[request.RuntimeID]; ok && hrt.GetHostedRuntime() != nil {
-		err := c.CheckTx(ctx, &api.CheckTxRequest{
-			RuntimeID: request.RuntimeID,
-			Data:      request.Data,
+type txStatusKey struct {
+	runtimeID common.Namespace
+	txHash    hash.Hash
+}
+
+func (c *runtimeClient) setTxStatus(runtimeID common.Namespace, txHash hash.Hash, status *api.TxStatus) {
+	// Put can only fail if the capacity is in bytes, which is not the case here.
+	_ = c.txStatuses.Put(txStatusKey{runtimeID, txHash}, status)
+}
+
+// failTxStatus records the status of a transaction whose submission has failed with the given
+// error.
+func (c *runtimeClient) failTxStatus(ctx context.Context, runtimeID common.Namespace, txHash hash.Hash, err error) {
+	switch {
+	case errors.Is(err, api.ErrTransactionExpired):
+		c.setTxStatus(runtimeID, txHash, &api.TxStatus{Status: api.TxStatusExpired})
+	case ctx.Err() != nil || c.common.ctx.Err() != nil:
+		// The transaction is no longer being watched, but may still be included. Stop tracking
+		// it so that the tag indexer is consulted instead.
+		c.txStatuses.Remove(txStatusKey{runtimeID, txHash})
+	default:
+		c.setTxStatus(runtimeID, txHash, &api.TxStatus{
+			Status: api.TxStatusRejected,
+			Error:  err.Error(),
 		})
-		if err != nil {
-			return nil, err
-		}
 	}
+}
 
-	var watcher *blockWatcher
-	var ok bool
-	var err error
+// checkSubmitTx performs a local transaction check when a hosted runtime is available.
+func (c *runtimeClient) checkSubmitTx(ctx context.Context, request *api.SubmitTxRequest) error {
+	if hrt, ok := c.hosts[request.RuntimeID]; !ok || hrt.GetHostedRuntime() == nil {
+		return nil
+	}
+	return c.CheckTx(ctx, &api.CheckTxRequest{
+		RuntimeID: request.RuntimeID,
+		Data:      request.Data,
+	})
+}
+
+// getWatcher returns the block watcher for the given runtime, starting it if needed.
+func (c *runtimeClient) getWatcher(runtimeID common.Namespace) (*blockWatcher, error) {
 	c.Lock()
-	if watcher, ok = c.watchers[request.RuntimeID]; !ok {
-		watcher, err = newWatcher(c.common, request.RuntimeID, c.common.p2p, c.maxTransactionAge)
+	defer c.Unlock()
+
+	if watcher, ok := c.watchers[runtimeID]; ok {
+		return watcher, nil
+	}
+
+	watcher, err := newWatcher(c.common, runtimeID, c.common.p2p, c.maxTransactionAge)
+	if err != nil {
+		return nil, err
+	}
+	if err = watcher.Start(); err != nil {
+		return nil, err
+	}
+	go c.detachedTxStatusWorker(runtimeID, watcher)
+	c.watchers[runtimeID] = watcher
+	return watcher, nil
+}
+
+func (c *runtimeClient) submitTx(ctx context.Context, request *api.SubmitTxRequest) (result []byte, err error) {
+	txHash := hash.NewFromBytes(request.Data)
+	c.setTxStatus(request.RuntimeID, txHash, &api.TxStatus{Status: api.TxStatusQueued})
+	defer func() {
 		if err != nil {
-			c.Unlock()
-			return nil, err
-		}
-		if err = watcher.Start(); err != nil {
-			c.Unlock()
-			return nil, err
+			c.failTxStatus(ctx, request.RuntimeID, txHash, err)
 		}
-		c.watchers[request.RuntimeID] = watcher
+	}()
+
+	if err = c.checkSubmitTx(ctx, request); err != nil {
+		return nil, err
+	}
+
+	watcher, err := c.getWatcher(request.RuntimeID)
+	if err != nil {
+		return nil, err
 	}
-	c.Unlock()
 
 	// Send a request for watching a new runtime transaction.
 	respCh := make(chan *watchResult)
 	req := &watchRequest{
+		id:     txHash,
 		ctx:    ctx,
 		respCh: respCh,
 	}
-	req.id.FromBytes(request.Data)
 	select {
 	case <-ctx.Done():
 		// The context we're working in was canceled, abort.
@@ -169,6 +351,11 @@ func (c *runtimeClient) SubmitTx(ctx context.Context, request *api.SubmitTxReque
 				break
 			}
 
+			c.setTxStatus(request.RuntimeID, txHash, &api.TxStatus{
+				Status: api.TxStatusIncluded,
+				Round:  resp.round,
+				Index:  resp.index,
+			})
 			return resp.result, nil
 		}
 
@@ -178,6 +365,7 @@ func (c *runtimeClient) SubmitTx(ctx context.Context, request *api.SubmitTxReque
 			},
 			GroupVersion: resp.groupVersion,
 		})
+		c.setTxStatus(request.RuntimeID, txHash, &api.TxStatus{Status: api.TxStatusChecked})
 	}
 }
 
@@ -202,7 +390,7 @@ func (c *runtimeClient) CheckTx(ctx context.Context, request *api.CheckTxRequest
 		return fmt.Errorf("client: failed to get light block at height %d: %w", rs.CurrentBlockHeight, err)
 	}
 
-	err = rt.CheckTx(ctx, rs.CurrentBlock, lb, request.Data)
+	_, err = rt.CheckTx(ctx, rs.CurrentBlock, lb, request.Data)
 	switch {
 	case err == nil:
 		return nil
@@ -345,6 +533,29 @@ func (c *runtimeClient) GetTxs(ctx context.Context, request *api.GetTxsRequest)
 	return inputs, nil
 }
 
+// Implements api.RuntimeClient.
+func (c *runtimeClient) GetTxsRange(ctx context.Context, request *api.GetTxsRangeRequest) (*api.GetTxsResponse, error) {
+	// Since all transactions are indexed, a range query is an unconditional index query.
+	rng := request.Range
+	results, next, err := c.queryTxs(ctx, request.RuntimeID, api.Query{
+		RoundMin:   rng.RoundMin,
+		RoundMax:   rng.RoundMax,
+		Limit:      rng.Limit,
+		Descending: rng.Descending,
+		After:      rng.After,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	inputs := [][]byte{}
+	for _, result := range results {
+		inputs = append(inputs, result.Input)
+	}
+
+	return &api.GetTxsResponse{Txs: inputs, Next: next}, nil
+}
+
 // Implements api.RuntimeClient.
 func (c *runtimeClient) GetEvents(ctx context.Context, request *api.GetEventsRequest) ([]*api.Event, error) {
 	blk, err := c.GetBlock(ctx, &api.GetBlockRequest{RuntimeID: request.RuntimeID, Round: request.Round})
@@ -352,10 +563,7 @@ func (c *runtimeClient) GetEvents(ctx context.Context, request *api.GetEventsReq
 		return nil, err
 	}
 
-	tree := c.getTxnTree(blk)
-	defer tree.Close()
-
-	tags, err := tree.GetTags(ctx)
+	tags, err := c.getTxnTags(ctx, blk)
 	if err != nil {
 		return nil, err
 	}
@@ -366,11 +574,172 @@ func (c *runtimeClient) GetEvents(ctx context.Context, request *api.GetEventsReq
 			Key:    tag.Key,
 			Value:  tag.Value,
 			TxHash: tag.TxHash,
+			Round:  blk.Header.Round,
 		})
 	}
 	return events, nil
 }
 
+// Implements api.RuntimeClient.
+func (c *runtimeClient) GetEventsRange(ctx context.Context, request *api.GetEventsRangeRequest) (*api.GetEventsResponse, error) {
+	rt, err := c.common.runtimeRegistry.GetRuntime(request.RuntimeID)
+	if err != nil {
+		return nil, err
+	}
+	history := rt.History()
+
+	latestBlk, err := history.GetLatestBlock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanEvents(&request.Range, latestBlk.Header.Round, api.MaxEventsRangeRounds, func(round uint64) ([]*api.Event, error) {
+		blk, err := history.GetBlock(ctx, round)
+		switch err {
+		case nil:
+		case roothash.ErrNotFound:
+			// Skip rounds that are not available (e.g., because they have been pruned).
+			return nil, nil
+		default:
+			return nil, err
+		}
+		if blk.Header.IORoot.IsEmpty() {
+			return nil, nil
+		}
+
+		tags, err := c.getTxnTags(ctx, blk)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch events for round %d: %w", round, err)
+		}
+
+		events := make([]*api.Event, 0, len(tags))
+		for _, tag := range tags {
+			events = append(events, &api.Event{
+				Key:    tag.Key,
+				Value:  tag.Value,
+				TxHash: tag.TxHash,
+				Round:  round,
+			})
+		}
+		return events, nil
+	})
+}
+
+// scanEvents returns a page of events in the given range, scanning at most maxRounds rounds up
+// to the given latest round. The getEvents function is used to fetch all events emitted in a
+// given round.
+func scanEvents(
+	rng *api.QueryRange,
+	latestRound uint64,
+	maxRounds uint64,
+	getEvents func(round uint64) ([]*api.Event, error),
+) (*api.GetEventsResponse, error) {
+	// Determine the range of rounds that need to be scanned.
+	roundMin, roundMax := rng.RoundMin, latestRound
+	if rng.RoundMax > 0 && rng.RoundMax < roundMax {
+		roundMax = rng.RoundMax
+	}
+	limit := api.EffectiveQueryLimit(rng.Limit)
+	rsp := &api.GetEventsResponse{Events: []*api.Event{}}
+	if after := rng.After; after != nil {
+		// Skip the round of the given position in case no events from it can follow it, so
+		// that continuing after a scan limit always makes progress.
+		switch {
+		case rng.Descending && after.Index == 0:
+			if after.Round == 0 {
+				return rsp, nil
+			}
+			if after.Round-1 < roundMax {
+				roundMax = after.Round - 1
+			}
+		case rng.Descending:
+			if after.Round < roundMax {
+				roundMax = after.Round
+			}
+		case after.Index == math.MaxUint32:
+			if after.Round == math.MaxUint64 {
+				return rsp, nil
+			}
+			if after.Round+1 > roundMin {
+				roundMin = after.Round + 1
+			}
+		default:
+			if after.Round > roundMin {
+				roundMin = after.Round
+			}
+		}
+	}
+	if roundMin > roundMax {
+		return rsp, nil
+	}
+
+	round, lastRound := roundMin, roundMax
+	if rng.Descending {
+		round, lastRound = roundMax, roundMin
+	}
+	for scanned := uint64(1); ; scanned++ {
+		events, err := getEvents(round)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range events {
+			idx := i
+			if rng.Descending {
+				idx = len(events) - 1 - i
+			}
+			pos := api.QueryPosition{Round: round, Index: uint32(idx)}
+			if !isAfterPosition(&pos, rng.After, rng.Descending) {
+				continue
+			}
+
+			rsp.Events = append(rsp.Events, events[idx])
+			if uint64(len(rsp.Events)) >= limit {
+				rsp.Next = &pos
+				return rsp, nil
+			}
+		}
+
+		if round == lastRound {
+			return rsp, nil
+		}
+		if scanned >= maxRounds {
+			// Continue after the last event of the last scanned round (in the range's order).
+			rsp.Next = &api.QueryPosition{Round: round, Index: math.MaxUint32}
+			if rng.Descending {
+				rsp.Next.Index = 0
+			}
+			return rsp, nil
+		}
+
+		if rng.Descending {
+			round--
+		} else {
+			round++
+		}
+	}
+}
+
+func (c *runtimeClient) getTxnTags(ctx context.Context, blk *block.Block) (transaction.Tags, error) {
+	tree := c.getTxnTree(blk)
+	defer tree.Close()
+
+	return tree.GetTags(ctx)
+}
+
+// isAfterPosition checks whether the given position comes after the given (optional) cursor
+// position in the given order.
+func isAfterPosition(pos, after *api.QueryPosition, descending bool) bool {
+	switch {
+	case after == nil:
+		return true
+	case pos.Round != after.Round:
+		return (pos.Round > after.Round) != descending
+	default:
+		return pos.Index != after.Index && (pos.Index > after.Index) != descending
+	}
+}
+
 // Implements api.RuntimeClient.
 func (c *runtimeClient) GetBlockByHash(ctx context.Context, request *api.GetBlockByHashRequest) (*block.Block, error) {
 	tagIndexer, err := c.tagIndexer(request.RuntimeID)
@@ -441,51 +810,115 @@ func (c *runtimeClient) QueryTx(ctx context.Context, request *api.QueryTxRequest
 
 // Implements api.RuntimeClient.
 func (c *runtimeClient) QueryTxs(ctx context.Context, request *api.QueryTxsRequest) ([]*api.TxResult, error) {
-	tagIndexer, err := c.tagIndexer(request.RuntimeID)
+	results, _, err := c.queryTxs(ctx, request.RuntimeID, request.Query)
+	return results, err
+}
+
+// Implements api.RuntimeClient.
+func (c *runtimeClient) QueryTxsPage(ctx context.Context, request *api.QueryTxsRequest) (*api.QueryTxsResponse, error) {
+	results, next, err := c.queryTxs(ctx, request.RuntimeID, request.Query)
 	if err != nil {
 		return nil, err
 	}
 
-	results, err := tagIndexer.QueryTxns(ctx, request.Query)
+	return &api.QueryTxsResponse{Results: results, Next: next}, nil
+}
+
+// queryTxs queries the tag indexer and returns the matching transactions in the query's order
+// together with the position of the next page (if more results may be available).
+func (c *runtimeClient) queryTxs(
+	ctx context.Context,
+	runtimeID common.Namespace,
+	query api.Query,
+) ([]*api.TxResult, *api.QueryPosition, error) {
+	tagIndexer, err := c.tagIndexer(runtimeID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	results, err := tagIndexer.QueryTxns(ctx, query)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	output := []*api.TxResult{}
-	for round, txResults := range results {
-		// Fetch block for the given round.
-		var blk *block.Block
-		blk, err = c.GetBlock(ctx, &api.GetBlockRequest{RuntimeID: request.RuntimeID, Round: round})
+	// Order rounds according to the query's order as map iteration order is random.
+	rounds := make([]uint64, 0, len(results))
+	for round := range results {
+		rounds = append(rounds, round)
+	}
+	sort.Slice(rounds, func(i, j int) bool {
+		return (rounds[i] < rounds[j]) != query.Descending
+	})
+
+	var (
+		output = []*api.TxResult{}
+		count  uint64
+	)
+	for _, round := range rounds {
+		txResults := results[round]
+		sort.Slice(txResults, func(i, j int) bool {
+			return (txResults[i].TxIndex < txResults[j].TxIndex) != query.Descending
+		})
+
+		var roundOutput []*api.TxResult
+		roundOutput, err = c.getTxResults(ctx, runtimeID, round, txResults)
 		if err != nil {
-			return nil, fmt.Errorf("failed to fetch block: %w", err)
+			return nil, nil, err
 		}
+		output = append(output, roundOutput...)
+		count += uint64(len(txResults))
+	}
 
-		tree := c.getTxnTree(blk)
-		defer tree.Close()
+	// In case the limit has been reached, there may be more results.
+	var next *api.QueryPosition
+	if count > 0 && count >= api.EffectiveQueryLimit(query.Limit) {
+		last := output[len(output)-1]
+		next = &api.QueryPosition{Round: last.Block.Header.Round, Index: last.Index}
+	}
 
-		// Extract transaction data for the specified indices.
-		var txHashes []hash.Hash
-		for _, txResult := range txResults {
-			txHashes = append(txHashes, txResult.TxHash)
-		}
+	return output, next, nil
+}
 
-		txes, err := tree.GetTransactionMultiple(ctx, txHashes)
-		if err != nil {
-			return nil, fmt.Errorf("failed to fetch transaction data: %w", err)
-		}
-		for _, txResult := range txResults {
-			tx, ok := txes[txResult.TxHash]
-			if !ok {
-				return nil, fmt.Errorf("transaction %s not found", txResult.TxHash)
-			}
+// getTxResults fetches transaction data for the given tag indexer results in a given round.
+func (c *runtimeClient) getTxResults(
+	ctx context.Context,
+	runtimeID common.Namespace,
+	round uint64,
+	txResults []tagindexer.Result,
+) ([]*api.TxResult, error) {
+	// Fetch block for the given round.
+	blk, err := c.GetBlock(ctx, &api.GetBlockRequest{RuntimeID: runtimeID, Round: round})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch block: %w", err)
+	}
 
-			output = append(output, &api.TxResult{
-				Block:  blk,
-				Index:  txResult.TxIndex,
-				Input:  tx.Input,
-				Output: tx.Output,
-			})
+	tree := c.getTxnTree(blk)
+	defer tree.Close()
+
+	// Extract transaction data for the specified indices.
+	var txHashes []hash.Hash
+	for _, txResult := range txResults {
+		txHashes = append(txHashes, txResult.TxHash)
+	}
+
+	txes, err := tree.GetTransactionMultiple(ctx, txHashes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch transaction data: %w", err)
+	}
+
+	var output []*api.TxResult
+	for _, txResult := range txResults {
+		tx, ok := txes[txResult.TxHash]
+		if !ok {
+			return nil, fmt.Errorf("transaction %s not found", txResult.TxHash)
 		}
+
+		output = append(output, &api.TxResult{
+			Block:  blk,
+			Index:  txResult.TxIndex,
+			Input:  tx.Input,
+			Output: tx.Output,
+		})
 	}
 
 	return output, nil
@@ -596,6 +1029,11 @@ func New(
 		return nil, fmt.Errorf("max transaction age too low: %d, minimum: %d", maxTransactionAge, minMaxTransactionAge)
 	}
 
+	txStatuses, err := lru.New(lru.Capacity(viper.GetUint64(CfgTxStatusCacheSize), false))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transaction status cache: %w", err)
+	}
+
 	c := &runtimeClient{
 		common: &clientCommon{
 			storage:         runtimeRegistry.StorageRouter(),
@@ -609,6 +1047,7 @@ func New(
 		watchers:          make(map[common.Namespace]*blockWatcher),
 		kmClients:         make(map[common.Namespace]*keymanager.Client),
 		maxTransactionAge: maxTransactionAge,
+		txStatuses:        txStatuses,
 		logger:            logging.GetLogger("runtime/client"),
 	}
 
@@ -630,6 +1069,7 @@ func New(
 
 func init() {
 	Flags.Int64(CfgMaxTransactionAge, 1500, "number of consensus blocks after which submitted transactions will be considered expired")
+	Flags.Uint64(CfgTxStatusCacheSize, 10000, "maximum number of submitted transactions for which the status is tracked")
 
 	_ = viper.BindPFlags(Flags)
 }
@@ -0,0 +1,189 @@
+package client
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cache/lru"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/service"
+	"github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+)
+
+func TestTrackSubmitTx(t *testing.T) {
+	require := require.New(t)
+
+	txStatuses, err := lru.New(lru.Capacity(100, false))
+	require.NoError(err, "lru.New")
+	c := &runtimeClient{
+		txStatuses: txStatuses,
+	}
+
+	var runtimeID common.Namespace
+	txHash := func(data string) hash.Hash {
+		return hash.NewFromBytes([]byte(data))
+	}
+	status := func(data string) (*api.TxStatus, bool) {
+		s, ok := c.txStatuses.Peek(txStatusKey{runtimeID, txHash(data)})
+		if !ok {
+			return nil, false
+		}
+		return s.(*api.TxStatus), true
+	}
+
+	require.True(c.trackSubmitTx(runtimeID, txHash("tx 1")), "new transaction should be submitted")
+	s, ok := status("tx 1")
+	require.True(ok, "status of submitted transaction should be tracked")
+	require.Equal(api.TxStatusQueued, s.Status, "transaction should be queued")
+
+	// Transactions that are already being submitted are not submitted again.
+	require.False(c.trackSubmitTx(runtimeID, txHash("tx 1")), "queued transaction should not be submitted again")
+	c.setTxStatus(runtimeID, txHash("tx 1"), &api.TxStatus{Status: api.TxStatusChecked})
+	require.False(c.trackSubmitTx(runtimeID, txHash("tx 1")), "checked transaction should not be submitted again")
+
+	// Rejected and expired transactions can be submitted again.
+	for _, kind := range []api.TxStatusKind{api.TxStatusRejected, api.TxStatusExpired} {
+		c.setTxStatus(runtimeID, txHash("tx 2"), &api.TxStatus{Status: kind})
+		require.True(c.trackSubmitTx(runtimeID, txHash("tx 2")), "%s transaction should be submitted again", kind)
+		s, _ = status("tx 2")
+		require.Equal(api.TxStatusQueued, s.Status, "%s transaction should be queued again", kind)
+	}
+
+	// Included transactions are not submitted again.
+	c.setTxStatus(runtimeID, txHash("tx 3"), &api.TxStatus{Status: api.TxStatusIncluded})
+	require.False(c.trackSubmitTx(runtimeID, txHash("tx 3")), "included transaction should not be submitted again")
+	s, _ = status("tx 3")
+	require.Equal(api.TxStatusIncluded, s.Status, "included transaction status should be kept")
+}
+
+func TestDetachedTxStatusWorker(t *testing.T) {
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	txStatuses, err := lru.New(lru.Capacity(100, false))
+	require.NoError(err, "lru.New")
+	c := &runtimeClient{
+		common:     &clientCommon{ctx: ctx},
+		txStatuses: txStatuses,
+	}
+
+	var runtimeID common.Namespace
+	watcher := &blockWatcher{
+		BaseBackgroundService: *service.NewBaseBackgroundService("client/watcher/test"),
+		detachedCh:            make(chan *watchResult),
+	}
+	go c.detachedTxStatusWorker(runtimeID, watcher)
+
+	status := func(txHash hash.Hash) *api.TxStatus {
+		var s interface{}
+		require.Eventually(func() bool {
+			var ok bool
+			s, ok = c.txStatuses.Peek(txStatusKey{runtimeID, txHash})
+			return ok
+		}, time.Second, 10*time.Millisecond, "transaction status should be tracked")
+		return s.(*api.TxStatus)
+	}
+
+	published, included, expired := hash.NewFromBytes([]byte("tx 1")), hash.NewFromBytes([]byte("tx 2")), hash.NewFromBytes([]byte("tx 3"))
+	watcher.detachedCh <- &watchResult{id: published, groupVersion: 1}
+	watcher.detachedCh <- &watchResult{id: included, result: []byte("result"), round: 10, index: 2}
+	watcher.detachedCh <- &watchResult{id: expired, err: api.ErrTransactionExpired}
+
+	require.Equal(&api.TxStatus{Status: api.TxStatusChecked}, status(published), "published transaction should be checked")
+	require.Equal(&api.TxStatus{Status: api.TxStatusIncluded, Round: 10, Index: 2}, status(included), "included transaction should be included")
+	require.Equal(&api.TxStatus{Status: api.TxStatusExpired}, status(expired), "expired transaction should be expired")
+
+	watcher.BaseBackgroundService.Stop()
+}
+
+func TestScanEvents(t *testing.T) {
+	require := require.New(t)
+
+	// Rounds 0-9 with round r containing r%3 events.
+	var scannedRounds []uint64
+	getEvents := func(round uint64) ([]*api.Event, error) {
+		scannedRounds = append(scannedRounds, round)
+		var events []*api.Event
+		for i := uint64(0); i < round%3; i++ {
+			events = append(events, &api.Event{Key: []byte{byte(round), byte(i)}, Round: round})
+		}
+		return events, nil
+	}
+	collect := func(rng api.QueryRange, maxRounds uint64) ([]*api.Event, int) {
+		var (
+			events []*api.Event
+			pages  int
+		)
+		for {
+			rsp, err := scanEvents(&rng, 9, maxRounds, getEvents)
+			require.NoError(err, "scanEvents")
+			events = append(events, rsp.Events...)
+			pages++
+			if rsp.Next == nil {
+				return events, pages
+			}
+			rng.After = rsp.Next
+		}
+	}
+
+	// The number of scanned rounds is capped even if no events are found.
+	scannedRounds = nil
+	rsp, err := scanEvents(&api.QueryRange{RoundMin: 3, RoundMax: 3}, 9, 2, getEvents)
+	require.NoError(err, "scanEvents")
+	require.Empty(rsp.Events, "round without events should not return events")
+	require.Nil(rsp.Next, "scanning the last round should not return a next position")
+
+	scannedRounds = nil
+	rsp, err = scanEvents(&api.QueryRange{}, 9, 3, getEvents)
+	require.NoError(err, "scanEvents")
+	require.Equal([]uint64{0, 1, 2}, scannedRounds, "at most maxRounds rounds should be scanned")
+	require.Len(rsp.Events, 3, "all events of scanned rounds should be returned")
+	require.Equal(&api.QueryPosition{Round: 2, Index: math.MaxUint32}, rsp.Next, "next position should skip the last scanned round")
+
+	scannedRounds = nil
+	rsp, err = scanEvents(&api.QueryRange{Descending: true}, 9, 3, getEvents)
+	require.NoError(err, "scanEvents")
+	require.Equal([]uint64{9, 8, 7}, scannedRounds, "at most maxRounds rounds should be scanned")
+	require.Len(rsp.Events, 3, "all events of scanned rounds should be returned")
+	require.Equal(&api.QueryPosition{Round: 7, Index: 0}, rsp.Next, "next position should skip the last scanned round")
+
+	// Paginating returns all events exactly once, in order.
+	all, _ := collect(api.QueryRange{}, 100)
+	require.Len(all, 9, "all events should be returned")
+	for _, tc := range []struct {
+		rng       api.QueryRange
+		maxRounds uint64
+	}{
+		{api.QueryRange{Limit: 2}, 100},
+		{api.QueryRange{Limit: 2}, 1},
+		{api.QueryRange{Limit: 100}, 4},
+		{api.QueryRange{Limit: 1, Descending: true}, 3},
+		{api.QueryRange{Limit: 100, Descending: true}, 1},
+	} {
+		events, pages := collect(tc.rng, tc.maxRounds)
+		expected := all
+		if tc.rng.Descending {
+			expected = make([]*api.Event, 0, len(all))
+			for i := len(all) - 1; i >= 0; i-- {
+				expected = append(expected, all[i])
+			}
+		}
+		require.Equal(expected, events, "pagination should return all events in order (limit: %d, max rounds: %d)", tc.rng.Limit, tc.maxRounds)
+		require.Greater(pages, 1, "pagination should return multiple pages")
+	}
+
+	// Rounds outside the range are not scanned.
+	scannedRounds = nil
+	events, _ := collect(api.QueryRange{RoundMin: 4, RoundMax: 6, Limit: 1}, 100)
+	require.Len(events, 3, "only events in range should be returned")
+	for _, round := range scannedRounds {
+		require.True(round >= 4 && round <= 6, "only rounds in range should be scanned")
+	}
+}
@@ -39,6 +39,12 @@ func ClientImplementationTests(
 		defer cancelFunc()
 		testQuery(ctx, t, runtimeID, client, testInput)
 	})
+
+	t.Run("Pagination", func(t *testing.T) {
+		ctx, cancelFunc := context.WithTimeout(context.Background(), timeout)
+		defer cancelFunc()
+		testPagination(ctx, t, runtimeID, client, testInput)
+	})
 }
 
 func testSubmitTransaction(
@@ -55,6 +61,16 @@ func testSubmitTransaction(
 	// Check if everything is in order.
 	require.NoError(t, err, "SubmitTx")
 	require.EqualValues(t, testInput, testOutput)
+
+	// The status of the submitted transaction should be tracked.
+	status, err := c.GetTxStatus(ctx, &api.GetTxStatusRequest{RuntimeID: runtimeID, TxHash: hash.NewFromBytes(testInput)})
+	require.NoError(t, err, "GetTxStatus")
+	require.Equal(t, api.TxStatusIncluded, status.Status, "submitted transaction should be included")
+	require.True(t, status.Round >= 3, "submitted transaction should be included in a normal block")
+
+	// Unknown transactions should not be found.
+	_, err = c.GetTxStatus(ctx, &api.GetTxStatusRequest{RuntimeID: runtimeID, TxHash: hash.NewFromBytes([]byte("unknown transaction"))})
+	require.Error(t, err, "GetTxStatus should fail for unknown transactions")
 }
 
 func testQuery(
@@ -153,6 +169,7 @@ func testQuery(
 	require.Len(t, events, 1)
 	require.EqualValues(t, []byte("txn_foo"), events[0].Key)
 	require.EqualValues(t, []byte("txn_bar"), events[0].Value)
+	require.EqualValues(t, 2, events[0].Round)
 
 	// Test advanced transaction queries.
 	query := api.Query{
@@ -201,3 +218,72 @@ func testQuery(
 	})
 	require.NoError(t, err, "CheckTx")
 }
+
+func testPagination(
+	ctx context.Context,
+	t *testing.T,
+	runtimeID common.Namespace,
+	c api.RuntimeClient,
+	input string,
+) {
+	// Based on SubmitTx and the mock worker.
+	testInput := []byte(input)
+
+	blk, err := c.GetBlock(ctx, &api.GetBlockRequest{RuntimeID: runtimeID, Round: api.RoundLatest})
+	require.NoError(t, err, "GetBlock(RoundLatest)")
+
+	// Transactions in a range of rounds.
+	txns, err := c.GetTxsRange(ctx, &api.GetTxsRangeRequest{
+		RuntimeID: runtimeID,
+		Range:     api.QueryRange{RoundMin: blk.Header.Round, RoundMax: blk.Header.Round},
+	})
+	require.NoError(t, err, "GetTxsRange")
+	require.Len(t, txns.Txs, 1)
+	require.EqualValues(t, testInput, txns.Txs[0])
+
+	// Events in a range of rounds.
+	events, err := c.GetEventsRange(ctx, &api.GetEventsRangeRequest{
+		RuntimeID: runtimeID,
+		Range:     api.QueryRange{RoundMin: 2, RoundMax: 2, Limit: 1},
+	})
+	require.NoError(t, err, "GetEventsRange")
+	require.Len(t, events.Events, 1)
+	require.EqualValues(t, []byte("txn_foo"), events.Events[0].Key)
+	require.EqualValues(t, &api.QueryPosition{Round: 2, Index: 0}, events.Next)
+
+	events, err = c.GetEventsRange(ctx, &api.GetEventsRangeRequest{
+		RuntimeID: runtimeID,
+		Range:     api.QueryRange{RoundMin: 2, RoundMax: 2, Limit: 1, After: events.Next},
+	})
+	require.NoError(t, err, "GetEventsRange(After)")
+	require.Empty(t, events.Events)
+	require.Nil(t, events.Next)
+
+	// Paginated transaction queries.
+	query := api.Query{
+		RoundMin: 0,
+		RoundMax: 3,
+		Limit:    1,
+		Conditions: []api.QueryCondition{
+			{Key: []byte("txn_foo"), Values: [][]byte{[]byte("txn_bar")}},
+		},
+	}
+	for _, descending := range []bool{false, true} {
+		query.Descending = descending
+		query.After = nil
+
+		var page []*api.TxResult
+		for {
+			rsp, err := c.QueryTxsPage(ctx, &api.QueryTxsRequest{RuntimeID: runtimeID, Query: query})
+			require.NoError(t, err, "QueryTxsPage")
+			page = append(page, rsp.Results...)
+			if rsp.Next == nil {
+				break
+			}
+			require.Len(t, rsp.Results, 1)
+			query.After = rsp.Next
+		}
+		require.Len(t, page, 2, "pagination should return all results")
+		require.NotEqual(t, page[0].Input, page[1].Input, "pagination should not return duplicates")
+	}
+}
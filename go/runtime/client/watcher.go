@@ -12,6 +12,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/runtime/transaction"
 	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
 	"github.com/oasisprotocol/oasis-core/go/worker/common/p2p"
+	executor "github.com/oasisprotocol/oasis-core/go/worker/compute/executor/api"
 )
 
 type watchRequest struct {
@@ -19,6 +20,15 @@ type watchRequest struct {
 	ctx    context.Context
 	respCh chan *watchResult
 	height int64
+
+	// data is the raw transaction of a detached watch. Detached transactions are (re)published
+	// by the watcher itself and their results are sent to the watcher's shared detachedCh
+	// instead of a per-transaction response channel.
+	data []byte
+}
+
+func (w *watchRequest) isDetached() bool {
+	return w.data != nil
 }
 
 func (w *watchRequest) send(res *watchResult, height int64) error {
@@ -33,8 +43,11 @@ func (w *watchRequest) send(res *watchResult, height int64) error {
 }
 
 type watchResult struct {
+	id           hash.Hash
 	err          error
 	result       []byte
+	round        uint64
+	index        uint32
 	groupVersion int64
 }
 
@@ -44,8 +57,9 @@ type blockWatcher struct {
 	common *clientCommon
 	id     common.Namespace
 
-	watched map[hash.Hash]*watchRequest
-	newCh   chan *watchRequest
+	watched    map[hash.Hash]*watchRequest
+	newCh      chan *watchRequest
+	detachedCh chan *watchResult
 
 	maxTransactionAge int64
 
@@ -85,22 +99,56 @@ func (w *blockWatcher) checkBlock(blk *block.Block) error {
 	if err != nil {
 		return fmt.Errorf("error getting block I/O from storage: %w", err)
 	}
+	if len(matches) == 0 {
+		return nil
+	}
+
+	// Determine the indices of matched transactions within the block.
+	txs, err := tree.GetTransactions(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting block I/O from storage: %w", err)
+	}
+	txIndices := make(map[hash.Hash]uint32, len(txs))
+	for idx, tx := range txs {
+		txIndices[tx.Hash()] = uint32(idx)
+	}
 
 	for txHash, tx := range matches {
-		watch := w.watched[txHash]
-		res := &watchResult{
+		w.finish(w.watched[txHash], &watchResult{
 			result: tx.Output,
-		}
-
-		// Ignore errors, the watch is getting deleted anyway.
-		_ = watch.send(res, 0)
-		close(watch.respCh)
-		delete(w.watched, txHash)
+			round:  blk.Header.Round,
+			index:  txIndices[txHash],
+		})
 	}
 
 	return nil
 }
 
+// resend notifies the watcher of the given transaction that it should be (re)published with the
+// given group version. Detached transactions are published directly.
+func (w *blockWatcher) resend(watch *watchRequest, groupVersion int64, height int64) error {
+	if watch.isDetached() {
+		w.common.p2p.Publish(context.Background(), w.id, &p2p.Message{
+			Tx: &executor.Tx{
+				Data: watch.data,
+			},
+			GroupVersion: groupVersion,
+		})
+	}
+	return watch.send(&watchResult{id: watch.id, groupVersion: groupVersion}, height)
+}
+
+// finish sends the final result to the watcher of the given transaction and stops watching it.
+func (w *blockWatcher) finish(watch *watchRequest, res *watchResult) {
+	res.id = watch.id
+	// Ignore errors, the watch is getting deleted anyway.
+	_ = watch.send(res, 0)
+	if !watch.isDetached() {
+		close(watch.respCh)
+	}
+	delete(w.watched, watch.id)
+}
+
 func (w *blockWatcher) getGroupVersion(height int64) (int64, error) {
 	epoch, err := w.common.consensus.Beacon().GetEpoch(w.common.ctx, height)
 	if err != nil {
@@ -112,7 +160,9 @@ func (w *blockWatcher) getGroupVersion(height int64) (int64, error) {
 func (w *blockWatcher) watch() {
 	defer func() {
 		for _, watch := range w.watched {
-			close(watch.respCh)
+			if !watch.isDetached() {
+				close(watch.respCh)
+			}
 		}
 		w.BaseBackgroundService.Stop()
 	}()
@@ -196,13 +246,10 @@ func (w *blockWatcher) watch() {
 				continue
 			}
 
-			// Tell every client to resubmit as messages with old groupVersion
+			// Resubmit every transaction as messages with old groupVersion
 			// will be discarded.
 			for key, watch := range w.watched {
-				res := &watchResult{
-					groupVersion: latestGroupVersion,
-				}
-				if watch.send(res, latestHeight) != nil {
+				if w.resend(watch, latestGroupVersion, latestHeight) != nil {
 					delete(w.watched, key)
 				}
 			}
@@ -223,21 +270,14 @@ func (w *blockWatcher) watch() {
 					"max_transaction_age", w.maxTransactionAge,
 					"watch_height", watch.height,
 				)
-				res := &watchResult{
+				w.finish(watch, &watchResult{
 					err: api.ErrTransactionExpired,
-				}
-				// Ignore errors, the watch is getting deleted anyway.
-				_ = watch.send(res, 0)
-				close(watch.respCh)
-				delete(w.watched, key)
+				})
 			}
 		case newWatch := <-w.newCh:
 			w.watched[newWatch.id] = newWatch
 
-			res := &watchResult{
-				groupVersion: latestGroupVersion,
-			}
-			if newWatch.send(res, latestHeight) != nil {
+			if w.resend(newWatch, latestGroupVersion, latestHeight) != nil {
 				delete(w.watched, newWatch.id)
 			}
 
@@ -274,6 +314,7 @@ func newWatcher(common *clientCommon, id common.Namespace, p2pSvc *p2p.P2P, maxT
 		maxTransactionAge:     maxTransactionAge,
 		watched:               make(map[hash.Hash]*watchRequest),
 		newCh:                 make(chan *watchRequest),
+		detachedCh:            make(chan *watchResult),
 		stopCh:                make(chan struct{}),
 	}
 	return watcher, nil
@@ -29,6 +29,11 @@ var (
 	//
 	// Value is CBOR-serialized []*roothash.MessageEvent.
 	messageResultsKeyFmt = keyformat.New(0x03, uint64(0))
+	// archivedRoundKeyFmt is the archived round key format.
+	//
+	// Value is empty. It marks archived rounds that have already been pruned by the prune
+	// handlers while their block is retained in history.
+	archivedRoundKeyFmt = keyformat.New(0x04, uint64(0))
 )
 
 type dbMetadata struct {
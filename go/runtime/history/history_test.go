@@ -137,6 +137,10 @@ type testPruneHandler struct {
 	batches      []int
 }
 
+func (h *testPruneHandler) CanPrune(ctx context.Context, round uint64) error {
+	return nil
+}
+
 func (h *testPruneHandler) Prune(ctx context.Context, rounds []uint64) error {
 	// NOTE: Users must ensure that accessing prunedRounds is safe (e.g., that
 	//       no more pruning happens using this handler before prunedRounds is
@@ -251,6 +255,10 @@ func TestHistoryPrune(t *testing.T) {
 type testPruneFailingHandler struct {
 }
 
+func (h *testPruneFailingHandler) CanPrune(ctx context.Context, round uint64) error {
+	return nil
+}
+
 func (h *testPruneFailingHandler) Prune(ctx context.Context, rounds []uint64) error {
 	return fmt.Errorf("thou shall not pass")
 }
@@ -296,3 +304,224 @@ func TestHistoryPruneError(t *testing.T) {
 		require.NoError(err, "GetBlock(%d)", i)
 	}
 }
+
+type testPruneVetoHandler struct {
+	vetoed  uint64
+	delayed uint64
+
+	prunedRounds []uint64
+}
+
+func (h *testPruneVetoHandler) CanPrune(ctx context.Context, round uint64) error {
+	switch round {
+	case h.vetoed:
+		return ErrPruneVetoed
+	case h.delayed:
+		return ErrPruneDelayed
+	default:
+		return nil
+	}
+}
+
+func (h *testPruneVetoHandler) Prune(ctx context.Context, rounds []uint64) error {
+	h.prunedRounds = append(h.prunedRounds, rounds...)
+	return nil
+}
+
+type testInOrderPruneHandler struct {
+	*testPruneVetoHandler
+}
+
+func (h *testInOrderPruneHandler) PrunesInOrder() {}
+
+func TestHistoryPruneStrategies(t *testing.T) {
+	now := time.Now()
+
+	for _, tc := range []struct {
+		name   string
+		pruner PrunerFactory
+		// handler is an optional prune handler to register.
+		handler *testPruneVetoHandler
+		// inOrder specifies whether the handler should be registered as an in-order handler.
+		inOrder bool
+		// retained returns true iff the given round should be retained.
+		retained func(round uint64) bool
+		// handled returns true iff the given round should be passed to the prune handler. If nil,
+		// all rounds that are not retained should be passed to the handler.
+		handled func(round uint64) bool
+	}{
+		{
+			name:     "KeepLastArchive",
+			pruner:   NewKeepLastPruner(10, WithArchiveInterval(15)),
+			retained: func(round uint64) bool { return round > 40 || round%15 == 0 },
+		},
+		{
+			name:     "KeepDuration",
+			pruner:   NewKeepDurationPruner(10*time.Minute + 30*time.Second),
+			retained: func(round uint64) bool { return round >= 40 },
+		},
+		{
+			name:     "KeepDurationArchive",
+			pruner:   NewKeepDurationPruner(10*time.Minute+30*time.Second, WithArchiveInterval(20)),
+			retained: func(round uint64) bool { return round >= 40 || round%20 == 0 },
+		},
+		{
+			name:   "KeepLastVetoDelay",
+			pruner: NewKeepLastPruner(10),
+			handler: &testPruneVetoHandler{
+				vetoed:  5,
+				delayed: 20,
+			},
+			retained: func(round uint64) bool { return round == 5 || round >= 20 },
+		},
+		{
+			name:   "KeepLastVetoInOrder",
+			pruner: NewKeepLastPruner(10),
+			handler: &testPruneVetoHandler{
+				vetoed:  5,
+				delayed: ^uint64(0),
+			},
+			inOrder:  true,
+			retained: func(round uint64) bool { return round >= 5 },
+		},
+		{
+			name:   "KeepLastArchiveInOrder",
+			pruner: NewKeepLastPruner(10, WithArchiveInterval(15)),
+			handler: &testPruneVetoHandler{
+				vetoed:  ^uint64(0),
+				delayed: ^uint64(0),
+			},
+			inOrder: true,
+			// Archived rounds are retained in history, but are still pruned by the handler.
+			retained: func(round uint64) bool { return round > 40 || round%15 == 0 },
+			handled:  func(round uint64) bool { return round <= 40 },
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+
+			dataDir, err := ioutil.TempDir("", "oasis-runtime-history-test_")
+			require.NoError(err, "TempDir")
+			defer os.RemoveAll(dataDir)
+
+			runtimeID := common.NewTestNamespaceFromSeed([]byte("history prune strategies test ns"), 0)
+
+			// Use a long prune interval so that pruning is only triggered explicitly.
+			history, err := New(dataDir, runtimeID, &Config{
+				Pruner:        tc.pruner,
+				PruneInterval: time.Hour,
+			})
+			require.NoError(err, "New")
+			defer history.Close()
+
+			switch {
+			case tc.handler != nil && tc.inOrder:
+				history.Pruner().RegisterHandler(&testInOrderPruneHandler{tc.handler})
+			case tc.handler != nil:
+				history.Pruner().RegisterHandler(tc.handler)
+			}
+
+			// Create some blocks, one per minute, with the last one being created now.
+			for i := 0; i <= 50; i++ {
+				ts := now.Add(time.Duration(i-50) * time.Minute).Unix()
+				blk := roothash.AnnotatedBlock{
+					Height: int64(i),
+					Block:  block.NewGenesisBlock(runtimeID, uint64(ts)),
+				}
+				blk.Block.Header.Round = uint64(i)
+
+				err = history.Commit(&blk, nil)
+				require.NoError(err, "Commit")
+			}
+
+			err = history.Pruner().Prune(context.Background(), 50)
+			require.NoError(err, "Prune")
+
+			var expectedPruned []uint64
+			for i := uint64(0); i <= 50; i++ {
+				_, err = history.GetBlock(context.Background(), i)
+				if tc.retained(i) {
+					require.NoError(err, "GetBlock(%d)", i)
+				} else {
+					require.Equal(roothash.ErrNotFound, err, "GetBlock should fail for pruned block %d", i)
+				}
+
+				handled := !tc.retained(i)
+				if tc.handled != nil {
+					handled = tc.handled(i)
+				}
+				if handled {
+					expectedPruned = append(expectedPruned, i)
+				}
+			}
+
+			if tc.handler != nil {
+				require.EqualValues(expectedPruned, tc.handler.prunedRounds, "prune handler should be called")
+
+				// Subsequent pruning passes should not pass the same rounds to the handler again.
+				err = history.Pruner().Prune(context.Background(), 50)
+				require.NoError(err, "Prune")
+				require.EqualValues(expectedPruned, tc.handler.prunedRounds, "prune handler should not be called again")
+			}
+		})
+	}
+}
+
+func TestHistoryPruneKeepSize(t *testing.T) {
+	require := require.New(t)
+
+	dataDir, err := ioutil.TempDir("", "oasis-runtime-history-test_")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dataDir)
+
+	runtimeID := common.NewTestNamespaceFromSeed([]byte("history prune keep size test ns"), 0)
+
+	const maxSize = 4096
+	history, err := New(dataDir, runtimeID, &Config{
+		Pruner:        NewKeepSizePruner(maxSize),
+		PruneInterval: time.Hour,
+	})
+	require.NoError(err, "New")
+	defer history.Close()
+
+	ph := testPruneVetoHandler{
+		vetoed:  ^uint64(0),
+		delayed: ^uint64(0),
+	}
+	history.Pruner().RegisterHandler(&ph)
+
+	for i := 0; i <= 50; i++ {
+		blk := roothash.AnnotatedBlock{
+			Height: int64(i),
+			Block:  block.NewGenesisBlock(runtimeID, 0),
+		}
+		blk.Block.Header.Round = uint64(i)
+
+		err = history.Commit(&blk, nil)
+		require.NoError(err, "Commit")
+	}
+
+	err = history.Pruner().Prune(context.Background(), 50)
+	require.NoError(err, "Prune")
+
+	// The oldest rounds should be pruned while the most recent ones are retained.
+	require.NotEmpty(ph.prunedRounds, "some rounds should be pruned")
+	require.Less(len(ph.prunedRounds), 51, "not all rounds should be pruned")
+	for i, round := range ph.prunedRounds {
+		require.EqualValues(i, round, "rounds should be pruned in order")
+	}
+	for i := uint64(0); i <= 50; i++ {
+		_, err = history.GetBlock(context.Background(), i)
+		if i < uint64(len(ph.prunedRounds)) {
+			require.Equal(roothash.ErrNotFound, err, "GetBlock should fail for pruned block %d", i)
+		} else {
+			require.NoError(err, "GetBlock(%d)", i)
+		}
+	}
+
+	// Pruning again should not prune anything as the history is within budget.
+	numPruned := len(ph.prunedRounds)
+	err = history.Pruner().Prune(context.Background(), 50)
+	require.NoError(err, "Prune")
+	require.Len(ph.prunedRounds, numPruned, "no further rounds should be pruned")
+}
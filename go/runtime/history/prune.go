@@ -2,12 +2,16 @@ package history
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/dgraph-io/badger/v2"
 
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 )
 
 const (
@@ -15,6 +19,21 @@ const (
 	PrunerStrategyNone = "none"
 	// PrunerStrategyKeepLast is the name of the keep last pruner strategy.
 	PrunerStrategyKeepLast = "keep_last"
+	// PrunerStrategyKeepDuration is the name of the keep duration pruner strategy.
+	PrunerStrategyKeepDuration = "keep_duration"
+	// PrunerStrategyKeepSize is the name of the keep size pruner strategy.
+	PrunerStrategyKeepSize = "keep_size"
+)
+
+var (
+	// ErrPruneDelayed is the error that a prune handler should return from CanPrune when the
+	// given round cannot be pruned yet. Pruning stops at the given round and is retried during
+	// the next pruning pass.
+	ErrPruneDelayed = errors.New("runtime/history: prune delayed")
+	// ErrPruneVetoed is the error that a prune handler should return from CanPrune when the
+	// given round must be retained. The round is skipped and pruning continues with subsequent
+	// rounds.
+	ErrPruneVetoed = errors.New("runtime/history: prune vetoed")
 )
 
 // PrunerFactory is the runtime history pruner factory interface.
@@ -23,6 +42,14 @@ type PrunerFactory func(db *DB) (Pruner, error)
 // PruneHandler is a handler that is called when rounds are pruned
 // from history.
 type PruneHandler interface {
+	// CanPrune is called for each round (in ascending order) that the pruner would like to prune
+	// before any rounds are actually pruned.
+	//
+	// If ErrPruneDelayed is returned, the given round and all subsequent rounds are not pruned
+	// during the current pruning pass. If ErrPruneVetoed is returned, the given round is retained
+	// but subsequent rounds may still be pruned. Any other error aborts pruning.
+	CanPrune(ctx context.Context, round uint64) error
+
 	// Prune is called before the specified rounds are pruned.
 	//
 	// If an error is returned, pruning is aborted and the rounds are
@@ -34,6 +61,19 @@ type PruneHandler interface {
 	Prune(ctx context.Context, rounds []uint64) error
 }
 
+// InOrderPruneHandler is a prune handler that can only prune rounds in ascending order without
+// gaps, e.g., because the underlying storage can only prune its earliest version.
+//
+// Rounds vetoed by a prune handler are a barrier for such handlers. When an in-order prune handler
+// is registered, pruning stops at the first vetoed round as none of the subsequent rounds could be
+// pruned by the handler.
+type InOrderPruneHandler interface {
+	PruneHandler
+
+	// PrunesInOrder is a marker method for prune handlers that can only prune rounds in order.
+	PrunesInOrder()
+}
+
 // Pruner is the runtime history pruner interface.
 type Pruner interface {
 	// Prune purges unneeded history, given the latest round.
@@ -43,10 +83,26 @@ type Pruner interface {
 	RegisterHandler(handler PruneHandler)
 }
 
+// PrunerOption is an option for the pruners that retain a subset of rounds.
+type PrunerOption func(p *retentionPruner)
+
+// WithArchiveInterval configures the pruner to never prune blocks of rounds that are a multiple of
+// the given interval from history, keeping them as an archive of sampled history. A zero interval
+// disables archiving.
+//
+// Archived rounds are still passed to the prune handlers (once), so that e.g. storage can keep
+// pruning its versions in order.
+func WithArchiveInterval(interval uint64) PrunerOption {
+	return func(p *retentionPruner) {
+		p.archiveInterval = interval
+	}
+}
+
 type prunerBase struct {
 	sync.RWMutex
 
 	handlers []PruneHandler
+	inOrder  bool
 }
 
 func (p *prunerBase) RegisterHandler(handler PruneHandler) {
@@ -54,6 +110,21 @@ func (p *prunerBase) RegisterHandler(handler PruneHandler) {
 	defer p.Unlock()
 
 	p.handlers = append(p.handlers, handler)
+	if _, ok := handler.(InOrderPruneHandler); ok {
+		p.inOrder = true
+	}
+}
+
+// canPrune checks with all registered handlers whether the given round can be pruned.
+//
+// The caller must hold the read lock.
+func (p *prunerBase) canPrune(ctx context.Context, round uint64) error {
+	for _, ph := range p.handlers {
+		if err := ph.CanPrune(ctx, round); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func newPrunerBase() prunerBase {
@@ -77,34 +148,54 @@ func NewNonePruner() PrunerFactory {
 	}
 }
 
-type keepLastPruner struct {
+// retentionPolicy decides which rounds are retained in history.
+type retentionPolicy interface {
+	// startPass is called at the start of each pruning pass.
+	startPass(tx *badger.Txn, latestRound uint64) error
+
+	// shouldPrune returns true iff the given round should be pruned. Rounds are visited in
+	// ascending order and a pruning pass stops at the first round that should not be pruned.
+	shouldPrune(tx *badger.Txn, round uint64, item *badger.Item) (bool, error)
+
+	// roundPruned is called when the given round has been selected for pruning, before any
+	// of its entries are removed.
+	roundPruned(tx *badger.Txn, round uint64, item *badger.Item)
+}
+
+type retentionPruner struct {
 	prunerBase
 
 	logger *logging.Logger
 	db     *DB
 
-	numKept uint64
+	policy          retentionPolicy
+	prefetchValues  bool
+	archiveInterval uint64
 }
 
-func (p *keepLastPruner) Prune(ctx context.Context, latestRound uint64) error {
-	if latestRound < p.numKept {
-		return nil
-	}
+func (p *retentionPruner) isArchived(round uint64) bool {
+	return p.archiveInterval > 0 && round%p.archiveInterval == 0
+}
 
+func (p *retentionPruner) Prune(ctx context.Context, latestRound uint64) error {
 	p.prunerBase.RLock()
 	defer p.prunerBase.RUnlock()
 
-	lastPrunedRound := latestRound - p.numKept
-
 	return p.db.db.Update(func(tx *badger.Txn) error {
-		// NOTE: Do not prefetch values as we are only looking at keys.
+		if err := p.policy.startPass(tx, latestRound); err != nil {
+			return err
+		}
+
 		it := tx.NewIterator(badger.IteratorOptions{
-			Prefix: blockKeyFmt.Encode(),
+			PrefetchValues: p.prefetchValues,
+			PrefetchSize:   100,
+			Prefix:         blockKeyFmt.Encode(),
 		})
 		defer it.Close()
 
 		// Start with the smallest round and proceed forward.
 		var pruned []uint64
+	roundLoop:
 		for it.Rewind(); it.Valid(); it.Next() {
 			item := it.Item()
 
@@ -114,11 +205,60 @@ func (p *keepLastPruner) Prune(ctx context.Context, latestRound uint64) error {
 				panic("runtime/history: bad iterator")
 			}
 
-			if round > lastPrunedRound {
+			prune, err := p.policy.shouldPrune(tx, round, item)
+			if err != nil {
+				return err
+			}
+			if !prune {
 				break
 			}
 
-			if err := tx.Delete(messageResultsKeyFmt.Encode(round)); err != nil {
+			archived := p.isArchived(round)
+			if archived {
+				// Skip archived rounds that have already been pruned by the handlers.
+				_, err = tx.Get(archivedRoundKeyFmt.Encode(round))
+				switch err {
+				case nil:
+					continue
+				case badger.ErrKeyNotFound:
+				default:
+					return err
+				}
+			}
+
+			switch err = p.prunerBase.canPrune(ctx, round); err {
+			case nil:
+			case ErrPruneVetoed:
+				if p.prunerBase.inOrder {
+					break roundLoop
+				}
+				continue
+			case ErrPruneDelayed:
+				break roundLoop
+			default:
+				p.logger.Error("prune handler failed, aborting prune",
+					"err", err,
+					"round", round,
+				)
+				return fmt.Errorf("runtime/history: prune handler failed: %w", err)
+			}
+
+			if archived {
+				// Keep the archived block in history and only mark the round as pruned.
+				if err = tx.Set(archivedRoundKeyFmt.Encode(round), []byte{}); err != nil {
+					if err == badger.ErrTxnTooBig {
+						// We can't prune any more rounds in this transaction.
+						break
+					}
+					return err
+				}
+				pruned = append(pruned, round)
+				continue
+			}
+
+			p.policy.roundPruned(tx, round, item)
+
+			if err = tx.Delete(messageResultsKeyFmt.Encode(round)); err != nil {
 				if err == badger.ErrTxnTooBig {
 					// We can't prune any more rounds in this transaction.
 					break
@@ -126,7 +266,7 @@ func (p *keepLastPruner) Prune(ctx context.Context, latestRound uint64) error {
 				return err
 			}
 
-			if err := tx.Delete(item.KeyCopy(nil)); err != nil {
+			if err = tx.Delete(item.KeyCopy(nil)); err != nil {
 				return err
 			}
 
@@ -156,15 +296,148 @@ func (p *keepLastPruner) Prune(ctx context.Context, latestRound uint64) error {
 	})
 }
 
+func newRetentionPruner(
+	name string,
+	db *DB,
+	policy retentionPolicy,
+	prefetchValues bool,
+	opts []PrunerOption,
+) *retentionPruner {
+	p := &retentionPruner{
+		prunerBase:     newPrunerBase(),
+		logger:         logging.GetLogger("history/prune/" + name),
+		db:             db,
+		policy:         policy,
+		prefetchValues: prefetchValues,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+type keepLastPolicy struct {
+	numKept uint64
+
+	lastPrunedRound uint64
+	skip            bool
+}
+
+func (kp *keepLastPolicy) startPass(tx *badger.Txn, latestRound uint64) error {
+	kp.skip = latestRound < kp.numKept
+	if !kp.skip {
+		kp.lastPrunedRound = latestRound - kp.numKept
+	}
+	return nil
+}
+
+func (kp *keepLastPolicy) shouldPrune(tx *badger.Txn, round uint64, item *badger.Item) (bool, error) {
+	return !kp.skip && round <= kp.lastPrunedRound, nil
+}
+
+func (kp *keepLastPolicy) roundPruned(tx *badger.Txn, round uint64, item *badger.Item) {
+}
+
 // NewKeepLastPruner creates a pruner that keeps the last configured
 // number of rounds.
-func NewKeepLastPruner(numKept uint64) PrunerFactory {
+func NewKeepLastPruner(numKept uint64, opts ...PrunerOption) PrunerFactory {
 	return func(db *DB) (Pruner, error) {
-		return &keepLastPruner{
-			prunerBase: newPrunerBase(),
-			logger:     logging.GetLogger("history/prune/keep_last"),
-			db:         db,
-			numKept:    numKept,
-		}, nil
+		policy := &keepLastPolicy{
+			numKept: numKept,
+		}
+		return newRetentionPruner(PrunerStrategyKeepLast, db, policy, false, opts), nil
+	}
+}
+
+type keepDurationPolicy struct {
+	keepFor time.Duration
+	nowFn   func() time.Time
+
+	cutoff uint64
+}
+
+func (kp *keepDurationPolicy) startPass(tx *badger.Txn, latestRound uint64) error {
+	cutoff := kp.nowFn().Add(-kp.keepFor).Unix()
+	if cutoff < 0 {
+		cutoff = 0
+	}
+	kp.cutoff = uint64(cutoff)
+	return nil
+}
+
+func (kp *keepDurationPolicy) shouldPrune(tx *badger.Txn, round uint64, item *badger.Item) (bool, error) {
+	var blk roothash.AnnotatedBlock
+	if err := item.Value(func(val []byte) error {
+		return cbor.UnmarshalTrusted(val, &blk)
+	}); err != nil {
+		return false, err
+	}
+
+	// Block timestamps are non-decreasing, so all subsequent blocks are retained as well.
+	return blk.Block.Header.Timestamp < kp.cutoff, nil
+}
+
+func (kp *keepDurationPolicy) roundPruned(tx *badger.Txn, round uint64, item *badger.Item) {
+}
+
+// NewKeepDurationPruner creates a pruner that keeps rounds whose block timestamp is within the
+// configured duration from the current time.
+func NewKeepDurationPruner(keepFor time.Duration, opts ...PrunerOption) PrunerFactory {
+	return func(db *DB) (Pruner, error) {
+		policy := &keepDurationPolicy{
+			keepFor: keepFor,
+			nowFn:   time.Now,
+		}
+		return newRetentionPruner(PrunerStrategyKeepDuration, db, policy, true, opts), nil
+	}
+}
+
+type keepSizePolicy struct {
+	maxSize uint64
+
+	totalSize uint64
+}
+
+// roundSize returns the estimated size of all entries stored for the given round.
+func (kp *keepSizePolicy) roundSize(tx *badger.Txn, round uint64, blockItem *badger.Item) uint64 {
+	size := uint64(blockItem.EstimatedSize())
+	if item, err := tx.Get(messageResultsKeyFmt.Encode(round)); err == nil {
+		size += uint64(item.EstimatedSize())
+	}
+	return size
+}
+
+func (kp *keepSizePolicy) startPass(tx *badger.Txn, latestRound uint64) error {
+	// NOTE: Do not prefetch values as we are only looking at keys.
+	it := tx.NewIterator(badger.IteratorOptions{})
+	defer it.Close()
+
+	kp.totalSize = 0
+	for it.Rewind(); it.Valid(); it.Next() {
+		kp.totalSize += uint64(it.Item().EstimatedSize())
+	}
+	return nil
+}
+
+func (kp *keepSizePolicy) shouldPrune(tx *badger.Txn, round uint64, item *badger.Item) (bool, error) {
+	return kp.totalSize > kp.maxSize, nil
+}
+
+func (kp *keepSizePolicy) roundPruned(tx *badger.Txn, round uint64, item *badger.Item) {
+	size := kp.roundSize(tx, round, item)
+	if size > kp.totalSize {
+		size = kp.totalSize
+	}
+	kp.totalSize -= size
+}
+
+// NewKeepSizePruner creates a pruner that prunes the oldest rounds until the estimated size of
+// the stored history is within the configured budget (in bytes).
+func NewKeepSizePruner(maxSize uint64, opts ...PrunerOption) PrunerFactory {
+	return func(db *DB) (Pruner, error) {
+		policy := &keepSizePolicy{
+			maxSize: maxSize,
+		}
+		return newRetentionPruner(PrunerStrategyKeepSize, db, policy, false, opts), nil
 	}
 }
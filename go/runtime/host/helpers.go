@@ -26,7 +26,14 @@ type RichRuntime interface {
 	Runtime
 
 	// CheckTx requests the runtime to check a given transaction.
-	CheckTx(ctx context.Context, rb *block.Block, lb *consensus.LightBlock, tx []byte) error
+	//
+	// In case the transaction is valid, the result of the check is returned.
+	CheckTx(ctx context.Context, rb *block.Block, lb *consensus.LightBlock, tx []byte) (*protocol.CheckTxResult, error)
+
+	// CheckTxBatch requests the runtime to check a given batch of transactions.
+	//
+	// The returned results correspond to the transactions in the batch, in the same order.
+	CheckTxBatch(ctx context.Context, rb *block.Block, lb *consensus.LightBlock, batch [][]byte) ([]protocol.CheckTxResult, error)
 
 	// Query requests the runtime to answer a runtime-specific query.
 	Query(ctx context.Context, rb *block.Block, method string, args cbor.RawMessage) (cbor.RawMessage, error)
@@ -37,34 +44,44 @@ type richRuntime struct {
 }
 
 // Implements RichRuntime.
-func (r *richRuntime) CheckTx(ctx context.Context, rb *block.Block, lb *consensus.LightBlock, tx []byte) error {
+func (r *richRuntime) CheckTx(ctx context.Context, rb *block.Block, lb *consensus.LightBlock, tx []byte) (*protocol.CheckTxResult, error) {
+	results, err := r.CheckTxBatch(ctx, rb, lb, [][]byte{tx})
+	if err != nil {
+		return nil, err
+	}
+
+	// Interpret CheckTx result.
+	result := results[0]
+	if !result.IsSuccess() {
+		return nil, fmt.Errorf("%w: %s", ErrCheckTxFailed, result.Error)
+	}
+
+	return &result, nil
+}
+
+// Implements RichRuntime.
+func (r *richRuntime) CheckTxBatch(ctx context.Context, rb *block.Block, lb *consensus.LightBlock, batch [][]byte) ([]protocol.CheckTxResult, error) {
 	if rb == nil || lb == nil {
-		return ErrInvalidArgument
+		return nil, ErrInvalidArgument
 	}
 
 	resp, err := r.Call(ctx, &protocol.Body{
 		RuntimeCheckTxBatchRequest: &protocol.RuntimeCheckTxBatchRequest{
 			ConsensusBlock: *lb,
-			Inputs:         transaction.RawBatch{tx},
+			Inputs:         transaction.RawBatch(batch),
 			Block:          *rb,
 		},
 	})
 	switch {
 	case err != nil:
-		return fmt.Errorf("%w: %s", ErrInternal, err)
+		return nil, fmt.Errorf("%w: %s", ErrInternal, err)
 	case resp.RuntimeCheckTxBatchResponse == nil:
-		return fmt.Errorf("%w: malformed runtime response", ErrInternal)
-	case len(resp.RuntimeCheckTxBatchResponse.Results) != 1:
-		return fmt.Errorf("%w: malformed runtime response: incorrect number of results", ErrInternal)
-	}
-
-	// Interpret CheckTx result.
-	result := resp.RuntimeCheckTxBatchResponse.Results[0]
-	if !result.IsSuccess() {
-		return fmt.Errorf("%w: %s", ErrCheckTxFailed, result.Error)
+		return nil, fmt.Errorf("%w: malformed runtime response", ErrInternal)
+	case len(resp.RuntimeCheckTxBatchResponse.Results) != len(batch):
+		return nil, fmt.Errorf("%w: malformed runtime response: incorrect number of results", ErrInternal)
 	}
 
-	return nil
+	return resp.RuntimeCheckTxBatchResponse.Results, nil
 }
 
 // Implements RichRuntime.
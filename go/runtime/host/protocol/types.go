@@ -215,6 +215,33 @@ func (r *CheckTxResult) IsSuccess() bool {
 	return r.Error.Code == errors.CodeNoError
 }
 
+// Metadata decodes the transaction scheduling metadata from the runtime-specific metadata.
+//
+// In case no metadata is available, nil is returned.
+func (r *CheckTxResult) Metadata() (*CheckTxMetadata, error) {
+	if len(r.Meta) == 0 {
+		return nil, nil
+	}
+	var meta CheckTxMetadata
+	if err := cbor.Unmarshal(r.Meta, &meta); err != nil {
+		return nil, fmt.Errorf("malformed check tx metadata: %w", err)
+	}
+	return &meta, nil
+}
+
+// CheckTxMetadata is the transaction metadata that the runtime may return from CheckTx in order
+// to enable prioritized transaction scheduling.
+type CheckTxMetadata struct {
+	// Priority is the transaction priority. Transactions with higher priority are scheduled first.
+	Priority uint64 `json:"priority,omitempty"`
+
+	// Sender is an opaque identifier of the transaction sender. Transactions of the same sender
+	// are scheduled in order of their sequence numbers.
+	Sender []byte `json:"sender,omitempty"`
+	// SenderSeq is the sequence number (nonce) of the transaction for the given sender.
+	SenderSeq uint64 `json:"sender_seq,omitempty"`
+}
+
 // RuntimeCheckTxBatchResponse is a worker check tx batch response message body.
 type RuntimeCheckTxBatchResponse struct {
 	// Batch of CheckTx results corresponding to transactions passed on input.
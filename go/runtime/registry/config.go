@@ -55,6 +55,15 @@ const (
 	// CfgHistoryPrunerKeepLastNum configures the number of last kept
 	// rounds when using the "keep last" pruner strategy.
 	CfgHistoryPrunerKeepLastNum = "runtime.history.pruner.num_kept"
+	// CfgHistoryPrunerKeepDuration configures the duration for which rounds
+	// are kept when using the "keep duration" pruner strategy.
+	CfgHistoryPrunerKeepDuration = "runtime.history.pruner.keep_duration"
+	// CfgHistoryPrunerKeepSize configures the maximum size of the history
+	// when using the "keep size" pruner strategy.
+	CfgHistoryPrunerKeepSize = "runtime.history.pruner.keep_size"
+	// CfgHistoryPrunerArchiveInterval configures the interval of archived
+	// rounds whose blocks are never pruned from history (0 disables archiving).
+	CfgHistoryPrunerArchiveInterval = "runtime.history.pruner.archive_interval"
 
 	// CfgTagIndexerBackend configures the history tag indexer backend.
 	CfgTagIndexerBackend = "runtime.history.tag_indexer.backend"
@@ -221,13 +230,30 @@ func newConfig(consensus consensus.Backend, ias ias.Endpoint) (*RuntimeConfig, e
 		cfg.Host = &rh
 	}
 
+	var prunerOpts []history.PrunerOption
+	if interval := viper.GetUint64(CfgHistoryPrunerArchiveInterval); interval > 0 {
+		prunerOpts = append(prunerOpts, history.WithArchiveInterval(interval))
+	}
+
 	strategy := viper.GetString(CfgHistoryPrunerStrategy)
 	switch strings.ToLower(strategy) {
 	case history.PrunerStrategyNone:
 		cfg.History.Pruner = history.NewNonePruner()
 	case history.PrunerStrategyKeepLast:
 		numKept := viper.GetUint64(CfgHistoryPrunerKeepLastNum)
-		cfg.History.Pruner = history.NewKeepLastPruner(numKept)
+		cfg.History.Pruner = history.NewKeepLastPruner(numKept, prunerOpts...)
+	case history.PrunerStrategyKeepDuration:
+		keepFor := viper.GetDuration(CfgHistoryPrunerKeepDuration)
+		if keepFor <= 0 {
+			return nil, fmt.Errorf("runtime/registry: history keep duration must be positive (got %s)", keepFor)
+		}
+		cfg.History.Pruner = history.NewKeepDurationPruner(keepFor, prunerOpts...)
+	case history.PrunerStrategyKeepSize:
+		maxSize := viper.GetSizeInBytes(CfgHistoryPrunerKeepSize)
+		if maxSize == 0 {
+			return nil, fmt.Errorf("runtime/registry: history keep size must be positive")
+		}
+		cfg.History.Pruner = history.NewKeepSizePruner(uint64(maxSize), prunerOpts...)
 	default:
 		return nil, fmt.Errorf("runtime/registry: unknown history pruner strategy: %s", strategy)
 	}
@@ -243,6 +269,8 @@ func newConfig(consensus consensus.Backend, ias ias.Endpoint) (*RuntimeConfig, e
 		cfg.TagIndexer = tagindexer.NewNopBackend()
 	case tagindexer.BleveBackendName:
 		cfg.TagIndexer = tagindexer.NewBleveBackend()
+	case tagindexer.SQLiteBackendName:
+		cfg.TagIndexer = tagindexer.NewSQLiteBackend()
 	default:
 		return nil, fmt.Errorf("runtime/registry: unknown tag indexer backend: %s", tagIndexer)
 	}
@@ -262,8 +290,11 @@ func init() {
 	Flags.String(CfgHistoryPrunerStrategy, history.PrunerStrategyNone, "History pruner strategy")
 	Flags.Duration(CfgHistoryPrunerInterval, 2*time.Minute, "History pruning interval")
 	Flags.Uint64(CfgHistoryPrunerKeepLastNum, 600, "Keep last history pruner: number of last rounds to keep")
+	Flags.Duration(CfgHistoryPrunerKeepDuration, 24*time.Hour, "Keep duration history pruner: how long to keep rounds for")
+	Flags.String(CfgHistoryPrunerKeepSize, "1gb", "Keep size history pruner: maximum size of the history")
+	Flags.Uint64(CfgHistoryPrunerArchiveInterval, 0, "History pruner: keep the block of every n-th round in history forever (0 disables archiving)")
 
-	Flags.String(CfgTagIndexerBackend, "", "Runtime tag indexer backend (disabled by default)")
+	Flags.String(CfgTagIndexerBackend, "", "Runtime tag indexer backend (bleve, sqlite; disabled by default)")
 
 	_ = viper.BindPFlags(Flags)
 }
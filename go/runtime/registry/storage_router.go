@@ -43,6 +43,14 @@ func (sr *storageRouter) SyncIterate(ctx context.Context, request *api.IterateRe
 	return rt.Storage().SyncIterate(ctx, request)
 }
 
+func (sr *storageRouter) SyncGetRange(ctx context.Context, request *api.GetRangeRequest) (*api.ProofResponse, error) {
+	rt, err := sr.getRuntime(request.Tree.Root.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	return rt.Storage().SyncGetRange(ctx, request)
+}
+
 func (sr *storageRouter) Apply(ctx context.Context, request *api.ApplyRequest) ([]*api.Receipt, error) {
 	rt, err := sr.getRuntime(request.Namespace)
 	if err != nil {
@@ -2,8 +2,11 @@
 package api
 
 import (
+	"time"
+
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
 )
 
 // Scheduler defines an algorithm for scheduling incoming transactions.
@@ -12,7 +15,10 @@ type Scheduler interface {
 	Name() string
 
 	// QueueTx queues a transaction for scheduling.
-	QueueTx(tx []byte) error
+	//
+	// The optional metadata returned by the runtime's CheckTx may be used by the scheduler to
+	// determine the order in which transactions are scheduled.
+	QueueTx(tx []byte, meta *protocol.CheckTxMetadata) error
 
 	// AppendTxBatch appends a transaction batch for scheduling.
 	//
@@ -32,9 +38,25 @@ type Scheduler interface {
 	// IsQueued returns if a transaction is queued.
 	IsQueued(hash.Hash) bool
 
+	// GetTransaction returns the queued transaction with the given hash (if any).
+	GetTransaction(txHash hash.Hash) (*QueuedTx, bool)
+
+	// GetTransactions returns all queued transactions.
+	GetTransactions() []*QueuedTx
+
 	// UpdateParameters updates the scheduling parameters.
 	UpdateParameters(registry.TxnSchedulerParameters) error
 
 	// Clear clears the transaction queue.
 	Clear()
 }
+
+// QueuedTx is a transaction queued for scheduling.
+type QueuedTx struct {
+	// Tx is the raw transaction.
+	Tx []byte
+	// Hash is the hash of the raw transaction.
+	Hash hash.Hash
+	// QueuedAt is the time at which the transaction was queued.
+	QueuedAt time.Time
+}
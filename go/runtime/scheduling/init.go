@@ -5,6 +5,7 @@ import (
 
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	"github.com/oasisprotocol/oasis-core/go/runtime/scheduling/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/scheduling/priority"
 	"github.com/oasisprotocol/oasis-core/go/runtime/scheduling/simple"
 	"github.com/oasisprotocol/oasis-core/go/runtime/scheduling/simple/txpool/orderedmap"
 )
@@ -14,6 +15,8 @@ func New(maxTxPoolSize uint64, params registry.TxnSchedulerParameters) (api.Sche
 	switch params.Algorithm {
 	case simple.Name:
 		return simple.New(orderedmap.Name, maxTxPoolSize, params)
+	case priority.Name:
+		return priority.New(maxTxPoolSize, params)
 	default:
 		return nil, fmt.Errorf("invalid transaction scheduler algorithm: %s", params.Algorithm)
 	}
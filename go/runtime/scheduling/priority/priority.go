@@ -0,0 +1,421 @@
+// Package priority implements a batching transaction scheduler that orders transactions by their
+// priority.
+package priority
+
+import (
+	"container/heap"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
+	"github.com/oasisprotocol/oasis-core/go/runtime/scheduling/api"
+	txpool "github.com/oasisprotocol/oasis-core/go/runtime/scheduling/simple/txpool/api"
+	p2pError "github.com/oasisprotocol/oasis-core/go/worker/common/p2p/error"
+)
+
+const (
+	// Name of the scheduler.
+	Name = registry.TxnSchedulerPriority
+)
+
+var (
+	// ErrPriorityTooLow is the error returned when a transaction does not have a high enough
+	// priority to either replace a transaction with the same sender sequence number or to evict
+	// another transaction from a full pool.
+	ErrPriorityTooLow = p2pError.Permanent(fmt.Errorf("priority: transaction priority too low"))
+
+	_ api.Scheduler = (*scheduler)(nil)
+)
+
+type item struct {
+	tx       []byte
+	hash     hash.Hash
+	queuedAt time.Time
+
+	priority  uint64
+	sender    string
+	hasSender bool
+	senderSeq uint64
+
+	// order is the order in which the transaction was queued and is used to break ties between
+	// transactions with the same priority.
+	order uint64
+}
+
+// higherPriority returns true iff item a should be scheduled before item b.
+func higherPriority(a, b *item) bool {
+	if a.priority != b.priority {
+		return a.priority > b.priority
+	}
+	return a.order < b.order
+}
+
+// readyQueue is a max-heap of transactions ready for scheduling. For each transaction its index
+// in the sender's queue is tracked so that the next transaction of the same sender can become
+// ready once the transaction is scheduled.
+type readyQueue []readyItem
+
+type readyItem struct {
+	*item
+
+	senderIndex int
+}
+
+func (q readyQueue) Len() int           { return len(q) }
+func (q readyQueue) Less(i, j int) bool { return higherPriority(q[i].item, q[j].item) }
+func (q readyQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func (q *readyQueue) Push(x interface{}) {
+	*q = append(*q, x.(readyItem))
+}
+
+func (q *readyQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	it := old[n-1]
+	*q = old[:n-1]
+	return it
+}
+
+type scheduler struct {
+	sync.Mutex
+
+	logger *logging.Logger
+
+	transactions map[hash.Hash]*item
+	// senders contains the transactions of each sender ordered by sequence number.
+	senders   map[string][]*item
+	sizeBytes uint64
+	nextOrder uint64
+
+	maxTxPoolSize     uint64
+	maxBatchSize      uint64
+	maxBatchSizeBytes uint64
+}
+
+func (s *scheduler) QueueTx(tx []byte, meta *protocol.CheckTxMetadata) error {
+	s.Lock()
+	defer s.Unlock()
+
+	switch err := s.addTxLocked(tx, hash.NewFromBytes(tx), meta); err {
+	case nil:
+		return nil
+	case txpool.ErrCallAlreadyExists:
+		// Return success in case of duplicate calls to avoid the client
+		// mistaking this for an actual error.
+		s.logger.Warn("ignoring duplicate call",
+			"batch", tx,
+		)
+		return nil
+	default:
+		return err
+	}
+}
+
+// AppendTxBatch appends a batch of transactions.
+//
+// As no metadata is available, the transactions are queued with the lowest priority. Transactions
+// that fail checks are skipped, not affecting the insertion of other transactions. If any
+// transaction fails a check a non-nil error is returned.
+func (s *scheduler) AppendTxBatch(batch [][]byte) error {
+	s.Lock()
+	defer s.Unlock()
+
+	var errs error
+	for i, tx := range batch {
+		if err := s.addTxLocked(tx, hash.NewFromBytes(tx), nil); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("failed inserting tx: %d, error: %w", i, err))
+		}
+	}
+	return errs
+}
+
+func (s *scheduler) RemoveTxBatch(batch [][]byte) error {
+	s.Lock()
+	defer s.Unlock()
+
+	for _, tx := range batch {
+		if it, ok := s.transactions[hash.NewFromBytes(tx)]; ok {
+			s.removeTxLocked(it)
+		}
+	}
+	return nil
+}
+
+func (s *scheduler) GetBatch(force bool) [][]byte {
+	s.Lock()
+	defer s.Unlock()
+
+	// Check if a batch is ready.
+	poolSize := uint64(len(s.transactions))
+	if poolSize < s.maxBatchSize && s.sizeBytes < s.maxBatchSizeBytes && !force {
+		return nil
+	}
+
+	// Transactions without a sender are always ready, while for each sender only the
+	// transaction with the lowest sequence number is ready.
+	var ready readyQueue
+	for _, it := range s.transactions {
+		if !it.hasSender {
+			ready = append(ready, readyItem{item: it})
+		}
+	}
+	for _, txs := range s.senders {
+		ready = append(ready, readyItem{item: txs[0]})
+	}
+	heap.Init(&ready)
+
+	var batch [][]byte
+	var batchSizeBytes uint64
+	for ready.Len() > 0 && uint64(len(batch)) < s.maxBatchSize {
+		next := heap.Pop(&ready).(readyItem)
+
+		// Skip transactions that do not fit into the batch. In case the transaction has a
+		// sender, none of the sender's later transactions can be scheduled either.
+		txSize := uint64(len(next.tx))
+		if batchSizeBytes+txSize > s.maxBatchSizeBytes {
+			continue
+		}
+
+		batch = append(batch, next.tx)
+		batchSizeBytes += txSize
+
+		if next.hasSender {
+			txs := s.senders[next.sender]
+			if idx := next.senderIndex + 1; idx < len(txs) {
+				heap.Push(&ready, readyItem{item: txs[idx], senderIndex: idx})
+			}
+		}
+	}
+
+	return batch
+}
+
+func (s *scheduler) UnscheduledSize() uint64 {
+	s.Lock()
+	defer s.Unlock()
+
+	return uint64(len(s.transactions))
+}
+
+func (s *scheduler) IsQueued(id hash.Hash) bool {
+	s.Lock()
+	defer s.Unlock()
+
+	_, ok := s.transactions[id]
+	return ok
+}
+
+func (s *scheduler) GetTransaction(txHash hash.Hash) (*api.QueuedTx, bool) {
+	s.Lock()
+	defer s.Unlock()
+
+	it, ok := s.transactions[txHash]
+	if !ok {
+		return nil, false
+	}
+	return &api.QueuedTx{
+		Tx:       it.tx,
+		Hash:     it.hash,
+		QueuedAt: it.queuedAt,
+	}, true
+}
+
+func (s *scheduler) GetTransactions() []*api.QueuedTx {
+	s.Lock()
+	defer s.Unlock()
+
+	txs := make([]*api.QueuedTx, 0, len(s.transactions))
+	for _, it := range s.transactions {
+		txs = append(txs, &api.QueuedTx{
+			Tx:       it.tx,
+			Hash:     it.hash,
+			QueuedAt: it.queuedAt,
+		})
+	}
+	return txs
+}
+
+func (s *scheduler) Clear() {
+	s.Lock()
+	defer s.Unlock()
+
+	s.clearLocked()
+}
+
+func (s *scheduler) UpdateParameters(params registry.TxnSchedulerParameters) error {
+	if params.Algorithm != Name {
+		return fmt.Errorf("unexpected transaction scheduling algorithm: %s", params.Algorithm)
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	s.maxBatchSize = params.MaxBatchSize
+	s.maxBatchSizeBytes = params.MaxBatchSizeBytes
+
+	// Remove any transactions that are bigger than the updated maximum batch size in bytes.
+	for _, it := range s.transactions {
+		if uint64(len(it.tx)) > s.maxBatchSizeBytes {
+			s.removeTxLocked(it)
+		}
+	}
+
+	return nil
+}
+
+func (s *scheduler) Name() string {
+	return Name
+}
+
+func (s *scheduler) clearLocked() {
+	s.transactions = make(map[hash.Hash]*item)
+	s.senders = make(map[string][]*item)
+	s.sizeBytes = 0
+}
+
+// NOTE: Assumes lock is held.
+func (s *scheduler) addTxLocked(tx []byte, txHash hash.Hash, meta *protocol.CheckTxMetadata) error {
+	if uint64(len(tx)) > s.maxBatchSizeBytes {
+		return txpool.ErrCallTooLarge
+	}
+	if _, ok := s.transactions[txHash]; ok {
+		return txpool.ErrCallAlreadyExists
+	}
+
+	it := &item{
+		tx:       tx,
+		hash:     txHash,
+		queuedAt: time.Now(),
+		order:    s.nextOrder,
+	}
+	if meta != nil {
+		it.priority = meta.Priority
+		it.hasSender = len(meta.Sender) > 0
+		it.sender = string(meta.Sender)
+		it.senderSeq = meta.SenderSeq
+	}
+
+	// A transaction with the same sender sequence number is only replaced by a transaction
+	// with a higher priority.
+	var replaced *item
+	if it.hasSender {
+		txs := s.senders[it.sender]
+		idx := sort.Search(len(txs), func(i int) bool { return txs[i].senderSeq >= it.senderSeq })
+		if idx < len(txs) && txs[idx].senderSeq == it.senderSeq {
+			if txs[idx].priority >= it.priority {
+				return ErrPriorityTooLow
+			}
+			replaced = txs[idx]
+		}
+	}
+
+	// Evict the lowest priority transaction in case the pool is full.
+	if replaced == nil && uint64(len(s.transactions)) >= s.maxTxPoolSize {
+		victim := s.evictionCandidateLocked()
+		switch {
+		case victim == nil:
+			return txpool.ErrFull
+		case !higherPriority(it, victim):
+			return ErrPriorityTooLow
+		case it.hasSender && victim.hasSender && it.sender == victim.sender && victim.senderSeq < it.senderSeq:
+			// Evicting an earlier transaction of the same sender would make this one unschedulable.
+			return ErrPriorityTooLow
+		}
+
+		s.logger.Debug("evicting lower priority transaction",
+			"tx_hash", victim.hash,
+			"priority", victim.priority,
+		)
+		s.removeTxLocked(victim)
+	}
+	if replaced != nil {
+		s.logger.Debug("replacing transaction with a higher priority transaction",
+			"tx_hash", replaced.hash,
+			"priority", replaced.priority,
+		)
+		s.removeTxLocked(replaced)
+	}
+
+	s.nextOrder++
+	s.transactions[txHash] = it
+	s.sizeBytes += uint64(len(tx))
+	if it.hasSender {
+		txs := s.senders[it.sender]
+		idx := sort.Search(len(txs), func(i int) bool { return txs[i].senderSeq >= it.senderSeq })
+		txs = append(txs, nil)
+		copy(txs[idx+1:], txs[idx:])
+		txs[idx] = it
+		s.senders[it.sender] = txs
+	}
+
+	return nil
+}
+
+// evictionCandidateLocked returns the transaction that should be evicted first when the pool is
+// full. Only the last transaction of each sender is considered so that eviction does not leave
+// gaps in the sender's sequence.
+//
+// NOTE: Assumes lock is held.
+func (s *scheduler) evictionCandidateLocked() *item {
+	var victim *item
+	for _, it := range s.transactions {
+		if it.hasSender {
+			txs := s.senders[it.sender]
+			if txs[len(txs)-1] != it {
+				continue
+			}
+		}
+		if victim == nil || higherPriority(victim, it) {
+			victim = it
+		}
+	}
+	return victim
+}
+
+// NOTE: Assumes lock is held.
+func (s *scheduler) removeTxLocked(it *item) {
+	delete(s.transactions, it.hash)
+	s.sizeBytes -= uint64(len(it.tx))
+
+	if !it.hasSender {
+		return
+	}
+	txs := s.senders[it.sender]
+	for i, v := range txs {
+		if v != it {
+			continue
+		}
+		txs = append(txs[:i], txs[i+1:]...)
+		break
+	}
+	if len(txs) == 0 {
+		delete(s.senders, it.sender)
+		return
+	}
+	s.senders[it.sender] = txs
+}
+
+// New creates a new priority scheduler.
+func New(maxTxPoolSize uint64, params registry.TxnSchedulerParameters) (api.Scheduler, error) {
+	if params.Algorithm != Name {
+		return nil, fmt.Errorf("unexpected transaction scheduling algorithm: %s", params.Algorithm)
+	}
+
+	s := &scheduler{
+		logger:            logging.GetLogger("runtime/scheduling").With("scheduler", "priority"),
+		maxTxPoolSize:     maxTxPoolSize,
+		maxBatchSize:      params.MaxBatchSize,
+		maxBatchSizeBytes: params.MaxBatchSizeBytes,
+	}
+	s.clearLocked()
+
+	return s, nil
+}
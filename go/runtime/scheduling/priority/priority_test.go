@@ -0,0 +1,91 @@
+package priority
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
+	"github.com/oasisprotocol/oasis-core/go/runtime/scheduling/tests"
+)
+
+func TestPriorityScheduler(t *testing.T) {
+	params := registry.TxnSchedulerParameters{
+		Algorithm:         Name,
+		MaxBatchSize:      10,
+		MaxBatchSizeBytes: 16 * 1024 * 1024,
+	}
+	algo, err := New(100, params)
+	require.NoError(t, err, "New()")
+
+	tests.SchedulerImplementationTests(t, algo)
+}
+
+func TestPrioritySchedulerOrdering(t *testing.T) {
+	require := require.New(t)
+
+	params := registry.TxnSchedulerParameters{
+		Algorithm:         Name,
+		MaxBatchSize:      10,
+		MaxBatchSizeBytes: 16 * 1024 * 1024,
+	}
+	algo, err := New(5, params)
+	require.NoError(err, "New()")
+
+	meta := func(priority uint64, sender string, seq uint64) *protocol.CheckTxMetadata {
+		return &protocol.CheckTxMetadata{
+			Priority:  priority,
+			Sender:    []byte(sender),
+			SenderSeq: seq,
+		}
+	}
+
+	// Transactions are ordered by priority, except that transactions of the same sender are
+	// ordered by their sequence numbers.
+	require.NoError(algo.QueueTx([]byte("low"), meta(1, "", 0)), "QueueTx")
+	require.NoError(algo.QueueTx([]byte("alice 1"), meta(10, "alice", 1)), "QueueTx")
+	require.NoError(algo.QueueTx([]byte("alice 0"), meta(2, "alice", 0)), "QueueTx")
+	require.NoError(algo.QueueTx([]byte("high"), meta(5, "", 0)), "QueueTx")
+	require.NoError(algo.QueueTx([]byte("no meta"), nil), "QueueTx")
+
+	batch := algo.GetBatch(true)
+	require.EqualValues([][]byte{
+		[]byte("high"),
+		[]byte("alice 0"),
+		[]byte("alice 1"),
+		[]byte("low"),
+		[]byte("no meta"),
+	}, batch, "transactions should be ordered by priority and sender sequence")
+
+	// A full pool should evict the lowest priority transaction.
+	require.NoError(algo.QueueTx([]byte("evicting"), meta(3, "", 0)), "QueueTx")
+	require.EqualValues(5, algo.UnscheduledSize(), "pool size should be bounded")
+	batch = algo.GetBatch(true)
+	require.NotContains(batch, []byte("no meta"), "lowest priority transaction should be evicted")
+	require.Contains(batch, []byte("evicting"), "higher priority transaction should be queued")
+
+	// Transactions with too low priority should be rejected when the pool is full.
+	err = algo.QueueTx([]byte("rejected"), meta(0, "", 0))
+	require.ErrorIs(err, ErrPriorityTooLow, "QueueTx should fail with too low priority")
+
+	// A transaction with the same sender sequence number needs a higher priority to replace
+	// an existing transaction.
+	err = algo.QueueTx([]byte("alice 0 again"), meta(2, "alice", 0))
+	require.ErrorIs(err, ErrPriorityTooLow, "QueueTx should fail with too low priority")
+	require.NoError(algo.QueueTx([]byte("alice 0 replacement"), meta(20, "alice", 0)), "QueueTx")
+	require.EqualValues(5, algo.UnscheduledSize(), "replacement should not change pool size")
+
+	batch = algo.GetBatch(true)
+	require.EqualValues([][]byte{
+		[]byte("alice 0 replacement"),
+		[]byte("alice 1"),
+		[]byte("high"),
+		[]byte("evicting"),
+		[]byte("low"),
+	}, batch, "transactions should be ordered by priority and sender sequence")
+
+	err = algo.RemoveTxBatch(batch[:2])
+	require.NoError(err, "RemoveTxBatch")
+	require.EqualValues(3, algo.UnscheduledSize(), "removed transactions should not be queued")
+}
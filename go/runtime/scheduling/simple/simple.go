@@ -7,6 +7,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
 	"github.com/oasisprotocol/oasis-core/go/runtime/scheduling/api"
 	txpool "github.com/oasisprotocol/oasis-core/go/runtime/scheduling/simple/txpool/api"
 	"github.com/oasisprotocol/oasis-core/go/runtime/scheduling/simple/txpool/orderedmap"
@@ -24,7 +25,7 @@ type scheduler struct {
 	maxTxPoolSize uint64
 }
 
-func (s *scheduler) QueueTx(tx []byte) error {
+func (s *scheduler) QueueTx(tx []byte, meta *protocol.CheckTxMetadata) error {
 	switch err := s.txPool.Add(tx); err {
 	case nil:
 		return nil
@@ -68,6 +69,14 @@ func (s *scheduler) IsQueued(id hash.Hash) bool {
 	return s.txPool.IsQueued(id)
 }
 
+func (s *scheduler) GetTransaction(txHash hash.Hash) (*api.QueuedTx, bool) {
+	return s.txPool.GetTransaction(txHash)
+}
+
+func (s *scheduler) GetTransactions() []*api.QueuedTx {
+	return s.txPool.GetTransactions()
+}
+
 func (s *scheduler) Clear() {
 	s.txPool.Clear()
 }
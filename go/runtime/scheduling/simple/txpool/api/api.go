@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/runtime/scheduling/api"
 	p2pError "github.com/oasisprotocol/oasis-core/go/worker/common/p2p/error"
 )
 
@@ -41,6 +42,12 @@ type TxPool interface {
 	// IsQueued returns whether a transaction is in the queue already.
 	IsQueued(txHash hash.Hash) bool
 
+	// GetTransaction returns the transaction with the given hash (if it is in the queue).
+	GetTransaction(txHash hash.Hash) (*api.QueuedTx, bool)
+
+	// GetTransactions returns all transactions in the transaction pool.
+	GetTransactions() []*api.QueuedTx
+
 	// Size returns the number of transactions in the transaction pool.
 	Size() uint64
 
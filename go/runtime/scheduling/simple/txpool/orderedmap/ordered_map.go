@@ -5,10 +5,12 @@ import (
 	"container/list"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
 
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	schedulingAPI "github.com/oasisprotocol/oasis-core/go/runtime/scheduling/api"
 	"github.com/oasisprotocol/oasis-core/go/runtime/scheduling/simple/txpool/api"
 )
 
@@ -18,8 +20,9 @@ const Name = "ordered-map"
 var _ api.TxPool = (*orderedMap)(nil)
 
 type pair struct {
-	Key   hash.Hash
-	Value []byte
+	Key      hash.Hash
+	Value    []byte
+	QueuedAt time.Time
 
 	element *list.Element
 }
@@ -162,6 +165,39 @@ func (q *orderedMap) IsQueued(txHash hash.Hash) bool {
 	return q.isQueuedLocked(txHash)
 }
 
+// Implements api.TxPool.
+func (q *orderedMap) GetTransaction(txHash hash.Hash) (*schedulingAPI.QueuedTx, bool) {
+	q.Lock()
+	defer q.Unlock()
+
+	el, ok := q.transactions[txHash]
+	if !ok {
+		return nil, false
+	}
+	return &schedulingAPI.QueuedTx{
+		Tx:       el.Value,
+		Hash:     el.Key,
+		QueuedAt: el.QueuedAt,
+	}, true
+}
+
+// Implements api.TxPool.
+func (q *orderedMap) GetTransactions() []*schedulingAPI.QueuedTx {
+	q.Lock()
+	defer q.Unlock()
+
+	txs := make([]*schedulingAPI.QueuedTx, 0, q.queue.Len())
+	for current := q.queue.Back(); current != nil; current = current.Prev() {
+		el := current.Value.(*pair)
+		txs = append(txs, &schedulingAPI.QueuedTx{
+			Tx:       el.Value,
+			Hash:     el.Key,
+			QueuedAt: el.QueuedAt,
+		})
+	}
+	return txs
+}
+
 // Implements api.TxPool.
 func (q *orderedMap) Size() uint64 {
 	q.Lock()
@@ -255,8 +291,9 @@ func (q *orderedMap) addTxLocked(tx []byte, txHash hash.Hash) {
 		return
 	}
 	p := &pair{
-		Key:   txHash,
-		Value: tx,
+		Key:      txHash,
+		Value:    tx,
+		QueuedAt: time.Now(),
 	}
 	p.element = q.queue.PushFront(p)
 	q.transactions[txHash] = p
@@ -33,7 +33,7 @@ func testScheduleTransactions(t *testing.T, scheduler api.Scheduler) {
 	// Test QueueTx.
 	testTx := []byte("hello world")
 	txBytes := hash.NewFromBytes(testTx)
-	err := scheduler.QueueTx(testTx)
+	err := scheduler.QueueTx(testTx, nil)
 	require.NoError(t, err, "QueueTx(testTx)")
 	require.True(t, scheduler.IsQueued(txBytes), "IsQueued(tx)")
 
@@ -65,13 +65,27 @@ func testScheduleTransactions(t *testing.T, scheduler api.Scheduler) {
 	for _, tx := range testBatch {
 		require.True(t, scheduler.IsQueued(hash.NewFromBytes(tx)), fmt.Sprintf("IsQueued(%s)", tx))
 	}
+	var queuedTxs [][]byte
+	for _, qtx := range scheduler.GetTransactions() {
+		require.EqualValues(t, hash.NewFromBytes(qtx.Tx), qtx.Hash, "GetTransactions hash")
+		require.False(t, qtx.QueuedAt.IsZero(), "GetTransactions queued at")
+		queuedTxs = append(queuedTxs, qtx.Tx)
+	}
+	require.ElementsMatch(t, testBatch, queuedTxs, "GetTransactions")
+	qtx, ok := scheduler.GetTransaction(hash.NewFromBytes(testBatch[1]))
+	require.True(t, ok, "GetTransaction")
+	require.EqualValues(t, testBatch[1], qtx.Tx, "GetTransaction tx")
+	require.EqualValues(t, hash.NewFromBytes(testBatch[1]), qtx.Hash, "GetTransaction hash")
+	require.False(t, qtx.QueuedAt.IsZero(), "GetTransaction queued at")
+	_, ok = scheduler.GetTransaction(hash.NewFromBytes([]byte("not queued")))
+	require.False(t, ok, "GetTransaction should fail for transactions that are not queued")
 	// Clear the queue.
 	scheduler.Clear()
 	require.EqualValues(t, 0, scheduler.UnscheduledSize(), "no transactions after flushing")
 
 	// Test Update configuration.
 	// First insert a transaction.
-	err = scheduler.QueueTx(testTx)
+	err = scheduler.QueueTx(testTx, nil)
 	require.NoError(t, err, "QueueTx(testTx)")
 	// Make sure transaction doesn't get scheduled.
 	batch = scheduler.GetBatch(false)
@@ -102,7 +116,7 @@ func testScheduleTransactions(t *testing.T, scheduler api.Scheduler) {
 	)
 	require.NoError(t, err, "UpdateParameters")
 	// Insert a transaction.
-	err = scheduler.QueueTx(testTx)
+	err = scheduler.QueueTx(testTx, nil)
 	require.NoError(t, err, "QueueTx(testTx)")
 	// Make sure transaction is queued.
 	require.EqualValues(t, 1, scheduler.UnscheduledSize(), "one transaction queued")
@@ -220,7 +234,7 @@ func benchmarkScheduleTransactions(b *testing.B, rng *rand.Rand, dispatcher benc
 		wg.Add(1)
 		go func(v []byte, wg *sync.WaitGroup) {
 			defer wg.Done()
-			err := scheduler.QueueTx(v)
+			err := scheduler.QueueTx(v, nil)
 			if err != nil {
 				panic(err)
 			}
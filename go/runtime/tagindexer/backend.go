@@ -10,11 +10,6 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/runtime/transaction"
 )
 
-const (
-	// maxQueryLimit is the maximum number of results to return.
-	maxQueryLimit = 1000
-)
-
 var (
 	// ErrTagTooLong is the error when either key or value is too long.
 	ErrTagTooLong = errors.New("tagindexer: tag too long to process")
@@ -53,6 +48,10 @@ type QueryableBackend interface {
 	// identified by its block round and index.
 	QueryTxnByIndex(ctx context.Context, round uint64, index uint32) (hash.Hash, error)
 
+	// QueryTxnByHash queries the transaction index for the block round and index of the
+	// transaction with the given hash.
+	QueryTxnByHash(ctx context.Context, txHash hash.Hash) (uint64, uint32, error)
+
 	// QueryTxns queries the transaction tag index of a given runtime with a complex
 	// query and returns multiple results.
 	//
@@ -119,6 +118,10 @@ func (n *nopBackend) QueryTxnByIndex(ctx context.Context, round uint64, index ui
 	return hash.Hash{}, errNopBackend
 }
 
+func (n *nopBackend) QueryTxnByHash(ctx context.Context, txHash hash.Hash) (uint64, uint32, error) {
+	return 0, 0, errNopBackend
+}
+
 func (n *nopBackend) QueryTxns(ctx context.Context, query api.Query) (Results, error) {
 	return nil, errNopBackend
 }
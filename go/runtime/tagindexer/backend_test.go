@@ -10,7 +10,10 @@ import (
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 	"github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/history"
 	"github.com/oasisprotocol/oasis-core/go/runtime/transaction"
 )
 
@@ -20,11 +23,13 @@ func testOperations(t *testing.T, backend Backend) {
 	tx1 := []byte("i am a transaction")
 	tx2 := []byte("i am a second transaction")
 	tx3 := []byte("i am a third transaction")
+	tx4 := []byte("i am a transaction without tags")
 
-	var tx1Hash, tx2Hash, tx3Hash hash.Hash
+	var tx1Hash, tx2Hash, tx3Hash, tx4Hash hash.Hash
 	tx1Hash.FromBytes(tx1)
 	tx2Hash.FromBytes(tx2)
 	tx3Hash.FromBytes(tx3)
+	tx4Hash.FromBytes(tx4)
 
 	var blockHash1 hash.Hash
 	blockHash1.FromBytes([]byte("this is a fake block hash 1"))
@@ -97,6 +102,7 @@ func testOperations(t *testing.T, backend Backend) {
 		// Transactions.
 		[]*transaction.Transaction{
 			{Input: tx3, Output: tx3},
+			{Input: tx4, Output: tx4},
 		},
 		// Tags.
 		transaction.Tags{
@@ -119,6 +125,20 @@ func testOperations(t *testing.T, backend Backend) {
 	require.NoError(t, err, "QueryBlock")
 	require.EqualValues(t, 42, round)
 
+	round, txnIndex, err = backend.QueryTxnByHash(ctx, tx2Hash)
+	require.NoError(t, err, "QueryTxnByHash")
+	require.EqualValues(t, 42, round)
+	require.EqualValues(t, 1, txnIndex)
+
+	round, txnIndex, err = backend.QueryTxnByHash(ctx, tx4Hash)
+	require.NoError(t, err, "QueryTxnByHash")
+	require.EqualValues(t, 43, round)
+	require.EqualValues(t, 1, txnIndex)
+
+	var invalidTxHash hash.Hash
+	_, _, err = backend.QueryTxnByHash(ctx, invalidTxHash)
+	require.Equal(t, api.ErrNotFound, err, "QueryTxnByHash must return a not found error")
+
 	// Test advanced transaction queries.
 	query := api.Query{
 		RoundMin: 40,
@@ -148,6 +168,45 @@ func testOperations(t *testing.T, backend Backend) {
 	require.Len(t, results[42], 2)
 	require.Contains(t, results[42], Result{TxHash: tx1Hash, TxIndex: 0})
 	require.Contains(t, results[42], Result{TxHash: tx2Hash, TxIndex: 1})
+
+	// Test paginated transaction queries.
+	query = api.Query{
+		Limit: 2,
+		After: &api.QueryPosition{Round: 42, Index: 0},
+	}
+	results, err = backend.QueryTxns(ctx, query)
+	require.NoError(t, err, "QueryTxns")
+	require.Len(t, results, 2)
+	require.EqualValues(t, []Result{{TxHash: tx2Hash, TxIndex: 1}}, results[42])
+	require.EqualValues(t, []Result{{TxHash: tx3Hash, TxIndex: 0}}, results[43])
+
+	query = api.Query{
+		Limit:      2,
+		Descending: true,
+	}
+	results, err = backend.QueryTxns(ctx, query)
+	require.NoError(t, err, "QueryTxns")
+	require.Len(t, results, 1)
+	require.EqualValues(t, []Result{{TxHash: tx4Hash, TxIndex: 1}, {TxHash: tx3Hash, TxIndex: 0}}, results[43])
+
+	query = api.Query{
+		Limit:      2,
+		Descending: true,
+		After:      &api.QueryPosition{Round: 43, Index: 0},
+	}
+	results, err = backend.QueryTxns(ctx, query)
+	require.NoError(t, err, "QueryTxns")
+	require.Len(t, results, 1)
+	require.EqualValues(t, []Result{{TxHash: tx2Hash, TxIndex: 1}, {TxHash: tx1Hash, TxIndex: 0}}, results[42])
+
+	// Test pruning.
+	err = backend.Prune(ctx, 43)
+	require.NoError(t, err, "Prune")
+
+	_, err = backend.QueryBlock(ctx, blockHash2)
+	require.Equal(t, api.ErrNotFound, err, "QueryBlock must return a not found error after pruning")
+	_, _, err = backend.QueryTxnByHash(ctx, tx3Hash)
+	require.Equal(t, api.ErrNotFound, err, "QueryTxnByHash must return a not found error after pruning")
 }
 
 func testLoadIndex(t *testing.T, backend Backend) {
@@ -193,3 +252,57 @@ func testBackend(t *testing.T, factory BackendFactory) {
 func TestBleveBackend(t *testing.T) {
 	testBackend(t, NewBleveBackend())
 }
+
+func TestSQLiteBackend(t *testing.T) {
+	testBackend(t, NewSQLiteBackend())
+}
+
+func TestReindex(t *testing.T) {
+	require := require.New(t)
+
+	dataDir, err := ioutil.TempDir("", "oasis-client-indexer-reindex-test_")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dataDir)
+
+	ctx := context.Background()
+	runtimeID := common.NewTestNamespaceFromSeed([]byte("tag indexer reindex test ns"), 0)
+
+	hist, err := history.New(dataDir, runtimeID, nil)
+	require.NoError(err, "history.New")
+	defer hist.Close()
+
+	// Commit some blocks without any transactions, skipping round 1 to simulate pruned history.
+	var blockHashes []hash.Hash
+	for i, round := range []uint64{0, 2, 3} {
+		blk := block.NewGenesisBlock(runtimeID, 0)
+		blk.Header.Round = round
+		err = hist.Commit(&roothash.AnnotatedBlock{Height: int64(i + 1), Block: blk}, nil)
+		require.NoError(err, "Commit")
+		blockHashes = append(blockHashes, blk.Header.EncodedHash())
+	}
+
+	backend, err := NewSQLiteBackend()(dataDir, runtimeID)
+	require.NoError(err, "NewSQLiteBackend")
+	defer backend.Close()
+
+	err = Reindex(ctx, backend, hist, nil, 2)
+	require.NoError(err, "Reindex")
+
+	_, err = backend.QueryBlock(ctx, blockHashes[0])
+	require.Equal(api.ErrNotFound, err, "rounds before the start round should not be indexed")
+
+	round, err := backend.QueryBlock(ctx, blockHashes[2])
+	require.NoError(err, "QueryBlock")
+	require.EqualValues(3, round)
+
+	err = Reindex(ctx, backend, hist, nil, 0)
+	require.NoError(err, "Reindex")
+
+	round, err = backend.QueryBlock(ctx, blockHashes[0])
+	require.NoError(err, "QueryBlock")
+	require.EqualValues(0, round)
+
+	round, err = backend.QueryBlock(ctx, blockHashes[1])
+	require.NoError(err, "QueryBlock")
+	require.EqualValues(2, round)
+}
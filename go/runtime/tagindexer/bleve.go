@@ -59,6 +59,7 @@ const (
 	// docTypeTx is the transaction document type.
 	docTypeTx = "tx"
 
+	fieldTxHash  = "TxHash"
 	fieldTxIndex = "TxIndex"
 	fieldTags    = "Tags"
 )
@@ -117,6 +118,32 @@ func queryByTag(key, value []byte) bleveQuery.Query {
 	return query
 }
 
+// queryAfterPosition returns a query matching transaction documents that come after the given
+// position in the given order.
+func queryAfterPosition(pos *api.QueryPosition, descending bool) bleveQuery.Query {
+	round := float64(pos.Round)
+	index := float64(pos.Index)
+	exclusive := false
+
+	// Either the round comes after the position's round, or it is the same round and the
+	// transaction index comes after the position's index.
+	var qRoundAfter, qIndexAfter *bleveQuery.NumericRangeQuery
+	if descending {
+		qRoundAfter = bleve.NewNumericRangeInclusiveQuery(nil, &round, nil, &exclusive)
+		qIndexAfter = bleve.NewNumericRangeInclusiveQuery(nil, &index, nil, &exclusive)
+	} else {
+		qRoundAfter = bleve.NewNumericRangeInclusiveQuery(&round, nil, &exclusive, nil)
+		qIndexAfter = bleve.NewNumericRangeInclusiveQuery(&index, nil, &exclusive, nil)
+	}
+	qRoundAfter.SetField(fieldRound)
+	qIndexAfter.SetField(fieldTxIndex)
+
+	return bleve.NewDisjunctionQuery(
+		qRoundAfter,
+		bleve.NewConjunctionQuery(queryByRound(pos.Round), qIndexAfter),
+	)
+}
+
 func (b *bleveBackend) Index(
 	ctx context.Context,
 	round uint64,
@@ -131,17 +158,26 @@ func (b *bleveBackend) Index(
 		txIndices[tx.Hash()] = uint32(idx)
 	}
 
-	// Generate documents for transactions.
+	// Generate documents for transactions. All transactions are indexed, even the ones without
+	// any tags, so that they can be looked up by their hash.
+	newTxDoc := func(txHash hash.Hash) txDocument {
+		return txDocument{
+			Kind:    docTypeTx,
+			ID:      string(txDocIDKeyFmt.Encode(round, &txHash, txIndices[txHash])),
+			Round:   round,
+			TxHash:  string(txHash[:]),
+			TxIndex: txIndices[txHash],
+			Tags:    make(map[string][]string),
+		}
+	}
 	txDocs := make(map[hash.Hash]txDocument)
+	for txHash := range txIndices {
+		txDocs[txHash] = newTxDoc(txHash)
+	}
 	for _, tag := range tags {
 		doc, ok := txDocs[tag.TxHash]
 		if !ok {
-			doc.Kind = docTypeTx
-			doc.ID = string(txDocIDKeyFmt.Encode(round, &tag.TxHash, txIndices[tag.TxHash]))
-			doc.Round = round
-			doc.TxHash = string(tag.TxHash[:])
-			doc.TxIndex = txIndices[tag.TxHash]
-			doc.Tags = make(map[string][]string)
+			doc = newTxDoc(tag.TxHash)
 		}
 		doc.Tags[string(tag.Key)] = append(doc.Tags[string(tag.Key)], string(tag.Value))
 		txDocs[tag.TxHash] = doc
@@ -255,6 +291,33 @@ func (b *bleveBackend) QueryTxnByIndex(ctx context.Context, round uint64, index
 	return decTxHash, nil
 }
 
+func (b *bleveBackend) QueryTxnByHash(ctx context.Context, txHash hash.Hash) (uint64, uint32, error) {
+	// Filter by transaction hash.
+	qTxHash := bleve.NewTermQuery(string(txHash[:]))
+	qTxHash.SetField(fieldTxHash)
+
+	q := bleve.NewConjunctionQuery(queryByKindTx, qTxHash)
+	rq := bleve.NewSearchRequest(q)
+	rq.Size = 1
+
+	result, err := b.index.SearchInContext(ctx, rq)
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(result.Hits) == 0 {
+		return 0, 0, api.ErrNotFound
+	}
+
+	var decRound uint64
+	var decTxHash hash.Hash
+	var decTxIndex uint32
+	if !txDocIDKeyFmt.Decode([]byte(result.Hits[0].ID), &decRound, &decTxHash, &decTxIndex) {
+		return 0, 0, ErrCorrupted
+	}
+
+	return decRound, decTxIndex, nil
+}
+
 func (b *bleveBackend) QueryTxns(ctx context.Context, query api.Query) (Results, error) {
 	qs := []bleveQuery.Query{queryByKindTx}
 
@@ -294,14 +357,19 @@ func (b *bleveBackend) QueryTxns(ctx context.Context, query api.Query) (Results,
 		}
 	}
 
+	// Filter by position.
+	if query.After != nil {
+		qs = append(qs, queryAfterPosition(query.After, query.Descending))
+	}
+
 	q := bleve.NewConjunctionQuery(qs...)
 	rq := bleve.NewSearchRequest(q)
-	if query.Limit > 0 {
-		rq.Size = int(query.Limit)
-	}
-	if rq.Size == 0 || rq.Size > maxQueryLimit {
-		rq.Size = maxQueryLimit
+	if query.Descending {
+		rq.SortBy([]string{"-" + fieldRound, "-" + fieldTxIndex})
+	} else {
+		rq.SortBy([]string{fieldRound, fieldTxIndex})
 	}
+	rq.Size = int(api.EffectiveQueryLimit(query.Limit))
 
 	result, err := b.index.SearchInContext(ctx, rq)
 	if err != nil {
@@ -0,0 +1,87 @@
+package tagindexer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	"github.com/oasisprotocol/oasis-core/go/runtime/transaction"
+	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
+)
+
+// reindexProgressInterval is the number of rounds after which reindexing progress is logged.
+const reindexProgressInterval = 1000
+
+// Reindex (re)builds the tag index in the given backend for all rounds starting at startRound up
+// to and including the latest round available in the runtime block history.
+//
+// Rounds which are not available in history (e.g., because they have been pruned) are skipped.
+// The storage backend must contain the I/O roots of all the rounds being indexed.
+func Reindex(
+	ctx context.Context,
+	backend Backend,
+	history roothash.BlockHistory,
+	storageBackend storage.Backend,
+	startRound uint64,
+) error {
+	logger := logging.GetLogger("runtime/history/tagindexer/reindex").With("runtime_id", history.RuntimeID())
+
+	latestBlk, err := history.GetLatestBlock(ctx)
+	if err != nil {
+		return fmt.Errorf("tagindexer: failed to get latest block: %w", err)
+	}
+	endRound := latestBlk.Header.Round
+
+	logger.Info("reindexing rounds",
+		"start_round", startRound,
+		"end_round", endRound,
+	)
+
+	var indexed, skipped uint64
+	for round := startRound; round <= endRound; round++ {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+
+		var blk *block.Block
+		blk, err = history.GetBlock(ctx, round)
+		switch err {
+		case nil:
+		case roothash.ErrNotFound:
+			skipped++
+			continue
+		default:
+			return fmt.Errorf("tagindexer: failed to get block for round %d: %w", round, err)
+		}
+
+		var (
+			txs  []*transaction.Transaction
+			tags transaction.Tags
+		)
+		txs, tags, err = getBlockTransactions(ctx, storageBackend, blk)
+		if err != nil {
+			return fmt.Errorf("tagindexer: failed to get transactions for round %d: %w", round, err)
+		}
+
+		if err = backend.Index(ctx, round, blk.Header.EncodedHash(), txs, tags); err != nil {
+			return fmt.Errorf("tagindexer: failed to index round %d: %w", round, err)
+		}
+		indexed++
+
+		if indexed%reindexProgressInterval == 0 {
+			logger.Info("reindexing in progress",
+				"round", round,
+				"end_round", endRound,
+			)
+		}
+	}
+
+	logger.Info("reindexing completed",
+		"indexed_rounds", indexed,
+		"skipped_rounds", skipped,
+	)
+
+	return nil
+}
@@ -0,0 +1,372 @@
+package tagindexer
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	// Register the pure-Go SQLite database driver.
+	_ "modernc.org/sqlite"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	"github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/transaction"
+)
+
+const (
+	// SQLiteBackendName is the name of the SQLite backend.
+	SQLiteBackendName = "sqlite"
+
+	sqliteIndexFile = "tag-index.sqlite.db"
+)
+
+var _ Backend = (*sqliteBackend)(nil)
+
+// sqliteSchema is the schema of the SQLite index.
+//
+// Tags are denormalized (they include the transaction's round, index and hash) so that tag
+// queries do not require joins.
+var sqliteSchema = []string{
+	`CREATE TABLE IF NOT EXISTS blocks (
+		round INTEGER NOT NULL PRIMARY KEY,
+		hash BLOB NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS blocks_by_hash ON blocks (hash)`,
+	`CREATE TABLE IF NOT EXISTS txs (
+		round INTEGER NOT NULL,
+		idx INTEGER NOT NULL,
+		hash BLOB NOT NULL,
+		PRIMARY KEY (round, idx)
+	)`,
+	`CREATE INDEX IF NOT EXISTS txs_by_hash ON txs (hash)`,
+	`CREATE TABLE IF NOT EXISTS tags (
+		round INTEGER NOT NULL,
+		idx INTEGER NOT NULL,
+		tx_hash BLOB NOT NULL,
+		key BLOB NOT NULL,
+		value BLOB NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS tags_by_key_value ON tags (key, value, round, idx)`,
+	`CREATE INDEX IF NOT EXISTS tags_by_round ON tags (round, idx)`,
+}
+
+type sqliteBackend struct {
+	logger *logging.Logger
+
+	db *sql.DB
+
+	blockIndexedNotifier *pubsub.Broker
+}
+
+func (b *sqliteBackend) Index(
+	ctx context.Context,
+	round uint64,
+	blockHash hash.Hash,
+	txs []*transaction.Transaction,
+	tags transaction.Tags,
+) error {
+	// The only reason why a list of transactions needs to be passed is to
+	// derive the transaction indices.
+	txIndices := make(map[hash.Hash]uint32)
+	for idx, tx := range txs {
+		txIndices[tx.Hash()] = uint32(idx)
+	}
+
+	dbTx, err := b.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer dbTx.Rollback() // nolint: errcheck
+
+	// Remove any previously indexed entries for the round so that indexing is idempotent.
+	if err = sqliteDeleteRound(ctx, dbTx, round); err != nil {
+		return err
+	}
+
+	if _, err = dbTx.ExecContext(ctx, `INSERT INTO blocks (round, hash) VALUES (?, ?)`,
+		round, blockHash[:],
+	); err != nil {
+		return err
+	}
+	for txHash, idx := range txIndices {
+		if _, err = dbTx.ExecContext(ctx, `INSERT INTO txs (round, idx, hash) VALUES (?, ?, ?)`,
+			round, idx, txHash[:],
+		); err != nil {
+			return err
+		}
+	}
+	for _, tag := range tags {
+		if _, err = dbTx.ExecContext(ctx, `INSERT INTO tags (round, idx, tx_hash, key, value) VALUES (?, ?, ?, ?, ?)`,
+			round, txIndices[tag.TxHash], tag.TxHash[:], tag.Key, tag.Value,
+		); err != nil {
+			return err
+		}
+	}
+
+	if err = dbTx.Commit(); err != nil {
+		return err
+	}
+
+	b.blockIndexedNotifier.Broadcast(round)
+
+	return nil
+}
+
+func (b *sqliteBackend) QueryBlock(ctx context.Context, blockHash hash.Hash) (uint64, error) {
+	var round uint64
+	err := b.db.QueryRowContext(ctx, `SELECT round FROM blocks WHERE hash = ? LIMIT 1`,
+		blockHash[:],
+	).Scan(&round)
+	if err != nil {
+		return 0, sqliteError(err)
+	}
+	return round, nil
+}
+
+func (b *sqliteBackend) QueryTxn(ctx context.Context, key, value []byte) (uint64, hash.Hash, uint32, error) {
+	var (
+		round  uint64
+		index  uint32
+		rawTx  []byte
+		txHash hash.Hash
+	)
+	err := b.db.QueryRowContext(ctx,
+		`SELECT round, idx, tx_hash FROM tags WHERE key = ? AND value = ? ORDER BY round, idx LIMIT 1`,
+		key, value,
+	).Scan(&round, &index, &rawTx)
+	if err != nil {
+		return 0, hash.Hash{}, 0, sqliteError(err)
+	}
+	if err = txHash.UnmarshalBinary(rawTx); err != nil {
+		return 0, hash.Hash{}, 0, ErrCorrupted
+	}
+	return round, txHash, index, nil
+}
+
+func (b *sqliteBackend) QueryTxnByIndex(ctx context.Context, round uint64, index uint32) (hash.Hash, error) {
+	var (
+		rawTx  []byte
+		txHash hash.Hash
+	)
+	err := b.db.QueryRowContext(ctx, `SELECT hash FROM txs WHERE round = ? AND idx = ?`,
+		round, index,
+	).Scan(&rawTx)
+	if err != nil {
+		return hash.Hash{}, sqliteError(err)
+	}
+	if err = txHash.UnmarshalBinary(rawTx); err != nil {
+		return hash.Hash{}, ErrCorrupted
+	}
+	return txHash, nil
+}
+
+func (b *sqliteBackend) QueryTxnByHash(ctx context.Context, txHash hash.Hash) (uint64, uint32, error) {
+	var (
+		round uint64
+		index uint32
+	)
+	err := b.db.QueryRowContext(ctx, `SELECT round, idx FROM txs WHERE hash = ? ORDER BY round LIMIT 1`,
+		txHash[:],
+	).Scan(&round, &index)
+	if err != nil {
+		return 0, 0, sqliteError(err)
+	}
+	return round, index, nil
+}
+
+func (b *sqliteBackend) QueryTxns(ctx context.Context, query api.Query) (Results, error) {
+	var (
+		conds []string
+		args  []interface{}
+	)
+
+	// Filter by round.
+	if query.RoundMin > 0 {
+		conds = append(conds, "t.round >= ?")
+		args = append(args, query.RoundMin)
+	}
+	if query.RoundMax > 0 {
+		conds = append(conds, "t.round <= ?")
+		args = append(args, query.RoundMax)
+	}
+
+	// Filter by key/value tag conditions.
+	for _, cond := range query.Conditions {
+		if len(cond.Values) == 0 {
+			// No values (strange, but ok).
+			continue
+		}
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(cond.Values)), ", ")
+		conds = append(conds, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM tags g WHERE g.round = t.round AND g.idx = t.idx AND g.key = ? AND g.value IN (%s))",
+			placeholders,
+		))
+		args = append(args, cond.Key)
+		for _, v := range cond.Values {
+			args = append(args, v)
+		}
+	}
+
+	// Filter by position.
+	order := "ASC"
+	cmp := ">"
+	if query.Descending {
+		order = "DESC"
+		cmp = "<"
+	}
+	if query.After != nil {
+		conds = append(conds, fmt.Sprintf("(t.round %s ? OR (t.round = ? AND t.idx %s ?))", cmp, cmp))
+		args = append(args, query.After.Round, query.After.Round, query.After.Index)
+	}
+
+	q := "SELECT t.round, t.idx, t.hash FROM txs t"
+	if len(conds) > 0 {
+		q += " WHERE " + strings.Join(conds, " AND ")
+	}
+	q += fmt.Sprintf(" ORDER BY t.round %s, t.idx %s LIMIT ?", order, order)
+	args = append(args, api.EffectiveQueryLimit(query.Limit))
+
+	rows, err := b.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := make(Results)
+	for rows.Next() {
+		var (
+			round  uint64
+			index  uint32
+			rawTx  []byte
+			txHash hash.Hash
+		)
+		if err = rows.Scan(&round, &index, &rawTx); err != nil {
+			return nil, err
+		}
+		if err = txHash.UnmarshalBinary(rawTx); err != nil {
+			return nil, ErrCorrupted
+		}
+
+		results[round] = append(results[round], Result{TxHash: txHash, TxIndex: index})
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (b *sqliteBackend) WaitBlockIndexed(ctx context.Context, round uint64) error {
+	sub := b.blockIndexedNotifier.Subscribe()
+	defer sub.Close()
+
+	ch := make(chan uint64)
+	sub.Unwrap(ch)
+
+	var indexed bool
+	err := b.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM blocks WHERE round = ?)`,
+		round,
+	).Scan(&indexed)
+	if err != nil {
+		return err
+	}
+	if indexed {
+		return nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case r := <-ch:
+			if r >= round {
+				return nil
+			}
+		}
+	}
+}
+
+func (b *sqliteBackend) Prune(ctx context.Context, round uint64) error {
+	dbTx, err := b.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer dbTx.Rollback() // nolint: errcheck
+
+	b.logger.Debug("pruning items from index",
+		"round", round,
+	)
+
+	if err = sqliteDeleteRound(ctx, dbTx, round); err != nil {
+		return err
+	}
+	return dbTx.Commit()
+}
+
+func (b *sqliteBackend) Close() {
+	if err := b.db.Close(); err != nil {
+		b.logger.Error("failed to close index",
+			"err", err,
+		)
+	}
+	b.db = nil
+}
+
+// sqliteDeleteRound removes all entries associated with the given round.
+func sqliteDeleteRound(ctx context.Context, dbTx *sql.Tx, round uint64) error {
+	for _, table := range []string{"blocks", "txs", "tags"} {
+		if _, err := dbTx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE round = ?", table), round); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sqliteError maps SQLite errors to tag indexer errors.
+func sqliteError(err error) error {
+	if err == sql.ErrNoRows {
+		return api.ErrNotFound
+	}
+	return err
+}
+
+func newSQLiteBackend(dataDir string, runtimeID common.Namespace) (Backend, error) {
+	b := &sqliteBackend{
+		logger:               logging.GetLogger("runtime/history/tagindexer/sqlite").With("runtime_id", runtimeID),
+		blockIndexedNotifier: pubsub.NewBroker(true),
+	}
+
+	db, err := sql.Open("sqlite", filepath.Join(dataDir, sqliteIndexFile))
+	if err != nil {
+		return nil, err
+	}
+	// SQLite only supports a single writer, so use a single connection to avoid lock contention
+	// between connections.
+	db.SetMaxOpenConns(1)
+
+	if _, err = db.Exec("PRAGMA journal_mode = WAL"); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("tagindexer: failed to configure index: %w", err)
+	}
+	for _, stmt := range sqliteSchema {
+		if _, err = db.Exec(stmt); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("tagindexer: failed to initialize index schema: %w", err)
+		}
+	}
+	b.db = db
+
+	b.logger.Info("initialized tag indexer backend")
+
+	return b, nil
+}
+
+// NewSQLiteBackend creates a new SQLite indexer backend factory.
+func NewSQLiteBackend() BackendFactory {
+	return newSQLiteBackend
+}
@@ -3,6 +3,7 @@ package tagindexer
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -11,6 +12,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/service"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 	"github.com/oasisprotocol/oasis-core/go/runtime/history"
 	"github.com/oasisprotocol/oasis-core/go/runtime/transaction"
 	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
@@ -36,6 +38,10 @@ type Service struct {
 	cancelCtx context.CancelFunc
 
 	stopCh chan struct{}
+
+	processedLock      sync.RWMutex
+	lastProcessedRound uint64
+	anyProcessed       bool
 }
 
 func (s *Service) worker(storageBackend storage.Backend) {
@@ -60,68 +66,108 @@ func (s *Service) worker(storageBackend storage.Backend) {
 			return
 		case annBlk := <-blocksCh:
 			// New blocks to index.
-			blk := annBlk.Block
-
-			// Fetch transactions from storage.
-			//
-			// NOTE: Currently the indexer requires all transactions as well since it needs to
-			//       expose a notion of a "transaction index within a block" which is hard to
-			//       provide as batches can be merged in arbitrary order and the sequence can
-			//       only be known after the fact.
-			var txs []*transaction.Transaction
-			var tags transaction.Tags
-			if !blk.Header.IORoot.IsEmpty() {
-				off := backoff.NewExponentialBackOff()
-				off.MaxElapsedTime = storageRetryTimeout
-
-				err = backoff.Retry(func() error {
-					bctx, cancel := context.WithTimeout(s.ctx, storageRequestTimeout)
-					defer cancel()
-
-					// Prioritize nodes that signed the storage receipt.
-					bctx = storage.WithNodePriorityHintFromSignatures(bctx, blk.Header.StorageSignatures)
-
-					ioRoot := storage.Root{
-						Namespace: blk.Header.Namespace,
-						Version:   blk.Header.Round,
-						Type:      storage.RootTypeIO,
-						Hash:      blk.Header.IORoot,
-					}
-
-					tree := transaction.NewTree(storageBackend, ioRoot)
-					defer tree.Close()
-
-					txs, err = tree.GetTransactions(bctx)
-					if err != nil {
-						return err
-					}
-
-					tags, err = tree.GetTags(bctx)
-					if err != nil {
-						return err
-					}
-
-					return nil
-				}, off)
-
-				if err != nil {
-					s.Logger.Error("can't get I/O root from storage",
-						"err", err,
-						"round", blk.Header.Round,
-					)
-					continue
-				}
-			}
-
-			if err = s.backend.Index(s.ctx, blk.Header.Round, blk.Header.EncodedHash(), txs, tags); err != nil {
-				s.Logger.Error("failed to index tags",
-					"err", err,
-					"round", blk.Header.Round,
-				)
-				continue
-			}
+			s.indexBlock(storageBackend, annBlk.Block)
+
+			// Rounds that failed to be indexed are not retried, so the round is considered as
+			// processed either way.
+			s.setLastProcessedRound(annBlk.Block.Header.Round)
+		}
+	}
+}
+
+func (s *Service) indexBlock(storageBackend storage.Backend, blk *block.Block) {
+	// Fetch transactions from storage.
+	//
+	// NOTE: Currently the indexer requires all transactions as well since it needs to
+	//       expose a notion of a "transaction index within a block" which is hard to
+	//       provide as batches can be merged in arbitrary order and the sequence can
+	//       only be known after the fact.
+	var (
+		txs  []*transaction.Transaction
+		tags transaction.Tags
+		err  error
+	)
+	if !blk.Header.IORoot.IsEmpty() {
+		off := backoff.NewExponentialBackOff()
+		off.MaxElapsedTime = storageRetryTimeout
+
+		err = backoff.Retry(func() error {
+			bctx, cancel := context.WithTimeout(s.ctx, storageRequestTimeout)
+			defer cancel()
+
+			// Prioritize nodes that signed the storage receipt.
+			bctx = storage.WithNodePriorityHintFromSignatures(bctx, blk.Header.StorageSignatures)
+
+			txs, tags, err = getBlockTransactions(bctx, storageBackend, blk)
+			return err
+		}, off)
+
+		if err != nil {
+			s.Logger.Error("can't get I/O root from storage",
+				"err", err,
+				"round", blk.Header.Round,
+			)
+			return
 		}
 	}
+
+	if err = s.backend.Index(s.ctx, blk.Header.Round, blk.Header.EncodedHash(), txs, tags); err != nil {
+		s.Logger.Error("failed to index tags",
+			"err", err,
+			"round", blk.Header.Round,
+		)
+		return
+	}
+}
+
+func (s *Service) setLastProcessedRound(round uint64) {
+	s.processedLock.Lock()
+	defer s.processedLock.Unlock()
+
+	s.lastProcessedRound = round
+	s.anyProcessed = true
+}
+
+// isProcessed returns true iff the indexer has already processed the given round.
+func (s *Service) isProcessed(round uint64) bool {
+	s.processedLock.RLock()
+	defer s.processedLock.RUnlock()
+
+	return s.anyProcessed && round <= s.lastProcessedRound
+}
+
+// getBlockTransactions fetches all transactions and tags contained in the I/O root of the given
+// block from storage.
+func getBlockTransactions(
+	ctx context.Context,
+	storageBackend storage.Backend,
+	blk *block.Block,
+) ([]*transaction.Transaction, transaction.Tags, error) {
+	if blk.Header.IORoot.IsEmpty() {
+		return nil, nil, nil
+	}
+
+	ioRoot := storage.Root{
+		Namespace: blk.Header.Namespace,
+		Version:   blk.Header.Round,
+		Type:      storage.RootTypeIO,
+		Hash:      blk.Header.IORoot,
+	}
+
+	tree := transaction.NewTree(storageBackend, ioRoot)
+	defer tree.Close()
+
+	txs, err := tree.GetTransactions(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tags, err := tree.GetTags(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return txs, tags, nil
 }
 
 func (s *Service) Start(storage storage.Backend) error {
@@ -176,7 +222,7 @@ func New(
 	// Register prune handler.
 	history.Pruner().RegisterHandler(&pruneHandler{
 		logger:  s.Logger,
-		backend: s.backend,
+		service: s,
 	})
 
 	return s, nil
@@ -184,13 +230,26 @@ func New(
 
 type pruneHandler struct {
 	logger  *logging.Logger
-	backend Backend
+	service *Service
+}
+
+func (p *pruneHandler) CanPrune(ctx context.Context, round uint64) error {
+	if _, ok := p.service.backend.(*nopBackend); ok {
+		// Nothing is being indexed.
+		return nil
+	}
+
+	// Make sure rounds are not pruned (together with their I/O roots) before being indexed.
+	if !p.service.isProcessed(round) {
+		return history.ErrPruneDelayed
+	}
+	return nil
 }
 
 func (p *pruneHandler) Prune(ctx context.Context, rounds []uint64) error {
 	// New blocks to prune from the index.
 	for _, round := range rounds {
-		if err := p.backend.Prune(ctx, round); err != nil {
+		if err := p.service.backend.Prune(ctx, round); err != nil {
 			p.logger.Error("failed to prune index",
 				"err", err,
 				"round", round,
@@ -157,6 +157,19 @@ func (c *Committee) EncodedMembersHash() hash.Hash {
 	return hash.NewFrom(c.Members)
 }
 
+// ElectionFailedEvent is the event emitted when a committee election is skipped, for example
+// because the runtime's scheduling constraints cannot be satisfied.
+type ElectionFailedEvent struct {
+	// RuntimeID is the runtime ID that the committee would be for.
+	RuntimeID common.Namespace `json:"runtime_id"`
+
+	// Kind is the kind of the committee that failed to be elected.
+	Kind CommitteeKind `json:"kind"`
+
+	// Reason is a human readable reason for the failed election.
+	Reason string `json:"reason"`
+}
+
 // BaseUnitsPerVotingPower is the ratio of base units staked to validator power.
 var BaseUnitsPerVotingPower quantity.Quantity
 
@@ -264,11 +277,78 @@ type ConsensusParameters struct {
 	RewardFactorEpochElectionAny quantity.Quantity `json:"reward_factor_epoch_election_any"`
 }
 
+// SanityCheck performs a sanity check on the consensus parameters.
+func (p *ConsensusParameters) SanityCheck() error {
+	unsafeFlags := p.DebugBypassStake || p.DebugStaticValidators
+	if unsafeFlags && !flags.DebugDontBlameOasis() {
+		return fmt.Errorf("one or more unsafe debug flags set")
+	}
+
+	return nil
+}
+
+// ConsensusParameterChanges are allowed scheduler consensus parameter changes.
+type ConsensusParameterChanges struct {
+	// MinValidators is the new minimum number of validators.
+	MinValidators *int `json:"min_validators,omitempty"`
+
+	// MaxValidators is the new maximum number of validators.
+	MaxValidators *int `json:"max_validators,omitempty"`
+
+	// MaxValidatorsPerEntity is the new maximum number of validators per entity.
+	MaxValidatorsPerEntity *int `json:"max_validators_per_entity,omitempty"`
+
+	// RewardFactorEpochElectionAny is the new epoch election any reward factor.
+	RewardFactorEpochElectionAny *quantity.Quantity `json:"reward_factor_epoch_election_any,omitempty"`
+}
+
+// SanityCheck performs a sanity check on the consensus parameter changes.
+func (c *ConsensusParameterChanges) SanityCheck() error {
+	if c.MinValidators == nil &&
+		c.MaxValidators == nil &&
+		c.MaxValidatorsPerEntity == nil &&
+		c.RewardFactorEpochElectionAny == nil {
+		return fmt.Errorf("consensus parameter changes should not be empty")
+	}
+	if c.MinValidators != nil && *c.MinValidators < 1 {
+		return fmt.Errorf("minimum number of validators must be at least 1")
+	}
+	if c.MaxValidators != nil && *c.MaxValidators < 1 {
+		return fmt.Errorf("maximum number of validators must be at least 1")
+	}
+	if c.MaxValidatorsPerEntity != nil && *c.MaxValidatorsPerEntity < 1 {
+		return fmt.Errorf("maximum number of validators per entity must be at least 1")
+	}
+	if c.RewardFactorEpochElectionAny != nil && !c.RewardFactorEpochElectionAny.IsValid() {
+		return fmt.Errorf("reward factor epoch election any has invalid value")
+	}
+	return nil
+}
+
+// Apply applies changes to the given consensus parameters.
+func (c *ConsensusParameterChanges) Apply(params *ConsensusParameters) error {
+	if c.MinValidators != nil {
+		params.MinValidators = *c.MinValidators
+	}
+	if c.MaxValidators != nil {
+		params.MaxValidators = *c.MaxValidators
+	}
+	if c.MaxValidatorsPerEntity != nil {
+		params.MaxValidatorsPerEntity = *c.MaxValidatorsPerEntity
+	}
+	if c.RewardFactorEpochElectionAny != nil {
+		params.RewardFactorEpochElectionAny = *c.RewardFactorEpochElectionAny.Clone()
+	}
+	if params.MaxValidators < params.MinValidators {
+		return fmt.Errorf("maximum number of validators must be at least the minimum number of validators")
+	}
+	return nil
+}
+
 // SanityCheck does basic sanity checking on the genesis state.
 func (g *Genesis) SanityCheck(stakingTotalSupply *quantity.Quantity) error {
-	unsafeFlags := g.Parameters.DebugBypassStake || g.Parameters.DebugStaticValidators
-	if unsafeFlags && !flags.DebugDontBlameOasis() {
-		return fmt.Errorf("scheduler: sanity check failed: one or more unsafe debug flags set")
+	if err := g.Parameters.SanityCheck(); err != nil {
+		return fmt.Errorf("scheduler: sanity check failed: %w", err)
 	}
 
 	if !g.Parameters.DebugBypassStake {
@@ -20,3 +20,33 @@ func TestSanityCheck(t *testing.T) {
 	require.NoError(t, q2e20.UnmarshalText([]byte("200_000_000_000_000_000_000")), "import q2e20")
 	require.Error(t, g.SanityCheck(q2e20), "sanity check total supply q2e20")
 }
+
+func TestConsensusParameterChanges(t *testing.T) {
+	require := require.New(t)
+
+	// Empty changes.
+	var changes ConsensusParameterChanges
+	require.Error(changes.SanityCheck(), "empty consensus parameter changes should be invalid")
+
+	// Invalid changes.
+	zero := 0
+	changes = ConsensusParameterChanges{MinValidators: &zero}
+	require.Error(changes.SanityCheck(), "zero minimum validators should be invalid")
+
+	// Valid changes.
+	minValidators := 10
+	changes = ConsensusParameterChanges{MinValidators: &minValidators}
+	require.NoError(changes.SanityCheck(), "consensus parameter changes should be valid")
+
+	params := ConsensusParameters{
+		MinValidators:          1,
+		MaxValidators:          5,
+		MaxValidatorsPerEntity: 1,
+	}
+	require.Error(changes.Apply(&params), "minimum validators above maximum validators should fail")
+
+	params.MaxValidators = 100
+	require.NoError(changes.Apply(&params), "Apply")
+	require.Equal(10, params.MinValidators, "minimum validators should be changed")
+	require.Equal(100, params.MaxValidators, "maximum validators should not be changed")
+}
@@ -6,9 +6,11 @@ import (
 	"sync"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/address"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/encoding/bech32"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 )
 
 var (
@@ -16,6 +18,8 @@ var (
 	AddressV0Context = address.NewContext("oasis-core/address: staking", 0)
 	// AddressRuntimeV0Context is the unique context for v0 runtime account addresses.
 	AddressRuntimeV0Context = address.NewContext("oasis-core/address: runtime", 0)
+	// AddressMultisigV0Context is the unique context for v0 multisig account addresses.
+	AddressMultisigV0Context = address.NewContext("oasis-core/address: multisig", 0)
 	// AddressBech32HRP is the unique human readable part of Bech32 encoded
 	// staking account addresses.
 	AddressBech32HRP = address.NewBech32HRP("oasis")
@@ -98,6 +102,11 @@ func NewRuntimeAddress(id common.Namespace) (a Address) {
 	return (Address)(address.NewAddress(AddressRuntimeV0Context, nsData))
 }
 
+// NewMultisigAddress creates a new multisig account address for the given multisig descriptor.
+func NewMultisigAddress(descriptor *transaction.MultisigDescriptor) (a Address) {
+	return (Address)(address.NewAddress(AddressMultisigV0Context, cbor.Marshal(descriptor)))
+}
+
 // NewReservedAddress creates a new reserved address from the given public key
 // or panics.
 // NOTE: The given public key is also blacklisted.
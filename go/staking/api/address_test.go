@@ -7,6 +7,7 @@ import (
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 )
 
 func TestReserved(t *testing.T) {
@@ -58,3 +59,29 @@ func TestRuntimeAddress(t *testing.T) {
 	addrPk1 := NewAddress(pk1)
 	require.NotEqualValues(addr1, addrPk1, "runtime addresses should be separated from staking addresses")
 }
+
+func TestMultisigAddress(t *testing.T) {
+	require := require.New(t)
+
+	pk1 := signature.NewPublicKey("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	pk2 := signature.NewPublicKey("bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+
+	addr1 := NewMultisigAddress(&transaction.MultisigDescriptor{
+		Threshold: 1,
+		Signers:   []signature.PublicKey{pk1, pk2},
+	})
+	require.True(addr1.IsValid(), "multisig address should be valid")
+
+	addr2 := NewMultisigAddress(&transaction.MultisigDescriptor{
+		Threshold: 2,
+		Signers:   []signature.PublicKey{pk1, pk2},
+	})
+	require.NotEqualValues(addr1, addr2, "multisig addresses for different thresholds should be different")
+
+	// Make sure domain separation works.
+	addr3 := NewMultisigAddress(&transaction.MultisigDescriptor{
+		Threshold: 1,
+		Signers:   []signature.PublicKey{pk1},
+	})
+	require.NotEqualValues(NewAddress(pk1), addr3, "multisig addresses should be separated from staking addresses")
+}
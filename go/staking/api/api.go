@@ -72,6 +72,10 @@ var (
 	// exceed the maximum allowed number.
 	ErrTooManyAllowances = errors.New(ModuleName, 7, "staking: too many allowances")
 
+	// ErrBalanceLocked is the error returned when an operation would spend tokens that are still
+	// locked by the account's vesting schedule.
+	ErrBalanceLocked = errors.New(ModuleName, 8, "staking: balance locked by vesting schedule")
+
 	// MethodTransfer is the method name for transfers.
 	MethodTransfer = transaction.NewMethodName(ModuleName, "Transfer", Transfer{})
 	// MethodBurn is the method name for burns.
@@ -430,6 +434,48 @@ func NewWithdrawTx(nonce uint64, fee *transaction.Fee, withdraw *Withdraw) *tran
 	return transaction.NewTransaction(nonce, fee, MethodWithdraw, withdraw)
 }
 
+// TransferResult is the non-error result of a transfer operation.
+type TransferResult struct {
+	From   Address           `json:"from"`
+	To     Address           `json:"to"`
+	Amount quantity.Quantity `json:"amount"`
+}
+
+// AddEscrowResult is the non-error result of an add escrow operation.
+type AddEscrowResult struct {
+	Owner     Address           `json:"owner"`
+	Escrow    Address           `json:"escrow"`
+	Amount    quantity.Quantity `json:"amount"`
+	NewShares quantity.Quantity `json:"new_shares"`
+}
+
+// ReclaimEscrowResult is the non-error result of a reclaim escrow operation.
+type ReclaimEscrowResult struct {
+	Owner           Address           `json:"owner"`
+	Escrow          Address           `json:"escrow"`
+	Amount          quantity.Quantity `json:"amount"`
+	RemainingShares quantity.Quantity `json:"remaining_shares"`
+	DebondingShares quantity.Quantity `json:"debonding_shares"`
+	DebondEndTime   beacon.EpochTime  `json:"debond_end_time"`
+}
+
+// AllowResult is the non-error result of an allow operation.
+type AllowResult struct { // nolint: maligned
+	Owner        Address           `json:"owner"`
+	Beneficiary  Address           `json:"beneficiary"`
+	Allowance    quantity.Quantity `json:"allowance"`
+	Negative     bool              `json:"negative,omitempty"`
+	AmountChange quantity.Quantity `json:"amount_change"`
+}
+
+// WithdrawResult is the non-error result of a withdraw operation.
+type WithdrawResult struct {
+	Owner        Address           `json:"owner"`
+	Beneficiary  Address           `json:"beneficiary"`
+	Allowance    quantity.Quantity `json:"allowance"`
+	AmountChange quantity.Quantity `json:"amount_change"`
+}
+
 // SharePool is a combined balance of several entries, the relative sizes
 // of which are tracked through shares.
 type SharePool struct {
@@ -505,8 +551,8 @@ func (p *SharePool) Deposit(shareDst, stakeSrc, baseUnitsAmount *quantity.Quanti
 	return nil
 }
 
-// stakeForShares computes the amount of base units for the given amount of shares.
-func (p *SharePool) stakeForShares(amount *quantity.Quantity) (*quantity.Quantity, error) {
+// StakeForShares computes the amount of base units for the given amount of shares.
+func (p *SharePool) StakeForShares(amount *quantity.Quantity) (*quantity.Quantity, error) {
 	if amount.IsZero() || p.Balance.IsZero() || p.TotalShares.IsZero() {
 		// No existing shares or no balance means no base units.
 		return quantity.NewQuantity(), nil
@@ -531,7 +577,7 @@ func (p *SharePool) stakeForShares(amount *quantity.Quantity) (*quantity.Quantit
 // Withdraw moves stake out of the combined balance, reducing the shares.
 // If an error occurs, the pool and affected accounts are left in an invalid state.
 func (p *SharePool) Withdraw(stakeDst, shareSrc, shareAmount *quantity.Quantity) error {
-	baseUnits, err := p.stakeForShares(shareAmount)
+	baseUnits, err := p.StakeForShares(shareAmount)
 	if err != nil {
 		return err
 	}
@@ -792,6 +838,33 @@ type GeneralAccount struct {
 	Nonce   uint64            `json:"nonce,omitempty"`
 
 	Allowances map[Address]quantity.Quantity `json:"allowances,omitempty"`
+
+	// Vesting is an optional vesting schedule that locks part of the balance.
+	Vesting *VestingSchedule `json:"vesting,omitempty"`
+}
+
+// LockedBalance returns the part of the general balance that is locked by the vesting schedule
+// at the given epoch.
+//
+// In case the balance is smaller than the amount that is still locked (e.g., because locked
+// tokens have been escrowed), the whole balance is locked.
+func (ga *GeneralAccount) LockedBalance(epoch beacon.EpochTime) *quantity.Quantity {
+	if ga.Vesting == nil {
+		return quantity.NewQuantity()
+	}
+	locked := ga.Vesting.LockedAmount(epoch)
+	if locked.Cmp(&ga.Balance) > 0 {
+		return ga.Balance.Clone()
+	}
+	return locked
+}
+
+// AvailableBalance returns the part of the general balance that is not locked by the vesting
+// schedule at the given epoch.
+func (ga *GeneralAccount) AvailableBalance(epoch beacon.EpochTime) *quantity.Quantity {
+	available := ga.Balance.Clone()
+	_ = available.Sub(ga.LockedBalance(epoch))
+	return available
 }
 
 // PrettyPrint writes a pretty-printed representation of GeneralAccount to the
@@ -803,6 +876,11 @@ func (ga GeneralAccount) PrettyPrint(ctx context.Context, prefix string, w io.Wr
 
 	fmt.Fprintf(w, "%sNonce:   %d\n", prefix, ga.Nonce)
 
+	if ga.Vesting != nil {
+		fmt.Fprintf(w, "%sVesting:\n", prefix)
+		ga.Vesting.PrettyPrint(ctx, prefix+"  ", w)
+	}
+
 	fmt.Fprintf(w, "%sAllowances:\n", prefix)
 	if len(ga.Allowances) == 0 {
 		fmt.Fprintf(w, "%s%snone\n", prefix, prefix)
@@ -1004,6 +1082,94 @@ type ConsensusParameters struct { // nolint: maligned
 	RewardFactorBlockProposed quantity.Quantity `json:"reward_factor_block_proposed"`
 }
 
+// ConsensusParameterChanges are allowed staking consensus parameter changes.
+type ConsensusParameterChanges struct {
+	// DebondingInterval is the new debonding interval.
+	DebondingInterval *beacon.EpochTime `json:"debonding_interval,omitempty"`
+
+	// GasCosts are the new gas costs.
+	GasCosts transaction.Costs `json:"gas_costs,omitempty"`
+
+	// MinDelegationAmount is the new minimum delegation amount.
+	MinDelegationAmount *quantity.Quantity `json:"min_delegation,omitempty"`
+
+	// DisableTransfers is the new disable transfers flag.
+	DisableTransfers *bool `json:"disable_transfers,omitempty"`
+	// DisableDelegation is the new disable delegation flag.
+	DisableDelegation *bool `json:"disable_delegation,omitempty"`
+
+	// MaxAllowances is the new maximum number of allowances.
+	MaxAllowances *uint32 `json:"max_allowances,omitempty"`
+
+	// FeeSplitWeightPropose is the new propose fee split weight.
+	FeeSplitWeightPropose *quantity.Quantity `json:"fee_split_weight_propose,omitempty"`
+	// FeeSplitWeightVote is the new vote fee split weight.
+	FeeSplitWeightVote *quantity.Quantity `json:"fee_split_weight_vote,omitempty"`
+	// FeeSplitWeightNextPropose is the new next propose fee split weight.
+	FeeSplitWeightNextPropose *quantity.Quantity `json:"fee_split_weight_next_propose,omitempty"`
+
+	// RewardFactorEpochSigned is the new epoch signed reward factor.
+	RewardFactorEpochSigned *quantity.Quantity `json:"reward_factor_epoch_signed,omitempty"`
+	// RewardFactorBlockProposed is the new block proposed reward factor.
+	RewardFactorBlockProposed *quantity.Quantity `json:"reward_factor_block_proposed,omitempty"`
+}
+
+// SanityCheck performs a sanity check on the consensus parameter changes.
+func (c *ConsensusParameterChanges) SanityCheck() error {
+	if c.DebondingInterval == nil &&
+		c.GasCosts == nil &&
+		c.MinDelegationAmount == nil &&
+		c.DisableTransfers == nil &&
+		c.DisableDelegation == nil &&
+		c.MaxAllowances == nil &&
+		c.FeeSplitWeightPropose == nil &&
+		c.FeeSplitWeightVote == nil &&
+		c.FeeSplitWeightNextPropose == nil &&
+		c.RewardFactorEpochSigned == nil &&
+		c.RewardFactorBlockProposed == nil {
+		return fmt.Errorf("consensus parameter changes should not be empty")
+	}
+	return nil
+}
+
+// Apply applies changes to the given consensus parameters.
+func (c *ConsensusParameterChanges) Apply(params *ConsensusParameters) error {
+	if c.DebondingInterval != nil {
+		params.DebondingInterval = *c.DebondingInterval
+	}
+	if c.GasCosts != nil {
+		params.GasCosts = c.GasCosts
+	}
+	if c.MinDelegationAmount != nil {
+		params.MinDelegationAmount = *c.MinDelegationAmount.Clone()
+	}
+	if c.DisableTransfers != nil {
+		params.DisableTransfers = *c.DisableTransfers
+	}
+	if c.DisableDelegation != nil {
+		params.DisableDelegation = *c.DisableDelegation
+	}
+	if c.MaxAllowances != nil {
+		params.MaxAllowances = *c.MaxAllowances
+	}
+	if c.FeeSplitWeightPropose != nil {
+		params.FeeSplitWeightPropose = *c.FeeSplitWeightPropose.Clone()
+	}
+	if c.FeeSplitWeightVote != nil {
+		params.FeeSplitWeightVote = *c.FeeSplitWeightVote.Clone()
+	}
+	if c.FeeSplitWeightNextPropose != nil {
+		params.FeeSplitWeightNextPropose = *c.FeeSplitWeightNextPropose.Clone()
+	}
+	if c.RewardFactorEpochSigned != nil {
+		params.RewardFactorEpochSigned = *c.RewardFactorEpochSigned.Clone()
+	}
+	if c.RewardFactorBlockProposed != nil {
+		params.RewardFactorBlockProposed = *c.RewardFactorBlockProposed.Clone()
+	}
+	return nil
+}
+
 const (
 	// GasOpTransfer is the gas operation identifier for transfer.
 	GasOpTransfer transaction.Op = "transfer"
@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 )
 
@@ -43,6 +44,38 @@ func TestConsensusParameters(t *testing.T) {
 	require.Error(degenerateFeeSplit.SanityCheck(), "consensus parameters with degenerate fee split should be invalid")
 }
 
+func TestConsensusParameterChanges(t *testing.T) {
+	require := require.New(t)
+
+	// Empty changes.
+	var changes ConsensusParameterChanges
+	require.Error(changes.SanityCheck(), "empty consensus parameter changes should be invalid")
+
+	// Valid changes.
+	debondingInterval := beacon.EpochTime(42)
+	disableTransfers := true
+	feeSplitWeightVote := mustInitQuantity(t, 10)
+	changes = ConsensusParameterChanges{
+		DebondingInterval:  &debondingInterval,
+		DisableTransfers:   &disableTransfers,
+		FeeSplitWeightVote: &feeSplitWeightVote,
+	}
+	require.NoError(changes.SanityCheck(), "consensus parameter changes should be valid")
+
+	params := ConsensusParameters{
+		DebondingInterval:     1,
+		FeeSplitWeightPropose: mustInitQuantity(t, 1),
+		FeeSplitWeightVote:    mustInitQuantity(t, 1),
+		MaxAllowances:         5,
+	}
+	require.NoError(changes.Apply(&params), "Apply")
+	require.EqualValues(42, params.DebondingInterval, "debonding interval should be changed")
+	require.True(params.DisableTransfers, "disable transfers flag should be changed")
+	require.Equal(feeSplitWeightVote, params.FeeSplitWeightVote, "fee split weight vote should be changed")
+	require.Equal(mustInitQuantity(t, 1), params.FeeSplitWeightPropose, "fee split weight propose should not be changed")
+	require.EqualValues(5, params.MaxAllowances, "max allowances should not be changed")
+}
+
 func TestThresholdKind(t *testing.T) {
 	require := require.New(t)
 
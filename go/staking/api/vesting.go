@@ -0,0 +1,95 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/prettyprint"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/staking/api/token"
+)
+
+var _ prettyprint.PrettyPrinter = (*VestingSchedule)(nil)
+
+// VestingSchedule is a schedule that locks part of an account's general balance and gradually
+// releases it.
+//
+// Nothing is released before the cliff epoch. From then on, the amount is released linearly
+// between the start and end epochs, so that the whole amount is released at the end epoch.
+//
+// Locked tokens cannot be transferred, burned or withdrawn by beneficiaries, but they can be
+// escrowed.
+type VestingSchedule struct {
+	// Amount is the total amount subject to vesting.
+	Amount quantity.Quantity `json:"amount"`
+	// Start is the epoch at which linear vesting starts.
+	Start beacon.EpochTime `json:"start"`
+	// Cliff is the epoch before which nothing is vested.
+	Cliff beacon.EpochTime `json:"cliff"`
+	// End is the epoch at which the whole amount is vested.
+	End beacon.EpochTime `json:"end"`
+}
+
+// ValidateBasic performs basic vesting schedule validity checks.
+func (v *VestingSchedule) ValidateBasic() error {
+	if !v.Amount.IsValid() {
+		return fmt.Errorf("vesting schedule amount is invalid")
+	}
+	if v.Amount.IsZero() {
+		return fmt.Errorf("vesting schedule amount must be positive")
+	}
+	if v.Cliff < v.Start {
+		return fmt.Errorf("vesting schedule cliff (%d) is before start (%d)", v.Cliff, v.Start)
+	}
+	if v.End < v.Cliff {
+		return fmt.Errorf("vesting schedule end (%d) is before cliff (%d)", v.End, v.Cliff)
+	}
+	return nil
+}
+
+// VestedAmount returns the amount that is vested at the given epoch.
+func (v *VestingSchedule) VestedAmount(epoch beacon.EpochTime) *quantity.Quantity {
+	switch {
+	case epoch < v.Cliff:
+		return quantity.NewQuantity()
+	case epoch >= v.End:
+		return v.Amount.Clone()
+	}
+
+	// Cliff <= epoch < End implies Start < End.
+	vested := v.Amount.Clone()
+	_ = vested.Mul(quantity.NewFromUint64(uint64(epoch - v.Start)))
+	_ = vested.Quo(quantity.NewFromUint64(uint64(v.End - v.Start)))
+	return vested
+}
+
+// LockedAmount returns the amount that is still locked at the given epoch.
+func (v *VestingSchedule) LockedAmount(epoch beacon.EpochTime) *quantity.Quantity {
+	locked := v.Amount.Clone()
+	_, _ = locked.SubUpTo(v.VestedAmount(epoch))
+	return locked
+}
+
+// PrettyPrint writes a pretty-printed representation of VestingSchedule to the given writer.
+func (v VestingSchedule) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
+	fmt.Fprintf(w, "%sAmount: ", prefix)
+	token.PrettyPrintAmount(ctx, v.Amount, w)
+	fmt.Fprintln(w)
+
+	fmt.Fprintf(w, "%sStart:  epoch %d\n", prefix, v.Start)
+	fmt.Fprintf(w, "%sCliff:  epoch %d\n", prefix, v.Cliff)
+	fmt.Fprintf(w, "%sEnd:    epoch %d\n", prefix, v.End)
+
+	if epoch, ok := ctx.Value(prettyprint.ContextKeyCurrentEpoch).(beacon.EpochTime); ok {
+		fmt.Fprintf(w, "%sLocked: ", prefix)
+		token.PrettyPrintAmount(ctx, *v.LockedAmount(epoch), w)
+		fmt.Fprintf(w, " (at epoch %d)\n", epoch)
+	}
+}
+
+// PrettyType returns a representation of VestingSchedule that can be used for pretty printing.
+func (v VestingSchedule) PrettyType() (interface{}, error) {
+	return v, nil
+}
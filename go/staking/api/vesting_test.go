@@ -0,0 +1,74 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+)
+
+func TestVestingSchedule(t *testing.T) {
+	require := require.New(t)
+
+	for _, tc := range []struct {
+		msg   string
+		v     VestingSchedule
+		valid bool
+	}{
+		{"zero amount", VestingSchedule{Start: 10, Cliff: 10, End: 20}, false},
+		{"cliff before start", VestingSchedule{Amount: *quantity.NewFromUint64(1), Start: 10, Cliff: 5, End: 20}, false},
+		{"end before cliff", VestingSchedule{Amount: *quantity.NewFromUint64(1), Start: 10, Cliff: 15, End: 12}, false},
+		{"cliff only", VestingSchedule{Amount: *quantity.NewFromUint64(1), Start: 10, Cliff: 10, End: 10}, true},
+		{"valid", VestingSchedule{Amount: *quantity.NewFromUint64(1), Start: 10, Cliff: 15, End: 20}, true},
+	} {
+		err := tc.v.ValidateBasic()
+		if tc.valid {
+			require.NoError(err, tc.msg)
+		} else {
+			require.Error(err, tc.msg)
+		}
+	}
+
+	v := VestingSchedule{
+		Amount: *quantity.NewFromUint64(1000),
+		Start:  10,
+		Cliff:  15,
+		End:    20,
+	}
+	for _, tc := range []struct {
+		epoch  beacon.EpochTime
+		vested uint64
+	}{
+		{0, 0},
+		{10, 0},
+		{14, 0},
+		{15, 500},
+		{17, 700},
+		{19, 900},
+		{20, 1000},
+		{100, 1000},
+	} {
+		require.Zero(v.VestedAmount(tc.epoch).Cmp(quantity.NewFromUint64(tc.vested)), "VestedAmount(%d)", tc.epoch)
+		require.Zero(v.LockedAmount(tc.epoch).Cmp(quantity.NewFromUint64(1000-tc.vested)), "LockedAmount(%d)", tc.epoch)
+	}
+
+	// Only the part of the general balance that is still locked should be unavailable.
+	ga := GeneralAccount{
+		Balance: *quantity.NewFromUint64(1200),
+		Vesting: &v,
+	}
+	require.Zero(ga.LockedBalance(10).Cmp(quantity.NewFromUint64(1000)), "LockedBalance")
+	require.Zero(ga.AvailableBalance(10).Cmp(quantity.NewFromUint64(200)), "AvailableBalance")
+	require.Zero(ga.AvailableBalance(17).Cmp(quantity.NewFromUint64(900)), "AvailableBalance")
+
+	// Locked tokens that were escrowed should not make the remaining balance available.
+	ga.Balance = *quantity.NewFromUint64(400)
+	require.Zero(ga.LockedBalance(15).Cmp(quantity.NewFromUint64(400)), "LockedBalance")
+	require.True(ga.AvailableBalance(15).IsZero(), "AvailableBalance")
+
+	ga.Vesting = nil
+	require.True(ga.LockedBalance(15).IsZero(), "LockedBalance without vesting")
+	require.Zero(ga.AvailableBalance(15).Cmp(quantity.NewFromUint64(400)), "AvailableBalance without vesting")
+}
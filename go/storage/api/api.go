@@ -3,6 +3,7 @@ package api
 
 import (
 	"context"
+	"time"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
@@ -107,18 +108,27 @@ type Config struct { // nolint: maligned
 
 	// ReadOnly will make the storage read-only.
 	ReadOnly bool
+
+	// CompactionInterval is the interval between online node database compaction passes.
+	CompactionInterval time.Duration
+
+	// CompactionOperationInterval is the minimum interval between consecutive online node
+	// database compaction operations.
+	CompactionOperationInterval time.Duration
 }
 
 // ToNodeDB converts from a Config to a node DB Config.
 func (cfg *Config) ToNodeDB() *nodedb.Config {
 	return &nodedb.Config{
-		DB:               cfg.DB,
-		Namespace:        cfg.Namespace,
-		MaxCacheSize:     cfg.MaxCacheSize,
-		NoFsync:          cfg.NoFsync,
-		MemoryOnly:       cfg.MemoryOnly,
-		ReadOnly:         cfg.ReadOnly,
-		DiscardWriteLogs: cfg.DiscardWriteLogs,
+		DB:                          cfg.DB,
+		Namespace:                   cfg.Namespace,
+		MaxCacheSize:                cfg.MaxCacheSize,
+		NoFsync:                     cfg.NoFsync,
+		MemoryOnly:                  cfg.MemoryOnly,
+		ReadOnly:                    cfg.ReadOnly,
+		DiscardWriteLogs:            cfg.DiscardWriteLogs,
+		CompactionInterval:          cfg.CompactionInterval,
+		CompactionOperationInterval: cfg.CompactionOperationInterval,
 	}
 }
 
@@ -234,6 +244,9 @@ type GetPrefixesRequest = syncer.GetPrefixesRequest
 // IterateRequest is a request for the SyncIterate operation.
 type IterateRequest = syncer.IterateRequest
 
+// GetRangeRequest is a request for the SyncGetRange operation.
+type GetRangeRequest = syncer.GetRangeRequest
+
 // ProofResponse is a response for requests that produce proofs.
 type ProofResponse = syncer.ProofResponse
 
@@ -26,6 +26,8 @@ var (
 	MethodSyncGetPrefixes = ServiceName.NewMethod("SyncGetPrefixes", GetPrefixesRequest{})
 	// MethodSyncIterate is the SyncIterate method.
 	MethodSyncIterate = ServiceName.NewMethod("SyncIterate", IterateRequest{})
+	// MethodSyncGetRange is the SyncGetRange method.
+	MethodSyncGetRange = ServiceName.NewMethod("SyncGetRange", GetRangeRequest{})
 	// MethodApply is the Apply method.
 	MethodApply = ServiceName.NewMethod("Apply", ApplyRequest{}).
 			WithNamespaceExtractor(func(ctx context.Context, req interface{}) (common.Namespace, error) {
@@ -108,6 +110,10 @@ var (
 				MethodName: MethodSyncIterate.ShortName(),
 				Handler:    handlerSyncIterate,
 			},
+			{
+				MethodName: MethodSyncGetRange.ShortName(),
+				Handler:    handlerSyncGetRange,
+			},
 			{
 				MethodName: MethodApply.ShortName(),
 				Handler:    handlerApply,
@@ -205,6 +211,29 @@ func handlerSyncIterate( // nolint: golint
 	return interceptor(ctx, &req, info, handler)
 }
 
+func handlerSyncGetRange( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var req GetRangeRequest
+	if err := dec(&req); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).SyncGetRange(ctx, &req)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MethodSyncGetRange.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).SyncGetRange(ctx, req.(*GetRangeRequest))
+	}
+	return interceptor(ctx, &req, info, handler)
+}
+
 func handlerApply( // nolint: golint
 	srv interface{},
 	ctx context.Context,
@@ -393,6 +422,14 @@ func (c *storageClient) SyncIterate(ctx context.Context, request *IterateRequest
 	return &rsp, nil
 }
 
+func (c *storageClient) SyncGetRange(ctx context.Context, request *GetRangeRequest) (*ProofResponse, error) {
+	var rsp ProofResponse
+	if err := c.conn.Invoke(ctx, MethodSyncGetRange.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *storageClient) Apply(ctx context.Context, request *ApplyRequest) ([]*Receipt, error) {
 	var rsp []*Receipt
 	if err := c.conn.Invoke(ctx, MethodApply.FullName(), request, &rsp); err != nil {
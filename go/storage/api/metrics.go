@@ -53,6 +53,7 @@ var (
 	labelSyncGet         = prometheus.Labels{"call": "sync_get"}
 	labelSyncGetPrefixes = prometheus.Labels{"call": "sync_get_prefixes"}
 	labelSyncIterate     = prometheus.Labels{"call": "sync_iterate"}
+	labelSyncGetRange    = prometheus.Labels{"call": "sync_get_range"}
 
 	_ LocalBackend  = (*metricsWrapper)(nil)
 	_ ClientBackend = (*metricsWrapper)(nil)
@@ -157,6 +158,19 @@ func (w *metricsWrapper) SyncIterate(ctx context.Context, request *IterateReques
 	return res, err
 }
 
+func (w *metricsWrapper) SyncGetRange(ctx context.Context, request *GetRangeRequest) (*ProofResponse, error) {
+	start := time.Now()
+	res, err := w.Backend.SyncGetRange(ctx, request)
+	storageLatency.With(labelSyncGetRange).Observe(time.Since(start).Seconds())
+	if err != nil {
+		storageFailures.With(labelSyncGetRange).Inc()
+		return nil, err
+	}
+
+	storageCalls.With(labelSyncGetRange).Inc()
+	return res, err
+}
+
 func (w *metricsWrapper) Checkpointer() checkpoint.CreateRestorer {
 	localBackend, ok := w.Backend.(LocalBackend)
 	if !ok {
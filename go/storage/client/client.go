@@ -394,6 +394,20 @@ func (b *storageClientBackend) SyncIterate(ctx context.Context, request *api.Ite
 	return rsp.(*api.ProofResponse), nil
 }
 
+func (b *storageClientBackend) SyncGetRange(ctx context.Context, request *api.GetRangeRequest) (*api.ProofResponse, error) {
+	rsp, err := b.readWithClient(
+		ctx,
+		request.Tree.Root.Namespace,
+		func(ctx context.Context, c api.Backend) (interface{}, error) {
+			return c.SyncGetRange(ctx, request)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return rsp.(*api.ProofResponse), nil
+}
+
 func (b *storageClientBackend) GetDiff(ctx context.Context, request *api.GetDiffRequest) (api.WriteLogIterator, error) {
 	rsp, err := b.readWithClient(
 		ctx,
@@ -14,6 +14,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
 	nodedb "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
 	badgerNodedb "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/badger"
+	boltNodedb "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/bolt"
 )
 
 const (
@@ -23,6 +24,12 @@ const (
 	// DBFileBadgerDB is the default BadgerDB backing store filename.
 	DBFileBadgerDB = "mkvs_storage.badger.db"
 
+	// BackendNameBoltDB is the name of the bbolt backed database backend.
+	BackendNameBoltDB = "bolt"
+
+	// DBFileBoltDB is the default bbolt backing store filename.
+	DBFileBoltDB = "mkvs_storage.bolt.db"
+
 	checkpointDir = "checkpoints"
 )
 
@@ -32,6 +39,8 @@ func DefaultFileName(backend string) string {
 	switch backend {
 	case BackendNameBadgerDB:
 		return DBFileBadgerDB
+	case BackendNameBoltDB:
+		return DBFileBoltDB
 	default:
 		panic("storage/database: can't get default filename for unknown backend")
 	}
@@ -59,6 +68,8 @@ func New(cfg *api.Config) (api.Backend, error) {
 	switch cfg.Backend {
 	case BackendNameBadgerDB:
 		ndb, err = badgerNodedb.New(ndbCfg)
+	case BackendNameBoltDB:
+		ndb, err = boltNodedb.New(ndbCfg)
 	default:
 		err = errors.New("storage/database: unsupported backend")
 	}
@@ -204,6 +215,16 @@ func (ba *databaseBackend) SyncIterate(ctx context.Context, request *api.Iterate
 	return tree.SyncIterate(ctx, request)
 }
 
+func (ba *databaseBackend) SyncGetRange(ctx context.Context, request *api.GetRangeRequest) (*api.ProofResponse, error) {
+	tree, err := ba.rootCache.GetTree(ctx, request.Tree.Root)
+	if err != nil {
+		return nil, err
+	}
+	defer tree.Close()
+
+	return tree.SyncGetRange(ctx, request)
+}
+
 func (ba *databaseBackend) GetDiff(ctx context.Context, request *api.GetDiffRequest) (api.WriteLogIterator, error) {
 	return ba.nodedb.GetWriteLog(ctx, request.StartRoot, request.EndRoot)
 }
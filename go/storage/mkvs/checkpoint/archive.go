@@ -0,0 +1,190 @@
+package checkpoint
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"strconv"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+)
+
+// maxArchiveMetadataSize is the maximum size of checkpoint metadata stored in an archive.
+const maxArchiveMetadataSize = 16 * 1024 * 1024
+
+// ExportArchive writes the given checkpoints together with all of their chunks, obtained from
+// the given chunk provider, into a single tar archive.
+//
+// The archive uses the same layout as the checkpoint directory of a node, with the metadata of
+// each checkpoint being stored before its chunks:
+//
+//	<root version>/<root hash>/meta
+//	<root version>/<root hash>/chunks/<index>
+func ExportArchive(ctx context.Context, provider ChunkProvider, checkpoints []*Metadata, w io.Writer) error {
+	tw := tar.NewWriter(w)
+
+	writeFile := func(name string, data []byte) error {
+		hdr := &tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     name,
+			Mode:     0o600,
+			Size:     int64(len(data)),
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		_, err := tw.Write(data)
+		return err
+	}
+
+	for _, cp := range checkpoints {
+		cpDir := archiveCheckpointDir(cp)
+		if err := writeFile(path.Join(cpDir, checkpointMetadataFile), cbor.Marshal(cp)); err != nil {
+			return fmt.Errorf("checkpoint: failed to write checkpoint metadata: %w", err)
+		}
+
+		for idx := range cp.Chunks {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			chunk, err := cp.GetChunkMetadata(uint64(idx))
+			if err != nil {
+				return err
+			}
+
+			// Chunk size must be known in advance, so buffer the chunk in memory.
+			var buf bytes.Buffer
+			if err = provider.GetCheckpointChunk(ctx, chunk, &buf); err != nil {
+				return fmt.Errorf("checkpoint: failed to fetch chunk %d of checkpoint %s: %w", idx, cp.Root, err)
+			}
+			if err = writeFile(path.Join(cpDir, chunksDir, strconv.Itoa(idx)), buf.Bytes()); err != nil {
+				return fmt.Errorf("checkpoint: failed to write chunk %d of checkpoint %s: %w", idx, cp.Root, err)
+			}
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("checkpoint: failed to finish archive: %w", err)
+	}
+	return nil
+}
+
+// ImportArchive restores all checkpoints contained in an archive created by ExportArchive using
+// the given restorer and returns their metadata in archive order.
+//
+// The archive must contain exactly one checkpoint for each of the given expected roots, which
+// should be obtained from a trusted source (e.g., the runtime block of the restored round).
+// Every chunk is verified against the root of its checkpoint before it is imported. In case of
+// any errors, the restore in progress is aborted.
+func ImportArchive(ctx context.Context, restorer Restorer, r io.Reader, roots []node.Root) (checkpoints []*Metadata, err error) {
+	var (
+		current *Metadata
+		started bool
+	)
+	pending := make(map[node.Root]bool)
+	for _, root := range roots {
+		pending[root] = true
+	}
+	defer func() {
+		if err != nil && started {
+			_ = restorer.AbortRestore(ctx)
+		}
+	}()
+
+	tr := tar.NewReader(r)
+	for {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		var hdr *tar.Header
+		hdr, err = tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrArchiveCorrupted, err)
+		}
+		if hdr.Typeflag == tar.TypeDir {
+			continue
+		}
+
+		cpDir, file := path.Split(path.Clean(hdr.Name))
+		switch {
+		case file == checkpointMetadataFile:
+			if current != nil {
+				return nil, fmt.Errorf("%w: checkpoint %s is missing chunks", ErrArchiveCorrupted, current.Root)
+			}
+
+			var data []byte
+			if data, err = ioutil.ReadAll(io.LimitReader(tr, maxArchiveMetadataSize)); err != nil {
+				return nil, fmt.Errorf("%w: failed to read checkpoint metadata: %s", ErrArchiveCorrupted, err)
+			}
+			var cp Metadata
+			if err = cbor.Unmarshal(data, &cp); err != nil {
+				return nil, fmt.Errorf("%w: corrupted checkpoint metadata: %s", ErrArchiveCorrupted, err)
+			}
+			switch {
+			case cp.Version != checkpointVersion:
+				return nil, fmt.Errorf("%w: unsupported checkpoint version %d", ErrArchiveCorrupted, cp.Version)
+			case len(cp.Chunks) == 0:
+				return nil, fmt.Errorf("%w: checkpoint %s has no chunks", ErrArchiveCorrupted, cp.Root)
+			case path.Clean(cpDir) != archiveCheckpointDir(&cp):
+				return nil, fmt.Errorf("%w: checkpoint %s stored under %s", ErrArchiveCorrupted, cp.Root, cpDir)
+			case !pending[cp.Root]:
+				return nil, fmt.Errorf("%w: unexpected checkpoint %s", ErrArchiveRootMismatch, cp.Root)
+			}
+			delete(pending, cp.Root)
+
+			if err = restorer.StartRestore(ctx, &cp); err != nil {
+				return nil, fmt.Errorf("checkpoint: failed to start restore of checkpoint %s: %w", cp.Root, err)
+			}
+			current = &cp
+			started = true
+		case path.Base(cpDir) == chunksDir:
+			if current == nil || path.Dir(path.Clean(cpDir)) != archiveCheckpointDir(current) {
+				return nil, fmt.Errorf("%w: unexpected chunk %s", ErrArchiveCorrupted, hdr.Name)
+			}
+
+			var idx uint64
+			if idx, err = strconv.ParseUint(file, 10, 64); err != nil {
+				return nil, fmt.Errorf("%w: malformed chunk name %s", ErrArchiveCorrupted, hdr.Name)
+			}
+
+			var done bool
+			if done, err = restorer.RestoreChunk(ctx, idx, tr); err != nil {
+				return nil, fmt.Errorf("checkpoint: failed to restore chunk %d of checkpoint %s: %w", idx, current.Root, err)
+			}
+			if done {
+				checkpoints = append(checkpoints, current)
+				current = nil
+			}
+		default:
+			return nil, fmt.Errorf("%w: unexpected file %s", ErrArchiveCorrupted, hdr.Name)
+		}
+	}
+
+	switch {
+	case current != nil:
+		err = fmt.Errorf("%w: checkpoint %s is missing chunks", ErrArchiveCorrupted, current.Root)
+		return nil, err
+	case len(checkpoints) == 0:
+		err = fmt.Errorf("%w: no checkpoints", ErrArchiveCorrupted)
+		return nil, err
+	case len(pending) > 0:
+		err = fmt.Errorf("%w: missing checkpoints for %d roots", ErrArchiveRootMismatch, len(pending))
+		return nil, err
+	}
+	return checkpoints, nil
+}
+
+func archiveCheckpointDir(cp *Metadata) string {
+	return path.Join(strconv.FormatUint(cp.Root.Version, 10), cp.Root.Hash.String())
+}
@@ -0,0 +1,216 @@
+package checkpoint
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	db "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
+	badgerDb "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/badger"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+)
+
+// rewriteArchive rewrites a tar archive by passing each file through the given function. Files
+// for which the function returns nil are dropped.
+func rewriteArchive(t *testing.T, archive []byte, fn func(name string, data []byte) []byte) []byte {
+	var buf bytes.Buffer
+	tr := tar.NewReader(bytes.NewReader(archive))
+	tw := tar.NewWriter(&buf)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err, "Next")
+
+		data, err := ioutil.ReadAll(tr)
+		require.NoError(t, err, "ReadAll")
+		if data = fn(hdr.Name, data); data == nil {
+			continue
+		}
+
+		hdr.Size = int64(len(data))
+		require.NoError(t, tw.WriteHeader(hdr), "WriteHeader")
+		_, err = tw.Write(data)
+		require.NoError(t, err, "Write")
+	}
+	require.NoError(t, tw.Close(), "Close")
+	return buf.Bytes()
+}
+
+func TestArchive(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "mkvs.checkpoint.archive")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dir)
+
+	newNodeDB := func(name string) db.NodeDB {
+		ndb, nerr := badgerDb.New(&db.Config{
+			DB:           filepath.Join(dir, name),
+			Namespace:    testNs,
+			MaxCacheSize: 16 * 1024 * 1024,
+		})
+		require.NoError(nerr, "New")
+		return ndb
+	}
+
+	// Generate state and I/O roots for the same version.
+	ctx := context.Background()
+	ndb := newNodeDB("db")
+	defer ndb.Close()
+
+	var roots []node.Root
+	for _, rootType := range []node.RootType{node.RootTypeState, node.RootTypeIO} {
+		tree := mkvs.New(nil, ndb, rootType)
+		for i := 0; i < 1000; i++ {
+			err = tree.Insert(ctx, []byte(rootType.String()+strconv.Itoa(i)), []byte(strconv.Itoa(i)))
+			require.NoError(err, "Insert")
+		}
+		_, rootHash, cerr := tree.Commit(ctx, testNs, 1)
+		require.NoError(cerr, "Commit")
+		tree.Close()
+
+		roots = append(roots, node.Root{
+			Namespace: testNs,
+			Version:   1,
+			Type:      rootType,
+			Hash:      rootHash,
+		})
+	}
+	err = ndb.Finalize(ctx, roots)
+	require.NoError(err, "Finalize")
+
+	fc, err := NewFileCreator(filepath.Join(dir, "checkpoints"), ndb)
+	require.NoError(err, "NewFileCreator")
+	var cps []*Metadata
+	for _, root := range roots {
+		cp, cerr := fc.CreateCheckpoint(ctx, root, 16*1024)
+		require.NoError(cerr, "CreateCheckpoint")
+		require.True(len(cp.Chunks) > 1, "checkpoint should have multiple chunks")
+		cps = append(cps, cp)
+	}
+
+	// Export the checkpoints into an archive.
+	var buf bytes.Buffer
+	err = ExportArchive(ctx, fc, cps, &buf)
+	require.NoError(err, "ExportArchive")
+	archive := buf.Bytes()
+
+	// Corrupted archives should fail to import and leave no restore in progress.
+	for _, tc := range []struct {
+		name string
+		fn   func(name string, data []byte) []byte
+	}{
+		{
+			"CorruptedChunk",
+			func(name string, data []byte) []byte {
+				if strings.HasSuffix(name, "/chunks/1") {
+					data[len(data)/2] ^= 0xff
+				}
+				return data
+			},
+		},
+		{
+			"MissingChunk",
+			func(name string, data []byte) []byte {
+				if strings.HasSuffix(name, "/chunks/1") {
+					return nil
+				}
+				return data
+			},
+		},
+		{
+			"MissingMetadata",
+			func(name string, data []byte) []byte {
+				if strings.HasSuffix(name, "/"+checkpointMetadataFile) {
+					return nil
+				}
+				return data
+			},
+		},
+		{
+			"UnexpectedFile",
+			func(name string, data []byte) []byte {
+				if strings.HasSuffix(name, "/chunks/0") {
+					return []byte("bogus")
+				}
+				return data
+			},
+		},
+	} {
+		ndb2 := newNodeDB("db-" + tc.name)
+		rs, rerr := NewRestorer(ndb2)
+		require.NoError(rerr, "NewRestorer")
+
+		_, err = ImportArchive(ctx, rs, bytes.NewReader(rewriteArchive(t, archive, tc.fn)), roots)
+		require.Error(err, "ImportArchive should fail with a corrupted archive (%s)", tc.name)
+		require.Nil(rs.GetCurrentCheckpoint(), "restore should be aborted (%s)", tc.name)
+		ndb2.Close()
+	}
+
+	_, err = ImportArchive(ctx, nil, bytes.NewReader(rewriteArchive(t, archive, func(string, []byte) []byte {
+		return nil
+	})), roots)
+	require.True(errors.Is(err, ErrArchiveCorrupted), "ImportArchive should fail with an empty archive")
+
+	// Archives that do not match the expected roots should fail to import.
+	var partial bytes.Buffer
+	err = ExportArchive(ctx, fc, cps[:1], &partial)
+	require.NoError(err, "ExportArchive")
+	for _, tc := range []struct {
+		name    string
+		archive []byte
+		roots   []node.Root
+	}{
+		{"MissingRoot", partial.Bytes(), roots},
+		{"UnexpectedRoot", archive, roots[:1]},
+		{"OtherVersion", archive, []node.Root{
+			{Namespace: testNs, Version: 2, Type: roots[0].Type, Hash: roots[0].Hash},
+			{Namespace: testNs, Version: 2, Type: roots[1].Type, Hash: roots[1].Hash},
+		}},
+	} {
+		ndb2 := newNodeDB("db-" + tc.name)
+		rs, rerr := NewRestorer(ndb2)
+		require.NoError(rerr, "NewRestorer")
+
+		_, err = ImportArchive(ctx, rs, bytes.NewReader(tc.archive), tc.roots)
+		require.True(errors.Is(err, ErrArchiveRootMismatch), "ImportArchive should fail with mismatched roots (%s)", tc.name)
+		require.Nil(rs.GetCurrentCheckpoint(), "restore should be aborted (%s)", tc.name)
+		ndb2.Close()
+	}
+
+	// Import the archive into a fresh node database.
+	ndb2 := newNodeDB("db2")
+	defer ndb2.Close()
+	rs, err := NewRestorer(ndb2)
+	require.NoError(err, "NewRestorer")
+
+	restored, err := ImportArchive(ctx, rs, bytes.NewReader(archive), roots)
+	require.NoError(err, "ImportArchive")
+	require.EqualValues(cps, restored, "restored checkpoints should be correct")
+	err = ndb2.Finalize(ctx, roots)
+	require.NoError(err, "Finalize")
+
+	// Verify that everything has been restored.
+	for _, root := range roots {
+		tree := mkvs.NewWithRoot(nil, ndb2, root)
+		for i := 0; i < 1000; i++ {
+			value, gerr := tree.Get(ctx, []byte(root.Type.String()+strconv.Itoa(i)))
+			require.NoError(gerr, "Get")
+			require.Equal([]byte(strconv.Itoa(i)), value)
+		}
+		tree.Close()
+	}
+}
@@ -35,6 +35,13 @@ var (
 
 	// ErrChunkCorrupted is the error when a chunk is corrupted.
 	ErrChunkCorrupted = errors.New(moduleName, 7, "chunk: corrupted chunk")
+
+	// ErrArchiveCorrupted is the error when a checkpoint archive is corrupted.
+	ErrArchiveCorrupted = errors.New(moduleName, 8, "checkpoint: corrupted archive")
+
+	// ErrArchiveRootMismatch is the error when the checkpoints in an archive do not match the
+	// expected roots.
+	ErrArchiveRootMismatch = errors.New(moduleName, 9, "checkpoint: archive roots do not match expected roots")
 )
 
 // ChunkProvider is a chunk provider.
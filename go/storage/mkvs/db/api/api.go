@@ -3,6 +3,7 @@ package api
 
 import (
 	"context"
+	"time"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/errors"
@@ -77,6 +78,33 @@ type Config struct { // nolint: maligned
 
 	// DiscardWriteLogs will cause all write logs to be discarded.
 	DiscardWriteLogs bool
+
+	// CompactionInterval is the interval between online compaction passes (if the backend
+	// supports it). If zero, the backend default is used.
+	CompactionInterval time.Duration
+
+	// CompactionOperationInterval is the minimum interval between consecutive online compaction
+	// operations (e.g., value log rewrites). The operations themselves are not throttled. Zero
+	// means no delay.
+	CompactionOperationInterval time.Duration
+}
+
+// SizeStats are the node database size statistics.
+type SizeStats struct {
+	// LiveBytes is the estimated number of bytes used by data that is still reachable from any
+	// of the retained versions.
+	LiveBytes uint64 `json:"live_bytes"`
+	// ReclaimableBytes is the estimated number of bytes used by data that is no longer reachable
+	// and has not yet been reclaimed by compaction.
+	ReclaimableBytes uint64 `json:"reclaimable_bytes"`
+	// LastCompaction is the time when the last compaction pass completed.
+	LastCompaction time.Time `json:"last_compaction"`
+}
+
+// SizeStatsProvider is a node database that supports size accounting.
+type SizeStatsProvider interface {
+	// SizeStats returns the current node database size statistics.
+	SizeStats() SizeStats
 }
 
 // NodeDB is the persistence layer used for persisting the in-memory tree.
@@ -8,9 +8,9 @@ import (
 	"sync"
 
 	"github.com/dgraph-io/badger/v2"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
-	cmnBadger "github.com/oasisprotocol/oasis-core/go/common/badger"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/keyformat"
@@ -63,6 +63,14 @@ var (
 	//
 	// Value is empty.
 	rootNodeKeyFmt = keyformat.New(0x06, &typedHash{})
+	// sizeStatsKeyFmt is the key format for the database size statistics.
+	//
+	// Value is CBOR-serialized size statistics.
+	sizeStatsKeyFmt = keyformat.New(0x07)
+	// versionSizeKeyFmt is the key format for per-version size accounting (version).
+	//
+	// Value is CBOR-serialized versionSize.
+	versionSizeKeyFmt = keyformat.New(0x08, uint64(0))
 )
 
 // New creates a new BadgerDB-backed node database.
@@ -70,6 +78,7 @@ func New(cfg *api.Config) (api.NodeDB, error) {
 	db := &badgerNodeDB{
 		logger:           logging.GetLogger("mkvs/db/badger"),
 		namespace:        cfg.Namespace,
+		dir:              cfg.DB,
 		readOnly:         cfg.ReadOnly,
 		discardWriteLogs: cfg.DiscardWriteLogs,
 	}
@@ -95,7 +104,19 @@ func New(cfg *api.Config) (api.NodeDB, error) {
 		return nil, fmt.Errorf("mkvs/badger: failed to clean leftovers from multipart restore: %w", err)
 	}
 
-	db.gc = cmnBadger.NewGCWorker(db.logger, db.db)
+	// Load size accounting.
+	if err = db.loadSizeStats(); err != nil {
+		_ = db.db.Close()
+		return nil, fmt.Errorf("mkvs/badger: failed to load size statistics: %w", err)
+	}
+	metricsOnce.Do(func() {
+		prometheus.MustRegister(nodeDBCollectors...)
+	})
+	db.updateSizeMetrics()
+
+	if !cfg.ReadOnly && !cfg.MemoryOnly {
+		db.compactor = newCompactor(db, cfg.CompactionInterval, cfg.CompactionOperationInterval)
+	}
 
 	return db, nil
 }
@@ -104,20 +125,22 @@ type badgerNodeDB struct { // nolint: maligned
 	logger *logging.Logger
 
 	namespace common.Namespace
+	dir       string
 
 	readOnly         bool
 	discardWriteLogs bool
 
 	multipartVersion uint64
 
-	db *badger.DB
-	gc *cmnBadger.GCWorker
+	db        *badger.DB
+	compactor *compactor
 
 	// metaUpdateLock must be held at any point where data at tsMetadata is read and updated. This
 	// is required because all metadata updates happen at the same timestamp and as such conflicts
 	// cannot be detected.
 	metaUpdateLock sync.Mutex
 	meta           metadata
+	sizes          sizeStats
 
 	closeOnce sync.Once
 }
@@ -594,9 +617,17 @@ func (d *badgerNodeDB) Finalize(ctx context.Context, roots []node.Root) error {
 		}
 	}
 
+	vs, err := loadVersionSize(tx, version)
+	if err != nil {
+		return err
+	}
+
 	// Go through all roots and prune them based on whether they are finalized or not.
 	maybeLoneNodes := make(map[hash.Hash]bool)
 	notLoneNodes := make(map[hash.Hash]bool)
+	removedNodes := make(map[hash.Hash]bool)
+	// Number of bytes that can be reclaimed immediately.
+	var freed uint64
 
 	for rootHash := range rootsMeta.Roots {
 		// TODO: Consider colocating updated nodes with the root metadata.
@@ -621,6 +652,7 @@ func (d *badgerNodeDB) Finalize(ctx context.Context, roots []node.Root) error {
 			for _, n := range updatedNodes {
 				if n.Removed {
 					maybeLoneNodes[n.Hash] = true
+					removedNodes[n.Hash] = true
 				} else {
 					notLoneNodes[n.Hash] = true
 				}
@@ -650,6 +682,13 @@ func (d *badgerNodeDB) Finalize(ctx context.Context, roots []node.Root) error {
 						if err = versionBatch.Delete(wit.Item().KeyCopy(nil)); err != nil {
 							return err
 						}
+						size := uint64(wit.Item().EstimatedSize())
+						freed += size
+						if size > vs.WriteLogs {
+							vs.WriteLogs = 0
+						} else {
+							vs.WriteLogs -= size
+						}
 					}
 					return nil
 				}(); err != nil {
@@ -674,6 +713,14 @@ func (d *badgerNodeDB) Finalize(ctx context.Context, roots []node.Root) error {
 		if err := versionBatch.Delete(key); err != nil {
 			return err
 		}
+
+		// Nodes removed by finalized roots remain reachable from earlier versions, while
+		// nodes only created by non-finalized roots can be reclaimed immediately.
+		if removedNodes[h] {
+			vs.Removed += itemSize(tx, key)
+		} else {
+			freed += itemSize(tx, key)
+		}
 	}
 
 	// Commit batch.
@@ -688,6 +735,15 @@ func (d *badgerNodeDB) Finalize(ctx context.Context, roots []node.Root) error {
 		}
 	}
 
+	// Update size accounting.
+	if err := vs.save(tx); err != nil {
+		return fmt.Errorf("mkvs/badger: failed to save version size: %w", err)
+	}
+	applySizes, err := d.sizes.update(tx, 0, freed)
+	if err != nil {
+		return fmt.Errorf("mkvs/badger: failed to save size statistics: %w", err)
+	}
+
 	// Update last finalized version.
 	if err := d.meta.setLastFinalizedVersion(tx, version); err != nil {
 		return fmt.Errorf("mkvs/badger: failed to set last finalized version: %w", err)
@@ -696,6 +752,9 @@ func (d *badgerNodeDB) Finalize(ctx context.Context, roots []node.Root) error {
 	if err := tx.CommitAt(tsMetadata, nil); err != nil {
 		return fmt.Errorf("mkvs/badger: failed to commit metadata: %w", err)
 	}
+	applySizes()
+	versionBytes.With(d.metricLabels()).Observe(float64(vs.Nodes + vs.WriteLogs))
+	d.updateSizeMetrics()
 
 	// Clean multipart metadata if there is any.
 	if d.multipartVersion != multipartVersionNone {
@@ -738,6 +797,8 @@ func (d *badgerNodeDB) Prune(ctx context.Context, version uint64) error {
 	if err != nil {
 		return err
 	}
+	// Number of bytes that become reclaimable by pruning this version.
+	var freed uint64
 
 	maybeLoneRoots := make(map[typedHash]bool)
 	for rootHash, derivedRoots := range rootsMeta.Roots {
@@ -767,9 +828,11 @@ func (d *badgerNodeDB) Prune(ctx context.Context, version uint64) error {
 		err := api.Visit(ctx, d, root, func(ctx context.Context, n node.Node) bool {
 			if n.GetCreatedVersion() == version {
 				h := n.GetHash()
-				if innerErr = batch.Delete(nodeKeyFmt.Encode(&h)); innerErr != nil {
+				key := nodeKeyFmt.Encode(&h)
+				if innerErr = batch.Delete(key); innerErr != nil {
 					return false
 				}
+				freed += itemSize(tx, key)
 			}
 			return true
 		})
@@ -803,9 +866,26 @@ func (d *badgerNodeDB) Prune(ctx context.Context, version uint64) error {
 			if err := batch.Delete(it.Item().KeyCopy(nil)); err != nil {
 				return err
 			}
+			freed += uint64(it.Item().EstimatedSize())
 		}
 	}
 
+	// Nodes removed when finalizing the next version are no longer reachable once this
+	// version is gone.
+	nextVersionSize, err := loadVersionSize(tx, version+1)
+	if err != nil {
+		return err
+	}
+	freed += nextVersionSize.Removed
+	prunedSize := &versionSize{version: version}
+	if err = prunedSize.delete(tx); err != nil {
+		return fmt.Errorf("mkvs/badger: failed to remove version size: %w", err)
+	}
+	applySizes, err := d.sizes.update(tx, 0, freed)
+	if err != nil {
+		return fmt.Errorf("mkvs/badger: failed to save size statistics: %w", err)
+	}
+
 	// Commit batch.
 	if err := batch.Flush(); err != nil {
 		return fmt.Errorf("mkvs/badger: failed to flush batch: %w", err)
@@ -819,8 +899,11 @@ func (d *badgerNodeDB) Prune(ctx context.Context, version uint64) error {
 		return fmt.Errorf("mkvs/badger: failed to commit: %w", err)
 	}
 
+	applySizes()
+
 	// Discard everything invalidated at or below given version.
 	d.db.SetDiscardTs(versionToTs(version + 1))
+	d.updateSizeMetrics()
 
 	return nil
 }
@@ -915,8 +998,8 @@ func (d *badgerNodeDB) Sync() error {
 
 func (d *badgerNodeDB) Close() {
 	d.closeOnce.Do(func() {
-		if d.gc != nil {
-			d.gc.Close()
+		if d.compactor != nil {
+			d.compactor.Close()
 		}
 
 		if err := d.db.Close(); err != nil {
@@ -944,6 +1027,9 @@ type badgerBatch struct {
 	writeLog     writelog.WriteLog
 	annotations  writelog.Annotations
 	updatedNodes []updatedNode
+
+	// nodeBytes is the number of bytes of nodes written by this batch.
+	nodeBytes uint64
 }
 
 func (ba *badgerBatch) MaybeStartSubtree(subtree api.Subtree, depth node.Depth, subtreeRoot *node.Pointer) api.Subtree {
@@ -1038,6 +1124,7 @@ func (ba *badgerBatch) Commit(root node.Root) error {
 		}
 	}
 
+	var writeLogBytes uint64
 	if ba.chunk {
 		// Skip most of metadata updates if we are just importing chunks.
 		key := rootUpdatedNodesKeyFmt.Encode(root.Version, &rootHash)
@@ -1082,9 +1169,25 @@ func (ba *badgerBatch) Commit(root node.Root) error {
 			if err = ba.bat.Set(key, bytes); err != nil {
 				return fmt.Errorf("mkvs/badger: set new write log returned error: %w", err)
 			}
+			writeLogBytes = uint64(len(key) + len(bytes))
 		}
 	}
 
+	// Account for the written data.
+	vs, err := loadVersionSize(tx, root.Version)
+	if err != nil {
+		return err
+	}
+	vs.Nodes += ba.nodeBytes
+	vs.WriteLogs += writeLogBytes
+	if err = vs.save(tx); err != nil {
+		return fmt.Errorf("mkvs/badger: failed to save version size: %w", err)
+	}
+	applySizes, err := ba.db.sizes.update(tx, ba.nodeBytes+writeLogBytes, 0)
+	if err != nil {
+		return fmt.Errorf("mkvs/badger: failed to save size statistics: %w", err)
+	}
+
 	// Flush node updates.
 	if ba.multipartNodes != nil {
 		if err = ba.multipartNodes.Flush(); err != nil {
@@ -1099,10 +1202,13 @@ func (ba *badgerBatch) Commit(root node.Root) error {
 	if err = tx.CommitAt(tsMetadata, nil); err != nil {
 		return err
 	}
+	applySizes()
 
 	ba.writeLog = nil
 	ba.annotations = nil
 	ba.updatedNodes = nil
+	ba.nodeBytes = 0
+	ba.db.updateSizeMetrics()
 
 	return ba.BaseBatch.Commit(root)
 }
@@ -1116,6 +1222,7 @@ func (ba *badgerBatch) Reset() {
 	ba.writeLog = nil
 	ba.annotations = nil
 	ba.updatedNodes = nil
+	ba.nodeBytes = 0
 }
 
 type badgerSubtree struct {
@@ -1142,6 +1249,7 @@ func (s *badgerSubtree) PutNode(depth node.Depth, ptr *node.Pointer) error {
 	if err = s.batch.bat.Set(nodeKey, data); err != nil {
 		return err
 	}
+	s.batch.nodeBytes += uint64(len(nodeKey) + len(data))
 	return nil
 }
 
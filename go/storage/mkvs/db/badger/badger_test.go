@@ -8,6 +8,7 @@ import (
 	"os"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/dgraph-io/badger/v2"
 	"github.com/stretchr/testify/require"
@@ -336,3 +337,205 @@ func TestReadOnlyBatch(t *testing.T) {
 	_, err = badgerdb.NewBatch(node.Root{}, 13, false)
 	require.Error(err, "NewBatch()")
 }
+
+func TestSizeAccounting(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	dir, err := ioutil.TempDir("", "oasis-storage-mkvs-badger-size-test")
+	require.NoError(err, "TempDir()")
+	defer os.RemoveAll(dir)
+
+	cfg := &api.Config{
+		DB:                 dir,
+		Namespace:          testNs,
+		MaxCacheSize:       16 * 1024 * 1024,
+		NoFsync:            true,
+		CompactionInterval: time.Hour,
+	}
+	ndb, err := New(cfg)
+	require.NoError(err, "New()")
+	badgerdb := ndb.(*badgerNodeDB)
+
+	initial := badgerdb.SizeStats()
+	require.EqualValues(0, initial.ReclaimableBytes, "nothing should be reclaimable initially")
+	require.True(initial.LastCompaction.IsZero(), "no compaction should have been performed")
+
+	commitVersion := func(tree mkvs.Tree, version uint64, value string) node.Root {
+		for i := 0; i < 10; i++ {
+			err = tree.Insert(ctx, []byte(fmt.Sprintf("key %d", i)), []byte(fmt.Sprintf("%s %d", value, i)))
+			require.NoError(err, "Insert")
+		}
+		_, rootHash, cerr := tree.Commit(ctx, testNs, version)
+		require.NoError(cerr, "Commit")
+		return node.Root{Namespace: testNs, Version: version, Type: node.RootTypeState, Hash: rootHash}
+	}
+
+	tree := mkvs.New(nil, badgerdb, node.RootTypeState)
+	defer tree.Close()
+	root0 := commitVersion(tree, 0, "value 0")
+	err = badgerdb.Finalize(ctx, []node.Root{root0})
+	require.NoError(err, "Finalize")
+
+	stats := badgerdb.SizeStats()
+	require.True(stats.LiveBytes > initial.LiveBytes, "live bytes should increase after commit")
+	require.EqualValues(0, stats.ReclaimableBytes, "nothing should be reclaimable")
+
+	// Commit a root that will not be finalized in version 1.
+	otherTree := mkvs.NewWithRoot(nil, badgerdb, root0)
+	defer otherTree.Close()
+	commitVersion(otherTree, 1, "other value 1")
+	root1 := commitVersion(tree, 1, "value 1")
+	beforeFinalize := badgerdb.SizeStats()
+	err = badgerdb.Finalize(ctx, []node.Root{root1})
+	require.NoError(err, "Finalize")
+
+	stats = badgerdb.SizeStats()
+	require.True(stats.ReclaimableBytes > 0, "non-finalized roots should be reclaimable")
+	require.True(stats.LiveBytes < beforeFinalize.LiveBytes, "live bytes should decrease after finalization")
+
+	// Pruning version 0 should make the nodes removed in version 1 reclaimable.
+	beforePrune := stats
+	err = badgerdb.Prune(ctx, 0)
+	require.NoError(err, "Prune")
+	stats = badgerdb.SizeStats()
+	require.True(stats.ReclaimableBytes > beforePrune.ReclaimableBytes, "pruned data should be reclaimable")
+	require.True(stats.LiveBytes < beforePrune.LiveBytes, "live bytes should decrease after pruning")
+
+	// Compaction should only account for what it actually reclaimed.
+	beforeCompaction := stats
+	diskBefore := badgerdb.diskUsage()
+	err = badgerdb.compactor.compact()
+	require.NoError(err, "compact")
+	var reclaimed uint64
+	if diskAfter := badgerdb.diskUsage(); diskBefore > diskAfter {
+		reclaimed = diskBefore - diskAfter
+	}
+	expectedReclaimable := uint64(0)
+	if beforeCompaction.ReclaimableBytes > reclaimed {
+		expectedReclaimable = beforeCompaction.ReclaimableBytes - reclaimed
+	}
+	stats = badgerdb.SizeStats()
+	require.EqualValues(expectedReclaimable, stats.ReclaimableBytes, "only reclaimed bytes should be subtracted")
+	require.Equal(beforeCompaction.LiveBytes, stats.LiveBytes, "compaction should not change live bytes")
+	require.False(stats.LastCompaction.IsZero(), "compaction time should be recorded")
+
+	// Reclaiming more than is reclaimable should not underflow.
+	err = badgerdb.markCompacted(stats.ReclaimableBytes+1, time.Now())
+	require.NoError(err, "markCompacted")
+	stats = badgerdb.SizeStats()
+	require.EqualValues(0, stats.ReclaimableBytes, "nothing should be reclaimable")
+
+	// Size statistics should not change unless the transaction is committed.
+	tx := badgerdb.db.NewTransactionAt(tsMetadata, true)
+	_, err = badgerdb.sizes.update(tx, 1000, 0)
+	require.NoError(err, "update")
+	tx.Discard()
+	require.Equal(stats, badgerdb.SizeStats(), "size statistics should not change without a commit")
+
+	// Size statistics should be persisted.
+	badgerdb.Close()
+	ndb, err = New(cfg)
+	require.NoError(err, "New()")
+	defer ndb.Close()
+	require.Equal(stats, ndb.(*badgerNodeDB).SizeStats(), "size statistics should be persisted")
+}
+
+func TestCompactionThrottle(t *testing.T) {
+	require := require.New(t)
+
+	c := &compactor{
+		opInterval: 100 * time.Millisecond,
+		closeCh:    make(chan struct{}),
+	}
+
+	start := time.Now()
+	require.True(c.throttle(), "throttle should succeed")
+	require.True(time.Since(start) < c.opInterval, "first operation should start immediately")
+
+	c.lastOp = time.Now()
+	require.True(c.throttle(), "throttle should succeed")
+	require.True(time.Since(c.lastOp) >= c.opInterval, "throttle should wait for the operation interval")
+
+	c.lastOp = time.Now().Add(-time.Hour)
+	start = time.Now()
+	require.True(c.throttle(), "throttle should succeed")
+	require.True(time.Since(start) < c.opInterval, "operation should start immediately after being idle")
+
+	c.lastOp = time.Now()
+	close(c.closeCh)
+	require.False(c.throttle(), "throttle should abort when closed")
+}
+
+func TestDecodeKey(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	ndb, err := New(dbCfg)
+	require.NoError(err, "New()")
+	defer ndb.Close()
+	badgerdb := ndb.(*badgerNodeDB)
+
+	root := fillDB(ctx, require, testValues, 1, ndb)
+	err = ndb.Finalize(ctx, []node.Root{root})
+	require.NoError(err, "Finalize()")
+
+	_, ok := TsToVersion(tsMetadata)
+	require.False(ok, "TsToVersion should fail for the metadata timestamp")
+
+	seen := make(map[KeyType]bool)
+	err = badgerdb.db.View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer it.Close()
+		for it.Rewind(); it.Valid(); it.Next() {
+			item := it.Item()
+			key, derr := DecodeKey(item.Key())
+			require.NoError(derr, "DecodeKey(%X)", item.Key())
+			seen[key.Type] = true
+
+			switch key.Type {
+			case KeyTypeNode:
+				version, vok := TsToVersion(item.Version())
+				require.True(vok, "nodes should be stored at a version timestamp")
+				require.EqualValues(root.Version, version, "nodes should be stored at the version timestamp")
+			case KeyTypeWriteLog:
+				require.EqualValues(root.Version, key.Version, "write log version should be decoded")
+			case KeyTypeRootsMetadata:
+				require.EqualValues(root.Version, key.Version, "roots metadata version should be decoded")
+				derr = item.Value(func(value []byte) error {
+					roots, rerr := DecodeRootsMetadata(testNs, key.Version, value)
+					require.NoError(rerr, "DecodeRootsMetadata()")
+					require.Equal([]node.Root{root}, roots, "roots metadata should contain the finalized root")
+					return nil
+				})
+				require.NoError(derr, "Value()")
+			case KeyTypeMetadata:
+				derr = item.Value(func(value []byte) error {
+					meta, merr := DecodeMetadata(value)
+					require.NoError(merr, "DecodeMetadata()")
+					require.Equal(testNs, meta.Namespace, "metadata namespace should be decoded")
+					require.NotNil(meta.LastFinalizedVersion, "metadata last finalized version should be decoded")
+					require.EqualValues(root.Version, *meta.LastFinalizedVersion, "metadata last finalized version should be decoded")
+					return nil
+				})
+				require.NoError(derr, "Value()")
+			}
+		}
+		return nil
+	})
+	require.NoError(err, "View()")
+
+	for _, keyType := range []KeyType{
+		KeyTypeNode,
+		KeyTypeWriteLog,
+		KeyTypeRootsMetadata,
+		KeyTypeMetadata,
+		KeyTypeRootNode,
+		KeyTypeSizeAccounting,
+	} {
+		require.True(seen[keyType], "key type %d should be present in the database", keyType)
+	}
+
+	_, err = DecodeKey([]byte{0xfe})
+	require.Error(err, "DecodeKey should fail for unknown keys")
+}
@@ -0,0 +1,261 @@
+package badger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+)
+
+const (
+	// defaultCompactionInterval is the default interval between compaction passes.
+	defaultCompactionInterval = 5 * time.Minute
+	// compactionDiscardRatio is the fraction of a value log file that must be reclaimable for
+	// the file to be rewritten.
+	compactionDiscardRatio = 0.5
+	// compactionFlattenRatio is the fraction of the LSM tree size that must be reclaimable
+	// before the LSM tree is flattened.
+	compactionFlattenRatio = 0.25
+)
+
+var (
+	liveBytes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "oasis_storage_mkvs_live_bytes",
+			Help: "Estimated number of bytes reachable from retained versions.",
+		},
+		[]string{"runtime"},
+	)
+	reclaimableBytes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "oasis_storage_mkvs_reclaimable_bytes",
+			Help: "Estimated number of bytes that can be reclaimed by compaction.",
+		},
+		[]string{"runtime"},
+	)
+	versionBytes = prometheus.NewSummaryVec(
+		prometheus.SummaryOpts{
+			Name: "oasis_storage_mkvs_version_bytes",
+			Help: "Number of bytes written per finalized version.",
+		},
+		[]string{"runtime"},
+	)
+	compactionReclaimedBytes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_storage_mkvs_compaction_reclaimed_bytes",
+			Help: "Number of bytes reclaimed by compaction.",
+		},
+		[]string{"runtime"},
+	)
+	compactionDuration = prometheus.NewSummaryVec(
+		prometheus.SummaryOpts{
+			Name: "oasis_storage_mkvs_compaction_duration",
+			Help: "Duration of compaction passes (seconds).",
+		},
+		[]string{"runtime"},
+	)
+
+	nodeDBCollectors = []prometheus.Collector{
+		liveBytes,
+		reclaimableBytes,
+		versionBytes,
+		compactionReclaimedBytes,
+		compactionDuration,
+	}
+
+	metricsOnce sync.Once
+)
+
+func (d *badgerNodeDB) metricLabels() prometheus.Labels {
+	return prometheus.Labels{"runtime": d.namespace.String()}
+}
+
+func (d *badgerNodeDB) updateSizeMetrics() {
+	stats := d.sizes.get()
+	liveBytes.With(d.metricLabels()).Set(float64(stats.LiveBytes))
+	reclaimableBytes.With(d.metricLabels()).Set(float64(stats.ReclaimableBytes))
+}
+
+// compactor is the online node database compaction worker.
+//
+// Each pass rewrites value log files with enough reclaimable data and flattens the LSM tree
+// when enough of it is reclaimable, so that removed versions are dropped from disk.
+//
+// Badger runs each of these operations at full speed and provides no way to bound their I/O, so
+// the compactor can only space them out. Before each operation it waits until at least the
+// configured operation interval has passed since the previous operation completed.
+type compactor struct {
+	logger *logging.Logger
+
+	db *badgerNodeDB
+
+	interval   time.Duration
+	opInterval time.Duration
+	lastOp     time.Time
+
+	closeOnce sync.Once
+	closeCh   chan struct{}
+	closedCh  chan struct{}
+}
+
+// Close halts the compaction worker.
+func (c *compactor) Close() {
+	c.closeOnce.Do(func() {
+		close(c.closeCh)
+		<-c.closedCh
+	})
+}
+
+// throttle waits until the operation interval has passed since the previous operation
+// completed. It returns false if the compactor was closed while waiting.
+func (c *compactor) throttle() bool {
+	wait := time.Until(c.lastOp.Add(c.opInterval))
+	if wait <= 0 {
+		return true
+	}
+	select {
+	case <-c.closeCh:
+		return false
+	case <-time.After(wait):
+		return true
+	}
+}
+
+// compact performs a single compaction pass.
+func (c *compactor) compact() error {
+	start := time.Now()
+	before := c.db.diskUsage()
+
+	// Rewrite value log files one at a time.
+	for {
+		if !c.throttle() {
+			return nil
+		}
+		err := c.db.db.RunValueLogGC(compactionDiscardRatio)
+		c.lastOp = time.Now()
+		if err == badger.ErrNoRewrite || err == badger.ErrRejected {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// Flatten the LSM tree if enough of it is reclaimable, dropping any versions below the
+	// discard timestamp.
+	stats := c.db.sizes.get()
+	lsm, _ := c.db.db.Size()
+	if lsm > 0 && float64(stats.ReclaimableBytes) >= compactionFlattenRatio*float64(lsm) {
+		if !c.throttle() {
+			return nil
+		}
+		err := c.db.db.Flatten(1)
+		c.lastOp = time.Now()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Data written concurrently also changes disk usage, so this only estimates the number of
+	// reclaimed bytes.
+	var reclaimed uint64
+	after := c.db.diskUsage()
+	if before > after {
+		reclaimed = before - after
+	}
+	compactionReclaimedBytes.With(c.db.metricLabels()).Add(float64(reclaimed))
+	compactionDuration.With(c.db.metricLabels()).Observe(time.Since(start).Seconds())
+
+	if err := c.db.markCompacted(reclaimed, time.Now()); err != nil {
+		return err
+	}
+
+	c.logger.Debug("compaction pass completed",
+		"duration", time.Since(start),
+		"disk_before", before,
+		"disk_after", after,
+		"reclaimed", reclaimed,
+	)
+	return nil
+}
+
+func (c *compactor) worker() {
+	defer close(c.closedCh)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.closeCh:
+			return
+		case <-ticker.C:
+		}
+
+		if err := c.compact(); err != nil {
+			c.logger.Error("failed to compact node database",
+				"err", err,
+			)
+		}
+	}
+}
+
+func newCompactor(db *badgerNodeDB, interval, opInterval time.Duration) *compactor {
+	if interval == 0 {
+		interval = defaultCompactionInterval
+	}
+
+	c := &compactor{
+		logger:     db.logger.With("component", "compactor"),
+		db:         db,
+		interval:   interval,
+		opInterval: opInterval,
+		closeCh:    make(chan struct{}),
+		closedCh:   make(chan struct{}),
+	}
+
+	go c.worker()
+
+	return c
+}
+
+// diskUsage returns the number of bytes used by database files on disk.
+func (d *badgerNodeDB) diskUsage() uint64 {
+	var size uint64
+	_ = filepath.Walk(d.dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if !info.IsDir() && (strings.HasSuffix(path, ".sst") || strings.HasSuffix(path, ".vlog")) {
+			size += uint64(info.Size())
+		}
+		return nil
+	})
+	return size
+}
+
+// markCompacted records a completed compaction pass that reclaimed the given number of bytes.
+func (d *badgerNodeDB) markCompacted(reclaimed uint64, now time.Time) error {
+	d.metaUpdateLock.Lock()
+	defer d.metaUpdateLock.Unlock()
+
+	tx := d.db.NewTransactionAt(tsMetadata, true)
+	defer tx.Discard()
+
+	applySizes, err := d.sizes.compacted(tx, reclaimed, now)
+	if err != nil {
+		return err
+	}
+	if err = tx.CommitAt(tsMetadata, nil); err != nil {
+		return err
+	}
+	applySizes()
+	d.updateSizeMetrics()
+	return nil
+}
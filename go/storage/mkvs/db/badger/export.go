@@ -0,0 +1,139 @@
+package badger
+
+import (
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+)
+
+// KeyType is the type of an entry stored in a badger node database.
+//
+// Together with DecodeKey and TsToVersion it describes the on-disk layout of the database so
+// that it can be converted into a different node database backend.
+type KeyType uint8
+
+const (
+	// KeyTypeUnknown is an unknown key.
+	KeyTypeUnknown KeyType = iota
+	// KeyTypeNode is a node, the value is the serialized node.
+	KeyTypeNode
+	// KeyTypeWriteLog is a write log, the value is the CBOR-serialized write log.
+	KeyTypeWriteLog
+	// KeyTypeRootsMetadata is the roots metadata of a version (see DecodeRootsMetadata).
+	KeyTypeRootsMetadata
+	// KeyTypeRootUpdatedNodes are the nodes updated by a non-finalized root.
+	KeyTypeRootUpdatedNodes
+	// KeyTypeMetadata is the database metadata (see DecodeMetadata).
+	KeyTypeMetadata
+	// KeyTypeMultipartRestoreNodeLog is a node inserted during an in-progress multipart restore.
+	KeyTypeMultipartRestoreNodeLog
+	// KeyTypeRootNode marks a root node.
+	KeyTypeRootNode
+	// KeyTypeSizeAccounting is size accounting specific to the badger backend.
+	KeyTypeSizeAccounting
+)
+
+// Key is a decoded key of an entry stored in a badger node database.
+type Key struct {
+	// Type is the type of the entry.
+	Type KeyType
+	// Version is the version of versioned entries (write logs, roots metadata and updated nodes).
+	Version uint64
+	// Hash is the hash of node entries.
+	Hash hash.Hash
+}
+
+// DecodeKey decodes a key of an entry stored in a badger node database.
+func DecodeKey(raw []byte) (*Key, error) {
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("mkvs/badger: malformed key")
+	}
+
+	var (
+		key Key
+		ok  = true
+	)
+	switch raw[0] {
+	case nodeKeyFmt.Prefix():
+		key.Type = KeyTypeNode
+		ok = nodeKeyFmt.Decode(raw, &key.Hash)
+	case writeLogKeyFmt.Prefix():
+		key.Type = KeyTypeWriteLog
+		ok = writeLogKeyFmt.Decode(raw, &key.Version)
+	case rootsMetadataKeyFmt.Prefix():
+		key.Type = KeyTypeRootsMetadata
+		ok = rootsMetadataKeyFmt.Decode(raw, &key.Version)
+	case rootUpdatedNodesKeyFmt.Prefix():
+		key.Type = KeyTypeRootUpdatedNodes
+		ok = rootUpdatedNodesKeyFmt.Decode(raw, &key.Version)
+	case metadataKeyFmt.Prefix():
+		key.Type = KeyTypeMetadata
+	case multipartRestoreNodeLogKeyFmt.Prefix():
+		key.Type = KeyTypeMultipartRestoreNodeLog
+	case rootNodeKeyFmt.Prefix():
+		key.Type = KeyTypeRootNode
+	case sizeStatsKeyFmt.Prefix(), versionSizeKeyFmt.Prefix():
+		key.Type = KeyTypeSizeAccounting
+	default:
+		return nil, fmt.Errorf("mkvs/badger: unknown key: %X", raw)
+	}
+	if !ok {
+		return nil, fmt.Errorf("mkvs/badger: malformed key: %X", raw)
+	}
+	return &key, nil
+}
+
+// TsToVersion converts a badger timestamp of an entry to a MKVS version. It returns false for
+// entries stored at the metadata timestamp, which do not belong to any version.
+func TsToVersion(ts uint64) (uint64, bool) {
+	if ts <= tsMetadata {
+		return 0, false
+	}
+	return ts - tsMetadata - 1, true
+}
+
+// Metadata is the badger node database metadata.
+type Metadata struct {
+	// Namespace is the namespace the database is for.
+	Namespace common.Namespace
+	// EarliestVersion is the earliest version.
+	EarliestVersion uint64
+	// LastFinalizedVersion is the last finalized version, if any.
+	LastFinalizedVersion *uint64
+}
+
+// DecodeMetadata decodes the value of a KeyTypeMetadata entry.
+func DecodeMetadata(value []byte) (*Metadata, error) {
+	var meta serializedMetadata
+	if err := cbor.Unmarshal(value, &meta); err != nil {
+		return nil, fmt.Errorf("mkvs/badger: failed to decode metadata: %w", err)
+	}
+	return &Metadata{
+		Namespace:            meta.Namespace,
+		EarliestVersion:      meta.EarliestVersion,
+		LastFinalizedVersion: meta.LastFinalizedVersion,
+	}, nil
+}
+
+// DecodeRootsMetadata decodes the value of a KeyTypeRootsMetadata entry and returns the roots
+// created in the given version.
+func DecodeRootsMetadata(namespace common.Namespace, version uint64, value []byte) ([]node.Root, error) {
+	var rootsMeta rootsMetadata
+	if err := cbor.Unmarshal(value, &rootsMeta); err != nil {
+		return nil, fmt.Errorf("mkvs/badger: failed to decode roots metadata: %w", err)
+	}
+
+	roots := make([]node.Root, 0, len(rootsMeta.Roots))
+	for rootHash := range rootsMeta.Roots {
+		roots = append(roots, node.Root{
+			Namespace: namespace,
+			Version:   version,
+			Type:      rootHash.Type(),
+			Hash:      rootHash.Hash(),
+		})
+	}
+	return roots, nil
+}
@@ -381,6 +381,10 @@ func TestBadgerV4KeyVersioning(t *testing.T) {
 			// Nothing to do.
 		case metadataKeyFmt.Decode(key):
 			// Nothing to do.
+		case sizeStatsKeyFmt.Decode(key):
+			// Nothing to do.
+		case versionSizeKeyFmt.Decode(key, &v):
+			// Nothing to do.
 		default:
 			require.FailNow(t, "unknown key")
 		}
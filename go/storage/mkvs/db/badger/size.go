@@ -0,0 +1,187 @@
+package badger
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
+)
+
+// serializedSizeStats is the on-disk serialized size statistics.
+type serializedSizeStats struct {
+	// LiveBytes is the estimated number of bytes reachable from retained versions.
+	LiveBytes uint64 `json:"live_bytes"`
+	// ReclaimableBytes is the estimated number of bytes that can be reclaimed by compaction.
+	ReclaimableBytes uint64 `json:"reclaimable_bytes"`
+	// LastCompaction is the UNIX timestamp of the last completed compaction pass.
+	LastCompaction int64 `json:"last_compaction"`
+}
+
+// sizeStats is the database size accounting.
+//
+// All updates happen while holding the metadata update lock, so the lock here only protects
+// concurrent readers.
+type sizeStats struct {
+	sync.RWMutex
+
+	value serializedSizeStats
+}
+
+func (s *sizeStats) get() api.SizeStats {
+	s.RLock()
+	defer s.RUnlock()
+
+	stats := api.SizeStats{
+		LiveBytes:        s.value.LiveBytes,
+		ReclaimableBytes: s.value.ReclaimableBytes,
+	}
+	if s.value.LastCompaction != 0 {
+		stats.LastCompaction = time.Unix(s.value.LastCompaction, 0)
+	}
+	return stats
+}
+
+// update stores the size statistics updated for newly written bytes and for bytes that became
+// reclaimable in the given transaction.
+//
+// The in-memory statistics are only updated when the returned function is called, which must
+// be done after the transaction has been successfully committed.
+func (s *sizeStats) update(tx *badger.Txn, written, freed uint64) (func(), error) {
+	return s.stage(tx, func(value *serializedSizeStats) {
+		value.LiveBytes += written
+		if freed > value.LiveBytes {
+			value.LiveBytes = 0
+		} else {
+			value.LiveBytes -= freed
+		}
+		value.ReclaimableBytes += freed
+	})
+}
+
+// compacted stores the size statistics updated for a completed compaction pass that reclaimed
+// the given number of bytes in the given transaction.
+//
+// The in-memory statistics are only updated when the returned function is called, which must
+// be done after the transaction has been successfully committed.
+func (s *sizeStats) compacted(tx *badger.Txn, reclaimed uint64, now time.Time) (func(), error) {
+	return s.stage(tx, func(value *serializedSizeStats) {
+		if reclaimed > value.ReclaimableBytes {
+			value.ReclaimableBytes = 0
+		} else {
+			value.ReclaimableBytes -= reclaimed
+		}
+		value.LastCompaction = now.Unix()
+	})
+}
+
+func (s *sizeStats) stage(tx *badger.Txn, fn func(*serializedSizeStats)) (func(), error) {
+	s.RLock()
+	value := s.value
+	s.RUnlock()
+
+	fn(&value)
+	if err := tx.Set(sizeStatsKeyFmt.Encode(), cbor.Marshal(value)); err != nil {
+		return nil, err
+	}
+	return func() {
+		s.Lock()
+		defer s.Unlock()
+
+		s.value = value
+	}, nil
+}
+
+func (s *sizeStats) save(tx *badger.Txn) error {
+	return tx.Set(sizeStatsKeyFmt.Encode(), cbor.Marshal(s.value))
+}
+
+// versionSize is the size accounting for a single version.
+//
+// NOTE: Public fields of this structure are part of the on-disk format.
+type versionSize struct {
+	_ struct{} `cbor:",toarray"`
+
+	// Nodes is the number of bytes of nodes written in this version.
+	Nodes uint64
+	// WriteLogs is the number of bytes of write logs stored for this version.
+	WriteLogs uint64
+	// Removed is the number of bytes of nodes removed by finalizing this version. These remain
+	// reachable from earlier versions until the version preceding this one is pruned.
+	Removed uint64
+
+	// version is the version this accounting is for.
+	version uint64
+}
+
+// loadVersionSize loads the size accounting for the given version from the database.
+func loadVersionSize(tx *badger.Txn, version uint64) (*versionSize, error) {
+	vs := &versionSize{version: version}
+	item, err := tx.Get(versionSizeKeyFmt.Encode(version))
+	switch err {
+	case nil:
+		if err = item.Value(func(val []byte) error { return cbor.Unmarshal(val, vs) }); err != nil {
+			return nil, fmt.Errorf("mkvs/badger: error reading version size: %w", err)
+		}
+	case badger.ErrKeyNotFound:
+	default:
+		return nil, fmt.Errorf("mkvs/badger: error reading version size: %w", err)
+	}
+	return vs, nil
+}
+
+// save saves the version size accounting to the database.
+func (vs *versionSize) save(tx *badger.Txn) error {
+	return tx.Set(versionSizeKeyFmt.Encode(vs.version), cbor.Marshal(vs))
+}
+
+// delete removes the version size accounting from the database.
+func (vs *versionSize) delete(tx *badger.Txn) error {
+	return tx.Delete(versionSizeKeyFmt.Encode(vs.version))
+}
+
+// itemSize returns the size of the given key in the given transaction or zero if the key
+// does not exist.
+func itemSize(tx *badger.Txn, key []byte) uint64 {
+	item, err := tx.Get(key)
+	if err != nil {
+		return 0
+	}
+	return uint64(item.EstimatedSize())
+}
+
+func (d *badgerNodeDB) loadSizeStats() error {
+	tx := d.db.NewTransactionAt(tsMetadata, !d.readOnly)
+	defer tx.Discard()
+
+	item, err := tx.Get(sizeStatsKeyFmt.Encode())
+	switch err {
+	case nil:
+		return item.Value(func(data []byte) error {
+			return cbor.UnmarshalTrusted(data, &d.sizes.value)
+		})
+	case badger.ErrKeyNotFound:
+	default:
+		return err
+	}
+
+	// Databases created before size accounting was introduced start out with everything
+	// currently on disk being considered live.
+	lsm, vlog := d.db.Size()
+	d.sizes.value.LiveBytes = uint64(lsm + vlog)
+	if d.readOnly {
+		return nil
+	}
+	if err = d.sizes.save(tx); err != nil {
+		return err
+	}
+	return tx.CommitAt(tsMetadata, nil)
+}
+
+// SizeStats implements api.SizeStatsProvider.
+func (d *badgerNodeDB) SizeStats() api.SizeStats {
+	return d.sizes.get()
+}
@@ -0,0 +1,1153 @@
+// Package bolt provides a bbolt-backed node database.
+//
+// Different from the Badger backend, bbolt does not support multi-version concurrency control
+// so removals of nodes that may still be referenced by earlier versions are deferred until
+// the corresponding version is pruned.
+package bolt
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/keyformat"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/writelog"
+)
+
+const (
+	dbVersion = 1
+
+	// dbFilename is the name of the database file inside the database directory.
+	dbFilename = "nodedb.bolt"
+
+	// openTimeout is the amount of time to wait for the database file lock.
+	openTimeout = 5 * time.Second
+
+	// multipartVersionNone is the value used for the multipart version in metadata
+	// when no multipart restore is in progress.
+	multipartVersionNone uint64 = 0
+)
+
+var (
+	// bucketName is the name of the bucket holding all of the node database entries.
+	bucketName = []byte("mkvs")
+
+	// nodeKeyFmt is the key format for nodes (node hash).
+	//
+	// Value is serialized node.
+	nodeKeyFmt = keyformat.New(0x00, &hash.Hash{})
+	// writeLogKeyFmt is the key format for write logs (version, new root,
+	// old root).
+	//
+	// Value is CBOR-serialized write log.
+	writeLogKeyFmt = keyformat.New(0x01, uint64(0), &typedHash{}, &typedHash{})
+	// rootsMetadataKeyFmt is the key format for roots metadata. The key format is (version).
+	//
+	// Value is CBOR-serialized rootsMetadata.
+	rootsMetadataKeyFmt = keyformat.New(0x02, uint64(0))
+	// rootUpdatedNodesKeyFmt is the key format for the pending updated nodes for the
+	// given root that need to be removed only in case the given root is not among
+	// the finalized roots. They key format is (version, root).
+	//
+	// Value is CBOR-serialized []updatedNode.
+	rootUpdatedNodesKeyFmt = keyformat.New(0x03, uint64(0), &typedHash{})
+	// metadataKeyFmt is the key format for metadata.
+	//
+	// Value is CBOR-serialized metadata.
+	metadataKeyFmt = keyformat.New(0x04)
+	// multipartRestoreNodeLogKeyFmt is the key format for the nodes inserted during a chunk restore.
+	// Once a set of chunks is fully restored, these entries should be removed. If chunk restoration
+	// is interrupted for any reason, the nodes associated with these keys should be removed, along
+	// with these entries.
+	//
+	// Value is empty.
+	multipartRestoreNodeLogKeyFmt = keyformat.New(0x05, &typedHash{})
+	// rootNodeKeyFmt is the key format for root nodes (typed node hash, version). A root is
+	// visible in all versions starting with the version it was committed in.
+	//
+	// Value is empty.
+	rootNodeKeyFmt = keyformat.New(0x06, &typedHash{}, uint64(0))
+	// pendingRemovalKeyFmt is the key format for nodes that were removed in the given version
+	// but may still be referenced by roots in earlier versions (version, node hash). The nodes
+	// are removed once all earlier versions have been pruned.
+	//
+	// Value is empty.
+	pendingRemovalKeyFmt = keyformat.New(0x07, uint64(0), &hash.Hash{})
+)
+
+// New creates a new bbolt-backed node database.
+func New(cfg *api.Config) (api.NodeDB, error) {
+	if cfg.MemoryOnly {
+		return nil, fmt.Errorf("mkvs/bolt: memory-only mode is not supported")
+	}
+
+	db := &boltNodeDB{
+		logger:           logging.GetLogger("mkvs/db/bolt"),
+		namespace:        cfg.Namespace,
+		readOnly:         cfg.ReadOnly,
+		discardWriteLogs: cfg.DiscardWriteLogs,
+	}
+
+	if !cfg.ReadOnly {
+		if err := common.Mkdir(cfg.DB); err != nil {
+			return nil, fmt.Errorf("mkvs/bolt: failed to create database directory: %w", err)
+		}
+	}
+
+	var err error
+	if db.db, err = bolt.Open(filepath.Join(cfg.DB, dbFilename), 0o600, &bolt.Options{
+		Timeout:      openTimeout,
+		ReadOnly:     cfg.ReadOnly,
+		FreelistType: bolt.FreelistMapType,
+	}); err != nil {
+		return nil, fmt.Errorf("mkvs/bolt: failed to open database: %w", err)
+	}
+	db.db.NoSync = cfg.NoFsync
+
+	// Load database metadata.
+	if err = db.load(); err != nil {
+		_ = db.db.Close()
+		return nil, fmt.Errorf("mkvs/bolt: failed to load metadata: %w", err)
+	}
+
+	// Cleanup any multipart restore remnants, since they can't be used anymore.
+	if err = db.cleanMultipartLocked(true); err != nil {
+		_ = db.db.Close()
+		return nil, fmt.Errorf("mkvs/bolt: failed to clean leftovers from multipart restore: %w", err)
+	}
+
+	return db, nil
+}
+
+type boltNodeDB struct { // nolint: maligned
+	logger *logging.Logger
+
+	namespace common.Namespace
+
+	readOnly         bool
+	discardWriteLogs bool
+
+	multipartVersion uint64
+
+	db *bolt.DB
+
+	// metaUpdateLock must be held at any point where metadata is read and updated.
+	metaUpdateLock sync.Mutex
+	meta           metadata
+
+	closeOnce sync.Once
+}
+
+// bucket returns the node database bucket.
+func bucket(tx *bolt.Tx) *bolt.Bucket {
+	return tx.Bucket(bucketName)
+}
+
+// prefixKeys returns copies of all keys with the given prefix.
+func prefixKeys(tx *bolt.Tx, prefix []byte) [][]byte {
+	var keys [][]byte
+	c := bucket(tx).Cursor()
+	for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+		keys = append(keys, append([]byte{}, k...))
+	}
+	return keys
+}
+
+// deleteKeys removes all of the given keys.
+func deleteKeys(tx *bolt.Tx, keys [][]byte) error {
+	b := bucket(tx)
+	for _, key := range keys {
+		if err := b.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *boltNodeDB) load() error {
+	loadFn := func(tx *bolt.Tx) error {
+		b := bucket(tx)
+		if b == nil {
+			if d.readOnly {
+				return fmt.Errorf("database not initialized")
+			}
+
+			var err error
+			if b, err = tx.CreateBucket(bucketName); err != nil {
+				return err
+			}
+		}
+
+		// Load metadata.
+		if data := b.Get(metadataKeyFmt.Encode()); data != nil {
+			// Metadata already exists, just load it and verify that it is
+			// compatible with what we have here.
+			if err := cbor.UnmarshalTrusted(data, &d.meta.value); err != nil {
+				return err
+			}
+
+			if d.meta.value.Version != dbVersion {
+				return fmt.Errorf("incompatible database version (expected: %d got: %d)",
+					dbVersion,
+					d.meta.value.Version,
+				)
+			}
+			if !d.meta.value.Namespace.Equal(&d.namespace) {
+				return fmt.Errorf("incompatible namespace (expected: %s got: %s)",
+					d.namespace,
+					d.meta.value.Namespace,
+				)
+			}
+			return nil
+		}
+		if d.readOnly {
+			return fmt.Errorf("database metadata not found")
+		}
+
+		// No metadata exists, create some.
+		d.meta.value.Version = dbVersion
+		d.meta.value.Namespace = d.namespace
+		return d.meta.save(tx)
+	}
+
+	if d.readOnly {
+		return d.db.View(loadFn)
+	}
+	return d.db.Update(loadFn)
+}
+
+func (d *boltNodeDB) sanityCheckNamespace(ns common.Namespace) error {
+	if !ns.Equal(&d.namespace) {
+		return api.ErrBadNamespace
+	}
+	return nil
+}
+
+func (d *boltNodeDB) checkRoot(tx *bolt.Tx, root node.Root) error {
+	rootHash := typedHashFromRoot(root)
+
+	// Find the latest version of the root which is not after the requested version.
+	c := bucket(tx).Cursor()
+	k, _ := c.Seek(rootNodeKeyFmt.Encode(&rootHash, root.Version))
+	switch {
+	case k == nil:
+		k, _ = c.Last()
+	case bytes.Equal(k, rootNodeKeyFmt.Encode(&rootHash, root.Version)):
+		return nil
+	default:
+		k, _ = c.Prev()
+	}
+	if k == nil || !bytes.HasPrefix(k, rootNodeKeyFmt.Encode(&rootHash)) {
+		return api.ErrRootNotFound
+	}
+	return nil
+}
+
+// Assumes metaUpdateLock is held when called.
+func (d *boltNodeDB) cleanMultipartLocked(removeNodes bool) error {
+	var version uint64
+
+	if d.multipartVersion != multipartVersionNone {
+		version = d.multipartVersion
+	} else {
+		version = d.meta.getMultipartVersion()
+	}
+	if version == multipartVersionNone {
+		// No multipart in progress, but it's not an error to call in a situation like this.
+		return nil
+	}
+
+	if err := d.db.Update(func(tx *bolt.Tx) error {
+		b := bucket(tx)
+
+		keys := prefixKeys(tx, multipartRestoreNodeLogKeyFmt.Encode())
+		if removeNodes && len(keys) > 0 {
+			d.logger.Info("removing some nodes from a multipart restore")
+		}
+		for _, key := range keys {
+			if removeNodes {
+				var hash typedHash
+				if !multipartRestoreNodeLogKeyFmt.Decode(key, &hash) {
+					panic("mkvs/bolt: bad iterator")
+				}
+				switch hash.Type() {
+				case node.RootTypeInvalid:
+					h := hash.Hash()
+					if err := b.Delete(nodeKeyFmt.Encode(&h)); err != nil {
+						return err
+					}
+				default:
+					if err := b.Delete(rootNodeKeyFmt.Encode(&hash, version)); err != nil {
+						return err
+					}
+				}
+			}
+			if err := b.Delete(key); err != nil {
+				return err
+			}
+		}
+
+		return d.meta.setMultipartVersion(tx, 0)
+	}); err != nil {
+		return err
+	}
+
+	d.multipartVersion = multipartVersionNone
+	return nil
+}
+
+func (d *boltNodeDB) GetNode(root node.Root, ptr *node.Pointer) (node.Node, error) {
+	if ptr == nil || !ptr.IsClean() {
+		panic("mkvs/bolt: attempted to get invalid pointer from node database")
+	}
+	if err := d.sanityCheckNamespace(root.Namespace); err != nil {
+		return nil, err
+	}
+	// If the version is earlier than the earliest version, we don't have the node (it was pruned).
+	// Note that the key can still be present in the database until the removal is processed.
+	if root.Version < d.meta.getEarliestVersion() {
+		return nil, api.ErrNodeNotFound
+	}
+
+	var n node.Node
+	err := d.db.View(func(tx *bolt.Tx) error {
+		// Check if the root actually exists.
+		if err := d.checkRoot(tx, root); err != nil {
+			return err
+		}
+
+		data := bucket(tx).Get(nodeKeyFmt.Encode(&ptr.Hash))
+		if data == nil {
+			return api.ErrNodeNotFound
+		}
+
+		// The data is only valid for the lifetime of the transaction and the unmarshalled
+		// node may reference it, so make a copy.
+		var err error
+		if n, err = node.UnmarshalBinary(append([]byte{}, data...)); err != nil {
+			d.logger.Error("failed to unmarshal node",
+				"err", err,
+			)
+			return fmt.Errorf("mkvs/bolt: failed to unmarshal node: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+func (d *boltNodeDB) GetWriteLog(ctx context.Context, startRoot, endRoot node.Root) (writelog.Iterator, error) {
+	if d.discardWriteLogs {
+		return nil, api.ErrWriteLogNotFound
+	}
+	if !endRoot.Follows(&startRoot) {
+		return nil, api.ErrRootMustFollowOld
+	}
+	if err := d.sanityCheckNamespace(startRoot.Namespace); err != nil {
+		return nil, err
+	}
+	// If the version is earlier than the earliest version, we don't have the roots.
+	if endRoot.Version < d.meta.getEarliestVersion() {
+		return nil, api.ErrWriteLogNotFound
+	}
+
+	// Start at the end root and search towards the start root. This assumes that the
+	// chains are not long and that there is not a lot of forks as in that case performance
+	// would suffer.
+	//
+	// In reality the two common cases are:
+	// - State updates: s -> s' (a single hop)
+	// - I/O updates: empty -> i -> io (two hops)
+	//
+	// For this reason, we currently refuse to traverse more than two hops.
+	const maxAllowedHops = 2
+
+	type wlItem struct {
+		depth       uint8
+		endRootHash typedHash
+		logKeys     [][]byte
+		logRoots    []typedHash
+	}
+
+	// Find the path and load the write logs in a single read transaction. The write logs are
+	// then revived outside of the transaction as that requires additional node lookups.
+	var (
+		logRoots []node.Root
+		logs     []api.HashedDBWriteLog
+	)
+	err := d.db.View(func(tx *bolt.Tx) error {
+		// Check if the root actually exists.
+		if err := d.checkRoot(tx, endRoot); err != nil {
+			return err
+		}
+
+		// NOTE: We could use a proper deque, but as long as we keep the number of hops and
+		//       forks low, this should not be a problem.
+		queue := []*wlItem{{depth: 0, endRootHash: typedHashFromRoot(endRoot)}}
+		startRootHash := typedHashFromRoot(startRoot)
+		for len(queue) > 0 {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			curItem := queue[0]
+			queue = queue[1:]
+
+			// Iterate over all write logs that result in the current item.
+			for _, key := range prefixKeys(tx, writeLogKeyFmt.Encode(endRoot.Version, &curItem.endRootHash)) {
+				var decVersion uint64
+				var decEndRootHash typedHash
+				var decStartRootHash typedHash
+
+				if !writeLogKeyFmt.Decode(key, &decVersion, &decEndRootHash, &decStartRootHash) {
+					// This should not happen as the prefix iteration should take care of it.
+					panic("mkvs/bolt: bad iterator")
+				}
+
+				nextItem := wlItem{
+					depth:       curItem.depth + 1,
+					endRootHash: decStartRootHash,
+					// Only store log keys to avoid keeping everything in memory while
+					// we are searching for the right path.
+					logKeys:  append(append([][]byte{}, curItem.logKeys...), key),
+					logRoots: append(append([]typedHash{}, curItem.logRoots...), curItem.endRootHash),
+				}
+				if nextItem.endRootHash.Equal(&startRootHash) {
+					// Path has been found, deserialize write logs.
+					for i, logKey := range nextItem.logKeys {
+						var log api.HashedDBWriteLog
+						if err := cbor.UnmarshalTrusted(bucket(tx).Get(logKey), &log); err != nil {
+							return err
+						}
+
+						logRoots = append(logRoots, node.Root{
+							Namespace: endRoot.Namespace,
+							Version:   endRoot.Version,
+							Type:      nextItem.logRoots[i].Type(),
+							Hash:      nextItem.logRoots[i].Hash(),
+						})
+						logs = append(logs, log)
+					}
+					return nil
+				}
+
+				if nextItem.depth < maxAllowedHops {
+					queue = append(queue, &nextItem)
+				}
+			}
+		}
+
+		return api.ErrWriteLogNotFound
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var index int
+	return api.ReviveHashedDBWriteLogs(ctx,
+		func() (node.Root, api.HashedDBWriteLog, error) {
+			if index >= len(logs) {
+				return node.Root{}, nil, nil
+			}
+
+			root, log := logRoots[index], logs[index]
+			index++
+			return root, log, nil
+		},
+		func(root node.Root, h hash.Hash) (*node.LeafNode, error) {
+			leaf, err := d.GetNode(root, &node.Pointer{Hash: h, Clean: true})
+			if err != nil {
+				return nil, err
+			}
+			return leaf.(*node.LeafNode), nil
+		},
+		func() {},
+	)
+}
+
+func (d *boltNodeDB) GetLatestVersion(ctx context.Context) (uint64, error) {
+	version, _ := d.meta.getLastFinalizedVersion()
+	return version, nil
+}
+
+func (d *boltNodeDB) GetEarliestVersion(ctx context.Context) (uint64, error) {
+	return d.meta.getEarliestVersion(), nil
+}
+
+func (d *boltNodeDB) GetRootsForVersion(ctx context.Context, version uint64) (roots []node.Root, err error) {
+	// If the version is earlier than the earliest version, we don't have the roots.
+	if version < d.meta.getEarliestVersion() {
+		return nil, nil
+	}
+
+	var rootsMeta *rootsMetadata
+	if err = d.db.View(func(tx *bolt.Tx) error {
+		rootsMeta, err = loadRootsMetadata(tx, version)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	for rootHash := range rootsMeta.Roots {
+		roots = append(roots, node.Root{
+			Namespace: d.namespace,
+			Version:   version,
+			Type:      rootHash.Type(),
+			Hash:      rootHash.Hash(),
+		})
+	}
+	return
+}
+
+func (d *boltNodeDB) HasRoot(root node.Root) bool {
+	if err := d.sanityCheckNamespace(root.Namespace); err != nil {
+		return false
+	}
+
+	// An empty root is always implicitly present.
+	if root.Hash.IsEmpty() {
+		return true
+	}
+
+	// If the version is earlier than the earliest version, we don't have the root.
+	if root.Version < d.meta.getEarliestVersion() {
+		return false
+	}
+
+	var rootsMeta *rootsMetadata
+	if err := d.db.View(func(tx *bolt.Tx) error {
+		var err error
+		rootsMeta, err = loadRootsMetadata(tx, root.Version)
+		return err
+	}); err != nil {
+		panic(err)
+	}
+	return rootsMeta.Roots[typedHashFromRoot(root)] != nil
+}
+
+func (d *boltNodeDB) Finalize(ctx context.Context, roots []node.Root) error { // nolint: gocyclo
+	if d.readOnly {
+		return api.ErrReadOnly
+	}
+
+	if len(roots) == 0 {
+		return fmt.Errorf("mkvs/bolt: need at least one root to finalize")
+	}
+	version := roots[0].Version
+
+	d.metaUpdateLock.Lock()
+	defer d.metaUpdateLock.Unlock()
+
+	if d.multipartVersion != multipartVersionNone && d.multipartVersion != version {
+		return api.ErrInvalidMultipartVersion
+	}
+
+	// Make sure that the previous version has been finalized (if we are not restoring).
+	lastFinalizedVersion, exists := d.meta.getLastFinalizedVersion()
+	if d.multipartVersion == multipartVersionNone && version > 0 && exists && lastFinalizedVersion < (version-1) {
+		return api.ErrNotFinalized
+	}
+	// Make sure that this version has not yet been finalized.
+	if exists && version <= lastFinalizedVersion {
+		return api.ErrAlreadyFinalized
+	}
+
+	// Determine a set of finalized roots. Finalization is transitive, so if
+	// a parent root is finalized the child should be consider finalized too.
+	finalizedRoots := make(map[typedHash]bool)
+	for _, root := range roots {
+		if root.Version != version {
+			return fmt.Errorf("mkvs/bolt: roots to finalize don't have matching versions")
+		}
+		finalizedRoots[typedHashFromRoot(root)] = true
+	}
+
+	// Nodes removed in this version may still be referenced by roots in earlier versions. Unless
+	// there are no earlier versions, their removal must be deferred until those are pruned.
+	earliestVersion := d.meta.getEarliestVersion()
+	if !exists {
+		earliestVersion = version
+	}
+	deferRemovals := version > earliestVersion
+
+	if err := d.db.Update(func(tx *bolt.Tx) error {
+		b := bucket(tx)
+
+		var rootsChanged bool
+		rootsMeta, err := loadRootsMetadata(tx, version)
+		if err != nil {
+			return err
+		}
+
+		for updated := true; updated; {
+			updated = false
+
+			for rootHash, derivedRoots := range rootsMeta.Roots {
+				if len(derivedRoots) == 0 {
+					continue
+				}
+
+				for _, nextRoot := range derivedRoots {
+					if !finalizedRoots[rootHash] && finalizedRoots[nextRoot] {
+						finalizedRoots[rootHash] = true
+						updated = true
+					}
+				}
+			}
+		}
+
+		// Go through all roots and prune them based on whether they are finalized or not.
+		createdLoneNodes := make(map[hash.Hash]bool)
+		removedLoneNodes := make(map[hash.Hash]bool)
+		notLoneNodes := make(map[hash.Hash]bool)
+
+		for rootHash := range rootsMeta.Roots {
+			rootUpdatedNodesKey := rootUpdatedNodesKeyFmt.Encode(version, &rootHash)
+
+			// Load hashes of nodes added during this version for this root.
+			data := b.Get(rootUpdatedNodesKey)
+			if data == nil {
+				panic(fmt.Errorf("mkvs/bolt: corrupted/missing root updated nodes index"))
+			}
+
+			var updatedNodes []updatedNode
+			if err = cbor.UnmarshalTrusted(data, &updatedNodes); err != nil {
+				panic(fmt.Errorf("mkvs/bolt: corrupted root updated nodes index: %w", err))
+			}
+
+			if finalizedRoots[rootHash] {
+				// Make sure not to remove any nodes shared with finalized roots.
+				for _, n := range updatedNodes {
+					if n.Removed {
+						removedLoneNodes[n.Hash] = true
+					} else {
+						notLoneNodes[n.Hash] = true
+					}
+				}
+			} else {
+				// Remove any non-finalized roots. It is safe to remove these nodes
+				// as they can never be resurrected due to the version being part of the
+				// node hash as long as we make sure that these nodes are not shared
+				// with any finalized roots added in the same version.
+				for _, n := range updatedNodes {
+					if !n.Removed {
+						createdLoneNodes[n.Hash] = true
+					}
+				}
+
+				delete(rootsMeta.Roots, rootHash)
+				rootsChanged = true
+
+				// Remove write logs for the non-finalized root.
+				if !d.discardWriteLogs {
+					if err = deleteKeys(tx, prefixKeys(tx, writeLogKeyFmt.Encode(version, &rootHash))); err != nil {
+						return err
+					}
+				}
+			}
+
+			// Set of updated nodes no longer needed after finalization.
+			if err = b.Delete(rootUpdatedNodesKey); err != nil {
+				return err
+			}
+		}
+
+		// Clean any lone nodes.
+		for h := range removedLoneNodes {
+			if notLoneNodes[h] {
+				continue
+			}
+
+			if deferRemovals {
+				if err = b.Put(pendingRemovalKeyFmt.Encode(version, &h), []byte{}); err != nil {
+					return err
+				}
+				continue
+			}
+			if err = b.Delete(nodeKeyFmt.Encode(&h)); err != nil {
+				return err
+			}
+		}
+		for h := range createdLoneNodes {
+			if notLoneNodes[h] || removedLoneNodes[h] {
+				continue
+			}
+
+			// Nodes created in this version cannot be referenced by earlier versions.
+			if err = b.Delete(nodeKeyFmt.Encode(&h)); err != nil {
+				return err
+			}
+		}
+
+		// Save roots metadata if changed.
+		if rootsChanged {
+			if err = rootsMeta.save(tx); err != nil {
+				return fmt.Errorf("mkvs/bolt: failed to save roots metadata: %w", err)
+			}
+		}
+
+		// Update last finalized version.
+		if err = d.meta.setLastFinalizedVersion(tx, version); err != nil {
+			return fmt.Errorf("mkvs/bolt: failed to set last finalized version: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// Clean multipart metadata if there is any.
+	if d.multipartVersion != multipartVersionNone {
+		if err := d.cleanMultipartLocked(false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *boltNodeDB) Prune(ctx context.Context, version uint64) error {
+	if d.readOnly {
+		return api.ErrReadOnly
+	}
+
+	d.metaUpdateLock.Lock()
+	defer d.metaUpdateLock.Unlock()
+
+	if d.multipartVersion != multipartVersionNone {
+		return api.ErrMultipartInProgress
+	}
+
+	// Make sure that the version that we try to prune has been finalized.
+	lastFinalizedVersion, exists := d.meta.getLastFinalizedVersion()
+	if !exists || lastFinalizedVersion < version {
+		return api.ErrNotFinalized
+	}
+	// Make sure that the version that we are trying to prune is the earliest version.
+	if version != d.meta.getEarliestVersion() {
+		return api.ErrNotEarliest
+	}
+
+	var rootsMeta *rootsMetadata
+	if err := d.db.View(func(tx *bolt.Tx) error {
+		var err error
+		rootsMeta, err = loadRootsMetadata(tx, version)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	maybeLoneRoots := make(map[typedHash]bool)
+	for rootHash, derivedRoots := range rootsMeta.Roots {
+		if len(derivedRoots) == 0 {
+			// Need to only set the flag iff the flag has not already been set
+			// to either value before.
+			if _, ok := maybeLoneRoots[rootHash]; !ok {
+				maybeLoneRoots[rootHash] = true
+			}
+		} else {
+			maybeLoneRoots[rootHash] = false
+		}
+	}
+
+	// Collect all nodes created in this version by lone roots. This needs to be done before
+	// starting the write transaction as traversal performs separate reads.
+	var prunedNodes []hash.Hash
+	for rootHash, isLone := range maybeLoneRoots {
+		if !isLone {
+			continue
+		}
+
+		// Traverse the root and prune all items created in this version.
+		root := node.Root{
+			Namespace: d.namespace,
+			Version:   version,
+			Type:      rootHash.Type(),
+			Hash:      rootHash.Hash(),
+		}
+		err := api.Visit(ctx, d, root, func(ctx context.Context, n node.Node) bool {
+			if n.GetCreatedVersion() == version {
+				prunedNodes = append(prunedNodes, n.GetHash())
+			}
+			return true
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return d.db.Update(func(tx *bolt.Tx) error {
+		b := bucket(tx)
+
+		for _, h := range prunedNodes {
+			if err := b.Delete(nodeKeyFmt.Encode(&h)); err != nil {
+				return err
+			}
+		}
+
+		// Remove all roots in version. Any roots with the same hash that are still needed
+		// have been committed again in a later version.
+		for rootHash := range rootsMeta.Roots {
+			if err := b.Delete(rootNodeKeyFmt.Encode(&rootHash, version)); err != nil {
+				return err
+			}
+		}
+
+		// Delete roots metadata.
+		if err := b.Delete(rootsMetadataKeyFmt.Encode(version)); err != nil {
+			return fmt.Errorf("mkvs/bolt: failed to remove roots metadata: %w", err)
+		}
+
+		// Prune all write logs in version.
+		if !d.discardWriteLogs {
+			if err := deleteKeys(tx, prefixKeys(tx, writeLogKeyFmt.Encode(version))); err != nil {
+				return err
+			}
+		}
+
+		// Process deferred removals of nodes that can no longer be referenced by any of the
+		// remaining versions.
+		var (
+			pendingKeys  [][]byte
+			pendingNodes []hash.Hash
+		)
+		c := b.Cursor()
+		for k, _ := c.Seek(pendingRemovalKeyFmt.Encode()); k != nil; k, _ = c.Next() {
+			var (
+				removedVersion uint64
+				h              hash.Hash
+			)
+			if !pendingRemovalKeyFmt.Decode(k, &removedVersion, &h) || removedVersion > version+1 {
+				break
+			}
+			pendingKeys = append(pendingKeys, append([]byte{}, k...))
+			pendingNodes = append(pendingNodes, h)
+		}
+		for i, h := range pendingNodes {
+			if err := b.Delete(nodeKeyFmt.Encode(&h)); err != nil {
+				return err
+			}
+			if err := b.Delete(pendingKeys[i]); err != nil {
+				return err
+			}
+		}
+
+		// Update metadata.
+		if err := d.meta.setEarliestVersion(tx, version+1); err != nil {
+			return fmt.Errorf("mkvs/bolt: failed to set earliest version: %w", err)
+		}
+		return nil
+	})
+}
+
+func (d *boltNodeDB) StartMultipartInsert(version uint64) error {
+	d.metaUpdateLock.Lock()
+	defer d.metaUpdateLock.Unlock()
+
+	if version == multipartVersionNone {
+		return api.ErrInvalidMultipartVersion
+	}
+
+	if d.multipartVersion != multipartVersionNone {
+		if d.multipartVersion != version {
+			return api.ErrMultipartInProgress
+		}
+		// Multipart already initialized at the same version, so this was
+		// probably called e.g. as part of a further checkpoint restore.
+		return nil
+	}
+
+	if err := d.db.Update(func(tx *bolt.Tx) error {
+		return d.meta.setMultipartVersion(tx, version)
+	}); err != nil {
+		return err
+	}
+
+	d.multipartVersion = version
+
+	return nil
+}
+
+func (d *boltNodeDB) AbortMultipartInsert() error {
+	d.metaUpdateLock.Lock()
+	defer d.metaUpdateLock.Unlock()
+
+	return d.cleanMultipartLocked(true)
+}
+
+func (d *boltNodeDB) NewBatch(oldRoot node.Root, version uint64, chunk bool) (api.Batch, error) {
+	if d.readOnly {
+		return nil, api.ErrReadOnly
+	}
+
+	d.metaUpdateLock.Lock()
+	defer d.metaUpdateLock.Unlock()
+
+	if d.multipartVersion != multipartVersionNone && d.multipartVersion != version {
+		return nil, api.ErrInvalidMultipartVersion
+	}
+	if chunk != (d.multipartVersion != multipartVersionNone) {
+		return nil, api.ErrMultipartInProgress
+	}
+
+	return &boltBatch{
+		db:        d,
+		multipart: d.multipartVersion != multipartVersionNone,
+		oldRoot:   oldRoot,
+		chunk:     chunk,
+	}, nil
+}
+
+func (d *boltNodeDB) Size() (size int64, err error) {
+	// NOTE: The database file is grown in large increments and freed pages are reused, so report
+	//       the number of bytes actually in use instead. This requires a traversal of all pages.
+	err = d.db.View(func(tx *bolt.Tx) error {
+		stats := bucket(tx).Stats()
+		size = int64(stats.BranchInuse + stats.LeafInuse + stats.InlineBucketInuse)
+		return nil
+	})
+	return
+}
+
+func (d *boltNodeDB) Sync() error {
+	return d.db.Sync()
+}
+
+func (d *boltNodeDB) Close() {
+	d.closeOnce.Do(func() {
+		if err := d.db.Close(); err != nil {
+			d.logger.Error("close returned error",
+				"err", err,
+			)
+		}
+	})
+}
+
+// batchNode is a node that has been added to a batch.
+type batchNode struct {
+	hash hash.Hash
+	data []byte
+}
+
+type boltBatch struct {
+	api.BaseBatch
+
+	db *boltNodeDB
+
+	// multipart is true iff the batch was created during a multipart restore, in which case
+	// all newly inserted nodes are logged.
+	multipart bool
+
+	oldRoot node.Root
+	chunk   bool
+
+	nodes        []batchNode
+	writeLog     writelog.WriteLog
+	annotations  writelog.Annotations
+	updatedNodes []updatedNode
+}
+
+func (ba *boltBatch) MaybeStartSubtree(subtree api.Subtree, depth node.Depth, subtreeRoot *node.Pointer) api.Subtree {
+	if subtree == nil {
+		return &boltSubtree{batch: ba}
+	}
+	return subtree
+}
+
+func (ba *boltBatch) PutWriteLog(writeLog writelog.WriteLog, annotations writelog.Annotations) error {
+	if ba.chunk {
+		return fmt.Errorf("mkvs/bolt: cannot put write log in chunk mode")
+	}
+	if ba.db.discardWriteLogs {
+		return nil
+	}
+
+	ba.writeLog = writeLog
+	ba.annotations = annotations
+	return nil
+}
+
+func (ba *boltBatch) RemoveNodes(nodes []node.Node) error {
+	if ba.chunk {
+		return fmt.Errorf("mkvs/bolt: cannot remove nodes in chunk mode")
+	}
+
+	for _, n := range nodes {
+		ba.updatedNodes = append(ba.updatedNodes, updatedNode{
+			Removed: true,
+			Hash:    n.GetHash(),
+		})
+	}
+	return nil
+}
+
+func (ba *boltBatch) Commit(root node.Root) error {
+	ba.db.metaUpdateLock.Lock()
+	defer ba.db.metaUpdateLock.Unlock()
+
+	if ba.db.multipartVersion != multipartVersionNone && ba.db.multipartVersion != root.Version {
+		return api.ErrInvalidMultipartVersion
+	}
+
+	if err := ba.db.sanityCheckNamespace(root.Namespace); err != nil {
+		return err
+	}
+	if !root.Follows(&ba.oldRoot) {
+		return api.ErrRootMustFollowOld
+	}
+
+	// Make sure that the version that we try to commit into has not yet been finalized.
+	lastFinalizedVersion, exists := ba.db.meta.getLastFinalizedVersion()
+	if exists && lastFinalizedVersion >= root.Version {
+		return api.ErrAlreadyFinalized
+	}
+
+	if err := ba.db.db.Update(func(tx *bolt.Tx) error {
+		b := bucket(tx)
+
+		// Update the set of roots for this version.
+		rootsMeta, err := loadRootsMetadata(tx, root.Version)
+		if err != nil {
+			return err
+		}
+
+		rootHash := typedHashFromRoot(root)
+		if rootsMeta.Roots[rootHash] != nil && !ba.chunk {
+			// Root already exists, no need to do anything since if the hash matches, everything will
+			// be identical and we would just be duplicating work.
+			//
+			// If we are importing a chunk, there can be multiple commits for the same root.
+			return nil
+		}
+
+		if err = b.Put(rootNodeKeyFmt.Encode(&rootHash, root.Version), []byte{}); err != nil {
+			return err
+		}
+		if ba.multipart {
+			if err = b.Put(multipartRestoreNodeLogKeyFmt.Encode(&rootHash), []byte{}); err != nil {
+				return err
+			}
+		}
+
+		if rootsMeta.Roots[rootHash] == nil {
+			// Create root with no derived roots.
+			rootsMeta.Roots[rootHash] = []typedHash{}
+
+			if err = rootsMeta.save(tx); err != nil {
+				return fmt.Errorf("mkvs/bolt: failed to save roots metadata: %w", err)
+			}
+		}
+
+		if ba.chunk {
+			// Skip most of metadata updates if we are just importing chunks.
+			key := rootUpdatedNodesKeyFmt.Encode(root.Version, &rootHash)
+			if err = b.Put(key, cbor.Marshal([]updatedNode{})); err != nil {
+				return fmt.Errorf("mkvs/bolt: set returned error: %w", err)
+			}
+		} else {
+			// Update the root link for the old root.
+			oldRootHash := typedHashFromRoot(ba.oldRoot)
+			if !ba.oldRoot.Hash.IsEmpty() {
+				if ba.oldRoot.Version < ba.db.meta.getEarliestVersion() && ba.oldRoot.Version != root.Version {
+					return api.ErrPreviousVersionMismatch
+				}
+
+				var oldRootsMeta *rootsMetadata
+				oldRootsMeta, err = loadRootsMetadata(tx, ba.oldRoot.Version)
+				if err != nil {
+					return err
+				}
+
+				if _, ok := oldRootsMeta.Roots[oldRootHash]; !ok {
+					return api.ErrRootNotFound
+				}
+
+				oldRootsMeta.Roots[oldRootHash] = append(oldRootsMeta.Roots[oldRootHash], rootHash)
+				if err = oldRootsMeta.save(tx); err != nil {
+					return fmt.Errorf("mkvs/bolt: failed to save old roots metadata: %w", err)
+				}
+			}
+
+			// Store updated nodes (only needed until the version is finalized).
+			key := rootUpdatedNodesKeyFmt.Encode(root.Version, &rootHash)
+			if err = b.Put(key, cbor.Marshal(ba.updatedNodes)); err != nil {
+				return fmt.Errorf("mkvs/bolt: set returned error: %w", err)
+			}
+
+			// Store write log.
+			if ba.writeLog != nil && ba.annotations != nil {
+				log := api.MakeHashedDBWriteLog(ba.writeLog, ba.annotations)
+				key := writeLogKeyFmt.Encode(root.Version, &rootHash, &oldRootHash)
+				if err = b.Put(key, cbor.Marshal(log)); err != nil {
+					return fmt.Errorf("mkvs/bolt: set new write log returned error: %w", err)
+				}
+			}
+		}
+
+		// Store nodes. Sort them first as bbolt performs much better with ordered inserts.
+		sort.Slice(ba.nodes, func(i, j int) bool {
+			return bytes.Compare(ba.nodes[i].hash[:], ba.nodes[j].hash[:]) < 0
+		})
+		for _, n := range ba.nodes {
+			nodeKey := nodeKeyFmt.Encode(&n.hash)
+			if ba.multipart && b.Get(nodeKey) == nil {
+				th := typedHashFromParts(node.RootTypeInvalid, n.hash)
+				if err = b.Put(multipartRestoreNodeLogKeyFmt.Encode(&th), []byte{}); err != nil {
+					return err
+				}
+			}
+			if err = b.Put(nodeKey, n.data); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	ba.Reset()
+	return ba.BaseBatch.Commit(root)
+}
+
+func (ba *boltBatch) Reset() {
+	ba.nodes = nil
+	ba.writeLog = nil
+	ba.annotations = nil
+	ba.updatedNodes = nil
+}
+
+type boltSubtree struct {
+	batch *boltBatch
+}
+
+func (s *boltSubtree) PutNode(depth node.Depth, ptr *node.Pointer) error {
+	data, err := ptr.Node.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	h := ptr.Node.GetHash()
+	s.batch.updatedNodes = append(s.batch.updatedNodes, updatedNode{Hash: h})
+	s.batch.nodes = append(s.batch.nodes, batchNode{hash: h, data: data})
+	return nil
+}
+
+func (s *boltSubtree) VisitCleanNode(depth node.Depth, ptr *node.Pointer) error {
+	return nil
+}
+
+func (s *boltSubtree) Commit() error {
+	return nil
+}
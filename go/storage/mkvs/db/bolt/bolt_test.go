@@ -0,0 +1,363 @@
+package bolt
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/writelog"
+)
+
+var (
+	nodePrefix = nodeKeyFmt.Encode()
+
+	logPrefix = multipartRestoreNodeLogKeyFmt.Encode()
+
+	pendingRemovalPrefix = pendingRemovalKeyFmt.Encode()
+
+	testNs = common.NewTestNamespaceFromSeed([]byte("bolt node db test ns"), 0)
+
+	testValues = [][]byte{
+		[]byte("colorless green ideas sleep furiously"),
+		[]byte("excepting understandable chairs piously"),
+		[]byte("at the prickle for rainbow hoovering"),
+	}
+)
+
+type keySet map[string]struct{}
+
+type test struct {
+	require *require.Assertions
+	ctx     context.Context
+	dir     string
+	boltdb  *boltNodeDB
+	ckMeta  *checkpoint.Metadata
+	ckNodes keySet
+}
+
+func newTestDB(require *require.Assertions) (*boltNodeDB, func()) {
+	dir, err := ioutil.TempDir("", "oasis-storage-mkvs-bolt-test")
+	require.NoError(err, "TempDir()")
+
+	ndb, err := New(&api.Config{
+		DB:        dir,
+		Namespace: testNs,
+		NoFsync:   true,
+	})
+	require.NoError(err, "New()")
+
+	return ndb.(*boltNodeDB), func() {
+		ndb.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func fillDB(ctx context.Context, require *require.Assertions, values [][]byte, version uint64, ndb api.NodeDB) node.Root {
+	emptyRoot := node.Root{
+		Namespace: testNs,
+		Version:   version,
+		Type:      node.RootTypeState,
+	}
+	emptyRoot.Hash.Empty()
+
+	tree := mkvs.NewWithRoot(nil, ndb, emptyRoot)
+	require.NotNil(tree, "NewWithRoot()")
+
+	var wl writelog.WriteLog
+	for i, val := range values {
+		wl = append(wl, writelog.LogEntry{Key: []byte(strconv.Itoa(i)), Value: val})
+	}
+
+	err := tree.ApplyWriteLog(ctx, writelog.NewStaticIterator(wl))
+	require.NoError(err, "ApplyWriteLog()")
+
+	_, hash, err := tree.Commit(ctx, testNs, 2)
+	require.NoError(err, "Commit()")
+
+	return node.Root{
+		Namespace: testNs,
+		Version:   version + 1,
+		Type:      node.RootTypeState,
+		Hash:      hash,
+	}
+}
+
+// prefixKeySet returns the set of all keys in the database with the given prefix.
+func prefixKeySet(require *require.Assertions, boltdb *boltNodeDB, prefix []byte) keySet {
+	keys := keySet{}
+	err := boltdb.db.View(func(tx *bolt.Tx) error {
+		for _, key := range prefixKeys(tx, prefix) {
+			keys[string(key)] = struct{}{}
+		}
+		return nil
+	})
+	require.NoError(err, "prefixKeySet()")
+	return keys
+}
+
+func createCheckpoint(ctx context.Context, require *require.Assertions, dir string, values [][]byte, version uint64) (*checkpoint.Metadata, keySet) {
+	boltdb, cleanup := newTestDB(require)
+	defer cleanup()
+	fc, err := checkpoint.NewFileCreator(dir, boltdb)
+	require.NoError(err, "NewFileCreator()")
+
+	ckRoot := fillDB(ctx, require, values, version, boltdb)
+	ckMeta, err := fc.CreateCheckpoint(ctx, ckRoot, 1024*1024)
+	require.NoError(err, "CreateCheckpoint()")
+
+	return ckMeta, prefixKeySet(require, boltdb, nodePrefix)
+}
+
+func verifyNodes(require *require.Assertions, boltdb *boltNodeDB, keySet keySet) {
+	require.Equal(keySet, prefixKeySet(require, boltdb, nodePrefix), "nodes in db should match")
+}
+
+func checkNoLogKeys(require *require.Assertions, boltdb *boltNodeDB) {
+	require.Empty(prefixKeySet(require, boltdb, logPrefix), "there should be no log keys")
+}
+
+func restoreCheckpoint(ctx *test, ckMeta *checkpoint.Metadata, ckNodes keySet) checkpoint.Restorer {
+	fc, err := checkpoint.NewFileCreator(ctx.dir, ctx.boltdb)
+	ctx.require.NoError(err, "NewFileCreator() - 2")
+
+	restorer, err := checkpoint.NewRestorer(ctx.boltdb)
+	ctx.require.NoError(err, "NewRestorer()")
+
+	err = restorer.StartRestore(ctx.ctx, ckMeta)
+	ctx.require.NoError(err, "StartRestore()")
+	for i := range ckMeta.Chunks {
+		idx := uint64(i)
+		chunkMeta, err := ckMeta.GetChunkMetadata(idx)
+		ctx.require.NoError(err, fmt.Sprintf("GetChunkMetadata(%d)", idx))
+		func() {
+			var buf bytes.Buffer
+			err = fc.GetCheckpointChunk(ctx.ctx, chunkMeta, &buf)
+			ctx.require.NoError(err, "GetCheckpointChunk()")
+			_, err = restorer.RestoreChunk(ctx.ctx, idx, &buf)
+			ctx.require.NoError(err, "RestoreChunk()")
+		}()
+	}
+
+	verifyNodes(ctx.require, ctx.boltdb, ckNodes)
+
+	return restorer
+}
+
+func TestMultipartRestore(t *testing.T) {
+	ctx := context.Background()
+	wrap := func(testFunc func(ctx *test), initialValues [][]byte) func(*testing.T) {
+		return func(t *testing.T) {
+			require := require.New(t)
+
+			dir, err := ioutil.TempDir("", "oasis-storage-database-test")
+			require.NoError(err, "TempDir()")
+			defer os.RemoveAll(dir)
+
+			ckMeta, ckNodes := createCheckpoint(ctx, require, dir, initialValues, 1)
+
+			boltdb, cleanup := newTestDB(require)
+			defer cleanup()
+
+			testCtx := &test{
+				require: require,
+				ctx:     ctx,
+				dir:     dir,
+				boltdb:  boltdb,
+				ckMeta:  ckMeta,
+				ckNodes: ckNodes,
+			}
+			testFunc(testCtx)
+		}
+	}
+
+	t.Run("Abort", wrap(testAbort, testValues))
+	t.Run("Finalize", wrap(testFinalize, testValues))
+	t.Run("ExistingNodes", wrap(testExistingNodes, testValues[:1]))
+}
+
+func testAbort(ctx *test) {
+	// Abort a restore, check nodes again.
+	// There should be no leftover nodes, and the log keys should be gone too.
+	restorer := restoreCheckpoint(ctx, ctx.ckMeta, ctx.ckNodes)
+	err := restorer.AbortRestore(ctx.ctx)
+	ctx.require.NoError(err, "AbortRestore()")
+
+	verifyNodes(ctx.require, ctx.boltdb, keySet{})
+	checkNoLogKeys(ctx.require, ctx.boltdb)
+}
+
+func testFinalize(ctx *test) {
+	// Finalize a restore, check nodes again.
+	// This time, all the restored nodes should be present, but the
+	// log keys should be gone.
+	restoreCheckpoint(ctx, ctx.ckMeta, ctx.ckNodes)
+
+	// Test parameter sanity checking first.
+	err := ctx.boltdb.Finalize(ctx.ctx, nil)
+	ctx.require.Error(err, "Finalize with no roots should fail")
+
+	bogusRoot := ctx.ckMeta.Root
+	bogusRoot.Version++
+	err = ctx.boltdb.Finalize(ctx.ctx, []node.Root{ctx.ckMeta.Root, bogusRoot})
+	ctx.require.Error(err, "Finalize with roots from different versions should fail")
+
+	err = ctx.boltdb.Finalize(ctx.ctx, []node.Root{ctx.ckMeta.Root})
+	ctx.require.NoError(err, "Finalize()")
+
+	verifyNodes(ctx.require, ctx.boltdb, ctx.ckNodes)
+	checkNoLogKeys(ctx.require, ctx.boltdb)
+}
+
+func testExistingNodes(ctx *test) {
+	// Create two checkpoints, so we have two sets of nodes.
+	// The first checkpoint will be the base for a fresh database and must include
+	// a node from the second checkpoint, which will be used for multipart restore.
+	// The pre-existing node should then not be deleted after aborting the second
+	// checkpoint.
+
+	// Create the checkpoint to be used as the overriding restore.
+	ckMeta2, ckNodes2 := createCheckpoint(ctx.ctx, ctx.require, ctx.dir, testValues, 2)
+	var overlap bool
+	for node1 := range ctx.ckNodes {
+		if _, ok := ckNodes2[node1]; ok {
+			overlap = true
+			break
+		}
+	}
+	ctx.require.Equal(true, overlap, "pointless test when no nodes would overlap")
+
+	// Restore first checkpoint. The database is empty.
+	restoreCheckpoint(ctx, ctx.ckMeta, ctx.ckNodes)
+	err := ctx.boltdb.Finalize(ctx.ctx, []node.Root{ctx.ckMeta.Root})
+	ctx.require.NoError(err, "Finalize()")
+	verifyNodes(ctx.require, ctx.boltdb, ctx.ckNodes)
+
+	// Restore the second checkpoint. One of the nodes from it already exists. After aborting,
+	// exactly the nodes from the first checkpoint should remain.
+	restorer := restoreCheckpoint(ctx, ckMeta2, ckNodes2)
+	err = restorer.AbortRestore(ctx.ctx)
+	ctx.require.NoError(err, "AbortRestore()")
+	verifyNodes(ctx.require, ctx.boltdb, ctx.ckNodes)
+}
+
+func TestDeferredRemoval(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	boltdb, cleanup := newTestDB(require)
+	defer cleanup()
+
+	// Create a tree in version 0.
+	tree := mkvs.New(nil, boltdb, node.RootTypeState)
+	err := tree.Insert(ctx, []byte("key"), []byte("value 0"))
+	require.NoError(err, "Insert")
+	_, rootHash0, err := tree.Commit(ctx, testNs, 0)
+	require.NoError(err, "Commit")
+	root0 := node.Root{Namespace: testNs, Version: 0, Type: node.RootTypeState, Hash: rootHash0}
+	err = boltdb.Finalize(ctx, []node.Root{root0})
+	require.NoError(err, "Finalize")
+	nodes0 := prefixKeySet(require, boltdb, nodePrefix)
+
+	// Replace the value in version 1 which removes the original leaf node.
+	err = tree.Insert(ctx, []byte("key"), []byte("value 1"))
+	require.NoError(err, "Insert")
+	_, rootHash1, err := tree.Commit(ctx, testNs, 1)
+	require.NoError(err, "Commit")
+	root1 := node.Root{Namespace: testNs, Version: 1, Type: node.RootTypeState, Hash: rootHash1}
+	err = boltdb.Finalize(ctx, []node.Root{root1})
+	require.NoError(err, "Finalize")
+
+	// The removal should be deferred as version 0 is still available.
+	require.Len(prefixKeySet(require, boltdb, pendingRemovalPrefix), len(nodes0), "removal should be deferred")
+	tree0 := mkvs.NewWithRoot(nil, boltdb, root0)
+	defer tree0.Close()
+	value, err := tree0.Get(ctx, []byte("key"))
+	require.NoError(err, "Get")
+	require.EqualValues([]byte("value 0"), value, "removed nodes should still be available in version 0")
+
+	// Pruning version 0 should process the deferred removal.
+	err = boltdb.Prune(ctx, 0)
+	require.NoError(err, "Prune")
+	require.Empty(prefixKeySet(require, boltdb, pendingRemovalPrefix), "deferred removal should be processed")
+	for key := range prefixKeySet(require, boltdb, nodePrefix) {
+		require.NotContains(nodes0, key, "nodes from version 0 should be removed")
+	}
+
+	tree1 := mkvs.NewWithRoot(nil, boltdb, root1)
+	defer tree1.Close()
+	value, err = tree1.Get(ctx, []byte("key"))
+	require.NoError(err, "Get")
+	require.EqualValues([]byte("value 1"), value, "version 1 should be intact")
+}
+
+func TestVersionChecks(t *testing.T) {
+	require := require.New(t)
+	boltdb, cleanup := newTestDB(require)
+	defer cleanup()
+
+	err := boltdb.StartMultipartInsert(0)
+	require.Error(err, "StartMultipartInsert(0)")
+
+	err = boltdb.StartMultipartInsert(42)
+	require.NoError(err, "StartMultipartInsert(42)")
+	err = boltdb.StartMultipartInsert(44)
+	require.Error(err, "StartMultipartInsert(44)")
+
+	root := node.Root{}
+	_, err = boltdb.NewBatch(root, 0, false) // Normal chunks not allowed during multipart.
+	require.Error(err, "NewBatch(.., 0, false)")
+	_, err = boltdb.NewBatch(root, 13, true)
+	require.Error(err, "NewBatch(.., 13, true)")
+	batch, err := boltdb.NewBatch(root, 42, true)
+	require.NoError(err, "NewBatch(.., 42, true)")
+	defer batch.Reset()
+
+	err = batch.Commit(root)
+	require.Error(err, "Commit(Root{0})")
+}
+
+func TestReadOnlyBatch(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "oasis-storage-database-test")
+	require.NoError(err, "TempDir()")
+	defer os.RemoveAll(dir)
+
+	cfg := &api.Config{
+		DB:        dir,
+		Namespace: testNs,
+		NoFsync:   true,
+	}
+
+	// Opening a non-existent database in read-only mode should fail.
+	cfg.ReadOnly = true
+	_, err = New(cfg)
+	require.Error(err, "New() - read-only, non-existent")
+
+	cfg.ReadOnly = false
+	func() {
+		ndb, errRw := New(cfg)
+		require.NoError(errRw, "New() - 1")
+		defer ndb.Close()
+	}()
+
+	cfg.ReadOnly = true
+	ndb, err := New(cfg)
+	require.NoError(err, "New() - 2")
+	defer ndb.Close()
+
+	_, err = ndb.NewBatch(node.Root{}, 13, false)
+	require.Error(err, "NewBatch()")
+}
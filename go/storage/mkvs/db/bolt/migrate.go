@@ -0,0 +1,275 @@
+package bolt
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/dgraph-io/badger/v2"
+	bolt "go.etcd.io/bbolt"
+
+	cmnBadger "github.com/oasisprotocol/oasis-core/go/common/badger"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
+	badgerNodedb "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/badger"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+)
+
+// migrateFlushInterval is the number of entries written in a single bolt transaction during
+// migration.
+const migrateFlushInterval = 10_000
+
+// migrateWriter buffers entries written to the destination database and flushes them in
+// batches to bound the size of individual bolt transactions.
+type migrateWriter struct {
+	db *bolt.DB
+
+	keys   [][]byte
+	values [][]byte
+}
+
+func (w *migrateWriter) put(key, value []byte) error {
+	w.keys = append(w.keys, key)
+	w.values = append(w.values, value)
+	if len(w.keys) >= migrateFlushInterval {
+		return w.flush()
+	}
+	return nil
+}
+
+func (w *migrateWriter) flush() error {
+	if len(w.keys) == 0 {
+		return nil
+	}
+	err := w.db.Update(func(tx *bolt.Tx) error {
+		b := bucket(tx)
+		for i := range w.keys {
+			if err := b.Put(w.keys[i], w.values[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	w.keys = nil
+	w.values = nil
+	return nil
+}
+
+// MigrateFromBadger converts an existing badger node database into a new bolt node database.
+//
+// The source database must not be in use by any other process and the destination database
+// must not exist yet. Only data for versions that have not yet been pruned is copied.
+func MigrateFromBadger(ctx context.Context, srcCfg, dstCfg *api.Config, display badgerNodedb.DisplayHelper) (err error) {
+	logger := logging.GetLogger("mkvs/db/bolt/migrate")
+
+	if _, err := os.Stat(filepath.Join(dstCfg.DB, dbFilename)); err == nil {
+		return fmt.Errorf("mkvs/bolt/migrate: destination database already exists")
+	}
+
+	// Open the source database using the badger backend first. This makes sure that the
+	// database is compatible and cleans up any remnants of an interrupted multipart restore.
+	display.DisplayStepBegin("checking source database")
+	srcDB, err := badgerNodedb.New(srcCfg)
+	if err != nil {
+		return fmt.Errorf("mkvs/bolt/migrate: failed to open source database: %w", err)
+	}
+	earliestVersion, _ := srcDB.GetEarliestVersion(ctx)
+	latestVersion, _ := srcDB.GetLatestVersion(ctx)
+	latestRoots, err := srcDB.GetRootsForVersion(ctx, latestVersion)
+	srcDB.Close()
+	if err != nil {
+		return fmt.Errorf("mkvs/bolt/migrate: failed to get latest roots: %w", err)
+	}
+	display.DisplayStepEnd(fmt.Sprintf("versions %d-%d", earliestVersion, latestVersion))
+
+	opts := badger.DefaultOptions(srcCfg.DB)
+	opts = opts.WithLogger(cmnBadger.NewLogAdapter(logger))
+	opts = opts.WithReadOnly(true)
+	src, err := badger.OpenManaged(opts)
+	if err != nil {
+		return fmt.Errorf("mkvs/bolt/migrate: failed to open source database: %w", err)
+	}
+	defer src.Close()
+
+	cfg := *dstCfg
+	cfg.ReadOnly = false
+	cfg.NoFsync = true
+	dstNodeDB, err := New(&cfg)
+	if err != nil {
+		return fmt.Errorf("mkvs/bolt/migrate: failed to create destination database: %w", err)
+	}
+	dst := dstNodeDB.(*boltNodeDB)
+	defer func() {
+		dst.Close()
+		// Never leave behind a partially migrated database.
+		if err != nil {
+			_ = os.Remove(filepath.Join(cfg.DB, dbFilename))
+		}
+	}()
+
+	display.DisplayStepBegin("copying database entries")
+	if err = migrateEntries(ctx, src, dst, earliestVersion); err != nil {
+		return fmt.Errorf("mkvs/bolt/migrate: %w", err)
+	}
+	if err = dst.db.Sync(); err != nil {
+		return fmt.Errorf("mkvs/bolt/migrate: failed to sync destination database: %w", err)
+	}
+	display.DisplayStepEnd("done")
+
+	// Make sure that the latest roots are complete in the destination database.
+	display.DisplayStepBegin("verifying latest roots")
+	for _, root := range latestRoots {
+		err = api.Visit(ctx, dst, root, func(ctx context.Context, n node.Node) bool {
+			return true
+		})
+		if err != nil {
+			return fmt.Errorf("mkvs/bolt/migrate: failed to verify root %s: %w", root, err)
+		}
+	}
+	display.DisplayStepEnd("done")
+
+	return nil
+}
+
+func migrateEntries(ctx context.Context, src *badger.DB, dst *boltNodeDB, earliestVersion uint64) error {
+	txn := src.NewTransactionAt(math.MaxUint64, false)
+	defer txn.Discard()
+
+	it := txn.NewIterator(badger.IteratorOptions{AllVersions: true})
+	defer it.Close()
+
+	w := &migrateWriter{db: dst.db}
+	var (
+		meta *badgerNodedb.Metadata
+		// lastKey is the last key for which the newest entry has been seen, since only the
+		// newest entry for each key is relevant.
+		lastKey []byte
+		// removedVersion is the version in which the node under lastKey was removed, or
+		// zero if the node does not need to be copied from an older entry.
+		removedVersion uint64
+	)
+	for it.Rewind(); it.Valid(); it.Next() {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		item := it.Item()
+		rawKey := item.KeyCopy(nil)
+		key, err := badgerNodedb.DecodeKey(rawKey)
+		if err != nil {
+			return fmt.Errorf("unsupported key in source database: %w", err)
+		}
+		newest := lastKey == nil || string(rawKey) != string(lastKey)
+
+		if !newest {
+			// Older entries are only needed for nodes whose removal has been deferred.
+			if removedVersion == 0 || item.IsDeletedOrExpired() {
+				continue
+			}
+			var value []byte
+			if value, err = item.ValueCopy(nil); err != nil {
+				return fmt.Errorf("failed to read node: %w", err)
+			}
+			if err = w.put(nodeKeyFmt.Encode(&key.Hash), value); err != nil {
+				return fmt.Errorf("failed to write node: %w", err)
+			}
+			if err = w.put(pendingRemovalKeyFmt.Encode(removedVersion, &key.Hash), []byte{}); err != nil {
+				return fmt.Errorf("failed to write pending removal: %w", err)
+			}
+			removedVersion = 0
+			continue
+		}
+		lastKey = rawKey
+		removedVersion = 0
+
+		if item.IsDeletedOrExpired() {
+			// A node removed in a version that is still available must be retained until
+			// that version is pruned.
+			if key.Type == badgerNodedb.KeyTypeNode {
+				if version, ok := badgerNodedb.TsToVersion(item.Version()); ok && version > earliestVersion {
+					removedVersion = version
+				}
+			}
+			continue
+		}
+
+		value, err := item.ValueCopy(nil)
+		if err != nil {
+			return fmt.Errorf("failed to read entry: %w", err)
+		}
+
+		var dstKey []byte
+		switch key.Type {
+		case badgerNodedb.KeyTypeNode:
+			dstKey = nodeKeyFmt.Encode(&key.Hash)
+		case badgerNodedb.KeyTypeWriteLog, badgerNodedb.KeyTypeRootUpdatedNodes:
+			if key.Version < earliestVersion {
+				continue
+			}
+			// The bolt backend uses the same key and value encoding for these entries.
+			dstKey = rawKey
+		case badgerNodedb.KeyTypeRootsMetadata:
+			if key.Version < earliestVersion {
+				continue
+			}
+			// The bolt backend uses the same value encoding for roots metadata.
+			dstKey = rootsMetadataKeyFmt.Encode(key.Version)
+
+			// Root node keys are versioned in the bolt backend so they are derived from the
+			// roots metadata instead of being copied.
+			var roots []node.Root
+			if roots, err = badgerNodedb.DecodeRootsMetadata(dst.namespace, key.Version, value); err != nil {
+				return err
+			}
+			for _, root := range roots {
+				var rootHash typedHash
+				rootHash.FromParts(root.Type, root.Hash)
+				if err = w.put(rootNodeKeyFmt.Encode(&rootHash, root.Version), []byte{}); err != nil {
+					return fmt.Errorf("failed to write root node: %w", err)
+				}
+			}
+		case badgerNodedb.KeyTypeMetadata:
+			if meta, err = badgerNodedb.DecodeMetadata(value); err != nil {
+				return err
+			}
+			continue
+		default:
+			// Multipart restore logs have been cleaned up when opening the source database,
+			// root nodes are derived from the roots metadata and size accounting is not
+			// supported by the bolt backend.
+			continue
+		}
+
+		if err = w.put(dstKey, value); err != nil {
+			return fmt.Errorf("failed to write entry: %w", err)
+		}
+	}
+	if err := w.flush(); err != nil {
+		return fmt.Errorf("failed to write entries: %w", err)
+	}
+	if meta == nil {
+		return fmt.Errorf("source database metadata not found")
+	}
+
+	dst.meta.Lock()
+	defer dst.meta.Unlock()
+
+	if meta.Namespace != dst.namespace {
+		return fmt.Errorf("source database namespace mismatch (expected: %s got: %s)",
+			dst.namespace,
+			meta.Namespace,
+		)
+	}
+	dst.meta.value = serializedMetadata{
+		Version:              dbVersion,
+		Namespace:            meta.Namespace,
+		EarliestVersion:      meta.EarliestVersion,
+		LastFinalizedVersion: meta.LastFinalizedVersion,
+	}
+	return dst.db.Update(dst.meta.save)
+}
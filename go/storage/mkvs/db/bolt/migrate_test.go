@@ -0,0 +1,130 @@
+package bolt
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
+	badgerNodedb "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/badger"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+)
+
+type testDisplayHelper struct{}
+
+func (h *testDisplayHelper) DisplayStepBegin(msg string)                       {}
+func (h *testDisplayHelper) DisplayStepEnd(msg string)                         {}
+func (h *testDisplayHelper) DisplayStep(msg string)                            {}
+func (h *testDisplayHelper) DisplayProgress(msg string, current, total uint64) {}
+
+func TestMigrateFromBadger(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	dir, err := ioutil.TempDir("", "oasis-storage-mkvs-bolt-migrate-test")
+	require.NoError(err, "TempDir()")
+	defer os.RemoveAll(dir)
+
+	srcCfg := &api.Config{
+		DB:        filepath.Join(dir, "badger"),
+		Namespace: testNs,
+		NoFsync:   true,
+	}
+	dstCfg := &api.Config{
+		DB:        filepath.Join(dir, "bolt"),
+		Namespace: testNs,
+		NoFsync:   true,
+	}
+
+	// Populate the badger database with a few versions, replacing values in each version so that
+	// nodes get removed, and prune the first version.
+	const numVersions = 5
+	srcDB, err := badgerNodedb.New(srcCfg)
+	require.NoError(err, "badger.New")
+
+	var roots []node.Root
+	tree := mkvs.New(nil, srcDB, node.RootTypeState)
+	for version := uint64(0); version < numVersions; version++ {
+		for i := 0; i < 10; i++ {
+			err = tree.Insert(ctx, []byte(fmt.Sprintf("key %d", i)), []byte(fmt.Sprintf("value %d/%d", i, version)))
+			require.NoError(err, "Insert")
+		}
+		_, rootHash, cerr := tree.Commit(ctx, testNs, version)
+		require.NoError(cerr, "Commit")
+		root := node.Root{Namespace: testNs, Version: version, Type: node.RootTypeState, Hash: rootHash}
+		err = srcDB.Finalize(ctx, []node.Root{root})
+		require.NoError(err, "Finalize")
+		roots = append(roots, root)
+	}
+	tree.Close()
+	err = srcDB.Prune(ctx, 0)
+	require.NoError(err, "Prune")
+	srcDB.Close()
+
+	err = MigrateFromBadger(ctx, srcCfg, dstCfg, &testDisplayHelper{})
+	require.NoError(err, "MigrateFromBadger")
+
+	// Migrating into an existing database should fail.
+	err = MigrateFromBadger(ctx, srcCfg, dstCfg, &testDisplayHelper{})
+	require.Error(err, "MigrateFromBadger should fail when the destination exists")
+
+	ndb, err := New(dstCfg)
+	require.NoError(err, "New")
+	defer ndb.Close()
+	boltdb := ndb.(*boltNodeDB)
+
+	earliest, err := boltdb.GetEarliestVersion(ctx)
+	require.NoError(err, "GetEarliestVersion")
+	require.EqualValues(1, earliest, "earliest version should be migrated")
+	latest, err := boltdb.GetLatestVersion(ctx)
+	require.NoError(err, "GetLatestVersion")
+	require.EqualValues(numVersions-1, latest, "latest version should be migrated")
+	require.False(boltdb.HasRoot(roots[0]), "pruned root should not be migrated")
+
+	checkVersions := func(from uint64) {
+		for version := from; version < numVersions; version++ {
+			root := roots[version]
+			require.True(boltdb.HasRoot(root), "HasRoot")
+
+			vroots, verr := boltdb.GetRootsForVersion(ctx, version)
+			require.NoError(verr, "GetRootsForVersion")
+			require.Equal([]node.Root{root}, vroots, "roots for version should be migrated")
+
+			vtree := mkvs.NewWithRoot(nil, boltdb, root)
+			for i := 0; i < 10; i++ {
+				value, verr := vtree.Get(ctx, []byte(fmt.Sprintf("key %d", i)))
+				require.NoError(verr, "Get")
+				require.EqualValues(fmt.Sprintf("value %d/%d", i, version), string(value))
+			}
+			vtree.Close()
+
+			if version > from {
+				it, verr := boltdb.GetWriteLog(ctx, roots[version-1], root)
+				require.NoError(verr, "GetWriteLog")
+				var entries int
+				for {
+					more, verr := it.Next()
+					require.NoError(verr, "it.Next")
+					if !more {
+						break
+					}
+					entries++
+				}
+				require.Equal(10, entries, "write log should be migrated")
+			}
+		}
+	}
+	checkVersions(1)
+
+	// Nodes removed in retained versions should only be removed once those are pruned.
+	require.NotEmpty(prefixKeySet(require, boltdb, pendingRemovalPrefix), "removals should be deferred")
+	err = boltdb.Prune(ctx, 1)
+	require.NoError(err, "Prune")
+	checkVersions(2)
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
+)
+
+const (
+	cfgVerifyRoot = "root"
+	cfgVerifyKey  = "key"
+)
+
+var (
+	verifyGetProofFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
+	verifyGetProofCmd = &cobra.Command{
+		Use:   "verify-get-proof",
+		Short: "verify a CBOR-encoded get proof read from stdin and write the CBOR-encoded result to stdout",
+		Run:   doVerifyGetProof,
+	}
+)
+
+// verifyGetProofResult is the result of verifying a get proof.
+type verifyGetProofResult struct {
+	// Present is true iff the key is present in the tree.
+	Present bool `json:"present"`
+	// Value is the value of the key in case it is present.
+	Value []byte `json:"value"`
+	// Incomplete is true iff the proof does not contain the nodes needed to determine whether
+	// the key is present.
+	Incomplete bool `json:"incomplete"`
+}
+
+func verifyGetProof(root hash.Hash, key []byte, rawProof []byte) (*verifyGetProofResult, error) {
+	var proof syncer.Proof
+	if err := cbor.Unmarshal(rawProof, &proof); err != nil {
+		return nil, fmt.Errorf("malformed proof: %w", err)
+	}
+
+	var pv syncer.ProofVerifier
+	value, present, err := pv.VerifyGetProof(context.Background(), root, &proof, key)
+	switch {
+	case err == nil:
+		return &verifyGetProofResult{Present: present, Value: value}, nil
+	case errors.Is(err, syncer.ErrIncompleteProof):
+		return &verifyGetProofResult{Incomplete: true}, nil
+	default:
+		return nil, err
+	}
+}
+
+func doVerifyGetProof(cmd *cobra.Command, args []string) {
+	if err := func() error {
+		var root hash.Hash
+		if err := root.UnmarshalHex(viper.GetString(cfgVerifyRoot)); err != nil {
+			return fmt.Errorf("malformed root hash: %w", err)
+		}
+		key, err := hex.DecodeString(viper.GetString(cfgVerifyKey))
+		if err != nil {
+			return fmt.Errorf("malformed key: %w", err)
+		}
+		rawProof, err := ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("failed to read proof: %w", err)
+		}
+
+		result, err := verifyGetProof(root, key, rawProof)
+		if err != nil {
+			return fmt.Errorf("failed to verify proof: %w", err)
+		}
+		_, err = os.Stdout.Write(cbor.Marshal(result))
+		return err
+	}(); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+}
+
+// RegisterVerifyGetProof registers the verify-get-proof sub-command.
+func RegisterVerifyGetProof(parentCmd *cobra.Command) {
+	verifyGetProofCmd.Flags().AddFlagSet(verifyGetProofFlags)
+
+	parentCmd.AddCommand(verifyGetProofCmd)
+}
+
+func init() {
+	verifyGetProofFlags.String(cfgVerifyRoot, "", "hex-encoded root hash the proof is verified against")
+	verifyGetProofFlags.String(cfgVerifyKey, "", "hex-encoded key to look up in the proof")
+	_ = viper.BindPFlags(verifyGetProofFlags)
+}
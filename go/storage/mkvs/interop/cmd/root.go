@@ -28,4 +28,5 @@ func Execute() {
 func init() {
 	// Register all of the sub-commands.
 	RegisterProtoServer(rootCmd)
+	RegisterVerifyGetProof(rootCmd)
 }
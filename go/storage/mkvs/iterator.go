@@ -57,6 +57,53 @@ func (t *tree) SyncIterate(ctx context.Context, request *syncer.IterateRequest)
 	}, nil
 }
 
+// Implements syncer.ReadSyncer.
+func (t *tree) SyncGetRange(ctx context.Context, request *syncer.GetRangeRequest) (*syncer.ProofResponse, error) {
+	t.cache.Lock()
+	defer t.cache.Unlock()
+
+	if t.cache.isClosed() {
+		return nil, ErrClosed
+	}
+	if !request.Tree.Root.Equal(&t.cache.syncRoot) {
+		return nil, syncer.ErrInvalidRoot
+	}
+	if !t.cache.pendingRoot.IsClean() {
+		return nil, syncer.ErrDirtyRoot
+	}
+
+	it := t.NewIterator(ctx,
+		WithProof(request.Tree.Root.Hash),
+		IteratorPrefetch(request.Limit),
+	)
+	defer it.Close()
+
+	// Also visit the first key after the range (or after the limit) as the verifier needs it
+	// to check that the proof is complete.
+	var total int
+	for it.Seek(request.Start); it.Valid(); it.Next() {
+		if len(request.End) > 0 && it.Key().Compare(request.End) >= 0 {
+			break
+		}
+		if total >= int(request.Limit) {
+			break
+		}
+		total++
+	}
+	if it.Err() != nil {
+		return nil, it.Err()
+	}
+
+	proof, err := it.GetProof()
+	if err != nil {
+		return nil, err
+	}
+
+	return &syncer.ProofResponse{
+		Proof: *proof,
+	}, nil
+}
+
 func (t *tree) newFetcherSyncIterate(key node.Key, prefetch uint16) readSyncFetcher {
 	return func(ctx context.Context, ptr *node.Pointer, rs syncer.ReadSyncer) (*syncer.Proof, error) {
 		rsp, err := rs.SyncIterate(ctx, &syncer.IterateRequest{
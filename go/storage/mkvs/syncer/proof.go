@@ -16,6 +16,10 @@ const (
 	proofEntryHash byte = 0x02
 )
 
+// ErrIncompleteProof is the error returned when a valid proof does not contain the nodes needed
+// to determine the result of a verified operation.
+var ErrIncompleteProof = errors.New("verifier: incomplete proof")
+
 // Proof is a Merkle proof for a subtree.
 type Proof struct {
 	// UntrustedRoot is the root hash this proof is for. This should only be
@@ -257,3 +261,185 @@ func (pv *ProofVerifier) verifyProof(ctx context.Context, proof *Proof, idx int)
 		return -1, nil, fmt.Errorf("verifier: unexpected entry in proof (%x)", entry[0])
 	}
 }
+
+// VerifyGetProof verifies a proof returned by SyncGet for the given key and returns the value of
+// the key together with a flag indicating whether the key is present in the tree.
+//
+// If the proof is valid but does not contain the nodes needed to determine whether the key is
+// present, ErrIncompleteProof is returned so that absence can be distinguished from proofs that
+// do not prove anything about the key.
+func (pv *ProofVerifier) VerifyGetProof(ctx context.Context, root hash.Hash, proof *Proof, key []byte) ([]byte, bool, error) {
+	rootPtr, err := pv.VerifyProof(ctx, root, proof)
+	if err != nil {
+		return nil, false, err
+	}
+	return lookupVerified(rootPtr, 0, key)
+}
+
+// lookupVerified looks up the given key in a subtree generated by VerifyProof.
+func lookupVerified(ptr *node.Pointer, bitDepth node.Depth, key node.Key) ([]byte, bool, error) {
+	if ptr == nil || (ptr.Node == nil && ptr.Hash.IsEmpty()) {
+		// Reached a nil node, there is nothing here.
+		return nil, false, nil
+	}
+	if ptr.Node == nil {
+		return nil, false, fmt.Errorf("%w: missing subtree %s", ErrIncompleteProof, ptr.Hash)
+	}
+
+	switch n := ptr.Node.(type) {
+	case *node.InternalNode:
+		bitLength := bitDepth + n.LabelBitLength
+
+		// Does lookup key end here? Look into LeafNode.
+		if key.BitLength() == bitLength {
+			return lookupVerified(n.LeafNode, bitLength, key)
+		}
+		// Lookup key is too short for the current n.Label. It's not stored.
+		if key.BitLength() < bitLength {
+			return nil, false, nil
+		}
+		// Continue recursively based on a bit value.
+		if key.GetBit(bitLength) {
+			return lookupVerified(n.Right, bitLength, key)
+		}
+		return lookupVerified(n.Left, bitLength, key)
+	case *node.LeafNode:
+		// Reached a leaf node, check if key matches.
+		if n.Key.Equal(key) {
+			return n.Value, true, nil
+		}
+		return nil, false, nil
+	default:
+		return nil, false, fmt.Errorf("verifier: unexpected node type in proof (%T)", n)
+	}
+}
+
+// RangeEntry is a key/value pair that is part of a verified key range.
+type RangeEntry struct {
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// RangeResult is the result of verifying a range proof.
+type RangeResult struct {
+	// Entries are the entries in the requested range in key order.
+	Entries []RangeEntry `json:"entries"`
+	// Truncated is true when the range contains more entries than the limit.
+	Truncated bool `json:"truncated,omitempty"`
+	// NextKey is the first key in the range that has not been returned because
+	// of the limit. It can be used as the start of the next request.
+	NextKey []byte `json:"next_key,omitempty"`
+}
+
+// VerifyRangeProof verifies a proof returned by SyncGetRange for the given
+// [start, end) range and limit. An empty end means that the range is unbounded.
+//
+// In addition to checking that all returned entries are present in the tree,
+// this also checks that the proof is complete, meaning that no entries in the
+// range (up to the limit) have been omitted.
+func (pv *ProofVerifier) VerifyRangeProof(
+	ctx context.Context,
+	root hash.Hash,
+	proof *Proof,
+	start, end []byte,
+	limit uint16,
+) (*RangeResult, error) {
+	rootPtr, err := pv.VerifyProof(ctx, root, proof)
+	if err != nil {
+		return nil, err
+	}
+
+	rv := rangeVerifier{
+		ctx:    ctx,
+		start:  start,
+		end:    end,
+		limit:  int(limit),
+		result: &RangeResult{},
+	}
+	if err = rv.walk(rootPtr, 0, node.Key{}, 0); err != nil {
+		return nil, err
+	}
+	return rv.result, nil
+}
+
+type rangeVerifier struct {
+	ctx    context.Context
+	start  node.Key
+	end    node.Key
+	limit  int
+	result *RangeResult
+	done   bool
+}
+
+// walk traverses the verified subtree in key order. The given path contains the
+// pathBits bits that are shared by all keys in the subtree.
+func (rv *rangeVerifier) walk(ptr *node.Pointer, bitDepth node.Depth, path node.Key, pathBits node.Depth) error {
+	if rv.done || ptr == nil || (ptr.Node == nil && ptr.Hash.IsEmpty()) {
+		return nil
+	}
+	if rv.ctx.Err() != nil {
+		return rv.ctx.Err()
+	}
+
+	if ptr.Node == nil {
+		// Subtree is not included in the proof, which is only allowed in case it
+		// lies completely outside the range.
+		switch {
+		case comparePrefix(path, pathBits, rv.start) < 0:
+			return nil
+		case len(rv.end) > 0 && comparePrefix(path, pathBits, rv.end) > 0:
+			rv.done = true
+			return nil
+		default:
+			return fmt.Errorf("%w: missing subtree %s", ErrIncompleteProof, ptr.Hash)
+		}
+	}
+
+	switch n := ptr.Node.(type) {
+	case *node.InternalNode:
+		bitLength := bitDepth + n.LabelBitLength
+		newPath := path.Merge(bitDepth, n.Label, n.LabelBitLength)
+
+		if err := rv.walk(n.LeafNode, bitLength, newPath, bitLength); err != nil {
+			return err
+		}
+		if err := rv.walk(n.Left, bitLength, newPath.AppendBit(bitLength, false), bitLength+1); err != nil {
+			return err
+		}
+		return rv.walk(n.Right, bitLength, newPath.AppendBit(bitLength, true), bitLength+1)
+	case *node.LeafNode:
+		switch {
+		case n.Key.Compare(rv.start) < 0:
+		case len(rv.end) > 0 && n.Key.Compare(rv.end) >= 0:
+			rv.done = true
+		case len(rv.result.Entries) >= rv.limit:
+			rv.result.Truncated = true
+			rv.result.NextKey = n.Key
+			rv.done = true
+		default:
+			rv.result.Entries = append(rv.result.Entries, RangeEntry{Key: n.Key, Value: n.Value})
+		}
+		return nil
+	default:
+		return fmt.Errorf("verifier: unexpected node type in range proof (%T)", n)
+	}
+}
+
+// comparePrefix compares all keys sharing the first prefixBits bits of the given
+// prefix with the given key. It returns -1 if all such keys are smaller than the
+// key, 1 if all such keys are greater than or equal to the key and 0 otherwise.
+func comparePrefix(prefix node.Key, prefixBits node.Depth, key node.Key) int {
+	keyBits := key.BitLength()
+	commonBits := prefix.CommonPrefixLen(prefixBits, key, keyBits)
+	switch {
+	case commonBits == keyBits:
+		// Key is a prefix of all keys under the given prefix.
+		return 1
+	case commonBits == prefixBits:
+		return 0
+	case prefix.GetBit(commonBits):
+		return 1
+	default:
+		return -1
+	}
+}
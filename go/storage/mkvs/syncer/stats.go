@@ -7,6 +7,7 @@ type StatsCollector struct {
 	SyncGetCount         int
 	SyncGetPrefixesCount int
 	SyncIterateCount     int
+	SyncGetRangeCount    int
 
 	rs ReadSyncer
 }
@@ -32,3 +33,8 @@ func (c *StatsCollector) SyncIterate(ctx context.Context, request *IterateReques
 	c.SyncIterateCount++
 	return c.rs.SyncIterate(ctx, request)
 }
+
+func (c *StatsCollector) SyncGetRange(ctx context.Context, request *GetRangeRequest) (*ProofResponse, error) {
+	c.SyncGetRangeCount++
+	return c.rs.SyncGetRange(ctx, request)
+}
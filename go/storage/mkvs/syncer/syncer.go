@@ -50,6 +50,17 @@ type IterateRequest struct {
 	Prefetch uint16 `json:"prefetch"`
 }
 
+// GetRangeRequest is a request for the SyncGetRange operation.
+type GetRangeRequest struct {
+	Tree TreeID `json:"tree"`
+	// Start is the (inclusive) start of the key range.
+	Start []byte `json:"start"`
+	// End is the (exclusive) end of the key range. A nil end means that the range is unbounded.
+	End []byte `json:"end,omitempty"`
+	// Limit is the maximum number of entries that should be returned.
+	Limit uint16 `json:"limit"`
+}
+
 // ProofResponse is a response for requests that produce proofs.
 type ProofResponse struct {
 	Proof Proof `json:"proof"`
@@ -68,6 +79,11 @@ type ReadSyncer interface {
 	// SyncIterate seeks to a given key and then fetches the specified
 	// number of following items based on key iteration order.
 	SyncIterate(ctx context.Context, request *IterateRequest) (*ProofResponse, error)
+
+	// SyncGetRange fetches up to limit keys in the [start, end) range and returns
+	// a proof from which both presence and completeness of the returned keys can
+	// be verified (see ProofVerifier.VerifyRangeProof).
+	SyncGetRange(ctx context.Context, request *GetRangeRequest) (*ProofResponse, error)
 }
 
 // nopReadSyncer is a no-op read syncer.
@@ -87,3 +103,7 @@ func (r *nopReadSyncer) SyncGetPrefixes(ctx context.Context, request *GetPrefixe
 func (r *nopReadSyncer) SyncIterate(ctx context.Context, request *IterateRequest) (*ProofResponse, error) {
 	return nil, ErrUnsupported
 }
+
+func (r *nopReadSyncer) SyncGetRange(ctx context.Context, request *GetRangeRequest) (*ProofResponse, error) {
+	return nil, ErrUnsupported
+}
@@ -1,8 +1,11 @@
 package mkvs
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
+	"errors"
+	"sort"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -12,6 +15,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/writelog"
 )
 
 func TestProof(t *testing.T) {
@@ -157,6 +161,213 @@ func TestProof(t *testing.T) {
 	require.Error(err, "VerifyProof should fail with invalid proof")
 }
 
+func TestGetProof(t *testing.T) {
+	require := require.New(t)
+
+	// Use the same tree as in TestProof.
+	ctx := context.Background()
+	keys, values := generateKeyValuePairsEx("", 10)
+	var ns common.Namespace
+
+	tree := New(nil, nil, node.RootTypeState)
+	defer tree.Close()
+	for i, key := range keys {
+		err := tree.Insert(ctx, key, values[i])
+		require.NoError(err, "Insert")
+	}
+	_, rootHash, err := tree.Commit(ctx, ns, 0)
+	require.NoError(err, "Commit")
+	root := node.Root{Namespace: ns, Type: node.RootTypeState, Hash: rootHash}
+
+	getProof := func(key []byte) *syncer.Proof {
+		rsp, rerr := tree.SyncGet(ctx, &syncer.GetRequest{
+			Tree: syncer.TreeID{Root: root, Position: root.Hash},
+			Key:  key,
+		})
+		require.NoError(rerr, "SyncGet")
+		return &rsp.Proof
+	}
+
+	var pv syncer.ProofVerifier
+	for i, key := range keys {
+		value, present, verr := pv.VerifyGetProof(ctx, rootHash, getProof(key), key)
+		require.NoError(verr, "VerifyGetProof")
+		require.True(present, "key should be present")
+		require.EqualValues(values[i], value, "value should be correct")
+	}
+
+	for _, key := range [][]byte{
+		[]byte("key 55"),
+		[]byte("key"),
+		[]byte("foo"),
+		[]byte("zzz"),
+		{},
+	} {
+		value, present, verr := pv.VerifyGetProof(ctx, rootHash, getProof(key), key)
+		require.NoError(verr, "VerifyGetProof(%q)", key)
+		require.False(present, "key %q should not be present", key)
+		require.Nil(value, "value should be nil for key %q", key)
+	}
+
+	// Absence proof should be stable.
+	absenceProof := getProof([]byte("key 55"))
+	testVectorProof := base64.StdEncoding.EncodeToString(cbor.Marshal(absenceProof))
+	require.EqualValues(
+		"omdlbnRyaWVziVIBAQAAAAAAAAAAJABrZXkgMAJOAQEAAAAAAAAAAAEAAAJYIQLfcbr2Zv0eZpMHlih4wq2kOBFhVcnJrZxX6NcwiYk7r04BAQAAAAAAAAAAAQCAAk4BAQAAAAAAAAAAAQAAAlghAlImlvtsnJQ6lOCA4YQBRmexxWoKSr0a10Y1OPnPlY1cWBwBAAAAAAAAAAAABQBrZXkgNQcAAAB2YWx1ZSA1WCEC8CQfesB8lsf0rCb5Mrp0YA/N1U9Q2hg82PqXARPihDBYIQLZzIpAXexuvGnJTOuNKNNvOlZxv9LkicEk7DQhZyGf0251bnRydXN0ZWRfcm9vdFggXrnnP51cnrhAJ3Ph7r28TVrMzqfGel3Okf+MTqHVzKw=",
+		testVectorProof,
+	)
+	require.EqualValues("5eb9e73f9d5c9eb8402773e1eebdbc4d5acccea7c67a5dce91ff8c4ea1d5ccac", rootHash.String())
+
+	// Proofs that do not cover the key should not prove anything about the key.
+	_, _, err = pv.VerifyGetProof(ctx, rootHash, getProof([]byte("key 5")), []byte("key 0"))
+	require.ErrorIs(err, syncer.ErrIncompleteProof, "VerifyGetProof should fail with a proof for a different key")
+	rootOnlyProof, err := syncer.NewProofBuilder(rootHash, rootHash).Build(ctx)
+	require.NoError(err, "Build")
+	_, _, err = pv.VerifyGetProof(ctx, rootHash, rootOnlyProof, []byte("key 55"))
+	require.ErrorIs(err, syncer.ErrIncompleteProof, "VerifyGetProof should fail with a root-only proof")
+
+	// Invalid proofs should not verify.
+	bogusHash := hash.NewFromBytes([]byte("i am a bogus hash"))
+	_, _, err = pv.VerifyGetProof(ctx, bogusHash, absenceProof, []byte("key 55"))
+	require.Error(err, "VerifyGetProof should fail with proof for a different root")
+	require.False(errors.Is(err, syncer.ErrIncompleteProof), "VerifyGetProof should not report an incomplete proof for a different root")
+
+	// Everything is absent in an empty tree.
+	var emptyHash hash.Hash
+	emptyHash.Empty()
+	emptyRootProof, err := syncer.NewProofBuilder(emptyHash, emptyHash).Build(ctx)
+	require.NoError(err, "Build")
+	_, present, err := pv.VerifyGetProof(ctx, emptyHash, emptyRootProof, []byte("key 55"))
+	require.NoError(err, "VerifyGetProof should not fail for an empty root")
+	require.False(present, "key should not be present in an empty tree")
+}
+
+func TestRangeProof(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	tree := New(nil, nil, node.RootTypeState)
+	defer tree.Close()
+
+	var items writelog.WriteLog
+	for _, prefix := range []string{"acct/alice/", "acct/bob/", "zzz/"} {
+		keys, values := generateKeyValuePairsEx(prefix, 20)
+		for i, key := range keys {
+			items = append(items, writelog.LogEntry{Key: key, Value: values[i]})
+		}
+	}
+	// Also include a key which is a prefix of other keys.
+	items = append(items, writelog.LogEntry{Key: []byte("acct/alice"), Value: []byte("alice")})
+	for _, item := range items {
+		err := tree.Insert(ctx, item.Key, item.Value)
+		require.NoError(err, "Insert")
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return bytes.Compare(items[i].Key, items[j].Key) < 0
+	})
+
+	root := node.Root{Type: node.RootTypeState}
+	_, rootHash, err := tree.Commit(ctx, root.Namespace, root.Version)
+	require.NoError(err, "Commit")
+	root.Hash = rootHash
+
+	// Serialize requests and responses to make sure they survive the round trip.
+	rs := &dummySerialSyncer{backing: tree}
+	getRange := func(start, end []byte, limit uint16) *syncer.Proof {
+		rsp, rerr := rs.SyncGetRange(ctx, &syncer.GetRangeRequest{
+			Tree:  syncer.TreeID{Root: root, Position: root.Hash},
+			Start: start,
+			End:   end,
+			Limit: limit,
+		})
+		require.NoError(rerr, "SyncGetRange")
+		return &rsp.Proof
+	}
+
+	var pv syncer.ProofVerifier
+	for _, tc := range []struct {
+		start string
+		end   string
+		limit uint16
+	}{
+		{"", "", 1000},
+		{"", "", 5},
+		{"acct/alice", "acct/alice0", 1000},
+		{"acct/alice/", "acct/alice0", 1000},
+		{"acct/alice/", "acct/alice0", 7},
+		{"acct/alice/", "acct/alice0", 20},
+		{"acct/bob/key 1", "acct/bob/key 2", 1000},
+		{"acct/carol/", "acct/carol0", 1000},
+		{"acct/", "", 0},
+		{"zzz/key 5", "", 1000},
+		{"zzzz", "", 1000},
+		{"b", "a", 1000},
+	} {
+		var expected []syncer.RangeEntry
+		var expectedNext []byte
+		for _, item := range items {
+			if bytes.Compare(item.Key, []byte(tc.start)) < 0 {
+				continue
+			}
+			if tc.end != "" && bytes.Compare(item.Key, []byte(tc.end)) >= 0 {
+				break
+			}
+			if len(expected) >= int(tc.limit) {
+				expectedNext = item.Key
+				break
+			}
+			expected = append(expected, syncer.RangeEntry{Key: item.Key, Value: item.Value})
+		}
+
+		proof := getRange([]byte(tc.start), []byte(tc.end), tc.limit)
+		result, err := pv.VerifyRangeProof(ctx, rootHash, proof, []byte(tc.start), []byte(tc.end), tc.limit)
+		require.NoError(err, "VerifyRangeProof(%q, %q, %d)", tc.start, tc.end, tc.limit)
+		require.EqualValues(expected, result.Entries, "entries should be correct (%q, %q, %d)", tc.start, tc.end, tc.limit)
+		require.Equal(expectedNext != nil, result.Truncated, "truncated should be correct (%q, %q, %d)", tc.start, tc.end, tc.limit)
+		require.EqualValues(expectedNext, result.NextKey, "next key should be correct (%q, %q, %d)", tc.start, tc.end, tc.limit)
+	}
+
+	// Paginating over a range should return all entries.
+	start, end := []byte("acct/alice/"), []byte("acct/alice0")
+	var entries []syncer.RangeEntry
+	for {
+		result, rerr := pv.VerifyRangeProof(ctx, rootHash, getRange(start, end, 3), start, end, 3)
+		require.NoError(rerr, "VerifyRangeProof")
+		entries = append(entries, result.Entries...)
+		if !result.Truncated {
+			break
+		}
+		start = result.NextKey
+	}
+	require.Len(entries, 20, "pagination should return all entries")
+
+	// Proofs that omit entries in the range should not verify.
+	start = []byte("acct/alice/")
+	_, err = pv.VerifyRangeProof(ctx, rootHash, getRange([]byte("acct/bob/"), nil, 1000), start, end, 1000)
+	require.ErrorIs(err, syncer.ErrIncompleteProof, "VerifyRangeProof should fail with a proof for a different range")
+	_, err = pv.VerifyRangeProof(ctx, rootHash, getRange(start, end, 3), start, end, 10)
+	require.ErrorIs(err, syncer.ErrIncompleteProof, "VerifyRangeProof should fail with a proof for a lower limit")
+	bogusHash := hash.NewFromBytes([]byte("i am a bogus hash"))
+	_, err = pv.VerifyRangeProof(ctx, bogusHash, getRange(start, end, 3), start, end, 3)
+	require.Error(err, "VerifyRangeProof should fail with proof for a different root")
+
+	// Empty tree.
+	emptyTree := New(nil, nil, node.RootTypeState)
+	defer emptyTree.Close()
+	var emptyRoot node.Root
+	emptyRoot.Type = node.RootTypeState
+	emptyRoot.Hash.Empty()
+	rsp, err := emptyTree.SyncGetRange(ctx, &syncer.GetRangeRequest{
+		Tree:  syncer.TreeID{Root: emptyRoot, Position: emptyRoot.Hash},
+		Limit: 10,
+	})
+	require.NoError(err, "SyncGetRange on an empty tree")
+	result, err := pv.VerifyRangeProof(ctx, emptyRoot.Hash, &rsp.Proof, nil, nil, 10)
+	require.NoError(err, "VerifyRangeProof on an empty tree")
+	require.Empty(result.Entries, "empty tree should have no entries")
+	require.False(result.Truncated, "empty tree range should not be truncated")
+}
+
 func copyProof(p *syncer.Proof) *syncer.Proof {
 	if p == nil {
 		return nil
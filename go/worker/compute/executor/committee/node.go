@@ -59,6 +59,8 @@ var (
 
 	// Duration to wait before submitting the propose timeout request.
 	proposeTimeoutDelay = 2 * time.Second
+	// Maximum number of queued transactions sent to the runtime in a single recheck request.
+	recheckTxBatchSize = 128
 )
 
 var (
@@ -118,6 +120,27 @@ var (
 		},
 		[]string{"runtime"},
 	)
+	recheckTxCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_worker_recheck_tx_count",
+			Help: "Number of rechecked queued transactions.",
+		},
+		[]string{"runtime"},
+	)
+	recheckTxInvalidCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_worker_recheck_tx_invalid_count",
+			Help: "Number of queued transactions removed as they failed a recheck.",
+		},
+		[]string{"runtime"},
+	)
+	recheckTxLatency = prometheus.NewSummaryVec(
+		prometheus.SummaryOpts{
+			Name: "oasis_worker_recheck_tx_latency",
+			Help: "Time it takes to recheck all queued transactions (seconds).",
+		},
+		[]string{"runtime"},
+	)
 	nodeCollectors = []prometheus.Collector{
 		discrepancyDetectedCount,
 		abortedBatchCount,
@@ -127,6 +150,9 @@ var (
 		batchRuntimeProcessingTime,
 		batchSize,
 		incomingQueueSize,
+		recheckTxCount,
+		recheckTxInvalidCount,
+		recheckTxLatency,
 	}
 
 	metricsOnce sync.Once
@@ -142,12 +168,15 @@ type Node struct { // nolint: maligned
 	scheduleCheckTxEnabled bool
 	scheduleMaxTxPoolSize  uint64
 	scheduleCh             *channels.RingChannel
+	recheckTxInterval      time.Duration
+	recheckCh              *channels.RingChannel
 
 	// The scheduler mutex is here to protect the initialization
 	// of the scheduler variable. After initialization the variable
-	// will never change though -- so if the variable is non-nil
-	// (which must be checked while holding the read lock) it can
-	// safely be used without holding the lock.
+	// only changes from the worker goroutine (when the scheduling
+	// algorithm changes) -- so the worker goroutine can safely use it
+	// without holding the lock, while any other goroutine must hold
+	// the read lock (and check that the variable is non-nil).
 	schedulerMutex sync.RWMutex
 	scheduler      schedulingAPI.Scheduler
 
@@ -254,15 +283,17 @@ func (n *Node) HandlePeerMessage(ctx context.Context, message *p2p.Message, isOw
 			return true, nil
 		}
 
+		var meta *protocol.CheckTxMetadata
 		if n.scheduleCheckTxEnabled {
 			// Check transaction before queuing it.
-			if err := n.checkTx(ctx, tx); err != nil {
+			var err error
+			if meta, err = n.checkTx(ctx, tx); err != nil {
 				return true, err
 			}
 			n.logger.Debug("worker CheckTx successful, queuing transaction")
 		}
 
-		err := n.QueueTx(tx)
+		err := n.queueTx(tx, meta)
 		if err != nil {
 			n.logger.Error("unable to queue transaction",
 				"err", err,
@@ -572,10 +603,16 @@ func (n *Node) HandleNewBlockLocked(blk *block.Block) {
 
 		n.scheduleCh.In() <- struct{}{}
 	}
+
+	// Recheck queued transactions against the new state.
+	if n.scheduleCheckTxEnabled {
+		n.recheckCh.In() <- struct{}{}
+	}
 }
 
-// checkTx requests the runtime to check the validity of the given transaction.
-func (n *Node) checkTx(ctx context.Context, tx []byte) error {
+// checkTx requests the runtime to check the validity of the given transaction and returns the
+// transaction's scheduling metadata (if any).
+func (n *Node) checkTx(ctx context.Context, tx []byte) (*protocol.CheckTxMetadata, error) {
 	n.commonNode.CrossNode.Lock()
 	currentBlock := n.commonNode.CurrentBlock
 	currentConsensusBlock := n.commonNode.CurrentConsensusBlock
@@ -584,26 +621,39 @@ func (n *Node) checkTx(ctx context.Context, tx []byte) error {
 	rt := n.GetHostedRuntime()
 	if rt == nil {
 		n.logger.Error("CheckTx: hosted runtime not initialized")
-		return errNotReady
+		return nil, errNotReady
 	}
 
-	err := rt.CheckTx(ctx, currentBlock, currentConsensusBlock, tx)
+	result, err := rt.CheckTx(ctx, currentBlock, currentConsensusBlock, tx)
 	switch {
 	case err == nil:
 	case errors.Is(err, host.ErrInvalidArgument):
-		return errNotReady
+		return nil, errNotReady
 	case errors.Is(err, host.ErrInternal):
-		return err
+		return nil, err
 	case errors.Is(err, host.ErrCheckTxFailed):
-		return p2pError.Permanent(err)
+		return nil, p2pError.Permanent(err)
 	default:
-		return err
+		return nil, err
 	}
-	return nil
+
+	meta, err := result.Metadata()
+	if err != nil {
+		// Metadata is only used for scheduling, so the transaction can still be queued.
+		n.logger.Warn("CheckTx: ignoring malformed transaction metadata",
+			"err", err,
+		)
+		return nil, nil
+	}
+	return meta, nil
 }
 
 // QueueTx queues a runtime transaction for scheduling.
 func (n *Node) QueueTx(tx []byte) error {
+	return n.queueTx(tx, nil)
+}
+
+func (n *Node) queueTx(tx []byte, meta *protocol.CheckTxMetadata) error {
 	n.schedulerMutex.RLock()
 	defer n.schedulerMutex.RUnlock()
 
@@ -619,7 +669,7 @@ func (n *Node) QueueTx(tx []byte) error {
 		}
 	}
 
-	if err := n.scheduler.QueueTx(tx); err != nil {
+	if err := n.scheduler.QueueTx(tx, meta); err != nil {
 		return err
 	}
 
@@ -642,8 +692,11 @@ func (n *Node) QueueTx(tx []byte) error {
 
 // removeTxBatch removes a batch from scheduling queue.
 func (n *Node) removeTxBatch(batch [][]byte) error {
-	// XXX: remove batch can only happen after a batch was already scheduled, meaning
-	// the scheduler already exists and there is no need for the scheduler mutex.
+	// Remove batch can only happen after a batch was already scheduled, meaning the scheduler
+	// already exists, but the scheduler may be replaced concurrently by the worker.
+	n.schedulerMutex.RLock()
+	defer n.schedulerMutex.RUnlock()
+
 	if err := n.scheduler.RemoveTxBatch(batch); err != nil {
 		return err
 	}
@@ -653,6 +706,145 @@ func (n *Node) removeTxBatch(batch [][]byte) error {
 	return nil
 }
 
+// GetQueuedTransactions returns all transactions queued for scheduling.
+func (n *Node) GetQueuedTransactions() ([]*schedulingAPI.QueuedTx, error) {
+	n.schedulerMutex.RLock()
+	defer n.schedulerMutex.RUnlock()
+
+	if n.scheduler == nil {
+		return nil, errNotReady
+	}
+	return n.scheduler.GetTransactions(), nil
+}
+
+// GetQueuedTransaction returns the transaction with the given hash queued for scheduling. In case
+// the transaction is not queued, nil is returned.
+func (n *Node) GetQueuedTransaction(txHash hash.Hash) (*schedulingAPI.QueuedTx, error) {
+	n.schedulerMutex.RLock()
+	defer n.schedulerMutex.RUnlock()
+
+	if n.scheduler == nil {
+		return nil, errNotReady
+	}
+	qtx, _ := n.scheduler.GetTransaction(txHash)
+	return qtx, nil
+}
+
+// RemoveQueuedTransactions removes the transactions with the given hashes from the scheduling
+// queue. Hashes of transactions that are not queued are ignored.
+func (n *Node) RemoveQueuedTransactions(hashes []hash.Hash) error {
+	var batch [][]byte
+	for _, h := range hashes {
+		qtx, err := n.GetQueuedTransaction(h)
+		if err != nil {
+			return err
+		}
+		if qtx != nil {
+			batch = append(batch, qtx.Tx)
+		}
+	}
+	if len(batch) == 0 {
+		return nil
+	}
+
+	n.logger.Info("removing queued transactions on request",
+		"num_txs", len(batch),
+	)
+	return n.removeTxBatch(batch)
+}
+
+// recheckTxs rechecks all queued transactions against the latest state and removes the ones that
+// are no longer valid.
+func (n *Node) recheckTxs() {
+	n.schedulerMutex.RLock()
+	if n.scheduler == nil {
+		n.schedulerMutex.RUnlock()
+		return
+	}
+	queuedTxs := n.scheduler.GetTransactions()
+	n.schedulerMutex.RUnlock()
+	if len(queuedTxs) == 0 {
+		return
+	}
+	txs := make([][]byte, 0, len(queuedTxs))
+	for _, qtx := range queuedTxs {
+		txs = append(txs, qtx.Tx)
+	}
+
+	n.commonNode.CrossNode.Lock()
+	currentBlock := n.commonNode.CurrentBlock
+	currentConsensusBlock := n.commonNode.CurrentConsensusBlock
+	n.commonNode.CrossNode.Unlock()
+
+	rt := n.GetHostedRuntime()
+	if rt == nil {
+		return
+	}
+
+	start := time.Now()
+	var invalid [][]byte
+	for len(txs) > 0 {
+		batch := txs
+		if len(batch) > recheckTxBatchSize {
+			batch = batch[:recheckTxBatchSize]
+		}
+		txs = txs[len(batch):]
+
+		results, err := rt.CheckTxBatch(n.ctx, currentBlock, currentConsensusBlock, batch)
+		if err != nil {
+			n.logger.Warn("failed to recheck queued transactions",
+				"err", err,
+			)
+			return
+		}
+		for i, result := range results {
+			if !result.IsSuccess() {
+				n.logger.Debug("removing queued transaction that failed recheck",
+					"tx_hash", hash.NewFromBytes(batch[i]),
+					"err", result.Error,
+				)
+				invalid = append(invalid, batch[i])
+			}
+		}
+		recheckTxCount.With(n.getMetricLabels()).Add(float64(len(batch)))
+	}
+	recheckTxLatency.With(n.getMetricLabels()).Observe(time.Since(start).Seconds())
+
+	if len(invalid) == 0 {
+		return
+	}
+	if err := n.removeTxBatch(invalid); err != nil {
+		n.logger.Warn("failed removing invalid transactions from queue",
+			"err", err,
+		)
+		return
+	}
+	recheckTxInvalidCount.With(n.getMetricLabels()).Add(float64(len(invalid)))
+}
+
+// txRecheckWorker rechecks queued transactions after each round and periodically.
+//
+// The worker is only started when transaction checks before scheduling are enabled.
+func (n *Node) txRecheckWorker() {
+	var tickerCh <-chan time.Time
+	if n.recheckTxInterval > 0 {
+		ticker := time.NewTicker(n.recheckTxInterval)
+		defer ticker.Stop()
+		tickerCh = ticker.C
+	}
+
+	for {
+		select {
+		case <-n.stopCh:
+			return
+		case <-tickerCh:
+		case <-n.recheckCh.Out():
+		}
+
+		n.recheckTxs()
+	}
+}
+
 func (n *Node) proposeTimeoutLocked() error {
 	// Do not propose a timeout if we are already proposing it.
 	// The flag will get cleared on the next round or if the propose timeout
@@ -1506,6 +1698,14 @@ func (n *Node) worker() {
 	}
 	defer rtSub.Close()
 
+	// Start rechecking queued transactions. As rechecks use the same runtime checks as
+	// transactions being queued, they are only performed when checks are enabled.
+	if n.scheduleCheckTxEnabled {
+		go n.txRecheckWorker()
+	} else {
+		n.logger.Info("transaction checks are disabled, queued transactions will not be rechecked")
+	}
+
 	// We are initialized.
 	close(n.initCh)
 
@@ -1533,12 +1733,27 @@ func (n *Node) worker() {
 			// Batch processing has finished.
 			n.handleProcessedBatch(batch, processingDoneCh)
 		case runtime := <-rtCh:
-			// XXX: Once there is more than one scheduling algorithm available
-			// this might need to recreate the scheduler and reinsert
-			// the transactions.
-			// At that point also update the schedulerMutex usage across the
-			// code, as it will be no longer be true that the scheduler
-			// variable never gets updated.
+			if runtime.TxnScheduler.Algorithm != n.scheduler.Name() {
+				// The scheduling algorithm has changed, switch to a new scheduler. Queued
+				// transactions are dropped as their scheduling metadata is not available and
+				// the last scheduled cache is cleared so that they can be resubmitted.
+				n.logger.Warn("transaction scheduling algorithm changed, dropping queued transactions",
+					"algorithm", runtime.TxnScheduler.Algorithm,
+					"num_dropped", n.scheduler.UnscheduledSize(),
+				)
+				if scheduler, err = scheduling.New(n.scheduleMaxTxPoolSize, runtime.TxnScheduler); err != nil {
+					n.logger.Error("failed to create new transaction scheduler algorithm",
+						"err", err,
+					)
+					return
+				}
+
+				n.schedulerMutex.Lock()
+				n.scheduler = scheduler
+				n.clearQueuedTxs()
+				n.schedulerMutex.Unlock()
+				continue
+			}
 			if err = n.scheduler.UpdateParameters(runtime.TxnScheduler); err != nil {
 				n.logger.Error("error updating scheduler parameters",
 					"err", err,
@@ -1563,6 +1778,7 @@ func NewNode(
 	commonCfg commonWorker.Config,
 	roleProvider registration.RoleProvider,
 	scheduleCheckTxEnabled bool,
+	scheduleRecheckTxInterval time.Duration,
 	scheduleMaxTxPoolSize uint64,
 	lastScheduledCacheSize uint64,
 ) (*Node, error) {
@@ -1595,6 +1811,8 @@ func NewNode(
 		scheduleMaxTxPoolSize:  scheduleMaxTxPoolSize,
 		lastScheduledCache:     cache,
 		scheduleCh:             channels.NewRingChannel(1),
+		recheckTxInterval:      scheduleRecheckTxInterval,
+		recheckCh:              channels.NewRingChannel(1),
 		ctx:                    ctx,
 		cancelCtx:              cancel,
 		stopCh:                 make(chan struct{}),
@@ -0,0 +1,205 @@
+package committee
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/errors"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/mock"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
+	runtimeRegistry "github.com/oasisprotocol/oasis-core/go/runtime/registry"
+	"github.com/oasisprotocol/oasis-core/go/runtime/scheduling"
+	"github.com/oasisprotocol/oasis-core/go/runtime/scheduling/simple"
+	commonWorker "github.com/oasisprotocol/oasis-core/go/worker/common"
+	committeeCommon "github.com/oasisprotocol/oasis-core/go/worker/common/committee"
+)
+
+// testRuntime is a registered runtime which is hosted by the given provisioner.
+type testRuntime struct {
+	runtimeRegistry.Runtime
+
+	id          common.Namespace
+	provisioner host.Provisioner
+}
+
+func (r *testRuntime) ID() common.Namespace {
+	return r.id
+}
+
+func (r *testRuntime) Host(ctx context.Context) (host.Config, host.Provisioner, error) {
+	return host.Config{RuntimeID: r.id}, r.provisioner, nil
+}
+
+// testRuntimeHostHandlerFactory is a runtime host handler factory without handlers and notifiers.
+type testRuntimeHostHandlerFactory struct {
+	runtime runtimeRegistry.Runtime
+}
+
+func (f *testRuntimeHostHandlerFactory) GetRuntime() runtimeRegistry.Runtime {
+	return f.runtime
+}
+
+func (f *testRuntimeHostHandlerFactory) NewRuntimeHostHandler() protocol.Handler {
+	return nil
+}
+
+func (f *testRuntimeHostHandlerFactory) NewNotifier(ctx context.Context, host host.Runtime) protocol.Notifier {
+	return nil
+}
+
+// checkTxProvisioner provisions mock runtimes which reject the transactions marked as invalid
+// during CheckTx.
+type checkTxProvisioner struct {
+	sync.Mutex
+
+	invalid map[hash.Hash]bool
+}
+
+func (p *checkTxProvisioner) invalidate(tx []byte) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.invalid[hash.NewFromBytes(tx)] = true
+}
+
+func (p *checkTxProvisioner) NewRuntime(ctx context.Context, cfg host.Config) (host.Runtime, error) {
+	rt, err := mock.New().NewRuntime(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &checkTxRuntime{Runtime: rt, provisioner: p}, nil
+}
+
+type checkTxRuntime struct {
+	host.Runtime
+
+	provisioner *checkTxProvisioner
+}
+
+func (r *checkTxRuntime) Call(ctx context.Context, body *protocol.Body) (*protocol.Body, error) {
+	if body.RuntimeCheckTxBatchRequest == nil {
+		return r.Runtime.Call(ctx, body)
+	}
+
+	r.provisioner.Lock()
+	defer r.provisioner.Unlock()
+
+	var results []protocol.CheckTxResult
+	for _, tx := range body.RuntimeCheckTxBatchRequest.Inputs {
+		code := uint32(errors.CodeNoError)
+		if r.provisioner.invalid[hash.NewFromBytes(tx)] {
+			code = 1
+		}
+		results = append(results, protocol.CheckTxResult{
+			Error: protocol.Error{Module: "test", Code: code},
+		})
+	}
+	return &protocol.Body{RuntimeCheckTxBatchResponse: &protocol.RuntimeCheckTxBatchResponse{
+		Results: results,
+	}}, nil
+}
+
+func TestRecheckQueuedTxs(t *testing.T) {
+	require := require.New(t)
+
+	var runtimeID common.Namespace
+	provisioner := &checkTxProvisioner{invalid: make(map[hash.Hash]bool)}
+	rt := &testRuntime{id: runtimeID, provisioner: provisioner}
+	commonNode := &committeeCommon.Node{
+		Runtime:               rt,
+		CurrentBlock:          block.NewGenesisBlock(runtimeID, 0),
+		CurrentConsensusBlock: &consensus.LightBlock{},
+	}
+
+	n, err := NewNode(commonNode, commonWorker.Config{}, nil, true, 0, 100, 0)
+	require.NoError(err, "NewNode")
+	n.RuntimeHostNode, err = runtimeRegistry.NewRuntimeHostNode(&testRuntimeHostHandlerFactory{rt})
+	require.NoError(err, "NewRuntimeHostNode")
+	_, _, err = n.ProvisionHostedRuntime(context.Background())
+	require.NoError(err, "ProvisionHostedRuntime")
+
+	n.scheduler, err = scheduling.New(100, registry.TxnSchedulerParameters{
+		Algorithm:         simple.Name,
+		MaxBatchSize:      10,
+		MaxBatchSizeBytes: 10000,
+		BatchFlushTimeout: time.Second,
+	})
+	require.NoError(err, "scheduling.New")
+
+	go n.txRecheckWorker()
+	defer n.Stop()
+
+	txs := [][]byte{[]byte("tx 1"), []byte("tx 2"), []byte("tx 3")}
+	for _, tx := range txs {
+		err = n.QueueTx(tx)
+		require.NoError(err, "QueueTx")
+	}
+
+	// Make a queued transaction invalid and signal the end of a round.
+	provisioner.invalidate(txs[1])
+	n.recheckCh.In() <- struct{}{}
+
+	require.Eventually(func() bool {
+		return n.scheduler.UnscheduledSize() == 2
+	}, 10*time.Second, 10*time.Millisecond, "invalid transaction should be removed from queue")
+
+	queuedTxs, err := n.GetQueuedTransactions()
+	require.NoError(err, "GetQueuedTransactions")
+	var queued [][]byte
+	for _, qtx := range queuedTxs {
+		queued = append(queued, qtx.Tx)
+	}
+	require.ElementsMatch([][]byte{txs[0], txs[2]}, queued, "valid transactions should remain queued")
+}
+
+func TestQueuedTransactions(t *testing.T) {
+	require := require.New(t)
+
+	var runtimeID common.Namespace
+	commonNode := &committeeCommon.Node{
+		Runtime: &testRuntime{id: runtimeID},
+	}
+	n, err := NewNode(commonNode, commonWorker.Config{}, nil, false, 0, 100, 0)
+	require.NoError(err, "NewNode")
+
+	_, err = n.GetQueuedTransaction(hash.NewFromBytes([]byte("tx 1")))
+	require.Equal(errNotReady, err, "GetQueuedTransaction should fail before the scheduler is ready")
+
+	n.scheduler, err = scheduling.New(100, registry.TxnSchedulerParameters{
+		Algorithm:         simple.Name,
+		MaxBatchSize:      10,
+		MaxBatchSizeBytes: 10000,
+		BatchFlushTimeout: time.Second,
+	})
+	require.NoError(err, "scheduling.New")
+
+	txs := [][]byte{[]byte("tx 1"), []byte("tx 2"), []byte("tx 3")}
+	for _, tx := range txs {
+		err = n.QueueTx(tx)
+		require.NoError(err, "QueueTx")
+	}
+
+	qtx, err := n.GetQueuedTransaction(hash.NewFromBytes(txs[1]))
+	require.NoError(err, "GetQueuedTransaction")
+	require.EqualValues(txs[1], qtx.Tx, "queued transaction should be returned")
+	qtx, err = n.GetQueuedTransaction(hash.NewFromBytes([]byte("not queued")))
+	require.NoError(err, "GetQueuedTransaction")
+	require.Nil(qtx, "transactions that are not queued should not be returned")
+
+	err = n.RemoveQueuedTransactions([]hash.Hash{hash.NewFromBytes(txs[1]), hash.NewFromBytes([]byte("not queued"))})
+	require.NoError(err, "RemoveQueuedTransactions")
+	require.EqualValues(2, n.scheduler.UnscheduledSize(), "removed transaction should no longer be queued")
+	qtx, err = n.GetQueuedTransaction(hash.NewFromBytes(txs[1]))
+	require.NoError(err, "GetQueuedTransaction")
+	require.Nil(qtx, "removed transaction should not be returned")
+}
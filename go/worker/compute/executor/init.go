@@ -1,6 +1,8 @@
 package executor
 
 import (
+	"time"
+
 	flag "github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
@@ -13,6 +15,11 @@ const (
 	// CfgScheduleCheckTxEnabled enables checking each transaction before
 	// scheduling it.
 	CfgScheduleCheckTxEnabled = "worker.executor.schedule_check_tx.enabled"
+	// CfgScheduleRecheckTxInterval is the interval at which queued transactions are periodically
+	// rechecked (in addition to being rechecked after each runtime round).
+	//
+	// Queued transactions are only rechecked when CfgScheduleCheckTxEnabled is set.
+	CfgScheduleRecheckTxInterval = "worker.executor.schedule_check_tx.recheck_interval"
 
 	cfgMaxTxPoolSize       = "worker.executor.schedule_max_tx_pool_size"
 	cfgScheduleTxCacheSize = "worker.executor.schedule_tx_cache_size"
@@ -33,6 +40,7 @@ func New(
 		commonWorker,
 		registration,
 		viper.GetBool(CfgScheduleCheckTxEnabled),
+		viper.GetDuration(CfgScheduleRecheckTxInterval),
 		viper.GetUint64(cfgMaxTxPoolSize),
 		viper.GetUint64(cfgScheduleTxCacheSize),
 	)
@@ -40,6 +48,7 @@ func New(
 
 func init() {
 	Flags.Bool(CfgScheduleCheckTxEnabled, false, "Enable checking transactions before scheduling them")
+	Flags.Duration(CfgScheduleRecheckTxInterval, 1*time.Minute, "Interval at which queued transactions are rechecked when schedule_check_tx is enabled (0 disables periodic rechecks)")
 	Flags.Uint64(cfgMaxTxPoolSize, 10000, "Maximum size of the scheduling transaction pool")
 	Flags.Uint64(cfgScheduleTxCacheSize, 1000, "Cache size of recently scheduled transactions to prevent re-scheduling")
 
@@ -3,6 +3,7 @@ package executor
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
@@ -17,9 +18,10 @@ import (
 type Worker struct {
 	enabled bool
 
-	scheduleCheckTxEnabled bool
-	scheduleMaxTxPoolSize  uint64
-	scheduleTxCacheSize    uint64
+	scheduleCheckTxEnabled    bool
+	scheduleRecheckTxInterval time.Duration
+	scheduleMaxTxPoolSize     uint64
+	scheduleTxCacheSize       uint64
 
 	commonWorker *workerCommon.Worker
 	registration *registration.Worker
@@ -149,7 +151,15 @@ func (w *Worker) registerRuntime(commonNode *committeeCommon.Node) error {
 	}
 
 	// Create committee node for the given runtime.
-	node, err := committee.NewNode(commonNode, w.commonWorker.GetConfig(), rp, w.scheduleCheckTxEnabled, w.scheduleMaxTxPoolSize, w.scheduleTxCacheSize)
+	node, err := committee.NewNode(
+		commonNode,
+		w.commonWorker.GetConfig(),
+		rp,
+		w.scheduleCheckTxEnabled,
+		w.scheduleRecheckTxInterval,
+		w.scheduleMaxTxPoolSize,
+		w.scheduleTxCacheSize,
+	)
 	if err != nil {
 		return err
 	}
@@ -170,24 +180,26 @@ func newWorker(
 	commonWorker *workerCommon.Worker,
 	registration *registration.Worker,
 	scheduleCheckTxEnabled bool,
+	scheduleRecheckTxInterval time.Duration,
 	scheduleMaxTxPoolSize uint64,
 	scheduleTxCacheSize uint64,
 ) (*Worker, error) {
 	ctx, cancelCtx := context.WithCancel(context.Background())
 
 	w := &Worker{
-		enabled:                enabled,
-		commonWorker:           commonWorker,
-		scheduleCheckTxEnabled: scheduleCheckTxEnabled,
-		scheduleMaxTxPoolSize:  scheduleMaxTxPoolSize,
-		scheduleTxCacheSize:    scheduleTxCacheSize,
-		registration:           registration,
-		runtimes:               make(map[common.Namespace]*committee.Node),
-		ctx:                    ctx,
-		cancelCtx:              cancelCtx,
-		quitCh:                 make(chan struct{}),
-		initCh:                 make(chan struct{}),
-		logger:                 logging.GetLogger("worker/executor"),
+		enabled:                   enabled,
+		commonWorker:              commonWorker,
+		scheduleCheckTxEnabled:    scheduleCheckTxEnabled,
+		scheduleRecheckTxInterval: scheduleRecheckTxInterval,
+		scheduleMaxTxPoolSize:     scheduleMaxTxPoolSize,
+		scheduleTxCacheSize:       scheduleTxCacheSize,
+		registration:              registration,
+		runtimes:                  make(map[common.Namespace]*committee.Node),
+		ctx:                       ctx,
+		cancelCtx:                 cancelCtx,
+		quitCh:                    make(chan struct{}),
+		initCh:                    make(chan struct{}),
+		logger:                    logging.GetLogger("worker/executor"),
 	}
 
 	if enabled {
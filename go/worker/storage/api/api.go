@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"time"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/errors"
@@ -11,8 +12,14 @@ import (
 // ModuleName is the storage worker module name.
 const ModuleName = "worker/storage"
 
-// ErrRuntimeNotFound is the error returned when the called references an unknown runtime.
-var ErrRuntimeNotFound = errors.New(ModuleName, 1, "worker/storage: runtime not found")
+var (
+	// ErrRuntimeNotFound is the error returned when the called references an unknown runtime.
+	ErrRuntimeNotFound = errors.New(ModuleName, 1, "worker/storage: runtime not found")
+
+	// ErrSizeStatsUnsupported is the error returned when the storage backend does not support
+	// size accounting.
+	ErrSizeStatsUnsupported = errors.New(ModuleName, 2, "worker/storage: size accounting not supported by backend")
+)
 
 // StorageWorker is the storage worker control API interface.
 type StorageWorker interface {
@@ -21,6 +28,9 @@ type StorageWorker interface {
 
 	// ForceFinalize forces finalization of a specific round.
 	ForceFinalize(ctx context.Context, request *ForceFinalizeRequest) error
+
+	// GetStorageUsage retrieves the node database disk usage for the given runtime.
+	GetStorageUsage(ctx context.Context, request *GetStorageUsageRequest) (*GetStorageUsageResponse, error)
 }
 
 // GetLastSyncedRoundRequest is a GetLastSyncedRound request.
@@ -41,6 +51,28 @@ type ForceFinalizeRequest struct {
 	Round     uint64           `json:"round"`
 }
 
+// GetStorageUsageRequest is a GetStorageUsage request.
+type GetStorageUsageRequest struct {
+	RuntimeID common.Namespace `json:"runtime_id"`
+}
+
+// GetStorageUsageResponse is a GetStorageUsage response.
+type GetStorageUsageResponse struct {
+	// DiskBytes is the total size of the node database on disk.
+	DiskBytes uint64 `json:"disk_bytes"`
+	// LiveBytes is the estimated number of bytes reachable from retained versions.
+	LiveBytes uint64 `json:"live_bytes"`
+	// ReclaimableBytes is the estimated number of bytes that will be reclaimed by compaction.
+	ReclaimableBytes uint64 `json:"reclaimable_bytes"`
+	// LastCompaction is the time when the last compaction pass completed.
+	LastCompaction time.Time `json:"last_compaction"`
+
+	// EarliestVersion is the earliest version retained in the node database.
+	EarliestVersion uint64 `json:"earliest_version"`
+	// LatestVersion is the latest version stored in the node database.
+	LatestVersion uint64 `json:"latest_version"`
+}
+
 // Status is the storage worker status.
 type Status struct {
 	// LastFinalizedRound is the last synced and finalized round.
@@ -16,6 +16,8 @@ var (
 	methodGetLastSyncedRound = serviceName.NewMethod("GetLastSyncedRound", &GetLastSyncedRoundRequest{})
 	// methodForceFinalize is the ForceFinalize method.
 	methodForceFinalize = serviceName.NewMethod("ForceFinalize", &ForceFinalizeRequest{})
+	// methodGetStorageUsage is the GetStorageUsage method.
+	methodGetStorageUsage = serviceName.NewMethod("GetStorageUsage", &GetStorageUsageRequest{})
 
 	// serviceDesc is the gRPC service descriptor.
 	serviceDesc = grpc.ServiceDesc{
@@ -30,6 +32,10 @@ var (
 				MethodName: methodForceFinalize.ShortName(),
 				Handler:    handlerForceFinalize,
 			},
+			{
+				MethodName: methodGetStorageUsage.ShortName(),
+				Handler:    handlerGetStorageUsage,
+			},
 		},
 		Streams: []grpc.StreamDesc{},
 	}
@@ -81,6 +87,29 @@ func handlerForceFinalize( // nolint: golint
 	return interceptor(ctx, rq, info, handler)
 }
 
+func handlerGetStorageUsage( // nolint: golint
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	rq := new(GetStorageUsageRequest)
+	if err := dec(rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageWorker).GetStorageUsage(ctx, rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetStorageUsage.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageWorker).GetStorageUsage(ctx, req.(*GetStorageUsageRequest))
+	}
+	return interceptor(ctx, rq, info, handler)
+}
+
 // RegisterService registers a new storage worker service with the given gRPC server.
 func RegisterService(server *grpc.Server, service StorageWorker) {
 	server.RegisterService(&serviceDesc, service)
@@ -102,6 +131,14 @@ func (c *storageWorkerClient) ForceFinalize(ctx context.Context, req *ForceFinal
 	return c.conn.Invoke(ctx, methodForceFinalize.FullName(), req, nil)
 }
 
+func (c *storageWorkerClient) GetStorageUsage(ctx context.Context, req *GetStorageUsageRequest) (*GetStorageUsageResponse, error) {
+	var rsp GetStorageUsageResponse
+	if err := c.conn.Invoke(ctx, methodGetStorageUsage.FullName(), req, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 // NewStorageWorkerClient creates a new gRPC transaction scheduler
 // client service.
 func NewStorageWorkerClient(c *grpc.ClientConn) StorageWorker {
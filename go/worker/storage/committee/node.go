@@ -12,6 +12,7 @@ import (
 	"github.com/eapache/channels"
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/accessctl"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/grpc/policy"
@@ -23,6 +24,7 @@ import (
 	registryApi "github.com/oasisprotocol/oasis-core/go/registry/api"
 	roothashApi "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	"github.com/oasisprotocol/oasis-core/go/runtime/history"
 	"github.com/oasisprotocol/oasis-core/go/runtime/nodes"
 	"github.com/oasisprotocol/oasis-core/go/runtime/nodes/grpc"
 	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
@@ -130,6 +132,21 @@ type watcherState struct {
 	LastBlock blockSummary `json:"last_block"`
 }
 
+// SetSyncedState overrides the persisted last synced round of the storage worker for the given
+// runtime, e.g. after its state has been restored from an external checkpoint.
+//
+// This must only be used while the storage worker is not running.
+func SetSyncedState(store *persistent.ServiceStore, runtimeID common.Namespace, round uint64, roots []storageApi.Root) error {
+	state := watcherState{
+		LastBlock: blockSummary{
+			Namespace: runtimeID,
+			Round:     round,
+			Roots:     roots,
+		},
+	}
+	return store.PutCBOR(runtimeID[:], &state)
+}
+
 // Node watches blocks for storage changes.
 type Node struct {
 	commonNode *committee.Node
@@ -444,6 +461,38 @@ func (n *Node) ForceFinalize(ctx context.Context, round uint64) error {
 	return n.localStorage.NodeDB().Finalize(ctx, block.Header.StorageRoots())
 }
 
+// GetStorageUsage returns the node database disk usage.
+func (n *Node) GetStorageUsage(ctx context.Context) (*api.GetStorageUsageResponse, error) {
+	ndb := n.localStorage.NodeDB()
+	provider, ok := ndb.(mkvsDB.SizeStatsProvider)
+	if !ok {
+		return nil, api.ErrSizeStatsUnsupported
+	}
+
+	diskBytes, err := ndb.Size()
+	if err != nil {
+		return nil, err
+	}
+	earliest, err := ndb.GetEarliestVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	latest, err := ndb.GetLatestVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stats := provider.SizeStats()
+
+	return &api.GetStorageUsageResponse{
+		DiskBytes:        uint64(diskBytes),
+		LiveBytes:        stats.LiveBytes,
+		ReclaimableBytes: stats.ReclaimableBytes,
+		LastCompaction:   stats.LastCompaction,
+		EarliestVersion:  earliest,
+		LatestVersion:    latest,
+	}, nil
+}
+
 func (n *Node) fetchDiff(round uint64, prevRoot, thisRoot storageApi.Root) {
 	result := &fetchedDiff{
 		fetched:  false,
@@ -1112,11 +1161,29 @@ mainLoop:
 	// context was canceled.
 }
 
+var _ history.InOrderPruneHandler = (*pruneHandler)(nil)
+
 type pruneHandler struct {
 	logger *logging.Logger
 	node   *Node
 }
 
+// PrunesInOrder marks the storage prune handler as an in-order prune handler since the local
+// storage can only prune its earliest version.
+func (p *pruneHandler) PrunesInOrder() {}
+
+func (p *pruneHandler) CanPrune(ctx context.Context, round uint64) error {
+	// Delay pruning of rounds that have not yet been synced.
+	lastSyncedRound, _, _ := p.node.GetLastSynced()
+	if round >= lastSyncedRound {
+		return history.ErrPruneDelayed
+	}
+
+	// TODO: Make sure we don't prune rounds that need to be checkpointed but haven't been yet.
+
+	return nil
+}
+
 func (p *pruneHandler) Prune(ctx context.Context, rounds []uint64) error {
 	// Make sure we never prune past what was synced.
 	lastSycnedRound, _, _ := p.node.GetLastSynced()
@@ -1128,8 +1195,6 @@ func (p *pruneHandler) Prune(ctx context.Context, rounds []uint64) error {
 			)
 		}
 
-		// TODO: Make sure we don't prune rounds that need to be checkpointed but haven't been yet.
-
 		p.logger.Debug("pruning storage for round", "round", round)
 
 		// Prune given block.
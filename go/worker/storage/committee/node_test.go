@@ -0,0 +1,148 @@
+package committee
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	"github.com/oasisprotocol/oasis-core/go/runtime/history"
+	storageApi "github.com/oasisprotocol/oasis-core/go/storage/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/database"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+)
+
+func TestPruneHandler(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		pruner history.PrunerFactory
+		// firstRound is the first round in history and storage.
+		firstRound uint64
+		// earliestRound is the earliest round that should be retained.
+		earliestRound uint64
+		// archived returns true iff the given round should be archived in history.
+		archived func(round uint64) bool
+	}{
+		{
+			name:          "KeepLast",
+			pruner:        history.NewKeepLastPruner(10),
+			firstRound:    0,
+			earliestRound: 21,
+			archived:      func(round uint64) bool { return false },
+		},
+		{
+			name:          "KeepLastArchive",
+			pruner:        history.NewKeepLastPruner(10, history.WithArchiveInterval(15)),
+			firstRound:    1,
+			earliestRound: 21,
+			archived:      func(round uint64) bool { return round%15 == 0 },
+		},
+		{
+			name:          "KeepLastArchiveGenesis",
+			pruner:        history.NewKeepLastPruner(10, history.WithArchiveInterval(15)),
+			firstRound:    0,
+			earliestRound: 21,
+			archived:      func(round uint64) bool { return round%15 == 0 },
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			testPruneHandler(t, tc.pruner, tc.firstRound, tc.earliestRound, tc.archived)
+		})
+	}
+}
+
+func testPruneHandler(
+	t *testing.T,
+	pruner history.PrunerFactory,
+	firstRound, earliestRound uint64,
+	archived func(round uint64) bool,
+) {
+	require := require.New(t)
+
+	const lastRound = 30
+	ctx := context.Background()
+	runtimeID := common.NewTestNamespaceFromSeed([]byte("storage prune handler test ns"), 0)
+
+	dataDir, err := ioutil.TempDir("", "oasis-storage-prune-handler-test_")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dataDir)
+
+	localStorage, err := database.New(&storageApi.Config{
+		Backend:      database.BackendNameBadgerDB,
+		DB:           filepath.Join(dataDir, "storage"),
+		Namespace:    runtimeID,
+		MaxCacheSize: 16 * 1024 * 1024,
+	})
+	require.NoError(err, "database.New")
+	defer localStorage.Cleanup()
+	ndb := localStorage.(storageApi.LocalBackend).NodeDB()
+
+	hist, err := history.New(dataDir, runtimeID, &history.Config{
+		Pruner:        pruner,
+		PruneInterval: time.Hour,
+	})
+	require.NoError(err, "history.New")
+	defer hist.Close()
+
+	n := &Node{
+		logger:       logging.GetLogger("worker/storage/committee/test"),
+		localStorage: localStorage.(storageApi.LocalBackend),
+	}
+	n.syncedState.LastBlock.Round = lastRound
+	hist.Pruner().RegisterHandler(&pruneHandler{
+		logger: n.logger,
+		node:   n,
+	})
+
+	// Create a finalized storage version and a block for each round.
+	for round := firstRound; round <= lastRound; round++ {
+		tree := mkvs.New(nil, ndb, node.RootTypeState)
+		err = tree.Insert(ctx, []byte("round"), []byte{byte(round)})
+		require.NoError(err, "Insert")
+		_, rootHash, cerr := tree.Commit(ctx, runtimeID, round)
+		require.NoError(cerr, "Commit")
+		tree.Close()
+
+		err = ndb.Finalize(ctx, []node.Root{{
+			Namespace: runtimeID,
+			Version:   round,
+			Type:      node.RootTypeState,
+			Hash:      rootHash,
+		}})
+		require.NoError(err, "Finalize")
+
+		blk := roothash.AnnotatedBlock{
+			Height: int64(round),
+			Block:  block.NewGenesisBlock(runtimeID, 0),
+		}
+		blk.Block.Header.Round = round
+		err = hist.Commit(&blk, nil)
+		require.NoError(err, "Commit")
+	}
+
+	err = hist.Pruner().Prune(ctx, lastRound)
+	require.NoError(err, "Prune")
+
+	// Storage must be pruned exactly up to the earliest retained round. Archived rounds only keep
+	// their blocks in history and must not stop storage from being pruned.
+	earliestVersion, err := ndb.GetEarliestVersion(ctx)
+	require.NoError(err, "GetEarliestVersion")
+	require.EqualValues(earliestRound, earliestVersion, "storage should be pruned up to the earliest retained round")
+	for round := firstRound; round <= lastRound; round++ {
+		_, err = hist.GetBlock(ctx, round)
+		if round < earliestRound && !archived(round) {
+			require.Equal(roothash.ErrNotFound, err, "GetBlock should fail for pruned block %d", round)
+		} else {
+			require.NoError(err, "GetBlock(%d)", round)
+		}
+	}
+}
@@ -48,6 +48,13 @@ func (w *crashingWrapper) SyncIterate(ctx context.Context, request *api.IterateR
 	return res, err
 }
 
+func (w *crashingWrapper) SyncGetRange(ctx context.Context, request *api.GetRangeRequest) (*api.ProofResponse, error) {
+	crash.Here(crashPointReadBefore)
+	res, err := w.Backend.SyncGetRange(ctx, request)
+	crash.Here(crashPointReadAfter)
+	return res, err
+}
+
 func (w *crashingWrapper) Apply(ctx context.Context, request *api.ApplyRequest) ([]*api.Receipt, error) {
 	crash.Here(crashPointWriteBefore)
 	res, err := w.Backend.Apply(ctx, request)
@@ -44,6 +44,12 @@ const (
 	// CfgMaxCacheSize configures the maximum in-memory cache size.
 	CfgMaxCacheSize = "worker.storage.max_cache_size"
 
+	// CfgCompactionInterval configures the online node database compaction interval.
+	CfgCompactionInterval = "worker.storage.compaction.interval"
+	// CfgCompactionOperationInterval configures the minimum interval between consecutive online
+	// node database compaction operations.
+	CfgCompactionOperationInterval = "worker.storage.compaction.operation_interval"
+
 	cfgCrashEnabled = "worker.storage.crash.enabled"
 
 	// CfgInsecureSkipChecks disables known root checks.
@@ -72,6 +78,9 @@ func NewLocalBackend(
 		InsecureSkipChecks: viper.GetBool(CfgInsecureSkipChecks) && cmdFlags.DebugDontBlameOasis(),
 		Namespace:          namespace,
 		MaxCacheSize:       int64(viper.GetSizeInBytes(CfgMaxCacheSize)),
+
+		CompactionInterval:          viper.GetDuration(CfgCompactionInterval),
+		CompactionOperationInterval: viper.GetDuration(CfgCompactionOperationInterval),
 	}
 
 	var (
@@ -79,7 +88,7 @@ func NewLocalBackend(
 		impl api.Backend
 	)
 	switch cfg.Backend {
-	case database.BackendNameBadgerDB:
+	case database.BackendNameBadgerDB, database.BackendNameBoltDB:
 		cfg.DB = GetLocalBackendDBDir(dataDir, cfg.Backend)
 		impl, err = database.New(cfg)
 	default:
@@ -107,10 +116,12 @@ func init() {
 	Flags.Bool(CfgWorkerDebugIgnoreApply, false, "Ignore Apply operations (for debugging purposes)")
 	_ = Flags.MarkHidden(CfgWorkerDebugIgnoreApply)
 
-	Flags.String(CfgBackend, database.BackendNameBadgerDB, "Storage backend")
+	Flags.String(CfgBackend, database.BackendNameBadgerDB, "Storage backend (badger, bolt)")
 	Flags.Bool(cfgCrashEnabled, false, "Enable the crashing storage wrapper")
 	Flags.Int(CfgLRUSlots, 1000, "How many LRU slots to use for Apply call locks in the MKVS tree root cache")
 	Flags.String(CfgMaxCacheSize, "64mb", "Maximum in-memory cache size")
+	Flags.Duration(CfgCompactionInterval, 5*time.Minute, "Online node database compaction interval")
+	Flags.Duration(CfgCompactionOperationInterval, 10*time.Second, "Minimum interval between online node database compaction operations (operations themselves are not throttled)")
 
 	Flags.Bool(CfgInsecureSkipChecks, false, "INSECURE: Skip known root checks")
 
@@ -76,6 +76,13 @@ func (s *storageService) SyncIterate(ctx context.Context, request *api.IterateRe
 	return s.storage.SyncIterate(ctx, request)
 }
 
+func (s *storageService) SyncGetRange(ctx context.Context, request *api.GetRangeRequest) (*api.ProofResponse, error) {
+	if err := s.ensureInitialized(ctx); err != nil {
+		return nil, err
+	}
+	return s.storage.SyncGetRange(ctx, request)
+}
+
 func (s *storageService) Apply(ctx context.Context, request *api.ApplyRequest) ([]*api.Receipt, error) {
 	if err := s.ensureInitialized(ctx); err != nil {
 		return nil, err
@@ -30,3 +30,12 @@ func (w *Worker) ForceFinalize(ctx context.Context, request *api.ForceFinalizeRe
 
 	return node.ForceFinalize(ctx, request.Round)
 }
+
+func (w *Worker) GetStorageUsage(ctx context.Context, request *api.GetStorageUsageRequest) (*api.GetStorageUsageResponse, error) {
+	node := w.runtimes[request.RuntimeID]
+	if node == nil {
+		return nil, api.ErrRuntimeNotFound
+	}
+
+	return node.GetStorageUsage(ctx)
+}
@@ -248,3 +248,17 @@ func (s *Worker) Cleanup() {
 func (s *Worker) GetRuntime(id common.Namespace) *committee.Node {
 	return s.runtimes[id]
 }
+
+// SetSyncedState overrides the last synced round of the storage worker for the given runtime in
+// the node's common store.
+//
+// This must only be used while the node is not running.
+func SetSyncedState(commonStore *persistent.CommonStore, runtimeID common.Namespace, round uint64, roots []api.Root) error {
+	store, err := commonStore.GetServiceStore(workerStorageDBBucketName)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	return committee.SetSyncedState(store, runtimeID, round, roots)
+}
@@ -1,15 +1,23 @@
 /// Protocol and runtime versioning.
 
 // NOTE: This should be kept in sync with go/common/version/version.go.
+use serde::{Deserialize, Serialize};
 
 /// A protocol or runtime version.
-#[derive(Clone, Copy, Debug)]
+#[derive(Clone, Copy, Debug, Default, PartialEq, Eq, Hash, Serialize, Deserialize)]
 pub struct Version {
+    #[serde(default, skip_serializing_if = "is_zero")]
     pub major: u16,
+    #[serde(default, skip_serializing_if = "is_zero")]
     pub minor: u16,
+    #[serde(default, skip_serializing_if = "is_zero")]
     pub patch: u16,
 }
 
+fn is_zero(v: &u16) -> bool {
+    *v == 0
+}
+
 #[macro_export]
 macro_rules! version_from_cargo {
     () => {
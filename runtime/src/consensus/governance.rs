@@ -0,0 +1,26 @@
+//! Consensus governance structures.
+//!
+//! # Note
+//!
+//! This **MUST** be kept in sync with go/governance/api.
+//!
+use serde::{Deserialize, Serialize};
+use serde_repr::*;
+
+/// A governance vote.
+#[derive(Clone, Debug, PartialEq, Eq, Hash, Serialize_repr, Deserialize_repr)]
+#[repr(u8)]
+pub enum Vote {
+    Yes = 1,
+    No = 2,
+    Abstain = 3,
+}
+
+/// A vote for a proposal.
+#[derive(Clone, Debug, PartialEq, Eq, Hash, Serialize, Deserialize)]
+pub struct ProposalVote {
+    /// Unique identifier of a proposal.
+    pub id: u64,
+    /// Vote.
+    pub vote: Vote,
+}
@@ -1,6 +1,8 @@
 //! Consensus service interfaces.
 
 pub mod address;
+pub mod governance;
 pub mod registry;
 pub mod roothash;
+pub mod scheduler;
 pub mod staking;
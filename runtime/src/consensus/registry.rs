@@ -4,11 +4,300 @@
 //!
 //! This **MUST** be kept in sync with go/registry/api.
 //!
+use std::collections::BTreeMap;
+
 use crate::{
-    common::crypto::{hash, signature::SignatureBundle},
+    common::{
+        crypto::{
+            hash,
+            signature::{PublicKey, SignatureBundle},
+        },
+        namespace::Namespace,
+        quantity::Quantity,
+        version::Version,
+    },
+    consensus::{scheduler, staking},
     storage::mkvs::WriteLog,
 };
 use serde::{Deserialize, Serialize};
+use serde_repr::*;
+
+/// Runtime kind.
+#[derive(Clone, Debug, PartialEq, Eq, Hash, Serialize_repr, Deserialize_repr)]
+#[repr(u32)]
+pub enum RuntimeKind {
+    /// Invalid runtime that should never be explicitly set.
+    KindInvalid = 0,
+    /// Generic compute runtime.
+    KindCompute = 1,
+    /// Key manager runtime.
+    KindKeyManager = 2,
+}
+
+impl Default for RuntimeKind {
+    fn default() -> Self {
+        RuntimeKind::KindInvalid
+    }
+}
+
+/// TEE hardware implementation.
+#[derive(Clone, Debug, PartialEq, Eq, Hash, Serialize_repr, Deserialize_repr)]
+#[repr(u8)]
+pub enum TEEHardware {
+    /// Non-TEE implementation.
+    TEEHardwareInvalid = 0,
+    /// Intel SGX TEE implementation.
+    TEEHardwareIntelSGX = 1,
+}
+
+impl Default for TEEHardware {
+    fn default() -> Self {
+        TEEHardware::TEEHardwareInvalid
+    }
+}
+
+/// Runtime governance model.
+#[derive(Clone, Debug, PartialEq, Eq, Hash, Serialize_repr, Deserialize_repr)]
+#[repr(u8)]
+pub enum RuntimeGovernanceModel {
+    /// Invalid model that should never be explicitly set.
+    GovernanceInvalid = 0,
+    /// Entity governance model.
+    GovernanceEntity = 1,
+    /// Runtime governance model.
+    GovernanceRuntime = 2,
+    /// Consensus governance model.
+    GovernanceConsensus = 3,
+}
+
+impl Default for RuntimeGovernanceModel {
+    fn default() -> Self {
+        RuntimeGovernanceModel::GovernanceInvalid
+    }
+}
+
+/// Parameters for the executor committee.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, Serialize, Deserialize)]
+pub struct ExecutorParameters {
+    /// Size of the committee.
+    pub group_size: u64,
+    /// Size of the discrepancy resolution group.
+    pub group_backup_size: u64,
+    /// Number of allowed stragglers.
+    pub allowed_stragglers: u64,
+    /// Round timeout in consensus blocks.
+    pub round_timeout: i64,
+    /// Maximum number of messages that can be emitted by the runtime in a single round.
+    pub max_messages: u32,
+    /// Minimum required candidate compute node pool size.
+    pub min_pool_size: u64,
+    /// Committee election algorithm. If not specified, the uniform election algorithm is used.
+    #[serde(default, skip_serializing_if = "String::is_empty")]
+    pub election_algorithm: String,
+}
+
+/// Parameters for the runtime transaction scheduler.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, Serialize, Deserialize)]
+pub struct TxnSchedulerParameters {
+    /// Transaction scheduling algorithm.
+    pub algorithm: String,
+    /// How long to wait for a scheduled batch in nanoseconds (when using the "simple" algorithm).
+    pub batch_flush_timeout: i64,
+    /// Maximum size of a scheduled batch.
+    pub max_batch_size: u64,
+    /// Maximum size of a scheduled batch in bytes.
+    pub max_batch_size_bytes: u64,
+    /// Timeout (in consensus blocks) for the scheduler to propose a batch.
+    pub propose_batch_timeout: i64,
+}
+
+/// Parameters for the storage committee.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, Serialize, Deserialize)]
+pub struct StorageParameters {
+    /// Size of the storage group.
+    pub group_size: u64,
+    /// Number of nodes to which any writes must be replicated before being assumed to be
+    /// committed. It must be less than or equal to the group_size.
+    pub min_write_replication: u64,
+    /// Maximum number of write log entries when performing an Apply operation.
+    pub max_apply_write_log_entries: u64,
+    /// Maximum number of apply operations in a batch.
+    pub max_apply_ops: u64,
+    /// Expected runtime state checkpoint interval (in rounds).
+    pub checkpoint_interval: u64,
+    /// Expected minimum number of checkpoints to keep.
+    pub checkpoint_num_kept: u64,
+    /// Chunk size parameter for checkpoint creation.
+    pub checkpoint_chunk_size: u64,
+    /// Minimum required candidate storage node pool size.
+    pub min_pool_size: u64,
+    /// Committee election algorithm. If not specified, the uniform election algorithm is used.
+    #[serde(default, skip_serializing_if = "String::is_empty")]
+    pub election_algorithm: String,
+}
+
+/// Admission policy that allows any node to register.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, Serialize, Deserialize)]
+pub struct AnyNodeRuntimeAdmissionPolicy {}
+
+/// Per-entity configuration of the entity whitelist admission policy.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, Serialize, Deserialize)]
+pub struct EntityWhitelistConfig {
+    /// Maximum number of nodes that an entity can register under the given runtime for a
+    /// specific role (keyed by the node roles mask). If the map is empty or absent, the number of
+    /// nodes is unlimited.
+    #[serde(default, skip_serializing_if = "BTreeMap::is_empty")]
+    pub max_nodes: BTreeMap<u32, u16>,
+}
+
+/// Admission policy that allows only whitelisted entities' nodes to register.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, Serialize, Deserialize)]
+pub struct EntityWhitelistRuntimeAdmissionPolicy {
+    pub entities: BTreeMap<PublicKey, EntityWhitelistConfig>,
+}
+
+/// Admission policy that allows only nodes of entities with enough stake to register.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, Serialize, Deserialize)]
+pub struct EntityStakeThresholdRuntimeAdmissionPolicy {
+    /// Minimum active escrow balance that the node's entity must have.
+    pub min_escrow: Quantity,
+}
+
+/// Admission policy that allows only whitelisted nodes to register.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, Serialize, Deserialize)]
+pub struct NodeWhitelistRuntimeAdmissionPolicy {
+    pub nodes: BTreeMap<PublicKey, bool>,
+}
+
+/// Specification of which nodes are allowed to register for a runtime.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, Serialize, Deserialize)]
+pub struct RuntimeAdmissionPolicy {
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub any_node: Option<AnyNodeRuntimeAdmissionPolicy>,
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub entity_whitelist: Option<EntityWhitelistRuntimeAdmissionPolicy>,
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub entity_stake_threshold: Option<EntityStakeThresholdRuntimeAdmissionPolicy>,
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub node_whitelist: Option<NodeWhitelistRuntimeAdmissionPolicy>,
+}
+
+/// Stake-related parameters for a runtime.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, Serialize, Deserialize)]
+pub struct RuntimeStakingParameters {
+    /// Minimum stake thresholds for a runtime. These per-runtime thresholds are in addition to
+    /// the global thresholds. May be left unspecified.
+    #[serde(default, skip_serializing_if = "BTreeMap::is_empty")]
+    pub thresholds: BTreeMap<staking::ThresholdKind, Quantity>,
+    /// Per-runtime misbehavior slashing parameters.
+    #[serde(default, skip_serializing_if = "BTreeMap::is_empty")]
+    pub slashing: BTreeMap<staking::SlashReason, staking::Slash>,
+    /// Percentage of the reward obtained when slashing for equivocation that is transferred to
+    /// the runtime's account.
+    #[serde(default, skip_serializing_if = "num_traits::Zero::is_zero")]
+    pub reward_equivocation: u8,
+    /// Percentage of the reward obtained when slashing for incorrect results that is transferred
+    /// to the runtime's account.
+    #[serde(default, skip_serializing_if = "num_traits::Zero::is_zero")]
+    pub reward_bad_results: u8,
+    /// Whether the runtime is allowed to perform AddEscrow and ReclaimEscrow staking operations
+    /// via runtime messages.
+    #[serde(default, skip_serializing_if = "std::ops::Not::not")]
+    pub allow_escrow_messages: bool,
+    /// Maximum number of allowances the runtime's account can have configured via Allow staking
+    /// operations performed by runtime messages. Zero disables such operations.
+    #[serde(default, skip_serializing_if = "num_traits::Zero::is_zero")]
+    pub max_allowances: u32,
+}
+
+impl RuntimeStakingParameters {
+    /// Whether all of the staking parameters are unset.
+    pub fn is_empty(&self) -> bool {
+        *self == Self::default()
+    }
+}
+
+/// Constraint specifying that the entity must have a node that is part of the validator set.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, Serialize, Deserialize)]
+pub struct ValidatorSetConstraint {}
+
+/// Constraint specifying that only the given number of nodes may be eligible per entity.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, Serialize, Deserialize)]
+pub struct MaxNodesConstraint {
+    pub limit: u16,
+}
+
+/// Constraint specifying the minimum required candidate pool size.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, Serialize, Deserialize)]
+pub struct MinPoolSizeConstraint {
+    pub limit: u16,
+}
+
+/// Node scheduling constraints.
+///
+/// Multiple fields may be set in which case ALL the constraints must be satisfied.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, Serialize, Deserialize)]
+pub struct SchedulingConstraints {
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub validator_set: Option<ValidatorSetConstraint>,
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub max_nodes: Option<MaxNodesConstraint>,
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub min_pool_size: Option<MinPoolSizeConstraint>,
+}
+
+/// Runtime version information.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, Serialize, Deserialize)]
+pub struct VersionInfo {
+    /// Version of the runtime.
+    pub version: Version,
+    /// Enclave version information, in an enclave provider specific format if any.
+    #[serde(default, with = "serde_bytes", skip_serializing_if = "Vec::is_empty")]
+    pub tee: Vec<u8>,
+}
+
+/// Runtime.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, Serialize, Deserialize)]
+pub struct Runtime {
+    /// Structure version.
+    pub v: u16,
+    /// Globally unique long term identifier of the runtime.
+    pub id: Namespace,
+    /// Public key identifying the entity controlling the runtime.
+    pub entity_id: PublicKey,
+    /// Runtime genesis information.
+    pub genesis: RuntimeGenesis,
+    /// Type of runtime.
+    pub kind: RuntimeKind,
+    /// Runtime's TEE hardware requirements.
+    pub tee_hardware: TEEHardware,
+    /// Runtime version information.
+    #[serde(rename = "versions")]
+    pub version: VersionInfo,
+    /// Key manager runtime ID for this runtime.
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub key_manager: Option<Namespace>,
+    /// Parameters of the executor committee.
+    #[serde(default)]
+    pub executor: ExecutorParameters,
+    /// Transaction scheduling parameters of the executor committee.
+    #[serde(default)]
+    pub txn_scheduler: TxnSchedulerParameters,
+    /// Parameters of the storage committee.
+    #[serde(default)]
+    pub storage: StorageParameters,
+    /// Which nodes are allowed to register for this runtime.
+    pub admission_policy: RuntimeAdmissionPolicy,
+    /// Runtime's staking-related parameters.
+    #[serde(default, skip_serializing_if = "RuntimeStakingParameters::is_empty")]
+    pub staking: RuntimeStakingParameters,
+    /// Node scheduling constraints.
+    #[serde(default, skip_serializing_if = "BTreeMap::is_empty")]
+    pub constraints:
+        BTreeMap<scheduler::CommitteeKind, BTreeMap<scheduler::Role, SchedulingConstraints>>,
+    /// Runtime governance model.
+    pub governance_model: RuntimeGovernanceModel,
+}
 
 /// Runtime genesis information that is used to initialize runtime state in the first block.
 #[derive(Clone, Debug, Default, PartialEq, Eq, Hash, Serialize, Deserialize)]
@@ -13,7 +13,7 @@ use crate::{
         crypto::{hash::Hash, signature::SignatureBundle},
         namespace::Namespace,
     },
-    consensus::staking,
+    consensus::{governance, registry, staking},
 };
 
 /// Runtime block.
@@ -62,6 +62,18 @@ pub enum Message {
         #[serde(flatten)]
         msg: StakingMessage,
     },
+    #[serde(rename = "registry")]
+    Registry {
+        v: u16,
+        #[serde(flatten)]
+        msg: RegistryMessage,
+    },
+    #[serde(rename = "governance")]
+    Governance {
+        v: u16,
+        #[serde(flatten)]
+        msg: GovernanceMessage,
+    },
 }
 
 impl Message {
@@ -81,6 +93,24 @@ pub enum StakingMessage {
     Transfer(staking::Transfer),
     #[serde(rename = "withdraw")]
     Withdraw(staking::Withdraw),
+    #[serde(rename = "add_escrow")]
+    AddEscrow(staking::Escrow),
+    #[serde(rename = "reclaim_escrow")]
+    ReclaimEscrow(staking::ReclaimEscrow),
+    #[serde(rename = "allow")]
+    Allow(staking::Allow),
+}
+
+#[derive(Clone, Debug, PartialEq, Eq, Hash, Serialize, Deserialize)]
+pub enum RegistryMessage {
+    #[serde(rename = "update_runtime")]
+    UpdateRuntime(registry::Runtime),
+}
+
+#[derive(Clone, Debug, PartialEq, Eq, Hash, Serialize, Deserialize)]
+pub enum GovernanceMessage {
+    #[serde(rename = "cast_vote")]
+    CastVote(governance::ProposalVote),
 }
 
 /// Result of a message being processed by the consensus layer.
@@ -168,6 +198,7 @@ impl ComputeResultsHeader {
 #[cfg(test)]
 mod tests {
     use super::*;
+    use crate::common::version::Version;
 
     #[test]
     fn test_consistent_hash_header() {
@@ -240,6 +271,84 @@ mod tests {
                 }],
                 "069b0fda76d804e3fd65d4bbd875c646f15798fb573ac613100df67f5ba4c3fd",
             ),
+            (
+                vec![Message::Staking {
+                    v: 0,
+                    msg: StakingMessage::AddEscrow(staking::Escrow::default()),
+                }],
+                "65049870b9dae657390e44065df0c78176816876e67b96dac7791ee6a1aa42e2",
+            ),
+            (
+                vec![Message::Staking {
+                    v: 0,
+                    msg: StakingMessage::ReclaimEscrow(staking::ReclaimEscrow::default()),
+                }],
+                "c78547eae2f104268e49827cbe624cf2b350ee59e8d693dec0673a70a4664a2e",
+            ),
+            (
+                vec![Message::Staking {
+                    v: 0,
+                    msg: StakingMessage::Allow(staking::Allow::default()),
+                }],
+                "b89c2537bfbf134ec3e567ae332480912e8af5b6a00ba5c7c5e7e6c56cfc1d64",
+            ),
+            (
+                vec![Message::Registry {
+                    v: 0,
+                    msg: RegistryMessage::UpdateRuntime(registry::Runtime {
+                        v: 2,
+                        kind: registry::RuntimeKind::KindCompute,
+                        version: registry::VersionInfo {
+                            version: Version::new(1, 2, 0),
+                            ..Default::default()
+                        },
+                        executor: registry::ExecutorParameters {
+                            group_size: 3,
+                            group_backup_size: 5,
+                            allowed_stragglers: 1,
+                            round_timeout: 10,
+                            max_messages: 32,
+                            min_pool_size: 8,
+                            ..Default::default()
+                        },
+                        txn_scheduler: registry::TxnSchedulerParameters {
+                            algorithm: "simple".to_string(),
+                            batch_flush_timeout: 1_000_000_000, // 1 second.
+                            max_batch_size: 1000,
+                            max_batch_size_bytes: 16 * 1024 * 1024,
+                            propose_batch_timeout: 5,
+                        },
+                        storage: registry::StorageParameters {
+                            group_size: 1,
+                            min_write_replication: 1,
+                            max_apply_write_log_entries: 100_000,
+                            max_apply_ops: 2,
+                            ..Default::default()
+                        },
+                        admission_policy: registry::RuntimeAdmissionPolicy {
+                            any_node: Some(registry::AnyNodeRuntimeAdmissionPolicy {}),
+                            ..Default::default()
+                        },
+                        staking: registry::RuntimeStakingParameters {
+                            max_allowances: 10,
+                            ..Default::default()
+                        },
+                        governance_model: registry::RuntimeGovernanceModel::GovernanceRuntime,
+                        ..Default::default()
+                    }),
+                }],
+                "58c6d0569b27b7e25e54935fb921c2d50ab9bad0912aeb1a3cd0e674c1f66bcc",
+            ),
+            (
+                vec![Message::Governance {
+                    v: 0,
+                    msg: GovernanceMessage::CastVote(governance::ProposalVote {
+                        id: 32,
+                        vote: governance::Vote::Yes,
+                    }),
+                }],
+                "f45e26eb8ace807ad5bd02966cde1f012d1d978d4cbddd59e9bfd742dcf39b90",
+            ),
         ];
         for (msgs, expected_hash) in tcs {
             assert_eq!(Message::messages_hash(&msgs), Hash::from(expected_hash));
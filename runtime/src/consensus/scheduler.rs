@@ -0,0 +1,31 @@
+//! Scheduler structures.
+//!
+//! # Note
+//!
+//! This **MUST** be kept in sync with go/scheduler/api.
+//!
+use serde_repr::*;
+
+/// The functionality a committee exists to provide.
+#[derive(Clone, Debug, PartialEq, Eq, PartialOrd, Ord, Hash, Serialize_repr, Deserialize_repr)]
+#[repr(u8)]
+pub enum CommitteeKind {
+    /// An invalid committee.
+    Invalid = 0,
+    /// An executor committee.
+    ComputeExecutor = 1,
+    /// A storage committee.
+    Storage = 2,
+}
+
+/// The role a given node plays in a committee.
+#[derive(Clone, Debug, PartialEq, Eq, PartialOrd, Ord, Hash, Serialize_repr, Deserialize_repr)]
+#[repr(u8)]
+pub enum Role {
+    /// An invalid role (should never appear on the wire).
+    Invalid = 0,
+    /// Indicates the node is a worker.
+    Worker = 1,
+    /// Indicates the node is a backup worker.
+    BackupWorker = 2,
+}
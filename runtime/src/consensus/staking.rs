@@ -5,6 +5,7 @@
 //! This **MUST** be kept in sync with go/staking/api.
 //!
 use serde::{Deserialize, Serialize};
+use serde_repr::*;
 
 use crate::{common::quantity::Quantity, consensus::address::Address};
 
@@ -21,3 +22,74 @@ pub struct Withdraw {
     pub from: Address,
     pub amount: Quantity,
 }
+
+/// A stake escrow.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, Serialize, Deserialize)]
+pub struct Escrow {
+    pub account: Address,
+    pub amount: Quantity,
+}
+
+/// A reclaim escrow.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, Serialize, Deserialize)]
+pub struct ReclaimEscrow {
+    pub account: Address,
+    pub shares: Quantity,
+}
+
+/// A beneficiary allowance configuration.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, Serialize, Deserialize)]
+pub struct Allow {
+    pub beneficiary: Address,
+    #[serde(default, skip_serializing_if = "std::ops::Not::not")]
+    pub negative: bool,
+    pub amount_change: Quantity,
+}
+
+/// Kind of staking threshold.
+#[derive(Clone, Debug, PartialEq, Eq, PartialOrd, Ord, Hash, Serialize_repr, Deserialize_repr)]
+#[repr(i32)]
+pub enum ThresholdKind {
+    /// Entity registration threshold.
+    KindEntity = 0,
+    /// Validator node registration threshold.
+    KindNodeValidator = 1,
+    /// Compute node registration threshold.
+    KindNodeCompute = 2,
+    /// Storage node registration threshold.
+    KindNodeStorage = 3,
+    /// Key manager node registration threshold.
+    KindNodeKeyManager = 4,
+    /// Compute runtime registration threshold.
+    KindRuntimeCompute = 5,
+    /// Key manager runtime registration threshold.
+    KindRuntimeKeyManager = 6,
+}
+
+/// Reason for slashing an entity.
+#[derive(Clone, Debug, PartialEq, Eq, PartialOrd, Ord, Hash, Serialize_repr, Deserialize_repr)]
+#[repr(u8)]
+pub enum SlashReason {
+    /// Slashing due to consensus equivocation.
+    ConsensusEquivocation = 0x00,
+    /// Slashing due to invalid commit behavior.
+    BeaconInvalidCommit = 0x01,
+    /// Slashing due to invalid reveal behavior.
+    BeaconInvalidReveal = 0x02,
+    /// Slashing due to nonparticipation.
+    BeaconNonparticipation = 0x03,
+    /// Slashing due to light client attacks.
+    ConsensusLightClientAttack = 0x04,
+    /// Slashing due to submission of incorrect results in runtime executor commitments.
+    RuntimeIncorrectResults = 0x80,
+    /// Slashing due to signing two different executor commits or proposed batches for the same
+    /// round.
+    RuntimeEquivocation = 0x81,
+}
+
+/// Slashing configuration for a specific reason.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, Serialize, Deserialize)]
+pub struct Slash {
+    pub amount: Quantity,
+    pub freeze_interval: u64,
+}
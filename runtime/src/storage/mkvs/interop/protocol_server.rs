@@ -4,19 +4,27 @@
 //! This should only be used for testing.
 use std::{
     any::Any,
-    process::{Child, Command},
+    io::Write,
+    process::{Child, Command, Stdio},
     sync::Arc,
 };
 
-use anyhow::Result;
+use anyhow::{anyhow, Result};
 use grpcio::{ChannelBuilder, EnvBuilder};
 use io_context::Context;
+use rustc_hex::ToHex;
+use serde::Deserialize;
+use serde_bytes;
 use tempfile::{self, TempDir};
 
 use super::{rpc, Driver};
 use crate::{
-    common::{crypto::hash::Hash, namespace::Namespace},
-    storage::mkvs::{sync::*, tree::RootType, WriteLog},
+    common::{cbor, crypto::hash::Hash, namespace::Namespace},
+    storage::mkvs::{
+        sync::*,
+        tree::{RootType, Value},
+        WriteLog,
+    },
 };
 
 /// Location of the protocol server binary.
@@ -34,6 +42,15 @@ struct ProtocolServerReadSyncer {
     client: rpc::StorageClient,
 }
 
+/// Result of verifying a get proof by the protocol server.
+#[derive(Deserialize)]
+struct VerifyGetProofResult {
+    present: bool,
+    #[serde(with = "serde_bytes")]
+    value: Option<Vec<u8>>,
+    incomplete: bool,
+}
+
 impl ProtocolServer {
     /// Create a new protocol server for testing.
     pub fn new() -> Self {
@@ -74,6 +91,49 @@ impl ProtocolServer {
             client: self.client.clone(),
         })
     }
+
+    /// Verify a get proof for the given key using the Go proof verifier and
+    /// return the value of the key in case it is present in the tree.
+    ///
+    /// Like `ProofVerifier::verify_get_proof`, this returns
+    /// `SyncerError::IncompleteProof` in case the proof does not contain the
+    /// nodes needed to determine whether the key is present.
+    pub fn verify_get_proof(
+        &self,
+        root: Hash,
+        proof: &Proof,
+        key: &[u8],
+    ) -> Result<Option<Value>> {
+        let mut verifier = Command::new(PROTOCOL_SERVER_BINARY)
+            .arg("verify-get-proof")
+            .arg("--root")
+            .arg(format!("{:x}", root))
+            .arg("--key")
+            .arg(key.to_hex::<String>())
+            .stdin(Stdio::piped())
+            .stdout(Stdio::piped())
+            .stderr(Stdio::piped())
+            .spawn()?;
+        verifier
+            .stdin
+            .take()
+            .expect("verifier stdin should be piped")
+            .write_all(&cbor::to_vec(proof))?;
+
+        let output = verifier.wait_with_output()?;
+        if !output.status.success() {
+            return Err(anyhow!("{}", String::from_utf8_lossy(&output.stderr).trim_end()));
+        }
+
+        let result: VerifyGetProofResult = cbor::from_slice(&output.stdout)?;
+        if result.incomplete {
+            return Err(SyncerError::IncompleteProof.into());
+        }
+        if !result.present {
+            return Ok(None);
+        }
+        Ok(Some(result.value.unwrap_or_default()))
+    }
 }
 
 impl Drop for ProtocolServer {
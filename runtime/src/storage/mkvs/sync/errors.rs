@@ -4,4 +4,6 @@ use thiserror::Error;
 pub enum SyncerError {
     #[error("mkvs: method not supported")]
     Unsupported,
+    #[error("verifier: incomplete proof")]
+    IncompleteProof,
 }
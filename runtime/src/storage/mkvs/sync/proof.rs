@@ -8,7 +8,7 @@ use serde_bytes;
 
 use crate::{
     common::crypto::hash::Hash,
-    storage::mkvs::{marshal::Marshal, tree::*},
+    storage::mkvs::{marshal::Marshal, sync::SyncerError, tree::*},
 };
 
 /// Proof entry type for full nodes.
@@ -96,6 +96,71 @@ impl ProofVerifier {
         Ok(root_node)
     }
 
+    /// Verify a proof returned by `sync_get` for the given key and return the
+    /// value of the key in case it is present in the tree.
+    ///
+    /// If the proof is valid but does not contain the nodes needed to determine
+    /// whether the key is present, `SyncerError::IncompleteProof` is returned so
+    /// that absence can be distinguished from proofs that do not prove anything
+    /// about the key.
+    pub fn verify_get_proof(
+        &self,
+        ctx: Context,
+        root: Hash,
+        proof: &Proof,
+        key: &[u8],
+    ) -> Result<Option<Value>> {
+        let root_ptr = self.verify_proof(ctx, root, proof)?;
+        self._lookup_verified(root_ptr, 0, &key.to_vec())
+    }
+
+    fn _lookup_verified(
+        &self,
+        ptr: NodePtrRef,
+        bit_depth: Depth,
+        key: &Key,
+    ) -> Result<Option<Value>> {
+        let ptr = ptr.borrow();
+        if ptr.is_null() {
+            // Reached a nil node, there is nothing here.
+            return Ok(None);
+        }
+        let node_ref = match ptr.node {
+            Some(ref node_ref) => node_ref.clone(),
+            None => return Err(SyncerError::IncompleteProof.into()),
+        };
+
+        let node = node_ref.borrow();
+        match *node {
+            NodeBox::Internal(ref n) => {
+                let bit_length = bit_depth + n.label_bit_length;
+
+                // Does lookup key end here? Look into LeafNode.
+                if key.bit_length() == bit_length {
+                    return self._lookup_verified(n.leaf_node.clone(), bit_length, key);
+                }
+                // Lookup key is too short for the current n.Label. It's not stored.
+                if key.bit_length() < bit_length {
+                    return Ok(None);
+                }
+                // Continue recursively based on a bit value.
+                if key.get_bit(bit_length) {
+                    self._lookup_verified(n.right.clone(), bit_length, key)
+                } else {
+                    self._lookup_verified(n.left.clone(), bit_length, key)
+                }
+            }
+            NodeBox::Leaf(ref n) => {
+                // Reached a leaf node, check if key matches.
+                if n.key == *key {
+                    Ok(Some(n.value.clone()))
+                } else {
+                    Ok(None)
+                }
+            }
+        }
+    }
+
     fn _verify_proof(&self, proof: &Proof, idx: usize) -> Result<(usize, NodePtrRef)> {
         if idx >= proof.entries.len() {
             return Err(anyhow!("verifier: malformed proof"));
@@ -242,4 +307,52 @@ mod test {
             "verify proof should fail with invalid proof"
         );
     }
+
+    #[test]
+    fn test_get_proof() {
+        // Test vector generated by Go.
+        let test_vector_proof = base64::decode(
+            "omdlbnRyaWVziVIBAQAAAAAAAAAAJABrZXkgMAJOAQEAAAAAAAAAAAEAAAJYIQLfcbr2Zv0eZpMHlih4wq2kOBFhVcnJrZxX6NcwiYk7r\
+04BAQAAAAAAAAAAAQCAAk4BAQAAAAAAAAAAAQAAAlghAlImlvtsnJQ6lOCA4YQBRmexxWoKSr0a10Y1OPnPlY1cWBwBAAAAAAAAAAAABQBrZX\
+kgNQcAAAB2YWx1ZSA1WCEC8CQfesB8lsf0rCb5Mrp0YA/N1U9Q2hg82PqXARPihDBYIQLZzIpAXexuvGnJTOuNKNNvOlZxv9LkicEk7DQhZyGf\
+0251bnRydXN0ZWRfcm9vdFggXrnnP51cnrhAJ3Ph7r28TVrMzqfGel3Okf+MTqHVzKw=",
+        ).unwrap();
+        let test_vector_root_hash =
+            "5eb9e73f9d5c9eb8402773e1eebdbc4d5acccea7c67a5dce91ff8c4ea1d5ccac";
+
+        // Proof should decode.
+        let proof: Proof = cbor::from_slice(&test_vector_proof).expect("proof should deserialize");
+        let root_hash = Hash::from(test_vector_root_hash);
+
+        // Proof should prove absence of the key it was generated for.
+        let pv = ProofVerifier;
+        let value = pv
+            .verify_get_proof(Context::background(), root_hash, &proof, b"key 55")
+            .expect("verify get proof should not fail with a valid proof");
+        assert_eq!(value, None, "key should not be present");
+
+        // Proof should also prove presence of keys on the same path.
+        let value = pv
+            .verify_get_proof(Context::background(), root_hash, &proof, b"key 5")
+            .expect("verify get proof should not fail with a valid proof");
+        assert_eq!(value, Some(b"value 5".to_vec()), "key should be present");
+
+        // Proof should not prove anything about keys it does not cover.
+        let result = pv.verify_get_proof(Context::background(), root_hash, &proof, b"key 0");
+        match result {
+            Err(err) => match err.downcast_ref::<SyncerError>() {
+                Some(SyncerError::IncompleteProof) => {}
+                _ => panic!("verify get proof should fail with an incomplete proof error"),
+            },
+            Ok(_) => panic!("verify get proof should fail with a proof for a different key"),
+        }
+
+        // Different root.
+        let bogus_hash = Hash::digest_bytes(b"i am a bogus hash");
+        let result = pv.verify_get_proof(Context::background(), bogus_hash, &proof, b"key 55");
+        assert!(
+            result.is_err(),
+            "verify get proof should fail with a proof for a different root"
+        );
+    }
 }
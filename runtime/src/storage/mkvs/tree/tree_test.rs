@@ -728,6 +728,121 @@ fn test_syncer_basic() {
     assert_eq!(0, stats.sync_iterate_count, "sync_iterate count");
 }
 
+#[test]
+fn test_syncer_get_proof() {
+    let server = ProtocolServer::new();
+
+    let mut tree = OverlayTree::new(
+        Tree::make()
+            .with_capacity(0, 0)
+            .with_root_type(RootType::State)
+            .new(Box::new(NoopReadSyncer)),
+    );
+
+    let (keys, values) = generate_key_value_pairs();
+    for i in 0..keys.len() {
+        tree.insert(
+            Context::background(),
+            keys[i].as_slice(),
+            values[i].as_slice(),
+        )
+        .expect("insert");
+    }
+
+    let (write_log, hash) = tree
+        .commit_both(Context::background(), Default::default(), 0)
+        .expect("commit");
+    assert_eq!(format!("{:?}", hash), ALL_ITEMS_ROOT);
+
+    server.apply(&write_log, hash, Default::default(), 0);
+
+    // Fetch proofs generated by the Go implementation and verify them. The Go
+    // verifier should agree with the results on proofs encoded by Rust.
+    let root = Root {
+        root_type: RootType::State,
+        hash,
+        ..Default::default()
+    };
+    let mut rs = server.read_sync();
+    let mut get_proof = |key: &[u8]| -> Proof {
+        rs.sync_get(
+            Context::background(),
+            GetRequest {
+                tree: TreeID {
+                    root,
+                    position: hash,
+                },
+                key: key.to_vec(),
+                include_siblings: false,
+            },
+        )
+        .expect("sync_get")
+        .proof
+    };
+    let pv = ProofVerifier;
+
+    for i in (0..keys.len()).step_by(100) {
+        let proof = get_proof(keys[i].as_slice());
+        let value = pv
+            .verify_get_proof(Context::background(), hash, &proof, keys[i].as_slice())
+            .expect("verify_get_proof");
+        assert_eq!(Some(values[i].clone()), value, "key should be present");
+
+        let go_value = server
+            .verify_get_proof(hash, &proof, keys[i].as_slice())
+            .expect("go verify_get_proof");
+        assert_eq!(value, go_value, "go verifier should agree on present key");
+    }
+
+    for key in &[
+        b"key 1000".to_vec(),
+        b"key".to_vec(),
+        b"foo".to_vec(),
+        b"zzz".to_vec(),
+        vec![],
+    ] {
+        let proof = get_proof(key.as_slice());
+        let value = pv
+            .verify_get_proof(Context::background(), hash, &proof, key.as_slice())
+            .expect("verify_get_proof");
+        assert_eq!(None, value, "key should not be present");
+
+        let go_value = server
+            .verify_get_proof(hash, &proof, key.as_slice())
+            .expect("go verify_get_proof");
+        assert_eq!(value, go_value, "go verifier should agree on absent key");
+    }
+
+    // Neither verifier should prove anything about keys not covered by the proof.
+    let proof = get_proof(keys[0].as_slice());
+    for result in vec![
+        pv.verify_get_proof(Context::background(), hash, &proof, keys[500].as_slice()),
+        server.verify_get_proof(hash, &proof, keys[500].as_slice()),
+    ] {
+        match result {
+            Err(err) => match err.downcast_ref::<SyncerError>() {
+                Some(SyncerError::IncompleteProof) => {}
+                _ => panic!("verify get proof should fail with an incomplete proof error"),
+            },
+            Ok(_) => panic!("verify get proof should fail with a proof for a different key"),
+        }
+    }
+
+    // Neither verifier should accept a proof for a different root.
+    let bogus_hash = Hash::digest_bytes(b"i am a bogus hash");
+    assert!(
+        pv.verify_get_proof(Context::background(), bogus_hash, &proof, keys[0].as_slice())
+            .is_err(),
+        "verify get proof should fail with a proof for a different root"
+    );
+    assert!(
+        server
+            .verify_get_proof(bogus_hash, &proof, keys[0].as_slice())
+            .is_err(),
+        "go verify get proof should fail with a proof for a different root"
+    );
+}
+
 #[test]
 fn test_syncer_remove() {
     let server = ProtocolServer::new();
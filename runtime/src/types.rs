@@ -218,6 +218,23 @@ pub struct CheckTxResult {
     pub meta: Option<cbor::Value>,
 }
 
+/// Transaction scheduling metadata that may be returned as CheckTx result metadata in order to
+/// enable prioritized transaction scheduling.
+#[derive(Clone, Debug, Default, Serialize, Deserialize)]
+pub struct CheckTxMetadata {
+    /// Transaction priority. Transactions with higher priority are scheduled first.
+    #[serde(default)]
+    pub priority: u64,
+
+    /// Opaque identifier of the transaction sender.
+    #[serde(default)]
+    #[serde(with = "serde_bytes")]
+    pub sender: Vec<u8>,
+    /// Sequence number (nonce) of the transaction for the given sender.
+    #[serde(default)]
+    pub sender_seq: u64,
+}
+
 #[derive(Clone, Copy, Debug)]
 #[repr(u8)]
 pub enum MessageType {